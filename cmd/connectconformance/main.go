@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	"connectrpc.com/conformance/internal/app/connectconformance"
@@ -49,6 +50,14 @@ const (
 	portFlagName          = "port"
 	bindFlagName          = "bind"
 	traceFlagName         = "trace"
+	captureDirFlagName    = "capture-dir"
+	recordDirFlagName     = "record-dir"
+	wireStatsFlagName     = "wire-stats"
+	wireStatsOutFlagName  = "wire-stats-out"
+	wireStatsBaseFlagName = "wire-stats-baseline"
+	deadlineTolFlagName   = "deadline-tolerance"
+	deadlineRatioFlagName = "deadline-overshoot-ratio"
+	deadlineStatsFlagName = "deadline-stats"
 )
 
 type flags struct {
@@ -69,6 +78,14 @@ type flags struct {
 	port                 uint
 	bind                 string
 	trace                bool
+	captureDir           string
+	recordDir            string
+	wireStats            bool
+	wireStatsOut         string
+	wireStatsBaseline    string
+	deadlineTolerance    time.Duration
+	deadlineRatio        float64
+	deadlineStats        bool
 }
 
 func main() {
@@ -101,7 +118,8 @@ properties to stdout in the same form as the input, but using a
 connectrpc.conformance.v1.ServerCompatResponse message. The server process should
 provide an implementation of the test service defined by
 connectrpc.conformance.v1.ConformanceService. The command should exit
-upon receiving a SIGTERM signal. The command maybe invoked repeatedly, to start
+upon receiving a SIGTERM signal, after gracefully shutting down the server by
+allowing in-progress RPCs to complete. The command maybe invoked repeatedly, to start
 and test servers with different properties.
 
 A configuration file may be provided which specifies what features the client
@@ -168,6 +186,22 @@ func bind(cmd *cobra.Command, flags *flags) {
 		"in client mode, the bind address on which the reference server should listen (0.0.0.0 means listen on all interfaces)")
 	cmd.Flags().BoolVar(&flags.trace, traceFlagName, false,
 		"if true, full HTTP traces will be captured and shown alongside failing test cases")
+	cmd.Flags().StringVar(&flags.captureDir, captureDirFlagName, "",
+		"if set along with --trace, raw HTTP/2 frames will also be captured and, for failing test cases, written to pcapng files in this directory")
+	cmd.Flags().StringVar(&flags.recordDir, recordDirFlagName, "",
+		"if set along with --trace, the HTTP exchanges of failing test cases are recorded to JSON files in this directory, which can be used with the replay command")
+	cmd.Flags().BoolVar(&flags.wireStats, wireStatsFlagName, false,
+		"if true along with --trace, statistics about bytes and frames put on the wire are reported, grouped by protocol, codec, and compression")
+	cmd.Flags().StringVar(&flags.wireStatsOut, wireStatsOutFlagName, "",
+		"if set along with --trace, statistics about what is put on the wire for each test case are written to this file in JSON format")
+	cmd.Flags().StringVar(&flags.wireStatsBaseline, wireStatsBaseFlagName, "",
+		"if set along with --trace, wire statistics are reported with deltas against those in this file, written via --wire-stats-out in a run against the reference implementation")
+	cmd.Flags().DurationVar(&flags.deadlineTolerance, deadlineTolFlagName, 250*time.Millisecond,
+		"how far from its timeout, earlier or later, an RPC may fail with a deadline exceeded error")
+	cmd.Flags().Float64Var(&flags.deadlineRatio, deadlineRatioFlagName, 0,
+		"the fraction of its timeout by which an RPC may overshoot it, in addition to --"+deadlineTolFlagName)
+	cmd.Flags().BoolVar(&flags.deadlineStats, deadlineStatsFlagName, false,
+		"if true, the distribution of how far RPCs overshoot their timeout is reported, grouped by protocol and HTTP version")
 }
 
 func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:gocyclo
@@ -198,6 +232,19 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 	if flags.parallel == 0 {
 		fatal(`Invalid parallelism: must be greater than zero`)
 	}
+	if flags.deadlineTolerance <= 0 {
+		fatal(`Invalid deadline tolerance: must be greater than zero`)
+	}
+	if flags.deadlineRatio < 0 {
+		fatal(`Invalid deadline overshoot ratio: must not be negative`)
+	}
+	if !flags.trace {
+		for _, flagName := range []string{captureDirFlagName, recordDirFlagName, wireStatsFlagName, wireStatsOutFlagName, wireStatsBaseFlagName} {
+			if cobraFlags.Changed(flagName) {
+				fatal(fmt.Sprintf("Cannot specify --%s flag without --%s", flagName, traceFlagName))
+			}
+		}
+	}
 
 	var clientCommand, serverCommand []string
 	switch flags.mode {
@@ -292,23 +339,31 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 
 	ok, err := connectconformance.Run(
 		&connectconformance.Flags{
-			ConfigFile:           flags.configFile,
-			RunPatterns:          runPatterns,
-			SkipPatterns:         skipPatterns,
-			KnownFailingPatterns: knownFailingPatterns,
-			KnownFlakyPatterns:   knownFlakyPatterns,
-			TestFiles:            flags.testFiles,
-			Verbose:              flags.verbose || flags.veryVerbose,
-			VeryVerbose:          flags.veryVerbose,
-			ClientCommand:        clientCommand,
-			ServerCommand:        serverCommand,
-			MaxServers:           flags.maxServers,
-			Parallelism:          flags.parallel,
-			TLSCertFile:          flags.tlsCertFile,
-			TLSKeyFile:           flags.tlsKeyFile,
-			ServerPort:           flags.port,
-			ServerBind:           flags.bind,
-			HTTPTrace:            flags.trace,
+			ConfigFile:             flags.configFile,
+			RunPatterns:            runPatterns,
+			SkipPatterns:           skipPatterns,
+			KnownFailingPatterns:   knownFailingPatterns,
+			KnownFlakyPatterns:     knownFlakyPatterns,
+			TestFiles:              flags.testFiles,
+			Verbose:                flags.verbose || flags.veryVerbose,
+			VeryVerbose:            flags.veryVerbose,
+			ClientCommand:          clientCommand,
+			ServerCommand:          serverCommand,
+			MaxServers:             flags.maxServers,
+			Parallelism:            flags.parallel,
+			TLSCertFile:            flags.tlsCertFile,
+			TLSKeyFile:             flags.tlsKeyFile,
+			ServerPort:             flags.port,
+			ServerBind:             flags.bind,
+			HTTPTrace:              flags.trace,
+			PacketCaptureDir:       flags.captureDir,
+			RecordDir:              flags.recordDir,
+			WireStats:              flags.wireStats,
+			WireStatsFile:          flags.wireStatsOut,
+			WireStatsBaselineFile:  flags.wireStatsBaseline,
+			DeadlineTolerance:      flags.deadlineTolerance,
+			DeadlineOvershootRatio: flags.deadlineRatio,
+			DeadlineStats:          flags.deadlineStats,
 		},
 		internal.NewPrinter(os.Stdout),
 		internal.NewPrinter(os.Stderr),
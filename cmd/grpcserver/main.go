@@ -18,12 +18,17 @@ import (
 	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"connectrpc.com/conformance/internal/app/grpcserver"
 )
 
 func main() {
-	err := grpcserver.Run(context.Background(), os.Args, os.Stdin, os.Stdout, os.Stderr)
+	// The server shuts down gracefully upon receiving a SIGTERM signal.
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	err := grpcserver.Run(ctx, os.Args, os.Stdin, os.Stdout, os.Stderr)
+	cancel()
 	if err != nil {
 		log.Fatalf("an error occurred running the gRPC server: %s", err.Error())
 	}
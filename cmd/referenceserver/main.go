@@ -18,12 +18,17 @@ import (
 	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"connectrpc.com/conformance/internal/app/referenceserver"
 )
 
 func main() {
-	err := referenceserver.Run(context.Background(), os.Args, os.Stdin, os.Stdout, os.Stderr)
+	// The server shuts down gracefully upon receiving a SIGTERM signal.
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	err := referenceserver.Run(ctx, os.Args, os.Stdin, os.Stdout, os.Stderr)
+	cancel()
 	if err != nil {
 		log.Fatalf("an error occurred running the reference server: %s", err.Error())
 	}
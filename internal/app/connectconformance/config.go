@@ -32,11 +32,16 @@ type configCase struct {
 	Protocol               conformancev1.Protocol
 	Codec                  conformancev1.Codec
 	Compression            conformancev1.Compression
+	ResponseCompression    conformancev1.Compression
 	StreamType             conformancev1.StreamType
 	UseTLS                 bool
 	UseTLSClientCerts      bool
 	UseConnectGET          bool
 	UseMessageReceiveLimit bool
+	UseMessageSendLimit    bool
+	UseRetries             bool
+	UseServerObservations  bool
+	UseHeaderSizeLimit     bool
 	ConnectVersionMode     conformancev1.TestSuite_ConnectVersionMode
 }
 
@@ -56,6 +61,14 @@ type supportedFeatures struct {
 	SupportsHalfDuplexBidiOverHTTP1 bool
 	SupportsConnectGet              bool
 	SupportsMessageReceiveLimit     bool
+	SupportsAsymmetricCompression   bool
+	SupportsMessageSendLimit        bool
+	SupportsRetries                 bool
+	SupportsServerObservations      bool
+	SupportsHeaderSizeLimit         bool
+	// The compressions that may be used for responses. This is
+	// only used when SupportsAsymmetricCompression is true.
+	ResponseCompressions []conformancev1.Compression
 }
 
 // parseConfig loads all config cases from the given file name. If the given
@@ -78,7 +91,7 @@ func parseConfig(configFileName string, data []byte) ([]configCase, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", configFileName, err)
 	}
-	cases := computeCasesFromFeatures(features, nil, nil, nil)
+	cases := computeCasesFromFeatures(features, nil, nil, nil, nil, nil, nil, nil)
 	for i, includeCase := range config.IncludeCases {
 		resolvedIncludes, err := resolveCase(features, includeCase)
 		if err != nil {
@@ -124,6 +137,11 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 		SupportsHalfDuplexBidiOverHTTP1: features.GetSupportsHalfDuplexBidiOverHttp1(),
 		SupportsConnectGet:              features.GetSupportsConnectGet(),
 		SupportsMessageReceiveLimit:     features.GetSupportsMessageReceiveLimit(),
+		SupportsAsymmetricCompression:   features.GetSupportsAsymmetricCompression(),
+		SupportsMessageSendLimit:        features.GetSupportsMessageSendLimit(),
+		SupportsRetries:                 features.GetSupportsRetries(),
+		SupportsServerObservations:      features.GetSupportsServerObservations(),
+		SupportsHeaderSizeLimit:         features.GetSupportsHeaderSizeLimit(),
 	}
 
 	// These flags should default to true if not provided
@@ -191,6 +209,8 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 		return result, errors.New("config features indicate gRPC protocol is supported but HTTP/2 is not")
 	}
 	canUseGRPC := result.SupportsTrailers && includesHTTP2
+	// The text variant of gRPC-Web and REST transcoding are not
+	// widely supported, so they are never included by default.
 	if len(result.Protocols) == 0 {
 		if canUseGRPC {
 			result.Protocols = []conformancev1.Protocol{
@@ -218,6 +238,9 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 			conformancev1.Compression_COMPRESSION_GZIP,
 		}
 	}
+	if result.SupportsAsymmetricCompression {
+		result.ResponseCompressions = result.Compressions
+	}
 
 	includesFullDuplex := contains(result.StreamTypes, conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM)
 	onlyHTTP1 := !includesHTTP2 && !includesHTTP3
@@ -260,8 +283,8 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 
 // computeCasesFromFeatures expands the given features into all matching config
 // permutations.
-func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCertCases, msgRecvLimitCases []bool) map[configCase]struct{} { //nolint:gocyclo
-	// if tlsCases, tlsClientCertCases, and msgRecvLimitCases not explicitly provided, derive them from features
+func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCertCases, msgRecvLimitCases, msgSendLimitCases, retryCases, observationCases, headerLimitCases []bool) map[configCase]struct{} { //nolint:gocyclo
+	// if tlsCases, tlsClientCertCases, msgRecvLimitCases, msgSendLimitCases, retryCases, observationCases, and headerLimitCases not explicitly provided, derive them from features
 	if len(tlsCases) == 0 {
 		if features.SupportsTLS {
 			tlsCases = []bool{false, true}
@@ -283,6 +306,34 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 			msgRecvLimitCases = []bool{false}
 		}
 	}
+	if len(msgSendLimitCases) == 0 {
+		if features.SupportsMessageSendLimit {
+			msgSendLimitCases = []bool{false, true}
+		} else {
+			msgSendLimitCases = []bool{false}
+		}
+	}
+	if len(retryCases) == 0 {
+		if features.SupportsRetries {
+			retryCases = []bool{false, true}
+		} else {
+			retryCases = []bool{false}
+		}
+	}
+	if len(observationCases) == 0 {
+		if features.SupportsServerObservations {
+			observationCases = []bool{false, true}
+		} else {
+			observationCases = []bool{false}
+		}
+	}
+	if len(headerLimitCases) == 0 {
+		if features.SupportsHeaderSizeLimit {
+			headerLimitCases = []bool{false, true}
+		} else {
+			headerLimitCases = []bool{false}
+		}
+	}
 	cases := map[configCase]struct{}{}
 	for _, version := range features.Versions {
 		for _, tlsCase := range tlsCases {
@@ -308,6 +359,10 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 					}
 
 					for _, streamType := range features.StreamTypes {
+						if protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING &&
+							streamType != conformancev1.StreamType_STREAM_TYPE_UNARY {
+							continue // REST transcoding only supports unary RPCs
+						}
 						switch streamType { //nolint:exhaustive
 						case conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM:
 							if !features.SupportsHalfDuplexBidiOverHTTP1 && version == conformancev1.HTTPVersion_HTTP_VERSION_1 {
@@ -317,6 +372,11 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 							if version == conformancev1.HTTPVersion_HTTP_VERSION_1 {
 								continue // HTTP/1.1 can't do full duplex
 							}
+							if protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
+								// The request body is one continuous base64 stream, so the end
+								// of a message may not be sent until the next message is.
+								continue
+							}
 						}
 
 						for _, codec := range features.Codecs {
@@ -325,20 +385,48 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 								// Deprecated, ignore
 								continue
 							}
+							if protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING &&
+								codec != conformancev1.Codec_CODEC_JSON {
+								continue // REST transcoding only uses JSON
+							}
 							for _, compression := range features.Compressions {
-								for _, connectGetCase := range connectGetCases {
-									for _, msgRecvLimitCase := range msgRecvLimitCases {
-										cases[configCase{
-											Version:                version,
-											Protocol:               protocol,
-											Codec:                  codec,
-											Compression:            compression,
-											StreamType:             streamType,
-											UseTLS:                 tlsCase,
-											UseTLSClientCerts:      tlsClientCertCase,
-											UseConnectGET:          connectGetCase,
-											UseMessageReceiveLimit: msgRecvLimitCase,
-										}] = struct{}{}
+								if protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING &&
+									compression != conformancev1.Compression_COMPRESSION_IDENTITY {
+									continue // nor does it negotiate compression
+								}
+								responseCompressions := []conformancev1.Compression{compression}
+								if features.SupportsAsymmetricCompression &&
+									protocol != conformancev1.Protocol_PROTOCOL_REST_TRANSCODING {
+									responseCompressions = features.ResponseCompressions
+								}
+								for _, responseCompression := range responseCompressions {
+									for _, connectGetCase := range connectGetCases {
+										for _, msgRecvLimitCase := range msgRecvLimitCases {
+											for _, msgSendLimitCase := range msgSendLimitCases {
+												for _, retryCase := range retryCases {
+													for _, observationCase := range observationCases {
+														for _, headerLimitCase := range headerLimitCases {
+															cases[configCase{
+																Version:                version,
+																Protocol:               protocol,
+																Codec:                  codec,
+																Compression:            compression,
+																ResponseCompression:    responseCompression,
+																StreamType:             streamType,
+																UseTLS:                 tlsCase,
+																UseTLSClientCerts:      tlsClientCertCase,
+																UseConnectGET:          connectGetCase,
+																UseMessageReceiveLimit: msgRecvLimitCase,
+																UseMessageSendLimit:    msgSendLimitCase,
+																UseRetries:             retryCase,
+																UseServerObservations:  observationCase,
+																UseHeaderSizeLimit:     headerLimitCase,
+															}] = struct{}{}
+														}
+													}
+												}
+											}
+										}
 									}
 								}
 							}
@@ -388,6 +476,17 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 	if unresolvedCase.Compression != conformancev1.Compression_COMPRESSION_UNSPECIFIED {
 		impliedFeatures.Compressions = []conformancev1.Compression{unresolvedCase.Compression}
 	}
+	if responseCompression := unresolvedCase.ResponseCompression; responseCompression != conformancev1.Compression_COMPRESSION_UNSPECIFIED {
+		switch {
+		case features.SupportsAsymmetricCompression:
+			impliedFeatures.ResponseCompressions = []conformancev1.Compression{responseCompression}
+		case unresolvedCase.Compression == conformancev1.Compression_COMPRESSION_UNSPECIFIED:
+			// Without asymmetric support, this is the same as specifying the compression.
+			impliedFeatures.Compressions = []conformancev1.Compression{responseCompression}
+		case responseCompression != unresolvedCase.Compression:
+			return nil, errors.New("config case indicates a response compression that differs from the compression, but features indicate that asymmetric compression is not supported")
+		}
+	}
 	if unresolvedCase.StreamType != conformancev1.StreamType_STREAM_TYPE_UNSPECIFIED {
 		switch unresolvedCase.StreamType { //nolint:exhaustive
 		case conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM:
@@ -401,7 +500,7 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 		}
 		impliedFeatures.StreamTypes = []conformancev1.StreamType{unresolvedCase.StreamType}
 	}
-	var tlsCases, tlsClientCertCases, msgReceiveLimitCases []bool
+	var tlsCases, tlsClientCertCases, msgReceiveLimitCases, msgSendLimitCases, retryCases, observationCases, headerLimitCases []bool
 	if unresolvedCase.UseTls != nil {
 		tlsCases = []bool{unresolvedCase.GetUseTls()}
 	}
@@ -419,7 +518,19 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 	if unresolvedCase.UseMessageReceiveLimit != nil {
 		msgReceiveLimitCases = []bool{unresolvedCase.GetUseMessageReceiveLimit()}
 	}
-	return computeCasesFromFeatures(impliedFeatures, tlsCases, tlsClientCertCases, msgReceiveLimitCases), nil
+	if unresolvedCase.UseMessageSendLimit != nil {
+		msgSendLimitCases = []bool{unresolvedCase.GetUseMessageSendLimit()}
+	}
+	if unresolvedCase.UseRetries != nil {
+		retryCases = []bool{unresolvedCase.GetUseRetries()}
+	}
+	if unresolvedCase.UseServerObservations != nil {
+		observationCases = []bool{unresolvedCase.GetUseServerObservations()}
+	}
+	if unresolvedCase.UseHeaderSizeLimit != nil {
+		headerLimitCases = []bool{unresolvedCase.GetUseHeaderSizeLimit()}
+	}
+	return computeCasesFromFeatures(impliedFeatures, tlsCases, tlsClientCertCases, msgReceiveLimitCases, msgSendLimitCases, retryCases, observationCases, headerLimitCases), nil
 }
 
 func checkForDeprecations(config *conformancev1.Config) {
@@ -75,6 +75,93 @@ func TestParseConfig_ComputesPermutations(t *testing.T) {
 				false, // default to NOT supporting half-duplex bidi over HTTP 1
 			),
 		},
+		{
+			name: "grpc-web text",
+			config: `features:
+                        protocols: [PROTOCOL_GRPC_WEB, PROTOCOL_GRPC_WEB_TEXT]
+                        codecs: [CODEC_PROTO]
+                        compressions: [COMPRESSION_IDENTITY]
+                        supportsTls: false
+                        supportsHalfDuplexBidiOverHttp1: true`,
+			expectedCases: excludeDisallowed(
+				computePermutations(
+					[]conformancev1.HTTPVersion{
+						conformancev1.HTTPVersion_HTTP_VERSION_1,
+						conformancev1.HTTPVersion_HTTP_VERSION_2,
+					},
+					[]conformancev1.Protocol{
+						conformancev1.Protocol_PROTOCOL_GRPC_WEB,
+						conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT,
+					},
+					[]conformancev1.Codec{
+						conformancev1.Codec_CODEC_PROTO,
+					},
+					[]conformancev1.Compression{
+						conformancev1.Compression_COMPRESSION_IDENTITY,
+					},
+					[]conformancev1.StreamType{
+						conformancev1.StreamType_STREAM_TYPE_UNARY,
+						conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM,
+						conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+						conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM,
+						conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
+					},
+					[]bool{false},
+					[]bool{false},
+					[]bool{false},
+					[]bool{true, false},
+					[]conformancev1.TestSuite_ConnectVersionMode{
+						conformancev1.TestSuite_CONNECT_VERSION_MODE_UNSPECIFIED,
+					},
+				),
+				true,
+				true,
+			),
+		},
+		{
+			name: "rest transcoding",
+			config: `features:
+                        protocols: [PROTOCOL_CONNECT, PROTOCOL_REST_TRANSCODING]
+                        compressions: [COMPRESSION_IDENTITY, COMPRESSION_GZIP]
+                        supportsTls: false
+                        supportsHalfDuplexBidiOverHttp1: true`,
+			expectedCases: excludeDisallowed(
+				computePermutations(
+					[]conformancev1.HTTPVersion{
+						conformancev1.HTTPVersion_HTTP_VERSION_1,
+						conformancev1.HTTPVersion_HTTP_VERSION_2,
+					},
+					[]conformancev1.Protocol{
+						conformancev1.Protocol_PROTOCOL_CONNECT,
+						conformancev1.Protocol_PROTOCOL_REST_TRANSCODING,
+					},
+					[]conformancev1.Codec{
+						conformancev1.Codec_CODEC_PROTO,
+						conformancev1.Codec_CODEC_JSON,
+					},
+					[]conformancev1.Compression{
+						conformancev1.Compression_COMPRESSION_IDENTITY,
+						conformancev1.Compression_COMPRESSION_GZIP,
+					},
+					[]conformancev1.StreamType{
+						conformancev1.StreamType_STREAM_TYPE_UNARY,
+						conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM,
+						conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+						conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM,
+						conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
+					},
+					[]bool{false},
+					[]bool{false},
+					[]bool{false, true},
+					[]bool{true, false},
+					[]conformancev1.TestSuite_ConnectVersionMode{
+						conformancev1.TestSuite_CONNECT_VERSION_MODE_UNSPECIFIED,
+					},
+				),
+				true,
+				true,
+			),
+		},
 		{
 			name: "simple features",
 			config: `features:
@@ -180,18 +267,20 @@ func TestParseConfig_ComputesPermutations(t *testing.T) {
 						Protocol:               conformancev1.Protocol_PROTOCOL_GRPC,
 						Codec:                  conformancev1.Codec_CODEC_PROTO,
 						Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+						ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 						StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 						UseTLS:                 true,
 						UseTLSClientCerts:      true,
 						UseMessageReceiveLimit: true,
 					},
 					{
-						Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
-						Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
-						Codec:       conformancev1.Codec_CODEC_PROTO,
-						Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-						StreamType:  conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
-						UseTLS:      true,
+						Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+						Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+						Codec:               conformancev1.Codec_CODEC_PROTO,
+						Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+						ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+						StreamType:          conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+						UseTLS:              true,
 					},
 				},
 			),
@@ -359,6 +448,198 @@ func TestParseConfig_ComputesPermutations(t *testing.T) {
 				),
 			),
 		},
+		{
+			name: "asymmetric compression",
+			config: `
+                      features:
+                        versions: [HTTP_VERSION_2]
+                        protocols: [PROTOCOL_GRPC]
+                        codecs: [CODEC_PROTO]
+                        compressions: [COMPRESSION_IDENTITY, COMPRESSION_GZIP]
+                        streamTypes: [STREAM_TYPE_UNARY]
+                        supportsTls: false
+                        supportsMessageReceiveLimit: false
+                        supportsAsymmetricCompression: true
+                      exclude_cases:
+                      - compression: COMPRESSION_GZIP
+                        responseCompression: COMPRESSION_IDENTITY`,
+			expectedCases: []configCase{
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_GZIP,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_GZIP,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_GZIP,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+			},
+		},
+		{
+			name: "message send limit",
+			config: `
+                      features:
+                        versions: [HTTP_VERSION_2]
+                        protocols: [PROTOCOL_GRPC]
+                        codecs: [CODEC_PROTO]
+                        compressions: [COMPRESSION_IDENTITY]
+                        streamTypes: [STREAM_TYPE_UNARY, STREAM_TYPE_SERVER_STREAM]
+                        supportsTls: false
+                        supportsMessageReceiveLimit: false
+                        supportsMessageSendLimit: true
+                      exclude_cases:
+                      - streamType: STREAM_TYPE_SERVER_STREAM
+                        useMessageSendLimit: true`,
+			expectedCases: []configCase{
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseMessageSendLimit: true,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+				},
+			},
+		},
+		{
+			name: "retries",
+			config: `
+                      features:
+                        versions: [HTTP_VERSION_2]
+                        protocols: [PROTOCOL_GRPC]
+                        codecs: [CODEC_PROTO]
+                        compressions: [COMPRESSION_IDENTITY]
+                        streamTypes: [STREAM_TYPE_UNARY, STREAM_TYPE_SERVER_STREAM]
+                        supportsTls: false
+                        supportsMessageReceiveLimit: false
+                        supportsRetries: true
+                      exclude_cases:
+                      - streamType: STREAM_TYPE_SERVER_STREAM
+                        useRetries: true`,
+			expectedCases: []configCase{
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseRetries:          true,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+				},
+			},
+		},
+		{
+			name: "server observations",
+			config: `
+                      features:
+                        versions: [HTTP_VERSION_2]
+                        protocols: [PROTOCOL_GRPC]
+                        codecs: [CODEC_PROTO]
+                        compressions: [COMPRESSION_IDENTITY]
+                        streamTypes: [STREAM_TYPE_UNARY]
+                        supportsTls: false
+                        supportsMessageReceiveLimit: false
+                        supportsServerObservations: true`,
+			expectedCases: []configCase{
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				{
+					Version:               conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:              conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:                 conformancev1.Codec_CODEC_PROTO,
+					Compression:           conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression:   conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:            conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseServerObservations: true,
+				},
+			},
+		},
+		{
+			name: "header size limit",
+			config: `
+                      features:
+                        versions: [HTTP_VERSION_2]
+                        protocols: [PROTOCOL_GRPC]
+                        codecs: [CODEC_PROTO]
+                        compressions: [COMPRESSION_IDENTITY]
+                        streamTypes: [STREAM_TYPE_UNARY]
+                        supportsTls: false
+                        supportsMessageReceiveLimit: false
+                        supportsHeaderSizeLimit: true`,
+			expectedCases: []configCase{
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+				},
+				{
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseHeaderSizeLimit:  true,
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -573,6 +854,15 @@ func TestParseConfig_RejectsInvalidConfigurations(t *testing.T) {
                       - useTlsClientCerts: true`,
 			expectedErr: "config case indicates use of TLS client certs but TLS is not supported",
 		},
+		{
+			name: "included case: asymmetric compression not supported",
+			config: `
+                     features:
+                     include_cases:
+                      - compression: COMPRESSION_GZIP
+                        responseCompression: COMPRESSION_IDENTITY`,
+			expectedErr: "config case indicates a response compression that differs from the compression, but features indicate that asymmetric compression is not supported",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -613,6 +903,7 @@ func computePermutations(
 											Protocol:               protocol,
 											Codec:                  codec,
 											Compression:            compression,
+											ResponseCompression:    compression,
 											StreamType:             streamType,
 											UseTLS:                 useTLS,
 											UseTLSClientCerts:      useTLSClientCerts,
@@ -653,6 +944,15 @@ func excludeDisallowed(cases []configCase, supportsH2C, supportsHalfDuplexBidiHT
 		case cfgCase.StreamType == conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM && cfgCase.Version == conformancev1.HTTPVersion_HTTP_VERSION_1:
 			// Can't do full-duplex streams w/ HTTP 1.1
 			disallowed[i] = struct{}{}
+		case cfgCase.StreamType == conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM && cfgCase.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT:
+			// Can't do full-duplex streams w/ gRPC-Web text
+			disallowed[i] = struct{}{}
+		case cfgCase.Protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING &&
+			(cfgCase.StreamType != conformancev1.StreamType_STREAM_TYPE_UNARY ||
+				cfgCase.Codec != conformancev1.Codec_CODEC_JSON ||
+				cfgCase.Compression != conformancev1.Compression_COMPRESSION_IDENTITY):
+			// REST transcoding is only for unary RPCs w/ JSON and no compression
+			disallowed[i] = struct{}{}
 		case cfgCase.StreamType == conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM && cfgCase.Version == conformancev1.HTTPVersion_HTTP_VERSION_1 && !supportsHalfDuplexBidiHTTP1:
 			// Can't do half-duplex streams w/ HTTP 1.1 either if impl doesn't support that
 			disallowed[i] = struct{}{}
@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
@@ -63,6 +66,35 @@ type Flags struct {
 	ServerPort           uint
 	ServerBind           string
 	HTTPTrace            bool
+	// If non-empty and HTTPTrace is true, the raw bytes of HTTP/2
+	// traffic are also captured and, for each failed test case,
+	// written to a pcapng file in this directory.
+	PacketCaptureDir string
+	// If non-empty and HTTPTrace is true, recordings of the HTTP
+	// exchanges for each failed test case are written, in JSON format,
+	// to files in this directory. They can be replayed using the
+	// replay command.
+	RecordDir string
+	// If true and HTTPTrace is true, statistics about what is put
+	// on the wire are computed from the traces and reported.
+	WireStats bool
+	// If non-empty and HTTPTrace is true, wire statistics for each
+	// test case are written to this file, in JSON format.
+	WireStatsFile string
+	// If non-empty and HTTPTrace is true, wire statistics are compared
+	// against those in this file, which should have been written via
+	// WireStatsFile in a prior run against the reference implementation.
+	WireStatsBaselineFile string
+	// How far from its timeout an RPC may fail with "deadline exceeded",
+	// either earlier or later. If zero, a default of 250ms is used.
+	DeadlineTolerance time.Duration
+	// The fraction of its timeout by which an RPC may additionally
+	// overshoot it. For example, with a value of 0.5, an RPC with a
+	// timeout of 1s may fail up to 500ms (plus the tolerance) late.
+	DeadlineOvershootRatio float64
+	// If true, the distribution of how far RPCs overshoot their
+	// timeout is reported.
+	DeadlineStats bool
 }
 
 func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer) (bool, error) {
@@ -75,6 +107,16 @@ func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer)
 	} else if flags.Verbose {
 		logPrinter.Printf("No config file provided. Using defaults.")
 	}
+	if flags.HTTPTrace && flags.PacketCaptureDir != "" {
+		if err := os.MkdirAll(flags.PacketCaptureDir, 0o755); err != nil {
+			return false, fmt.Errorf("failed to create packet capture directory: %w", err)
+		}
+	}
+	if flags.HTTPTrace && flags.RecordDir != "" {
+		if err := os.MkdirAll(flags.RecordDir, 0o755); err != nil {
+			return false, fmt.Errorf("failed to create recording directory: %w", err)
+		}
+	}
 	configCases, err := parseConfig(flags.ConfigFile, configData)
 	if err != nil {
 		return false, err
@@ -128,7 +170,26 @@ func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer)
 	if err != nil {
 		errPrinter.Printf("%v", err)
 	}
-	return results.report(logPrinter) && err == nil, nil
+	ok := results.report(logPrinter)
+	if results.wireStats != nil {
+		if flags.WireStats || flags.WireStatsBaselineFile != "" {
+			results.wireStats.report(logPrinter)
+		}
+		if flags.WireStatsFile != "" {
+			if statsErr := results.wireStats.writeFile(flags.WireStatsFile); statsErr != nil {
+				errPrinter.Printf("failed to write wire statistics: %v", statsErr)
+				ok = false
+			}
+		}
+	}
+	if flags.DeadlineStats {
+		description := "the client under test"
+		if results.mode == conformancev1.TestSuite_TEST_MODE_SERVER {
+			description = "the server under test"
+		}
+		results.deadlines.report(description, logPrinter)
+	}
+	return ok && err == nil, nil
 }
 
 func run( //nolint:gocyclo
@@ -215,6 +276,7 @@ func run( //nolint:gocyclo
 
 	filter := newFilter(run, skip)
 	var filteredTestCount int
+	var canCapturePackets bool
 	type serverConfig struct {
 		serverInstance
 		isGrpcClient, isGrpcServer bool
@@ -222,12 +284,7 @@ func run( //nolint:gocyclo
 	allServerConfigs, filteredServerConfigs := map[serverConfig]struct{}{}, map[serverConfig]struct{}{}
 	for _, testCase := range allPermutations {
 		svrConfig := serverConfig{
-			serverInstance: serverInstance{
-				protocol:          testCase.Request.Protocol,
-				httpVersion:       testCase.Request.HttpVersion,
-				useTLS:            len(testCase.Request.ServerTlsCert) > 0,
-				useTLSClientCerts: testCase.Request.ClientTlsCreds != nil,
-			},
+			serverInstance: serverInstanceForCase(testCase),
 			isGrpcClient: strings.Contains(testCase.Request.TestName, grpcImplMarker) ||
 				strings.Contains(testCase.Request.TestName, grpcClientImplMarker),
 			isGrpcServer: strings.Contains(testCase.Request.TestName, grpcImplMarker) ||
@@ -237,8 +294,16 @@ func run( //nolint:gocyclo
 		if filter.accept(testCase) {
 			filteredTestCount++
 			filteredServerConfigs[svrConfig] = struct{}{}
+			// Only the gRPC reference implementations and the reference
+			// client's frame-script sender capture raw HTTP/2 frames.
+			canCapturePackets = canCapturePackets ||
+				useReferenceClient && (svrConfig.isGrpcClient || testCase.Request.GetRawRequest().GetFrameScript() != nil) ||
+				useReferenceServer && svrConfig.isGrpcServer
 		}
 	}
+	if flags.HTTPTrace && flags.PacketCaptureDir != "" && !canCapturePackets {
+		errPrinter.Printf("WARNING: packet captures were requested, but none of the reference implementations in this run can capture packets, so none will be written.")
+	}
 
 	if flags.Verbose {
 		logPrinter.Printf("Computed %d test case permutation(s) across %d server configuration(s).",
@@ -275,9 +340,17 @@ func run( //nolint:gocyclo
 		}
 	}
 
-	var trace *tracer.Tracer
+	// When both the client and server are reference implementations,
+	// traces are recorded on both sides, so they can be correlated.
+	var clientTrace, serverTrace *tracer.Tracer
 	if flags.HTTPTrace {
-		trace = &tracer.Tracer{}
+		capturePackets := flags.PacketCaptureDir != ""
+		if useReferenceClient {
+			clientTrace = &tracer.Tracer{CapturePackets: capturePackets}
+		}
+		if useReferenceServer {
+			serverTrace = &tracer.Tracer{CapturePackets: capturePackets}
+		}
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -293,7 +366,7 @@ func run( //nolint:gocyclo
 						referenceClientName,
 						"-p", strconv.Itoa(int(flags.Parallelism)),
 					}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-						return referenceclient.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
+						return referenceclient.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, clientTrace)
 					},
 				),
 				isReferenceImpl: true,
@@ -305,7 +378,7 @@ func run( //nolint:gocyclo
 						grpcReferenceClientName,
 						"-p", strconv.Itoa(int(flags.Parallelism)),
 					}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-						return grpcclient.RunWithTrace(ctx, args, inReader, outWriter, errWriter, trace)
+						return grpcclient.RunWithTrace(ctx, args, inReader, outWriter, errWriter, clientTrace)
 					},
 				),
 				isGrpcImpl: true,
@@ -319,7 +392,22 @@ func run( //nolint:gocyclo
 		}
 	}
 
-	results := newResults(mode, filteredTestCount, knownFailing, knownFlaky, trace)
+	results := newResults(mode, filteredTestCount, knownFailing, knownFlaky, clientTrace, serverTrace)
+	deadlineTolerance := flags.DeadlineTolerance
+	if deadlineTolerance == 0 {
+		deadlineTolerance = defaultDeadlineTolerance
+	}
+	results.deadlines = newDeadlineStats(deadlineTolerance, flags.DeadlineOvershootRatio)
+	if flags.HTTPTrace {
+		results.captureDir = flags.PacketCaptureDir
+		results.recordDir = flags.RecordDir
+		if flags.WireStats || flags.WireStatsFile != "" || flags.WireStatsBaselineFile != "" {
+			results.wireStats, err = newWireStats(flags.WireStatsBaselineFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load wire statistics baseline: %w", err)
+			}
+		}
+	}
 
 	for _, clientInfo := range clients {
 		clientProcess, err := runClient(ctx, clientInfo.start)
@@ -340,8 +428,9 @@ func run( //nolint:gocyclo
 							"-bind", flags.ServerBind,
 							"-cert", flags.TLSCertFile,
 							"-key", flags.TLSKeyFile,
+							"-cancel-wait", referenceServerCancelWait(flags.Parallelism).String(),
 						}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-							return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
+							return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, serverTrace)
 						},
 					),
 					isReferenceImpl: true,
@@ -354,7 +443,7 @@ func run( //nolint:gocyclo
 							"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
 							"-bind", flags.ServerBind,
 						}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-							return grpcserver.RunWithTrace(ctx, args, inReader, outWriter, errWriter, trace)
+							return grpcserver.RunWithTrace(ctx, args, inReader, outWriter, errWriter, serverTrace)
 						},
 					),
 					isGrpcImpl: true,
@@ -372,71 +461,116 @@ func run( //nolint:gocyclo
 			var wg sync.WaitGroup
 			defer wg.Wait()
 			sema := semaphore.NewWeighted(int64(flags.MaxServers))
+			// Limits the number of RPCs that are in progress for servers that are
+			// asked to shut down gracefully. (See below.)
+			rpcSema := semaphore.NewWeighted(int64(flags.Parallelism))
 
 			var svrIndex int
-			for _, serverInfo := range servers {
-				for _, svrInstance := range svrInstances {
-					testCases := testCaseLib.casesByServer[svrInstance]
-					testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
-					testCases = filter.apply(testCases)
-					if len(testCases) == 0 {
-						continue
-					}
-					svrIndex++
-
-					if err := sema.Acquire(ctx, 1); err != nil {
-						return err
-					}
-
-					// Double-check that client is still running before spawning a server process.
-					if !clientProcess.isRunning() {
-						err := clientProcess.waitForResponses()
-						if err == nil {
-							err = errors.New("client process unexpectedly stopped")
+			// Servers that are asked to shut down gracefully are run last, once the
+			// client is otherwise idle. That way, the client starts their RPCs right
+			// away, and they are in progress when the server is asked to shut down.
+			for _, gracefulShutdown := range []bool{false, true} {
+				if gracefulShutdown {
+					wg.Wait()
+				}
+				for _, serverInfo := range servers {
+					for _, svrInstance := range svrInstances {
+						if (svrInstance.gracefulShutdownAfterMs > 0) != gracefulShutdown {
+							continue
 						}
-						return err
-					}
-
-					if flags.Verbose {
-						var with string
-						switch {
-						case clientInfo.name != "" && serverInfo.name != "":
-							with = clientInfo.name + " and " + serverInfo.name
-						case clientInfo.name != "":
-							with = clientInfo.name
-						case serverInfo.name != "":
-							with = serverInfo.name
+						testCases := testCaseLib.casesByServer[svrInstance]
+						testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
+						testCases = filter.apply(testCases)
+						if len(testCases) == 0 {
+							continue
+						}
+						results.wireStats.register(testCases)
+
+						batches := [][]*conformancev1.TestCase{testCases}
+						if gracefulShutdown {
+							// The client would not start all of the RPCs right away if there
+							// are more than it issues in parallel. So these are split into
+							// batches, each run against its own server process.
+							batches = slices.Collect(slices.Chunk(testCases, int(flags.Parallelism)))
+						}
+						for _, testCases := range batches {
+							svrIndex++
+
+							if err := sema.Acquire(ctx, 1); err != nil {
+								return err
+							}
+							var numRPCs int64
+							if gracefulShutdown {
+								numRPCs = int64(len(testCases))
+								if err := rpcSema.Acquire(ctx, numRPCs); err != nil {
+									sema.Release(1)
+									return err
+								}
+							}
+
+							// Double-check that client is still running before spawning a server process.
+							if !clientProcess.isRunning() {
+								err := clientProcess.waitForResponses()
+								if err == nil {
+									err = errors.New("client process unexpectedly stopped")
+								}
+								return err
+							}
+
+							if flags.Verbose {
+								var with string
+								switch {
+								case clientInfo.name != "" && serverInfo.name != "":
+									with = clientInfo.name + " and " + serverInfo.name
+								case clientInfo.name != "":
+									with = clientInfo.name
+								case serverInfo.name != "":
+									with = serverInfo.name
+								}
+								logTestCaseInfo(with, svrInstance, svrIndex, len(testCases), logPrinter)
+							}
+
+							var svrName string
+							if serverInfo.name != "" {
+								svrName = fmt.Sprintf("%s#%d", serverInfo.name, svrIndex)
+							}
+							wg.Add(1)
+							go func(ctx context.Context, clientInfo processInfo, serverInfo processInfo, svrInstance serverInstance, testCases []*conformancev1.TestCase, numRPCs int64) {
+								defer wg.Done()
+								defer sema.Release(1)
+								if numRPCs > 0 {
+									defer rpcSema.Release(numRPCs)
+								}
+
+								numConnections := runTestCasesForServer(
+									ctx,
+									clientInfo.isReferenceImpl,
+									serverInfo.isReferenceImpl,
+									svrInstance,
+									svrName,
+									testCases,
+									serverCreds,
+									clientCreds,
+									serverInfo.start,
+									logPrinter,
+									errPrinter,
+									results,
+									clientProcess,
+									clientTrace,
+									serverTrace,
+									flags.VeryVerbose,
+								)
+								if numConnections > 0 {
+									results.addConnections(svrInstance, numConnections, len(testCases))
+									if flags.Verbose {
+										// Clients should reuse connections, so this is ideally
+										// much smaller than the number of test cases.
+										logPrinter.Printf("%s accepted %d connection(s) for %d test case(s).", svrName, numConnections, len(testCases))
+									}
+								}
+							}(ctx, clientInfo, serverInfo, svrInstance, testCases, numRPCs)
 						}
-						logTestCaseInfo(with, svrInstance, svrIndex, len(testCases), logPrinter)
-					}
-
-					var svrName string
-					if serverInfo.name != "" {
-						svrName = fmt.Sprintf("%s#%d", serverInfo.name, svrIndex)
 					}
-					wg.Add(1)
-					go func(ctx context.Context, clientInfo processInfo, serverInfo processInfo, svrInstance serverInstance) {
-						defer wg.Done()
-						defer sema.Release(1)
-
-						runTestCasesForServer(
-							ctx,
-							clientInfo.isReferenceImpl,
-							serverInfo.isReferenceImpl,
-							svrInstance,
-							svrName,
-							testCases,
-							serverCreds,
-							clientCreds,
-							serverInfo.start,
-							logPrinter,
-							errPrinter,
-							results,
-							clientProcess,
-							trace,
-							flags.VeryVerbose,
-						)
-					}(ctx, clientInfo, serverInfo, svrInstance)
 				}
 			}
 			return nil
@@ -473,23 +607,28 @@ func serverInstancesSlice(testCaseLib *testCaseLibrary, sorted bool) []serverIns
 		if svrInstances[i].useTLS != svrInstances[j].useTLS {
 			return !svrInstances[i].useTLS
 		}
-		return !svrInstances[i].useTLSClientCerts || svrInstances[j].useTLSClientCerts
+		if svrInstances[i].useTLSClientCerts != svrInstances[j].useTLSClientCerts {
+			return !svrInstances[i].useTLSClientCerts
+		}
+		if svrInstances[i].gracefulShutdownAfterMs != svrInstances[j].gracefulShutdownAfterMs {
+			return svrInstances[i].gracefulShutdownAfterMs < svrInstances[j].gracefulShutdownAfterMs
+		}
+		return !svrInstances[i].verifiesConnectionReuse && svrInstances[j].verifiesConnectionReuse
 	})
 	return svrInstances
 }
 
+// referenceServerCancelWait returns how long the reference server should
+// wait for a client's cancellation of an RPC to arrive. The more RPCs run in
+// parallel for each CPU, the longer it may take on a loaded machine.
+func referenceServerCancelWait(parallelism uint) time.Duration {
+	perCPU := parallelism / uint(runtime.GOMAXPROCS(0))
+	return time.Second * time.Duration(max(1, perCPU))
+}
+
 func logTestCaseInfo(with string, svrInstance serverInstance, svrIndex, numCases int, logPrinter internal.Printer) {
-	var tlsMode string
-	switch {
-	case !svrInstance.useTLS:
-		tlsMode = "false"
-	case svrInstance.useTLS && svrInstance.useTLSClientCerts:
-		tlsMode = "true (with client certs)"
-	default:
-		tlsMode = "true"
-	}
-	logPrinter.Printf("Running %d tests with %s for server config #%d {%s, %s, TLS:%s}...",
-		numCases, with, svrIndex, svrInstance.httpVersion, svrInstance.protocol, tlsMode)
+	logPrinter.Printf("Running %d tests with %s for server config #%d %s...",
+		numCases, with, svrIndex, svrInstance.configString())
 }
 
 func tryMatchPatterns(what string, patterns *testTrie, testCases []*conformancev1.TestCase) (int, error) {
@@ -37,6 +37,7 @@ func TestRun(t *testing.T) {
 			Protocol:               conformancev1.Protocol_PROTOCOL_CONNECT,
 			Codec:                  conformancev1.Codec_CODEC_JSON,
 			Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+			ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 			StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 			UseTLS:                 false,
 			UseTLSClientCerts:      false,
@@ -49,6 +50,7 @@ func TestRun(t *testing.T) {
 			Protocol:               conformancev1.Protocol_PROTOCOL_CONNECT,
 			Codec:                  conformancev1.Codec_CODEC_JSON,
 			Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+			ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 			StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 			UseTLS:                 false,
 			UseTLSClientCerts:      false,
@@ -61,6 +63,7 @@ func TestRun(t *testing.T) {
 			Protocol:               conformancev1.Protocol_PROTOCOL_GRPC,
 			Codec:                  conformancev1.Codec_CODEC_PROTO,
 			Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+			ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 			StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 			UseTLS:                 false,
 			UseTLSClientCerts:      false,
@@ -73,6 +76,7 @@ func TestRun(t *testing.T) {
 			Protocol:               conformancev1.Protocol_PROTOCOL_GRPC_WEB,
 			Codec:                  conformancev1.Codec_CODEC_PROTO,
 			Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+			ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 			StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 			UseTLS:                 false,
 			UseTLSClientCerts:      false,
@@ -85,6 +89,7 @@ func TestRun(t *testing.T) {
 			Protocol:               conformancev1.Protocol_PROTOCOL_GRPC,
 			Codec:                  conformancev1.Codec_CODEC_PROTO,
 			Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+			ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 			StreamType:             conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
 			UseTLS:                 false,
 			UseTLSClientCerts:      false,
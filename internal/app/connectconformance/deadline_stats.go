@@ -0,0 +1,224 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// defaultDeadlineTolerance is how far from its timeout an RPC may be
+// terminated with a "deadline exceeded" error, if no tolerance is
+// configured.
+const defaultDeadlineTolerance = 250 * time.Millisecond
+
+// deadlineStats accumulates measurements of how long it took for RPCs,
+// which are expected to fail with a "deadline exceeded" error, to be
+// terminated. The elapsed time is measured by the reference server when
+// testing a client and by the reference client when testing a server.
+// Test cases where the RPC was terminated too early, or far too late, fail.
+// The overshoot (elapsed time minus timeout) can also be reported in
+// aggregate, grouped by protocol and HTTP version.
+type deadlineStats struct {
+	tolerance time.Duration
+	// The fraction of the timeout by which an RPC may overshoot it, in
+	// addition to the tolerance.
+	overshootRatio float64
+
+	mu       sync.Mutex
+	expected map[string]deadlineExpectation
+	elapsed  map[string]*deadlineMeasurement
+}
+
+// deadlineMeasurement is how long the first attempt of the RPC for a test
+// case took to be terminated, along with how many attempts were measured.
+// When a client retries, or issues several RPCs for one test case, later
+// attempts start after the first, so only the first is checked.
+type deadlineMeasurement struct {
+	first    time.Duration
+	attempts int
+}
+
+// deadlineExpectation is the timeout for a test case, along with the group
+// whose statistics include it.
+type deadlineExpectation struct {
+	timeout time.Duration
+	group   deadlineStatsGroup
+}
+
+// deadlineStatsGroup identifies a group of test cases whose overshoots
+// are aggregated together.
+type deadlineStatsGroup struct {
+	protocol    conformancev1.Protocol
+	httpVersion conformancev1.HTTPVersion
+}
+
+func (g deadlineStatsGroup) String() string {
+	return fmt.Sprintf("%s, %s",
+		strings.TrimPrefix(g.protocol.String(), "PROTOCOL_"),
+		strings.TrimPrefix(g.httpVersion.String(), "HTTP_VERSION_"))
+}
+
+func (g deadlineStatsGroup) less(other deadlineStatsGroup) bool {
+	if g.protocol != other.protocol {
+		return g.protocol < other.protocol
+	}
+	return g.httpVersion < other.httpVersion
+}
+
+// newDeadlineStats creates a new deadlineStats that allows the elapsed time
+// to differ from the timeout by up to the given tolerance. The elapsed time
+// may additionally exceed the timeout by the given fraction of the timeout.
+func newDeadlineStats(tolerance time.Duration, overshootRatio float64) *deadlineStats {
+	return &deadlineStats{
+		tolerance:      tolerance,
+		overshootRatio: overshootRatio,
+		expected:       map[string]deadlineExpectation{},
+		elapsed:        map[string]*deadlineMeasurement{},
+	}
+}
+
+// register records the timeout for the given test case, if it has one
+// and is expected to fail with a "deadline exceeded" error.
+func (d *deadlineStats) register(testCase string, definition *conformancev1.TestCase) {
+	if d == nil || definition.Request.TimeoutMs == nil ||
+		definition.ExpectedResponse.GetError().GetCode() != conformancev1.Code_CODE_DEADLINE_EXCEEDED {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.expected[testCase] = deadlineExpectation{
+		timeout: time.Duration(definition.Request.GetTimeoutMs()) * time.Millisecond,
+		group: deadlineStatsGroup{
+			protocol:    definition.Request.Protocol,
+			httpVersion: definition.Request.HttpVersion,
+		},
+	}
+}
+
+// add records the time it took for the given attempt, starting at one, of
+// the RPC in the given test case to be terminated.
+func (d *deadlineStats) add(testCase string, attempt int, elapsed time.Duration) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	measurement := d.elapsed[testCase]
+	if measurement == nil {
+		measurement = &deadlineMeasurement{}
+		d.elapsed[testCase] = measurement
+	}
+	measurement.attempts++
+	if attempt == 1 {
+		measurement.first = elapsed
+	}
+}
+
+// failures returns an error message for each test case whose RPC was
+// terminated too early or too late. It is too early if it was terminated
+// before its timeout, less the tolerance. This is only checked when there
+// was one attempt, since the first of several attempts can fail early for
+// other reasons. It is too late if the overshoot is more than the tolerance
+// plus the overshoot ratio times the timeout.
+func (d *deadlineStats) failures() map[string]string {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	failures := map[string]string{}
+	for testCase, measurement := range d.elapsed {
+		expected, ok := d.expected[testCase]
+		if !ok {
+			continue
+		}
+		elapsed := measurement.first
+		overshoot := elapsed - expected.timeout
+		if limit := d.overshootLimit(expected.timeout); overshoot > limit {
+			failures[testCase] = fmt.Sprintf("RPC was terminated after %dms, but the timeout was %dms (it may overshoot by at most %dms)",
+				elapsed.Milliseconds(), expected.timeout.Milliseconds(), limit.Milliseconds())
+		} else if overshoot < -d.tolerance && measurement.attempts == 1 {
+			failures[testCase] = fmt.Sprintf("RPC was terminated after %dms, before the timeout of %dms (tolerance is %dms)",
+				elapsed.Milliseconds(), expected.timeout.Milliseconds(), d.tolerance.Milliseconds())
+		}
+	}
+	return failures
+}
+
+// overshootLimit returns how far an RPC with the given timeout may overshoot it.
+func (d *deadlineStats) overshootLimit(timeout time.Duration) time.Duration {
+	return d.tolerance + time.Duration(d.overshootRatio*float64(timeout))
+}
+
+// report prints the distribution of overshoots, grouped by protocol and
+// HTTP version. The given description identifies the implementation whose
+// timeouts were measured.
+func (d *deadlineStats) report(description string, printer internal.Printer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	byGroup := map[deadlineStatsGroup][]time.Duration{}
+	for testCase, measurement := range d.elapsed {
+		expected, ok := d.expected[testCase]
+		if !ok {
+			continue
+		}
+		byGroup[expected.group] = append(byGroup[expected.group], measurement.first-expected.timeout)
+	}
+	groups := make([]deadlineStatsGroup, 0, len(byGroup))
+	for group := range byGroup {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].less(groups[j])
+	})
+
+	printer.Printf("Deadline overshoot for %s (ms, tolerance %d, overshoot ratio %g):",
+		description, d.tolerance.Milliseconds(), d.overshootRatio)
+	if len(groups) == 0 {
+		printer.Printf("  no measurements")
+		return
+	}
+	for _, group := range groups {
+		overshoots := byGroup[group]
+		sort.Slice(overshoots, func(i, j int) bool {
+			return overshoots[i] < overshoots[j]
+		})
+		printer.Printf("  %-24s min %5d, p50 %5d, p90 %5d, p99 %5d, max %5d (%d test cases)",
+			group.String()+":",
+			overshoots[0].Milliseconds(),
+			percentile(overshoots, 50).Milliseconds(),
+			percentile(overshoots, 90).Milliseconds(),
+			percentile(overshoots, 99).Milliseconds(),
+			overshoots[len(overshoots)-1].Milliseconds(),
+			len(overshoots))
+	}
+}
+
+// percentile returns the given percentile of the given sorted values,
+// using the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,108 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDeadlineStats(t *testing.T) {
+	t.Parallel()
+	testCase := func(protocol conformancev1.Protocol, timeoutMs *uint32, code conformancev1.Code) *conformancev1.TestCase {
+		return &conformancev1.TestCase{
+			Request: &conformancev1.ClientCompatRequest{
+				Protocol:    protocol,
+				HttpVersion: conformancev1.HTTPVersion_HTTP_VERSION_2,
+				TimeoutMs:   timeoutMs,
+			},
+			ExpectedResponse: &conformancev1.ClientResponseResult{
+				Error: &conformancev1.Error{Code: code},
+			},
+		}
+	}
+	stats := newDeadlineStats(100*time.Millisecond, 0)
+	stats.register("grpc/on-time", testCase(conformancev1.Protocol_PROTOCOL_GRPC, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	stats.register("grpc/late", testCase(conformancev1.Protocol_PROTOCOL_GRPC, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	stats.register("grpc/slow", testCase(conformancev1.Protocol_PROTOCOL_GRPC, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	stats.register("connect/early", testCase(conformancev1.Protocol_PROTOCOL_CONNECT, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	stats.register("connect/retried", testCase(conformancev1.Protocol_PROTOCOL_CONNECT, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	stats.register("connect/canceled", testCase(conformancev1.Protocol_PROTOCOL_CONNECT, proto.Uint32(200), conformancev1.Code_CODE_CANCELED))
+	stats.register("connect/no-timeout", testCase(conformancev1.Protocol_PROTOCOL_CONNECT, nil, conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	stats.add("grpc/on-time", 1, 250*time.Millisecond)
+	stats.add("grpc/late", 1, 5*time.Second)
+	stats.add("grpc/slow", 1, 350*time.Millisecond)
+	stats.add("connect/early", 1, 50*time.Millisecond)
+	// Later attempts are not measured, and the first
+	// may fail early, as long as it is not late.
+	stats.add("connect/retried", 2, 150*time.Millisecond)
+	stats.add("connect/retried", 1, 40*time.Millisecond)
+	stats.add("connect/canceled", 1, 5*time.Second)
+	stats.add("connect/no-timeout", 1, 5*time.Second)
+
+	assert.Equal(t, map[string]string{
+		"grpc/late":     "RPC was terminated after 5000ms, but the timeout was 200ms (it may overshoot by at most 100ms)",
+		"grpc/slow":     "RPC was terminated after 350ms, but the timeout was 200ms (it may overshoot by at most 100ms)",
+		"connect/early": "RPC was terminated after 50ms, before the timeout of 200ms (tolerance is 100ms)",
+	}, stats.failures())
+
+	// With an overshoot ratio, slow RPCs with a longer timeout are
+	// allowed to overshoot it by more.
+	lenient := newDeadlineStats(100*time.Millisecond, 0.5)
+	lenient.register("grpc/slow", testCase(conformancev1.Protocol_PROTOCOL_GRPC, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	lenient.register("grpc/late", testCase(conformancev1.Protocol_PROTOCOL_GRPC, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	lenient.add("grpc/slow", 1, 350*time.Millisecond)
+	lenient.add("grpc/late", 1, 450*time.Millisecond)
+	assert.Equal(t, map[string]string{
+		"grpc/late": "RPC was terminated after 450ms, but the timeout was 200ms (it may overshoot by at most 200ms)",
+	}, lenient.failures())
+
+	var printer internal.SimplePrinter
+	stats.report("the client under test", &printer)
+	var lines []string
+	for _, msg := range printer.Messages {
+		lines = append(lines, strings.TrimSuffix(msg, "\n"))
+	}
+	assert.Equal(t, []string{
+		"Deadline overshoot for the client under test (ms, tolerance 100, overshoot ratio 0):",
+		"  CONNECT, 2:              min  -160, p50  -160, p90  -150, p99  -150, max  -150 (2 test cases)",
+		"  GRPC, 2:                 min    50, p50   150, p90  4800, p99  4800, max  4800 (3 test cases)",
+	}, lines)
+
+	// Methods on a nil deadlineStats are no-ops.
+	var disabled *deadlineStats
+	disabled.register("grpc/on-time", testCase(conformancev1.Protocol_PROTOCOL_GRPC, proto.Uint32(200), conformancev1.Code_CODE_DEADLINE_EXCEEDED))
+	disabled.add("grpc/on-time", 1, time.Second)
+	assert.Empty(t, disabled.failures())
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+	values := make([]time.Duration, 0, 100)
+	for i := 1; i <= 100; i++ {
+		values = append(values, time.Duration(i))
+	}
+	assert.Equal(t, time.Duration(1), percentile(values, 0))
+	assert.Equal(t, time.Duration(50), percentile(values, 50))
+	assert.Equal(t, time.Duration(90), percentile(values, 90))
+	assert.Equal(t, time.Duration(100), percentile(values, 100))
+	assert.Equal(t, time.Duration(7), percentile(values[6:7], 99))
+}
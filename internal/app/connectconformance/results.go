@@ -17,20 +17,26 @@ package connectconformance
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/tracer"
 	"connectrpc.com/connect"
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/net/http2"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -57,23 +63,159 @@ type testResults struct {
 	totalTestCount int
 	knownFailing   *testTrie
 	knownFlaky     *testTrie
-	tracer         *tracer.Tracer
+	clientTracer   *tracer.Tracer
+	serverTracer   *tracer.Tracer
+	// If non-empty, packet captures for failed test cases
+	// are written to files in this directory.
+	captureDir string
+	// If non-empty, recordings of the HTTP exchanges for failed
+	// test cases are written to files in this directory.
+	recordDir string
+	// If non-nil, statistics are computed from
+	// the traces for all test cases.
+	wireStats *wireStats
+	// If non-nil, the time it takes for RPCs to fail with
+	// "deadline exceeded" is checked against their timeout.
+	deadlines *deadlineStats
 
 	traceWaitGroup sync.WaitGroup
 
 	mu             sync.Mutex
 	outcomes       map[string]testOutcome
-	traces         map[string]*tracer.Trace
+	traces         map[string]*testTraces
 	serverSideband map[string]string
+	// The number of connections that the reference server accepted, and
+	// the number of test cases it served, keyed by server config.
+	connections map[string]*connectionCount
 }
 
-func newResults(mode conformancev1.TestSuite_TestMode, totalTestCount int, knownFailing, knownFlaky *testTrie, tracer *tracer.Tracer) *testResults {
+type connectionCount struct {
+	connections, testCases int
+}
+
+// testTraces are the HTTP traces for a test case. Either may be nil if
+// the corresponding side of the RPC was not traced, or if its trace could
+// not be retrieved. In the latter case, the corresponding error is set.
+type testTraces struct {
+	client, server       *tracer.Trace
+	clientErr, serverErr error
+}
+
+func (t *testTraces) print(printer internal.Printer) {
+	switch {
+	case t.client != nil && t.server != nil:
+		printer.Printf("---- HTTP Trace (client and server) ----")
+		tracer.PrintCorrelated(t.client, t.server, printer)
+	case t.clientErr != nil || t.serverErr != nil:
+		// Both sides were traced, but at least one trace is missing. Say
+		// so explicitly, so it isn't mistaken for a complete timeline.
+		printer.Printf("---- HTTP Trace (client and server) ----")
+		if t.clientErr != nil {
+			printer.Printf("!! client trace unavailable: %v", t.clientErr)
+		}
+		if t.serverErr != nil {
+			printer.Printf("!! server trace unavailable: %v", t.serverErr)
+		}
+		if t.client != nil {
+			t.client.Print(printer)
+		}
+		if t.server != nil {
+			t.server.Print(printer)
+		}
+	case t.client != nil:
+		printer.Printf("---- HTTP Trace ----")
+		t.client.Print(printer)
+	default:
+		printer.Printf("---- HTTP Trace ----")
+		t.server.Print(printer)
+	}
+	printer.Printf("--------------------")
+}
+
+// writePacketCaptures writes the packet captures for the given test case,
+// if any were recorded, to files in the given directory.
+func (t *testTraces) writePacketCaptures(dir, testCase string, printer internal.Printer) {
+	for _, side := range []struct {
+		name  string
+		trace *tracer.Trace
+	}{{"client", t.client}, {"server", t.server}} {
+		if side.trace == nil || !side.trace.HasPacketCapture() {
+			continue
+		}
+		path := filepath.Join(dir, traceFileName(testCase, side.name, "pcapng"))
+		if err := writeTraceFile(path, side.trace.WritePacketCapture); err != nil {
+			printer.Printf("Failed to write %s packet capture: %v", side.name, err)
+			continue
+		}
+		printer.Printf("Packet capture (%s): %s", side.name, path)
+	}
+}
+
+// writeRecordings writes recordings of the HTTP exchanges for the given
+// test case to files in the given directory. The recordings can be used
+// with the replay command.
+func (t *testTraces) writeRecordings(dir, testCase string, printer internal.Printer) {
+	for _, side := range []struct {
+		name  string
+		trace *tracer.Trace
+	}{{"client", t.client}, {"server", t.server}} {
+		if side.trace == nil {
+			continue
+		}
+		path := filepath.Join(dir, traceFileName(testCase, side.name, "json"))
+		err := writeTraceFile(path, func(w io.Writer) error {
+			data, err := json.MarshalIndent(side.trace.Record(), "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+		if err != nil {
+			printer.Printf("Failed to write %s recording: %v", side.name, err)
+			continue
+		}
+		printer.Printf("Recording (%s): %s", side.name, path)
+	}
+}
+
+func writeTraceFile(path string, write func(io.Writer) error) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return write(file)
+}
+
+// traceFileName returns the name of the file, with the given extension,
+// for a trace artifact of the given test case and side. Characters in the
+// test case name that are not safe for use in a file name are replaced
+// with underscores.
+func traceFileName(testCase, side, extension string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, testCase)
+	return name + "." + side + "." + extension
+}
+
+func newResults(mode conformancev1.TestSuite_TestMode, totalTestCount int, knownFailing, knownFlaky *testTrie, clientTracer, serverTracer *tracer.Tracer) *testResults {
 	return &testResults{
 		mode:           mode,
 		totalTestCount: totalTestCount,
 		knownFailing:   knownFailing,
 		knownFlaky:     knownFlaky,
-		tracer:         tracer,
+		clientTracer:   clientTracer,
+		serverTracer:   serverTracer,
 		outcomes:       map[string]testOutcome{},
 		serverSideband: map[string]string{},
 	}
@@ -101,19 +243,29 @@ func (r *testResults) setOutcomeLocked(testCase string, setupError bool, err err
 
 //nolint:contextcheck,nolintlint // intentionally using context.Background; nolintlint incorrectly complains about this
 func (r *testResults) fetchTrace(testCase string) {
-	if r.tracer == nil {
+	if r.clientTracer == nil && r.serverTracer == nil {
 		return
 	}
 	r.traceWaitGroup.Add(1)
 	go func() {
 		defer r.traceWaitGroup.Done()
-		ctx, cancel := context.WithTimeout(context.Background(), tracer.TraceTimeout)
-		defer cancel()
-		trace, err := r.tracer.Await(ctx, testCase)
-		r.tracer.Clear(testCase)
-		if err != nil {
+		var traces testTraces
+		var clientErr, serverErr error
+		if r.clientTracer != nil {
+			traces.client, clientErr = awaitTrace(r.clientTracer, testCase)
+		}
+		if r.serverTracer != nil {
+			traces.server, serverErr = awaitTrace(r.serverTracer, testCase)
+		}
+		if traces.client == nil && traces.server == nil {
 			return
 		}
+		if r.clientTracer != nil && r.serverTracer != nil {
+			// Only report a missing side when the other side is present,
+			// since a merged timeline would otherwise hide the difference.
+			traces.clientErr, traces.serverErr = clientErr, serverErr
+		}
+		r.wireStats.add(testCase, &traces)
 
 		r.mu.Lock()
 		defer r.mu.Unlock()
@@ -122,12 +274,41 @@ func (r *testResults) fetchTrace(testCase string) {
 			return
 		}
 		if r.traces == nil {
-			r.traces = map[string]*tracer.Trace{}
+			r.traces = map[string]*testTraces{}
 		}
-		r.traces[testCase] = trace
+		r.traces[testCase] = &traces
 	}()
 }
 
+// awaitTrace waits for the trace for the given test case and then clears
+// it from the given tracer. Each call has its own timeout, so that a late
+// trace from one side does not shorten the wait for the other side.
+func awaitTrace(tr *tracer.Tracer, testCase string) (*tracer.Trace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tracer.TraceTimeout)
+	defer cancel()
+	defer tr.Clear(testCase)
+	return tr.Await(ctx, testCase)
+}
+
+// addConnections records that a reference server with the given config
+// accepted the given number of connections while serving the given number
+// of test cases.
+func (r *testResults) addConnections(svrInstance serverInstance, numConnections, numTestCases int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.connections == nil {
+		r.connections = map[string]*connectionCount{}
+	}
+	key := svrInstance.configString()
+	count := r.connections[key]
+	if count == nil {
+		count = &connectionCount{}
+		r.connections[key] = count
+	}
+	count.connections += numConnections
+	count.testCases += numTestCases
+}
+
 // failedToStart marks all the given test cases with the given setup error.
 // This convenience method is to mark many tests in a batch when the relevant
 // server process could not be started.
@@ -217,8 +398,29 @@ func (r *testResults) assert(
 		errs = append(errs, checkHeaders("response trailers", expected.ResponseTrailers, actual.ResponseTrailers)...)
 	}
 
+	if definition.Request.GetCancel().GetAfterNumRequests() != nil &&
+		expected.NumUnsentRequests != actual.NumUnsentRequests {
+		// The client canceled in the middle of the request stream, so
+		// it should report the requests that it did not send.
+		errs = append(errs, fmt.Errorf("actual number of unsent requests does not match: wanted %d; got %d",
+			expected.NumUnsentRequests, actual.NumUnsentRequests))
+	}
+
+	errs = append(errs, checkHTTP2Outcome(expected.Http2Outcome, actual.Http2Outcome, definition.OtherAllowedHttp2Outcomes)...)
+	errs = append(errs, checkServerObservation(expected.ServerObservation, actual.ServerObservation)...)
+	errs = append(errs, checkReceiveTimes(expected.PayloadReceiveTimesMs, actual.PayloadReceiveTimesMs, len(actual.Payloads))...)
+
+	r.deadlines.register(testCase, definition)
+	if actual.ElapsedMs != nil {
+		r.deadlines.add(testCase, 1, time.Duration(actual.GetElapsedMs())*time.Millisecond)
+	}
+
+	// If the actual error code is one of the other allowed codes, the status
+	// code is not checked, since a different error usually comes with a
+	// different status.
 	if expected.HttpStatusCode != nil &&
 		actual.HttpStatusCode != nil &&
+		(expected.Error == nil || expected.Error.Code == actual.Error.GetCode()) &&
 		expected.GetHttpStatusCode() != actual.GetHttpStatusCode() {
 		errs = append(errs, fmt.Errorf("actual HTTP status code does not match: wanted %d; got %d",
 			expected.GetHttpStatusCode(), actual.GetHttpStatusCode()))
@@ -241,18 +443,24 @@ func (r *testResults) recordSideband(testCase string, errMsg string) {
 // is created.
 func (r *testResults) processSidebandInfoLocked() {
 	for name, msg := range r.serverSideband {
-		outcome, ok := r.outcomes[name]
-		if ok {
-			// Update outcome to include reference server's feedback
-			if outcome.actualFailure == nil {
-				outcome.actualFailure = errors.New(msg)
-			} else {
-				outcome.actualFailure = fmt.Errorf("%s; %w", msg, outcome.actualFailure)
-			}
-			r.outcomes[name] = outcome
+		r.addFailureLocked(name, msg)
+	}
+}
+
+// addFailureLocked adds the given error message to the outcome
+// of the named test case.
+func (r *testResults) addFailureLocked(name string, msg string) {
+	outcome, ok := r.outcomes[name]
+	if ok {
+		// Update outcome to include reference server's feedback
+		if outcome.actualFailure == nil {
+			outcome.actualFailure = errors.New(msg)
 		} else {
-			r.setOutcomeLocked(name, false, errors.New(msg))
+			outcome.actualFailure = fmt.Errorf("%s; %w", msg, outcome.actualFailure)
 		}
+		r.outcomes[name] = outcome
+	} else {
+		r.setOutcomeLocked(name, false, errors.New(msg))
 	}
 }
 
@@ -264,6 +472,9 @@ func (r *testResults) report(printer internal.Printer) bool {
 		r.processSidebandInfoLocked()
 		r.serverSideband = map[string]string{}
 	}
+	for name, msg := range r.deadlines.failures() {
+		r.addFailureLocked(name, msg)
+	}
 	testCaseNames := make([]string, 0, len(r.outcomes))
 	for testCaseName := range r.outcomes {
 		testCaseNames = append(testCaseNames, testCaseName)
@@ -287,11 +498,14 @@ func (r *testResults) report(printer internal.Printer) bool {
 			couldNotRun++
 		case !expectError && outcome.actualFailure != nil:
 			printer.Printf("FAILED: %s:\n%s", name, indent(outcome.actualFailure.Error()))
-			trace := r.traces[name]
-			if trace != nil {
-				printer.Printf("---- HTTP Trace ----")
-				trace.Print(printer)
-				printer.Printf("--------------------")
+			if traces := r.traces[name]; traces != nil {
+				traces.print(printer)
+				if r.captureDir != "" {
+					traces.writePacketCaptures(r.captureDir, name, printer)
+				}
+				if r.recordDir != "" {
+					traces.writeRecordings(r.recordDir, name, printer)
+				}
 			}
 			failed++
 		case expectError && outcome.actualFailure == nil:
@@ -316,6 +530,20 @@ func (r *testResults) report(printer internal.Printer) bool {
 	if expectedFailures > 0 {
 		printer.Printf("(Another %d failed as expected due to being known failures/flakes.)", expectedFailures)
 	}
+	if len(r.connections) > 0 {
+		// Clients should reuse connections, so these are ideally much
+		// smaller than the number of test cases.
+		configs := make([]string, 0, len(r.connections))
+		for config := range r.connections {
+			configs = append(configs, config)
+		}
+		sort.Strings(configs)
+		printer.Printf("\nConnections accepted by the reference server:")
+		for _, config := range configs {
+			count := r.connections[config]
+			printer.Printf("  %s: %d connection(s) for %d test case(s)", config, count.connections, count.testCases)
+		}
+	}
 	return failed == 0
 }
 
@@ -563,6 +791,101 @@ func checkError(expected, actual *conformancev1.Error, otherCodes []conformancev
 	return errs
 }
 
+func checkHTTP2Outcome(expected, actual *conformancev1.HTTP2StreamOutcome, otherOutcomes []*conformancev1.HTTP2StreamOutcome) multiErrors {
+	if expected == nil {
+		// Outcome is only checked when test case indicates one.
+		return nil
+	}
+	if actual == nil {
+		return multiErrors{errors.New("expecting an HTTP/2 stream outcome but received none")}
+	}
+	for _, allowed := range append([]*conformancev1.HTTP2StreamOutcome{expected}, otherOutcomes...) {
+		if proto.Equal(allowed, actual) {
+			return nil
+		}
+	}
+	return multiErrors{fmt.Errorf("actual HTTP/2 stream outcome {%s} does not match expected outcome {%s}",
+		http2OutcomeString(actual), http2OutcomeString(expected))}
+}
+
+func checkServerObservation(expected, actual *conformancev1.ServerObservation) multiErrors {
+	if expected == nil {
+		// Observation is only checked when test case indicates one.
+		return nil
+	}
+	if actual == nil {
+		return multiErrors{errors.New("expecting the server's observation of the RPC but received none")}
+	}
+	if !expected.Canceled {
+		if actual.Canceled {
+			return multiErrors{fmt.Errorf("server observed the RPC being canceled (with code %s) after %d ms, but it should have completed normally",
+				connect.Code(actual.Code), actual.CanceledAfterMs)}
+		}
+		return nil
+	}
+	if !actual.Canceled {
+		return multiErrors{fmt.Errorf("server should have observed the RPC being canceled (with code %s), but it completed normally",
+			connect.Code(expected.Code))}
+	}
+	var errs multiErrors
+	// When the deadline elapses, the client also cancels the RPC. So the
+	// server may observe that before its own deadline timer fires.
+	if actual.Code != expected.Code &&
+		(expected.Code != conformancev1.Code_CODE_DEADLINE_EXCEEDED || actual.Code != conformancev1.Code_CODE_CANCELED) {
+		errs = append(errs, fmt.Errorf("server observed the RPC being canceled with code %s, but it should have been %s",
+			connect.Code(actual.Code), connect.Code(expected.Code)))
+	}
+	if expected.CanceledAfterMs > 0 {
+		// The server starts its clock a little after the client.
+		minAllowed := max(int64(expected.CanceledAfterMs)-timeoutCheckGracePeriodMillis, 0)
+		maxAllowed := int64(expected.CanceledAfterMs) + timeoutCheckGracePeriodMillis
+		if actualMs := int64(actual.CanceledAfterMs); actualMs < minAllowed || actualMs > maxAllowed {
+			errs = append(errs, fmt.Errorf("server observed the RPC being canceled after %d ms, but it should have been after about %d ms",
+				actual.CanceledAfterMs, expected.CanceledAfterMs))
+		}
+	}
+	return errs
+}
+
+func checkReceiveTimes(expected, actual []uint32, numPayloads int) multiErrors {
+	if len(expected) == 0 {
+		// Receive times are only checked when test case indicates them.
+		return nil
+	}
+	if len(actual) != numPayloads {
+		return multiErrors{fmt.Errorf("client reported %d payload receive times, but it received %d payloads", len(actual), numPayloads)}
+	}
+	for i := 1; i < len(actual) && i < len(expected); i++ {
+		if actual[i] < actual[i-1] {
+			return multiErrors{fmt.Errorf("client reported payload #%d being received at %d ms, which is before the previous payload (at %d ms)",
+				i+1, actual[i], actual[i-1])}
+		}
+		// The server waits before sending each message. Since network latency
+		// can vary, we only require that the messages be spread out over at
+		// least half of the time it took the server to send them. A client
+		// that buffers the stream will receive them all at the same time.
+		expectedSpread, actualSpread := expected[i]-expected[0], actual[i]-actual[0]
+		if actualSpread < expectedSpread/2 {
+			return multiErrors{fmt.Errorf("client received payload #%d %d ms after the first, but the server sent it about %d ms after the first; "+
+				"the client should provide each message as it arrives instead of buffering the stream",
+				i+1, actualSpread, expectedSpread)}
+		}
+	}
+	return nil
+}
+
+func http2OutcomeString(outcome *conformancev1.HTTP2StreamOutcome) string {
+	parts := []string{fmt.Sprintf("end_stream: %v", outcome.EndStream)}
+	if outcome.RstStreamErrorCode != nil {
+		parts = append(parts, fmt.Sprintf("rst_stream: %v", http2.ErrCode(outcome.GetRstStreamErrorCode())))
+	}
+	if outcome.GoAwayErrorCode != nil {
+		parts = append(parts, fmt.Sprintf("go_away: %v", http2.ErrCode(outcome.GetGoAwayErrorCode())))
+	}
+	parts = append(parts, fmt.Sprintf("connection_alive: %v", outcome.ConnectionAlive))
+	return strings.Join(parts, ", ")
+}
+
 func indent(s string) string {
 	lines := strings.Split(s, "\n")
 	for i, line := range lines {
@@ -22,6 +22,7 @@ import (
 
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -30,7 +31,7 @@ import (
 
 func TestResults_SetOutcome(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("foo/bar/1", false, nil)
 	results.setOutcome("foo/bar/2", true, errors.New("fail"))
 	results.setOutcome("foo/bar/3", false, errors.New("fail"))
@@ -60,7 +61,7 @@ func TestResults_SetOutcome(t *testing.T) {
 
 func TestResults_FailedToStart(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.failedToStart([]*conformancev1.TestCase{
 		{Request: &conformancev1.ClientCompatRequest{TestName: "foo/bar/1"}},
 		{Request: &conformancev1.ClientCompatRequest{TestName: "known-to-fail/1"}},
@@ -80,7 +81,7 @@ func TestResults_FailedToStart(t *testing.T) {
 
 func TestResults_FailRemaining(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("foo/bar/1", false, nil)
 	results.setOutcome("known-to-fail/1", false, errors.New("fail"))
 	results.failRemaining([]*conformancev1.TestCase{
@@ -107,7 +108,7 @@ func TestResults_FailRemaining(t *testing.T) {
 
 func TestResults_Failed(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.failed("foo/bar/1", &conformancev1.ClientErrorResult{Message: "fail"})
 	results.failed("known-to-fail/1", &conformancev1.ClientErrorResult{Message: "fail"})
 
@@ -124,7 +125,7 @@ func TestResults_Failed(t *testing.T) {
 
 func TestResults_Assert(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	payload1 := &conformancev1.ClientResponseResult{
 		Payloads: []*conformancev1.ConformancePayload{
 			{Data: []byte{0, 1, 2, 3, 4}},
@@ -680,12 +681,32 @@ func TestResults_Assert_ReportsAllErrors(t *testing.T) {
 				`actual response trailers missing "xyz"`,
 			},
 		},
+		{
+			name: "http2 outcome mismatch",
+			expected: `{
+				"http2_outcome": {"end_stream": true, "connection_alive": true}
+			}`,
+			actual: `{
+				"http2_outcome": {"rst_stream_error_code": 5, "connection_alive": true}
+			}`,
+			expectedErrors: []string{
+				"actual HTTP/2 stream outcome {end_stream: false, rst_stream: STREAM_CLOSED, connection_alive: true} does not match expected outcome {end_stream: true, connection_alive: true}",
+			},
+		},
+		{
+			name: "http2 outcome missing",
+			expected: `{
+				"http2_outcome": {"end_stream": true, "connection_alive": true}
+			}`,
+			actual:         `{}`,
+			expectedErrors: []string{"expecting an HTTP/2 stream outcome but received none"},
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
-			results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, &testTrie{}, &testTrie{}, nil)
+			results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, &testTrie{}, &testTrie{}, nil, nil)
 
 			expected := &conformancev1.TestCase{
 				Request:          &conformancev1.ClientCompatRequest{StreamType: conformancev1.StreamType_STREAM_TYPE_UNARY},
@@ -720,9 +741,39 @@ func TestResults_Assert_ReportsAllErrors(t *testing.T) {
 	}
 }
 
+func TestCheckHTTP2Outcome(t *testing.T) {
+	t.Parallel()
+	resetOutcome := &conformancev1.HTTP2StreamOutcome{RstStreamErrorCode: proto.Uint32(5), ConnectionAlive: true}
+	goAwayOutcome := &conformancev1.HTTP2StreamOutcome{GoAwayErrorCode: proto.Uint32(1)}
+	assert.Empty(t, checkHTTP2Outcome(nil, resetOutcome, nil))
+	assert.Empty(t, checkHTTP2Outcome(resetOutcome, resetOutcome, nil))
+	assert.Empty(t, checkHTTP2Outcome(resetOutcome, goAwayOutcome, []*conformancev1.HTTP2StreamOutcome{goAwayOutcome}))
+	errs := checkHTTP2Outcome(resetOutcome, goAwayOutcome, nil)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "{end_stream: false, go_away: PROTOCOL_ERROR, connection_alive: false}")
+}
+
+func TestCheckReceiveTimes(t *testing.T) {
+	t.Parallel()
+	expected := []uint32{300, 600, 900}
+	assert.Empty(t, checkReceiveTimes(nil, []uint32{5, 5, 5}, 3))
+	assert.Empty(t, checkReceiveTimes(expected, []uint32{310, 620, 905}, 3))
+	// Latency can vary, so they need only be spread out over half the time.
+	assert.Empty(t, checkReceiveTimes(expected, []uint32{450, 610, 760}, 3))
+	errs := checkReceiveTimes(expected, []uint32{900, 901, 901}, 3)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "client received payload #2 1 ms after the first, but the server sent it about 300 ms after the first")
+	errs = checkReceiveTimes(expected, []uint32{300, 600}, 3)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "client reported 2 payload receive times, but it received 3 payloads")
+	errs = checkReceiveTimes(expected, []uint32{300, 900, 600}, 3)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "which is before the previous payload")
+}
+
 func TestResults_ServerSideband(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("foo/bar/1", false, nil)
 	results.setOutcome("foo/bar/2", false, errors.New("fail"))
 	results.setOutcome("foo/bar/3", false, nil)
@@ -747,7 +798,7 @@ func TestResults_ServerSideband(t *testing.T) {
 
 func TestResults_Report(t *testing.T) {
 	t.Parallel()
-	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	logger := &internal.SimplePrinter{}
 
 	// No test cases? Report success.
@@ -755,47 +806,70 @@ func TestResults_Report(t *testing.T) {
 	require.True(t, success)
 
 	// Only successful outcomes? Report success.
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("foo/bar/1", false, nil)
 	success = results.report(logger)
 	require.True(t, success)
 
 	// Unexpected failure? Report failure.
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("foo/bar/1", false, errors.New("ruh roh"))
 	success = results.report(logger)
 	require.False(t, success)
 
 	// Unexpected failure during setup? Report failure.
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("foo/bar/1", true, errors.New("ruh roh"))
 	success = results.report(logger)
 	require.False(t, success)
 
 	// Expected failure? Report success.
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("known-to-fail/1", false, errors.New("ruh roh"))
 	success = results.report(logger)
 	require.True(t, success)
 
 	// Setup error from expected failure? Report failure (setup errors never acceptable).
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("known-to-fail/1", true, errors.New("ruh roh"))
 	success = results.report(logger)
 	require.False(t, success)
 
 	// Flaky? Report success whether it passes or fails
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("known-to-flake/1", false, nil) // succeeds
 	success = results.report(logger)
 	require.True(t, success)
 
-	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results = newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
 	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
 	success = results.report(logger)
 	require.True(t, success)
 }
 
+func TestResults_ReportConnections(t *testing.T) {
+	t.Parallel()
+	results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, 0, makeKnownFailing(), makeKnownFlaky(), nil, nil)
+	h2 := serverInstance{protocol: conformancev1.Protocol_PROTOCOL_GRPC, httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_2}
+	results.addConnections(h2, 2, 100)
+	// Instances that are separate only to isolate some test cases are
+	// reported together with the others for the same config.
+	h2Reuse := h2
+	h2Reuse.verifiesConnectionReuse = true
+	results.addConnections(h2Reuse, 1, 3)
+	h1 := serverInstance{protocol: conformancev1.Protocol_PROTOCOL_CONNECT, httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1, useTLS: true}
+	results.addConnections(h1, 10, 50)
+
+	logger := &internal.SimplePrinter{}
+	require.True(t, results.report(logger))
+	assert.Equal(t, []string{
+		"Total cases: 0\n0 passed, 0 failed\n",
+		"\nConnections accepted by the reference server:\n",
+		"  {HTTP_VERSION_1, PROTOCOL_CONNECT, TLS:true}: 10 connection(s) for 50 test case(s)\n",
+		"  {HTTP_VERSION_2, PROTOCOL_GRPC, TLS:false}: 3 connection(s) for 103 test case(s)\n",
+	}, logger.Messages)
+}
+
 func TestCanonicalizeHeaderVals(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -888,6 +962,27 @@ func TestExpectedCodeString(t *testing.T) {
 	}
 }
 
+func TestTraceFileName(t *testing.T) {
+	t.Parallel()
+	require.Equal(t,
+		"Client_Cancellation_HTTPVersion_2_Protocol_PROTOCOL_GRPC_server-stream_cancel-after-responses.client.pcapng",
+		traceFileName("Client Cancellation/HTTPVersion:2/Protocol:PROTOCOL_GRPC/server-stream/cancel-after-responses", "client", "pcapng"),
+	)
+}
+
+func TestTestTraces_PrintUnavailable(t *testing.T) {
+	t.Parallel()
+	serverTracer := &tracer.Tracer{}
+	_, err := awaitTrace(serverTracer, "foo/bar/1")
+	require.Error(t, err)
+	traces := &testTraces{client: &tracer.Trace{}, serverErr: err}
+	printer := &internal.SimplePrinter{}
+	traces.print(printer)
+	require.Len(t, printer.Messages, 3)
+	assert.Equal(t, "---- HTTP Trace (client and server) ----\n", printer.Messages[0])
+	assert.Equal(t, "!! server trace unavailable: foo/bar/1: trace already cleared\n", printer.Messages[1])
+}
+
 func makeKnownFailing() *testTrie {
 	return parsePatterns([]string{"known-to-fail/**"})
 }
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -28,6 +29,7 @@ import (
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/tracer"
+	"connectrpc.com/conformance/internal/transcoding"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -46,7 +48,8 @@ const (
 // with the requestWriter or responseReader.
 //
 // If isReferenceServer is true, then the server's stderr will be examined as well, to
-// record out-of-band feedback about the client requests.
+// record out-of-band feedback about the client requests. In that case, the number of
+// connections that the server accepted is returned. Otherwise, zero is returned.
 //
 //nolint:gocyclo
 func runTestCasesForServer(
@@ -63,9 +66,10 @@ func runTestCasesForServer(
 	errPrinter internal.Printer,
 	results *testResults,
 	client clientRunner,
-	tracer *tracer.Tracer,
+	clientTracer *tracer.Tracer,
+	serverTracer *tracer.Tracer,
 	logEach bool,
-) {
+) int {
 	testCaseNameSet := make(map[string]struct{}, len(testCases))
 	for _, testCase := range testCases {
 		testCaseNameSet[testCase.Request.TestName] = struct{}{}
@@ -76,7 +80,7 @@ func runTestCasesForServer(
 	serverProcess, err := startServer(procCtx, isReferenceServer)
 	if err != nil {
 		results.failedToStart(testCases, fmt.Errorf("error starting server: %w", err))
-		return
+		return 0
 	}
 	defer serverProcess.abort()
 	serverProcess.whenDone(func(_ error) {
@@ -84,6 +88,7 @@ func runTestCasesForServer(
 	})
 
 	var refServerFinished chan struct{}
+	var numConnections int
 	if isReferenceServer { //nolint:nestif
 		refServerFinished = make(chan struct{})
 		go func() {
@@ -92,6 +97,24 @@ func runTestCasesForServer(
 			for {
 				origLine, err := r.ReadString('\n')
 				str := strings.TrimSpace(origLine)
+				if count, ok := strings.CutPrefix(str, internal.ConnectionCountPrefix); ok {
+					if num, err := strconv.Atoi(count); err == nil {
+						numConnections = num
+						str = ""
+					}
+				}
+				if elapsed, ok := strings.CutPrefix(str, internal.DeadlineElapsedPrefix); ok {
+					ms, rest, _ := strings.Cut(elapsed, " ")
+					attempt, testCaseName, _ := strings.Cut(rest, " ")
+					num, err := strconv.Atoi(ms)
+					attemptNum, attemptErr := strconv.Atoi(attempt)
+					if err == nil && attemptErr == nil {
+						if _, ok := testCaseNameSet[testCaseName]; ok {
+							results.deadlines.add(testCaseName, attemptNum, time.Duration(num)*time.Millisecond)
+							str = ""
+						}
+					}
+				}
 				if str != "" {
 					var isSideband bool
 					parts := strings.SplitN(str, ": ", 2)
@@ -132,14 +155,15 @@ func runTestCasesForServer(
 		// We always set this. If server-under-test does not support it, we just
 		// won't run the test cases that verify that it's enforced.
 		MessageReceiveLimit: serverReceiveLimit,
+		HeaderSizeLimit:     headerSizeLimit,
 	})
 	if err != nil {
 		results.failedToStart(testCases, fmt.Errorf("error writing server request: %w", err))
-		return
+		return 0
 	}
 	if err := serverProcess.stdin.Close(); err != nil {
 		results.failedToStart(testCases, fmt.Errorf("error writing server request: %w", err))
-		return
+		return 0
 	}
 
 	// Read response.
@@ -147,11 +171,11 @@ func runTestCasesForServer(
 	err = internal.ReadDelimitedMessage(serverProcess.stdout, &resp, "server", serverResponseTimeout, maxServerResponseSize)
 	if err != nil {
 		results.failedToStart(testCases, fmt.Errorf("error reading server response: %w", err))
-		return
+		return 0
 	}
 	if meta.useTLS && len(resp.PemCert) == 0 {
 		results.failedToStart(testCases, errors.New("server config uses TLS, but server response did not indicate a certificate"))
-		return
+		return 0
 	}
 
 	// Send all test cases to the client.
@@ -164,7 +188,7 @@ func runTestCasesForServer(
 			for j := i; j < len(testCases); j++ {
 				results.setOutcome(testCases[j].Request.TestName, true, err)
 			}
-			return
+			return 0
 		}
 		req := proto.Clone(testCase.Request).(*conformancev1.ClientCompatRequest) //nolint:errcheck,forcetypeassert
 		req.Host = resp.Host
@@ -181,12 +205,25 @@ func runTestCasesForServer(
 		if req.RawRequest != nil {
 			req.RawRequest.Headers = append(req.RawRequest.Headers, testCaseHeader)
 		}
+		if req.FetchServerObservation {
+			// Servers only observe RPCs that include this header.
+			req.RequestHeaders = append(req.RequestHeaders, &conformancev1.Header{
+				Name:  strings.ToLower(internal.ExpectServerObservationHeader),
+				Value: []string{"true"},
+			})
+		}
 		if isReferenceServer {
 			// The reference server wants more metadata in headers, to perform add'l validations.
 			httpMethod := http.MethodPost
 			if req.UseGetHttpMethod {
 				httpMethod = http.MethodGet
 			}
+			if req.Protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING {
+				// The method is determined by the RPC's google.api.http annotation.
+				if rule, err := transcoding.RuleForProcedure(req.GetService(), req.GetMethod()); err == nil {
+					httpMethod = rule.Method
+				}
+			}
 			extraHeaders := []*conformancev1.Header{
 				{Name: "x-expect-http-version", Value: []string{strconv.Itoa(int(req.HttpVersion))}},
 				{Name: "x-expect-http-method", Value: []string{httpMethod}},
@@ -195,19 +232,45 @@ func runTestCasesForServer(
 				{Name: "x-expect-compression", Value: []string{strconv.Itoa(int(req.Compression))}},
 				{Name: "x-expect-tls", Value: []string{strconv.FormatBool(len(resp.PemCert) > 0)}},
 			}
+			if len(req.AcceptCompressions) > 0 {
+				acceptCompressions := make([]string, len(req.AcceptCompressions))
+				for i, acceptCompression := range req.AcceptCompressions {
+					acceptCompressions[i] = strconv.Itoa(int(acceptCompression))
+				}
+				extraHeaders = append(
+					extraHeaders,
+					&conformancev1.Header{Name: "x-expect-accept-compression", Value: acceptCompressions},
+				)
+			}
 			if clientCreds != nil {
 				extraHeaders = append(
 					extraHeaders,
 					&conformancev1.Header{Name: "x-expect-client-cert", Value: []string{internal.ClientCertName}},
 				)
 			}
+			if req.SequentialRpcCount > 1 {
+				extraHeaders = append(
+					extraHeaders,
+					&conformancev1.Header{Name: "x-expect-sequential-rpcs", Value: []string{strconv.FormatUint(uint64(req.SequentialRpcCount), 10)}},
+				)
+			}
+			if req.MessageSendLimit > 0 {
+				extraHeaders = append(
+					extraHeaders,
+					&conformancev1.Header{Name: "x-expect-message-send-limit", Value: []string{strconv.FormatUint(uint64(req.MessageSendLimit), 10)}},
+				)
+			}
+			extraHeaders = append(extraHeaders, retryPolicyHeaders(req)...)
+			extraHeaders = append(extraHeaders, cancelHeaders(req)...)
+			extraHeaders = append(extraHeaders, requestDelayHeaders(req)...)
 			req.RequestHeaders = append(req.RequestHeaders, extraHeaders...)
 			if req.RawRequest != nil {
 				req.RawRequest.Headers = append(req.RawRequest.Headers, extraHeaders...)
 			}
 		}
 
-		tracer.Init(req.TestName)
+		clientTracer.Init(req.TestName)
+		serverTracer.Init(req.TestName)
 		wg.Add(1)
 		if logEach {
 			logPrinter.Printf("Sending request for %q...", req.TestName)
@@ -244,6 +307,13 @@ func runTestCasesForServer(
 		}
 	}
 
+	if meta.gracefulShutdownAfterMs > 0 {
+		// The RPCs should still be in progress when the server is asked
+		// to shut down. They are expected to complete normally.
+		timer := time.AfterFunc(time.Duration(meta.gracefulShutdownAfterMs)*time.Millisecond, serverProcess.abort)
+		defer timer.Stop()
+	}
+
 	// Wait for all responses.
 	wg.Wait()
 
@@ -255,6 +325,76 @@ func runTestCasesForServer(
 
 	// If there are any tests without outcomes, mark them now.
 	results.failRemaining(testCases, &failedToGetResultError{errNoOutcome})
+	return numConnections
+}
+
+// retryPolicyHeaders returns headers that describe the given request's
+// retry policy, if any, so the reference server can verify the attempts
+// that it receives.
+func retryPolicyHeaders(req *conformancev1.ClientCompatRequest) []*conformancev1.Header {
+	policy := req.RetryPolicy
+	if policy == nil {
+		return nil
+	}
+	// The backoff before each retry is chosen at random, up to a
+	// limit that grows with each retry. So we indicate that limit
+	// for each attempt after the first.
+	backoffs := make([]string, 0, policy.MaxAttempts)
+	backoff := float64(policy.InitialBackoffMs)
+	for i := uint32(1); i < policy.MaxAttempts; i++ {
+		backoff = min(backoff, float64(policy.MaxBackoffMs))
+		backoffs = append(backoffs, strconv.Itoa(int(math.Ceil(backoff))))
+		backoff *= float64(policy.BackoffMultiplier)
+	}
+	return []*conformancev1.Header{
+		{Name: "x-expect-max-attempts", Value: []string{strconv.FormatUint(uint64(policy.MaxAttempts), 10)}},
+		{Name: "x-expect-retry-backoff-ms", Value: []string{strings.Join(backoffs, ",")}},
+	}
+}
+
+// cancelHeaders returns a header that describes when the client should
+// cancel the given request, if at all, so the reference server can verify
+// that the cancellation reaches it.
+func cancelHeaders(req *conformancev1.ClientCompatRequest) []*conformancev1.Header {
+	if req.Cancel == nil {
+		return nil
+	}
+	timing, err := internal.GetCancelTiming(req.Cancel)
+	if err != nil {
+		return nil
+	}
+	var val string
+	switch {
+	case timing.BeforeCloseSend != nil:
+		val = "before-close-send"
+	case timing.AfterNumResponses >= 0:
+		val = "after-num-responses=" + strconv.Itoa(timing.AfterNumResponses)
+	case timing.AfterNumRequests >= 0:
+		val = "after-num-requests=" + strconv.Itoa(timing.AfterNumRequests)
+		if timing.AfterNumRequestsDelayMs > 0 {
+			val += ";delay-ms=" + strconv.Itoa(timing.AfterNumRequestsDelayMs)
+		}
+	default:
+		val = "after-close-send-ms=" + strconv.Itoa(timing.AfterCloseSendMs)
+	}
+	return []*conformancev1.Header{{Name: "x-expect-cancel", Value: []string{val}}}
+}
+
+// requestDelayHeaders returns the header that tells the reference server
+// how long the client waits before sending each request message, so that
+// it can verify that the client does not buffer the request stream.
+func requestDelayHeaders(req *conformancev1.ClientCompatRequest) []*conformancev1.Header {
+	if req.RequestDelayMs == 0 || len(req.RequestMessages) < 2 || req.RawRequest != nil {
+		return nil
+	}
+	switch req.StreamType { //nolint:exhaustive
+	case conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
+		return []*conformancev1.Header{{Name: "x-expect-request-delay-ms", Value: []string{strconv.FormatUint(uint64(req.RequestDelayMs), 10)}}}
+	default:
+		return nil
+	}
 }
 
 type couldNotRunError struct {
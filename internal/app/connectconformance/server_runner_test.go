@@ -57,6 +57,7 @@ func TestRunTestCasesForServer(t *testing.T) {
 		UseTls:              false,
 		ClientTlsCert:       nil,
 		MessageReceiveLimit: 200 * 1024,
+		HeaderSizeLimit:     16 * 1024,
 	})
 	require.NoError(t, err)
 	expectedSvrReqData := expectedSvrReqBuf.Bytes()
@@ -195,7 +196,7 @@ func TestRunTestCasesForServer(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
-			results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, len(requests), &testTrie{}, &testTrie{}, nil)
+			results := newResults(conformancev1.TestSuite_TEST_MODE_UNSPECIFIED, len(requests), &testTrie{}, &testTrie{}, nil, nil)
 
 			var procAddr atomic.Pointer[process] // populated when server process created
 			var actualSvrRequest bytes.Buffer
@@ -276,6 +277,7 @@ func TestRunTestCasesForServer(t *testing.T) {
 				results,
 				&client,
 				nil,
+				nil,
 				false,
 			)
 
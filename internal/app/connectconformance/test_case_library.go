@@ -15,17 +15,22 @@
 package connectconformance
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"path"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"buf.build/go/protoyaml"
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/conformance/internal/transcoding"
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -38,6 +43,12 @@ const (
 	// response (which echoes back the request data) won't exceed client limit.
 	clientReceiveLimit = 1024 * 1024 // 1 MB
 	serverReceiveLimit = 200 * 1024  // 200 KB
+	// This is less than the server's limit, so that the server will
+	// not reject a request that exceeds the client's limit.
+	clientSendLimit = 100 * 1024 // 100 KB
+	// The same limit is used for request headers (on the server) and for
+	// response headers and trailers (on the client).
+	headerSizeLimit = 16 * 1024 // 16 KB
 
 	// these are inserted into test case permutation names for the permutations
 	// of a test case that use the grpc-go implementation of a reference client
@@ -133,9 +144,38 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 	if suite.ConnectVersionMode == conformancev1.TestSuite_CONNECT_VERSION_MODE_REQUIRE && !only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_CONNECT) {
 		return fmt.Errorf("suite %q is misconfigured: it requires Connect Version headers, but has unexpected relevant protocols: %v", suite.Name, suite.RelevantProtocols)
 	}
+	if suite.ReliesOnMessageSendLimit && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+		return fmt.Errorf("suite %q is misconfigured: it relies on message send limit, but mode is not TEST_MODE_CLIENT", suite.Name)
+	}
+	if suite.ReliesOnRetries && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+		return fmt.Errorf("suite %q is misconfigured: it relies on retries, but mode is not TEST_MODE_CLIENT", suite.Name)
+	}
+	if suite.ReliesOnServerObservations && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+		return fmt.Errorf("suite %q is misconfigured: it relies on server observations, but mode is not TEST_MODE_SERVER", suite.Name)
+	}
+	if suite.ReliesOnHeaderSizeLimit && suite.Mode == conformancev1.TestSuite_TEST_MODE_UNSPECIFIED {
+		return fmt.Errorf("suite %q is misconfigured: it relies on header size limit, but mode is not set", suite.Name)
+	}
+	if suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+		for _, testCase := range suite.TestCases {
+			if testCase.GracefulShutdownAfterMs > 0 {
+				return fmt.Errorf("suite %q is misconfigured: test case %q uses graceful shutdown, but mode is not TEST_MODE_SERVER", suite.Name, testCase.Request.GetTestName())
+			}
+		}
+	}
+	if suite.ReliesOnAsymmetricCompression && len(suite.RelevantCompressions) == 1 {
+		return fmt.Errorf("suite %q is misconfigured: it relies on asymmetric compression, but has only one relevant compression", suite.Name)
+	}
 	protocols := suite.RelevantProtocols
 	if len(protocols) == 0 {
-		protocols = allProtocols
+		// REST transcoding only applies to the TranscodingService, so
+		// it is only relevant to suites that explicitly name it.
+		protocols = make([]conformancev1.Protocol, 0, len(allProtocols))
+		for _, protocol := range allProtocols {
+			if protocol != conformancev1.Protocol_PROTOCOL_REST_TRANSCODING {
+				protocols = append(protocols, protocol)
+			}
+		}
 	}
 	for _, protocol := range protocols {
 		httpVersions := suite.RelevantHttpVersions
@@ -158,23 +198,39 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 						compressions = allCompressions
 					}
 					for _, compression := range compressions {
-						for _, streamType := range allStreamTypes {
-							cfgCase := configCase{
-								Version:                httpVersion,
-								Protocol:               protocol,
-								Codec:                  codec,
-								Compression:            compression,
-								StreamType:             streamType,
-								UseTLS:                 tlsCase,
-								UseTLSClientCerts:      suite.ReliesOnTlsClientCerts,
-								UseConnectGET:          suite.ReliesOnConnectGet,
-								ConnectVersionMode:     suite.ConnectVersionMode,
-								UseMessageReceiveLimit: suite.ReliesOnMessageReceiveLimit,
+						responseCompressions := []conformancev1.Compression{compression}
+						if suite.ReliesOnAsymmetricCompression {
+							responseCompressions = make([]conformancev1.Compression, 0, len(compressions)-1)
+							for _, responseCompression := range compressions {
+								if responseCompression != compression {
+									responseCompressions = append(responseCompressions, responseCompression)
+								}
 							}
-							if _, ok := configCases[cfgCase]; ok {
-								namePrefix := generateTestCasePrefix(suite, cfgCase)
-								if err := lib.expandCases(cfgCase, namePrefix, suite.TestCases); err != nil {
-									return fmt.Errorf("failed to expand test cases for suite %s: %w", suite.Name, err)
+						}
+						for _, responseCompression := range responseCompressions {
+							for _, streamType := range allStreamTypes {
+								cfgCase := configCase{
+									Version:                httpVersion,
+									Protocol:               protocol,
+									Codec:                  codec,
+									Compression:            compression,
+									ResponseCompression:    responseCompression,
+									StreamType:             streamType,
+									UseTLS:                 tlsCase,
+									UseTLSClientCerts:      suite.ReliesOnTlsClientCerts,
+									UseConnectGET:          suite.ReliesOnConnectGet,
+									ConnectVersionMode:     suite.ConnectVersionMode,
+									UseMessageReceiveLimit: suite.ReliesOnMessageReceiveLimit,
+									UseMessageSendLimit:    suite.ReliesOnMessageSendLimit,
+									UseRetries:             suite.ReliesOnRetries,
+									UseServerObservations:  suite.ReliesOnServerObservations,
+									UseHeaderSizeLimit:     suite.ReliesOnHeaderSizeLimit,
+								}
+								if _, ok := configCases[cfgCase]; ok {
+									namePrefix := generateTestCasePrefix(suite, cfgCase)
+									if err := lib.expandCases(cfgCase, namePrefix, suite.TestCases); err != nil {
+										return fmt.Errorf("failed to expand test cases for suite %s: %w", suite.Name, err)
+									}
 								}
 							}
 						}
@@ -250,9 +306,24 @@ func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string,
 		testCase.Request.Protocol = cfgCase.Protocol
 		testCase.Request.Codec = cfgCase.Codec
 		testCase.Request.Compression = cfgCase.Compression
+		if cfgCase.ResponseCompression != cfgCase.Compression {
+			testCase.Request.AcceptCompressions = []conformancev1.Compression{cfgCase.ResponseCompression}
+		}
 		// We always set this. If client-under-test does not support it, we just
 		// won't run the test cases that verify that it's enforced.
 		testCase.Request.MessageReceiveLimit = clientReceiveLimit
+		testCase.Request.HeaderSizeLimit = headerSizeLimit
+		if expandMd := oversizedMetadata(testCase); expandMd != nil &&
+			expandMd.Kind == conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS &&
+			cfgCase.Version == conformancev1.HTTPVersion_HTTP_VERSION_2 {
+			// HTTP/2 libraries refuse to send headers that are larger than
+			// the limit the server advertises. So the reference client must
+			// write the frames itself for them to reach the server.
+			testCase.Request.IgnoreMaxHeaderListSize = true
+		}
+		if cfgCase.UseMessageSendLimit {
+			testCase.Request.MessageSendLimit = clientSendLimit
+		}
 		lib.testCases[fullName] = testCase
 		lib.testCaseNames[fullName] = simpleName
 	}
@@ -315,7 +386,8 @@ func (lib *testCaseLibrary) filterGRPCImplTestCases(testCases []*conformancev1.T
 			continue
 		}
 
-		if testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB {
+		if testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB ||
+			testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
 			// grpc-web supports HTTP/1 and HTTP/2
 			switch testCase.Request.HttpVersion {
 			case conformancev1.HTTPVersion_HTTP_VERSION_1, conformancev1.HTTPVersion_HTTP_VERSION_2:
@@ -335,16 +407,50 @@ func (lib *testCaseLibrary) filterGRPCImplTestCases(testCases []*conformancev1.T
 			continue
 		}
 
+		if len(testCase.Request.AcceptCompressions) > 0 {
+			// Neither supports asymmetric compression.
+			continue
+		}
 		if len(testCase.Request.ServerTlsCert) > 0 {
 			continue
 		}
 
-		if testCase.Request.RawRequest != nil && clientIsGRPCImpl {
+		if (testCase.Request.RawRequest != nil || testCase.Request.IgnoreMaxHeaderListSize) && clientIsGRPCImpl {
 			continue
 		}
 		if hasRawResponse(testCase.Request.RequestMessages) && serverIsGRPCImpl {
 			continue
 		}
+		if len(testCase.Request.InflatedRequestSizes) > 0 && clientIsGRPCImpl {
+			continue
+		}
+		if hasInflatedResponse(testCase.Request.RequestMessages) && serverIsGRPCImpl {
+			continue
+		}
+		if testCase.Request.MessageSendLimit > 0 && serverIsGRPCImpl {
+			// Only the reference server verifies that the client
+			// did not send a message that exceeds its send limit.
+			continue
+		}
+		if testCase.Request.RetryPolicy != nil && serverIsGRPCImpl {
+			// Only the reference server can define responses per attempt.
+			continue
+		}
+		if testCase.Request.FetchServerObservation && clientIsGRPCImpl {
+			// Only the reference client fetches the server's observations.
+			continue
+		}
+		if hasConnectionDrain(testCase.Request.RequestMessages) && serverIsGRPCImpl {
+			continue
+		}
+		if testCase.Request.RequestDelayMs > 0 && serverIsGRPCImpl &&
+			testCase.Request.HttpVersion == conformancev1.HTTPVersion_HTTP_VERSION_1 &&
+			testCase.Request.StreamType == conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM {
+			// The gRPC server starts the response before it has read the
+			// whole request stream. With HTTP/1.1, that prevents it from
+			// reading any requests that arrive later.
+			continue
+		}
 
 		filteredCase := proto.Clone(testCase).(*conformancev1.TestCase) //nolint:errcheck,forcetypeassert
 		baseName := lib.testCaseNames[filteredCase.Request.TestName]
@@ -398,6 +504,34 @@ type serverInstance struct {
 	httpVersion       conformancev1.HTTPVersion
 	useTLS            bool
 	useTLSClientCerts bool
+	// If non-zero, the server is asked to shut down gracefully this
+	// long after test cases are sent to the client.
+	gracefulShutdownAfterMs uint32
+	// If true, the test cases verify that clients reuse connections. These
+	// are run against their own server, so that other test cases, which may
+	// drain or close connections, don't interfere.
+	verifiesConnectionReuse bool
+	// If true, the test cases send headers or trailers that are larger than
+	// the limit. Some implementations treat that as a connection error. So
+	// these are run against their own server, so that other test cases, which
+	// use the same connections, aren't disrupted.
+	exceedsHeaderSizeLimit bool
+}
+
+// configString describes the server config, for logging and reporting.
+// It only includes the properties that test cases have in common, not
+// the reasons why test cases may be run against a separate instance.
+func (s serverInstance) configString() string {
+	var tlsMode string
+	switch {
+	case !s.useTLS:
+		tlsMode = "false"
+	case s.useTLS && s.useTLSClientCerts:
+		tlsMode = "true (with client certs)"
+	default:
+		tlsMode = "true"
+	}
+	return fmt.Sprintf("{%s, %s, TLS:%s}", s.httpVersion, s.protocol, tlsMode)
 }
 
 func serverInstanceForCase(testCase *conformancev1.TestCase) serverInstance {
@@ -406,6 +540,10 @@ func serverInstanceForCase(testCase *conformancev1.TestCase) serverInstance {
 		httpVersion:       testCase.Request.HttpVersion,
 		useTLS:            len(testCase.Request.ServerTlsCert) > 0,
 		useTLSClientCerts: testCase.Request.ClientTlsCreds != nil,
+
+		gracefulShutdownAfterMs: testCase.GracefulShutdownAfterMs,
+		verifiesConnectionReuse: testCase.Request.SequentialRpcCount > 1,
+		exceedsHeaderSizeLimit:  oversizedMetadata(testCase) != nil,
 	}
 }
 
@@ -459,15 +597,79 @@ func parseTestSuites(testFileData map[string][]byte) (map[string]*conformancev1.
 				return nil, fmt.Errorf("%s: test case %q has raw response, but does not specify an explicit expected response",
 					testFilePath, testCase.Request.TestName)
 			}
+			if len(testCase.Request.InflatedRequestSizes) > 0 && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+				return nil, fmt.Errorf("%s: test case %q has inflated requests, but that is only allowed when mode is TEST_MODE_SERVER",
+					testFilePath, testCase.Request.TestName)
+			}
+			if len(testCase.Request.InflatedRequestSizes) > len(testCase.Request.RequestMessages) {
+				return nil, fmt.Errorf("%s: test case %q has %d inflated request sizes, but there are only %d requests",
+					testFilePath, testCase.Request.TestName, len(testCase.Request.InflatedRequestSizes), len(testCase.Request.RequestMessages))
+			}
+			if hasInflatedResponse(testCase.Request.RequestMessages) && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q has inflated response, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
+			if (len(testCase.Request.InflatedRequestSizes) > 0 || hasInflatedResponse(testCase.Request.RequestMessages)) &&
+				testCase.ExpectedResponse == nil {
+				return nil, fmt.Errorf("%s: test case %q has inflated messages, but does not specify an explicit expected response",
+					testFilePath, testCase.Request.TestName)
+			}
 			// The expand request directive uses the proto codec for size calculations, so it doesn't make sense to test with other codecs
 			if len(testCase.ExpandRequests) > 0 && (len(suite.RelevantCodecs) > 1 || !hasCodec(suite.RelevantCodecs, conformancev1.Codec_CODEC_PROTO)) {
 				return nil, fmt.Errorf("%s: test case %q specifies expand requests directive, but includes codecs other than CODEC_PROTO",
 					testFilePath, testCase.Request.TestName)
 			}
-			if err := expandRequestData(testCase); err != nil {
+			requestLimit := int64(serverReceiveLimit)
+			if suite.Mode == conformancev1.TestSuite_TEST_MODE_CLIENT {
+				requestLimit = clientSendLimit
+			}
+			if err := expandRequestData(testCase, requestLimit); err != nil {
 				return nil, fmt.Errorf("%s: failed to expand request sizes as directed for test case %q: %w",
 					testFilePath, testCase.Request.TestName, err)
 			}
+			if len(testCase.ExpandResponses) > 0 && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q specifies expand responses directive, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
+			// Like the above, the expand response directive uses the proto codec for size calculations
+			if len(testCase.ExpandResponses) > 0 && (len(suite.RelevantCodecs) > 1 || !hasCodec(suite.RelevantCodecs, conformancev1.Codec_CODEC_PROTO)) {
+				return nil, fmt.Errorf("%s: test case %q specifies expand responses directive, but includes codecs other than CODEC_PROTO",
+					testFilePath, testCase.Request.TestName)
+			}
+			if err := expandResponseData(testCase); err != nil {
+				return nil, fmt.Errorf("%s: failed to expand response sizes as directed for test case %q: %w",
+					testFilePath, testCase.Request.TestName, err)
+			}
+			if err := checkExpandMetadata(suite, testCase); err != nil {
+				return nil, fmt.Errorf("%s: test case %q: %w", testFilePath, testCase.Request.TestName, err)
+			}
+			if err := expandMetadata(testCase); err != nil {
+				return nil, fmt.Errorf("%s: failed to expand metadata as directed for test case %q: %w",
+					testFilePath, testCase.Request.TestName, err)
+			}
+			if err := checkRetryPolicy(suite, testCase); err != nil {
+				return nil, fmt.Errorf("%s: test case %q: %w", testFilePath, testCase.Request.TestName, err)
+			}
+			// Each of the sequential RPCs should be an ordinary RPC, so that
+			// the server can tell them apart from retries.
+			if testCase.Request.SequentialRpcCount > 1 && (testCase.Request.RetryPolicy != nil || testCase.Request.RawRequest != nil) {
+				return nil, fmt.Errorf("%s: test case %q specifies sequential RPC count, but also a retry policy or a raw request",
+					testFilePath, testCase.Request.TestName)
+			}
+			if err := checkCancelAfterNumRequests(testCase); err != nil {
+				return nil, fmt.Errorf("%s: test case %q: %w", testFilePath, testCase.Request.TestName, err)
+			}
+			if testCase.Request.FetchServerObservation && !suite.ReliesOnServerObservations {
+				return nil, fmt.Errorf("%s: test case %q fetches the server observation, but the suite does not rely on server observations",
+					testFilePath, testCase.Request.TestName)
+			}
+			if testCase.ExpectIncrementalResponses && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q expects incremental responses, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
+			if err := checkIncrementalResponses(testCase); err != nil {
+				return nil, fmt.Errorf("%s: test case %q: %w", testFilePath, testCase.Request.TestName, err)
+			}
 		}
 		allSuites[testFilePath] = suite
 	}
@@ -476,7 +678,8 @@ func parseTestSuites(testFileData map[string][]byte) (map[string]*conformancev1.
 
 // expandRequestData expands the request_data field of RPC requests in the
 // given test case, per directives in the expand_requests test case field.
-func expandRequestData(testCase *conformancev1.TestCase) error {
+// The sizes in the directives are relative to the given limit.
+func expandRequestData(testCase *conformancev1.TestCase, limit int64) error {
 	if len(testCase.ExpandRequests) == 0 {
 		return nil // nothing to do...
 	}
@@ -491,7 +694,7 @@ func expandRequestData(testCase *conformancev1.TestCase) error {
 			// Absent size means do not expand this one.
 			continue
 		}
-		totalSize := serverReceiveLimit + int64(expandSz.GetSizeRelativeToLimit())
+		totalSize := limit + int64(expandSz.GetSizeRelativeToLimit())
 		if totalSize < 0 || totalSize > math.MaxUint32 {
 			return fmt.Errorf("expand directive #%d (%d) results in an invalid request size: %d",
 				i+1, expandSz.GetSizeRelativeToLimit(), totalSize)
@@ -550,13 +753,453 @@ func expandRequestData(testCase *conformancev1.TestCase) error {
 	return nil
 }
 
+// expandResponseData expands the response data in the response definition of
+// the given test case, per directives in the expand_responses test case field.
+// The response data is not padded here, since the request that contains the
+// response definition would then be too large for the server to accept.
+// Instead, the inflated response size fields in the definition are set, so
+// that the reference server pads the data when it sends the responses.
+func expandResponseData(testCase *conformancev1.TestCase) error {
+	if len(testCase.ExpandResponses) == 0 {
+		return nil // nothing to do...
+	}
+
+	if len(testCase.Request.RequestMessages) == 0 {
+		return errors.New("expand directives indicate responses, but there are no requests")
+	}
+	concreteReq, err := testCase.Request.RequestMessages[0].UnmarshalNew()
+	if err != nil {
+		return fmt.Errorf("request message #1: %w", err)
+	}
+	var responseData [][]byte
+	var setSizes func([]uint32)
+	switch msg := concreteReq.(type) {
+	case unaryResponseDefiner:
+		def := msg.GetResponseDefinition()
+		if def.GetError() != nil {
+			return errors.New("expand directives indicate responses, but the response definition indicates an error")
+		}
+		if def.GetInflatedResponseSize() != 0 {
+			return errors.New("expand directives can't be used with a response definition that has an inflated response size")
+		}
+		if def != nil {
+			responseData = [][]byte{def.GetResponseData()}
+		}
+		setSizes = func(sizes []uint32) {
+			def.InflatedResponseSize = sizes[0]
+		}
+	case streamResponseDefiner:
+		def := msg.GetResponseDefinition()
+		if len(def.GetInflatedResponseSizes()) > 0 {
+			return errors.New("expand directives can't be used with a response definition that has inflated response sizes")
+		}
+		responseData = def.GetResponseData()
+		setSizes = func(sizes []uint32) {
+			def.InflatedResponseSizes = sizes
+		}
+	default:
+		return fmt.Errorf("request message #1: message type %s has no response definition",
+			concreteReq.ProtoReflect().Descriptor().FullName())
+	}
+	if len(testCase.ExpandResponses) > len(responseData) {
+		return fmt.Errorf("expand directives indicate %d messages, but there are only %d responses",
+			len(testCase.ExpandResponses), len(responseData))
+	}
+
+	sizes := make([]uint32, len(testCase.ExpandResponses))
+	for i, expandSz := range testCase.ExpandResponses {
+		if expandSz.SizeRelativeToLimit == nil {
+			// Absent size means do not expand this one.
+			continue
+		}
+		totalSize := clientReceiveLimit + int64(expandSz.GetSizeRelativeToLimit())
+		if totalSize < 0 || totalSize > math.MaxUint32 {
+			return fmt.Errorf("expand directive #%d (%d) results in an invalid response size: %d",
+				i+1, expandSz.GetSizeRelativeToLimit(), totalSize)
+		}
+		// All response messages have the same shape: the payload is field 1.
+		// So we can use any of them to compute the size.
+		resp := &conformancev1.UnaryResponse{Payload: &conformancev1.ConformancePayload{}}
+		dataSize := totalSize
+		var adjustCount int
+		for {
+			if dataSize < int64(len(responseData[i])) {
+				return fmt.Errorf("response message #%d: response data is already too large to pad to %d bytes",
+					i+1, totalSize)
+			}
+			resp.Payload.Data = make([]byte, dataSize)
+			size := proto.Size(resp)
+			delta := totalSize - int64(size)
+			if delta == 0 {
+				// it's the right size
+				break
+			}
+			if adjustCount >= 2 {
+				// See expandRequestData for how this can happen.
+				return fmt.Errorf("response message #%d: can't pad to exactly %d bytes; closest we can get is %d",
+					i+1, totalSize, size)
+			}
+			dataSize += delta
+			adjustCount++
+		}
+		sizes[i] = uint32(dataSize)
+	}
+	setSizes(sizes)
+
+	if err := testCase.Request.RequestMessages[0].MarshalFrom(concreteReq); err != nil {
+		return fmt.Errorf("request message #1: %w", err)
+	}
+	return nil
+}
+
+// firstOversizedResponse returns the index of the first response that the
+// expand_responses directive makes larger than the client's limit. It returns
+// -1 if there is no such response.
+func firstOversizedResponse(testCase *conformancev1.TestCase) int {
+	for i, expandSz := range testCase.ExpandResponses {
+		if expandSz.GetSizeRelativeToLimit() > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkExpandMetadata verifies that the given test case makes valid use of
+// the expand_metadata directive.
+func checkExpandMetadata(suite *conformancev1.TestSuite, testCase *conformancev1.TestCase) error {
+	for i, expandMd := range testCase.ExpandMetadata {
+		switch expandMd.Kind {
+		case conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS:
+			if suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+				return fmt.Errorf("expand metadata directive #%d adds request headers, but that is only allowed when mode is TEST_MODE_SERVER", i+1)
+			}
+		case conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS,
+			conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_TRAILERS:
+			if suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return fmt.Errorf("expand metadata directive #%d adds response metadata, but that is only allowed when mode is TEST_MODE_CLIENT", i+1)
+			}
+		default:
+			return fmt.Errorf("expand metadata directive #%d has invalid kind: %v", i+1, expandMd.Kind)
+		}
+		if expandMd.SizeRelativeToLimit <= 0 {
+			continue
+		}
+		if !suite.ReliesOnHeaderSizeLimit {
+			return fmt.Errorf("expand metadata directive #%d exceeds the header size limit, but the suite does not rely on the header size limit", i+1)
+		}
+		if expandMd.Kind == conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS &&
+			testCase.Request.StreamType != conformancev1.StreamType_STREAM_TYPE_UNARY &&
+			testCase.Request.StreamType != conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM {
+			// With HTTP/2, the reference client writes the frames for such
+			// a request itself, which requires the whole request up front.
+			return fmt.Errorf("expand metadata directive #%d has request headers that exceed the header size limit, but stream type is not unary or server-stream", i+1)
+		}
+		if expandMd.Kind == conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_TRAILERS &&
+			!only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_GRPC) {
+			// With the other protocols, trailers may be sent in the response
+			// body, where the header size limit does not apply.
+			return fmt.Errorf("expand metadata directive #%d has response trailers that exceed the header size limit, but relevant protocols are not only PROTOCOL_GRPC", i+1)
+		}
+	}
+	if oversizedMetadata(testCase) != nil && testCase.Request.SequentialRpcCount > 1 {
+		return errors.New("has metadata that exceeds the header size limit, but also specifies sequential RPC count")
+	}
+	return nil
+}
+
+// expandMetadata adds header or trailer fields to the given test case, per
+// directives in the expand_metadata test case field. Request headers are added
+// to the request. Response headers and trailers are added to the response
+// definition in the first request message.
+func expandMetadata(testCase *conformancev1.TestCase) error {
+	if len(testCase.ExpandMetadata) == 0 {
+		return nil // nothing to do...
+	}
+
+	var concreteReq proto.Message
+	var respHeaders, respTrailers *[]*conformancev1.Header
+	var numFields int
+	for i, expandMd := range testCase.ExpandMetadata {
+		headers, err := generateMetadata(expandMd, numFields)
+		if err != nil {
+			return fmt.Errorf("expand metadata directive #%d: %w", i+1, err)
+		}
+		numFields += len(headers)
+		if expandMd.Kind == conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS {
+			testCase.Request.RequestHeaders = append(testCase.Request.RequestHeaders, headers...)
+			continue
+		}
+		if concreteReq == nil {
+			if len(testCase.Request.RequestMessages) == 0 {
+				return errors.New("expand directives indicate response metadata, but there are no requests")
+			}
+			concreteReq, err = testCase.Request.RequestMessages[0].UnmarshalNew()
+			if err != nil {
+				return fmt.Errorf("request message #1: %w", err)
+			}
+			switch msg := concreteReq.(type) {
+			case unaryResponseDefiner:
+				if def := msg.GetResponseDefinition(); def != nil {
+					respHeaders, respTrailers = &def.ResponseHeaders, &def.ResponseTrailers
+				}
+			case streamResponseDefiner:
+				if def := msg.GetResponseDefinition(); def != nil {
+					respHeaders, respTrailers = &def.ResponseHeaders, &def.ResponseTrailers
+				}
+			}
+			if respHeaders == nil {
+				return errors.New("expand directives indicate response metadata, but request message #1 has no response definition")
+			}
+		}
+		if expandMd.Kind == conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS {
+			*respHeaders = append(*respHeaders, headers...)
+		} else {
+			*respTrailers = append(*respTrailers, headers...)
+		}
+	}
+
+	if concreteReq != nil {
+		if err := testCase.Request.RequestMessages[0].MarshalFrom(concreteReq); err != nil {
+			return fmt.Errorf("request message #1: %w", err)
+		}
+	}
+	return nil
+}
+
+// generateMetadata returns header fields whose total size is as indicated by
+// the given directive. The given offset is the number of fields that were
+// already generated for the test case, so the new fields have distinct names.
+func generateMetadata(expandMd *conformancev1.TestCase_ExpandedMetadata, offset int) ([]*conformancev1.Header, error) {
+	totalSize := int64(headerSizeLimit) + int64(expandMd.SizeRelativeToLimit)
+	numFields := max(int(expandMd.NumFields), 1)
+	names := make([]string, numFields)
+	for i := range names {
+		names[i] = fmt.Sprintf("x-padding-%d", offset+i+1)
+		if expandMd.Binary {
+			names[i] += "-bin"
+		}
+		totalSize -= int64(headerFieldSize(names[i], ""))
+	}
+	if totalSize < int64(numFields) {
+		return nil, fmt.Errorf("size (%d relative to limit) is too small for %d fields",
+			expandMd.SizeRelativeToLimit, numFields)
+	}
+	// Divide the size evenly among the fields.
+	valueLens := make([]int, numFields)
+	if expandMd.Binary {
+		// Unpadded base64 can't be one more than a multiple of four bytes
+		// long. So each value is a multiple of four bytes long, except
+		// the last, which gets the remainder.
+		perField := totalSize / int64(numFields) &^ 3
+		extra := totalSize - perField*int64(numFields)
+		for i := range valueLens {
+			valueLens[i] = int(perField)
+			if extra >= 4 {
+				valueLens[i] += 4
+				extra -= 4
+			}
+		}
+		valueLens[numFields-1] += int(extra)
+		if extra == 1 {
+			// Move two bytes from the last value to the first one.
+			if numFields == 1 || valueLens[numFields-1] < 3 {
+				return nil, fmt.Errorf("can't divide %d bytes among %d binary fields", totalSize, numFields)
+			}
+			valueLens[numFields-1] -= 2
+			valueLens[0] += 2
+		}
+	} else {
+		for i := range valueLens {
+			valueLens[i] = int(totalSize / int64(numFields))
+			if int64(i) < totalSize%int64(numFields) {
+				valueLens[i]++
+			}
+		}
+	}
+
+	headers := make([]*conformancev1.Header, numFields)
+	for i, name := range names {
+		var value string
+		if expandMd.Binary {
+			value = base64.RawStdEncoding.EncodeToString(paddingBytes(base64.RawStdEncoding.DecodedLen(valueLens[i])))
+		} else {
+			value = paddingText(valueLens[i])
+		}
+		headers[i] = &conformancev1.Header{Name: name, Value: []string{value}}
+	}
+	return headers, nil
+}
+
+// headerFieldSize returns the size of the given header field, computed the
+// same way as for HTTP/2's SETTINGS_MAX_HEADER_LIST_SIZE.
+func headerFieldSize(name, value string) int {
+	return len(name) + len(value) + 32
+}
+
+// paddingText returns a header value of the given length.
+func paddingText(length int) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+	return strings.Repeat(alphabet, length/len(alphabet)+1)[:length]
+}
+
+// paddingBytes returns a binary header value of the given length.
+func paddingBytes(length int) []byte {
+	data := make([]byte, length)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+// oversizedMetadata returns the first directive in the given test case's
+// expand_metadata field that exceeds the header size limit. It returns
+// nil if there is no such directive.
+func oversizedMetadata(testCase *conformancev1.TestCase) *conformancev1.TestCase_ExpandedMetadata {
+	for _, expandMd := range testCase.ExpandMetadata {
+		if expandMd.SizeRelativeToLimit > 0 {
+			return expandMd
+		}
+	}
+	return nil
+}
+
+// checkRetryPolicy verifies that the given test case makes valid use of
+// a retry policy and of response definitions for each attempt.
+func checkRetryPolicy(suite *conformancev1.TestSuite, testCase *conformancev1.TestCase) error {
+	attemptDefs, err := attemptResponseDefinitions(testCase)
+	if err != nil {
+		return err
+	}
+	policy := testCase.Request.RetryPolicy
+	if policy == nil {
+		if len(attemptDefs) > 0 {
+			return errors.New("defines responses for each attempt, but has no retry policy")
+		}
+		return nil
+	}
+	if !suite.ReliesOnRetries {
+		return errors.New("has retry policy, but the suite does not rely on retries")
+	}
+	if testCase.Request.StreamType != conformancev1.StreamType_STREAM_TYPE_UNARY {
+		return errors.New("has retry policy, but that is only allowed for unary RPCs")
+	}
+	if policy.MaxAttempts < 2 {
+		return fmt.Errorf("has retry policy with max attempts %d, but it must be at least 2", policy.MaxAttempts)
+	}
+	if len(attemptDefs) == 0 {
+		return errors.New("has retry policy, but does not define responses for each attempt")
+	}
+	return nil
+}
+
+// checkCancelAfterNumRequests verifies that, if the given test case cancels
+// in the middle of the request stream, the RPC has a request stream and the
+// cancellation happens before the last request is sent.
+func checkCancelAfterNumRequests(testCase *conformancev1.TestCase) error {
+	afterNumRequests := testCase.Request.GetCancel().GetAfterNumRequests()
+	if afterNumRequests == nil {
+		return nil
+	}
+	switch testCase.Request.StreamType { //nolint:exhaustive
+	case conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
+	default:
+		return fmt.Errorf("cancels after a number of requests, but stream type %v has no request stream", testCase.Request.StreamType)
+	}
+	if numRequests := afterNumRequests.NumRequests; numRequests == 0 || int(numRequests) >= len(testCase.Request.RequestMessages) {
+		return fmt.Errorf("cancels after %d requests, but that must be greater than zero and less than the number of requests (%d)",
+			numRequests, len(testCase.Request.RequestMessages))
+	}
+	return nil
+}
+
+// checkIncrementalResponses verifies that, if the given test case expects
+// incremental responses, the RPC has a response stream and the server is
+// asked to send at least two responses with a delay between them.
+func checkIncrementalResponses(testCase *conformancev1.TestCase) error {
+	if !testCase.ExpectIncrementalResponses {
+		return nil
+	}
+	switch testCase.Request.StreamType { //nolint:exhaustive
+	case conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
+	default:
+		return fmt.Errorf("expects incremental responses, but stream type %v has no response stream", testCase.Request.StreamType)
+	}
+	def, err := streamResponseDefinition(testCase)
+	if err != nil {
+		return err
+	}
+	if len(def.GetResponseData()) < 2 || def.GetResponseDelayMs() == 0 {
+		return errors.New("expects incremental responses, but the response definition does not specify at least two responses and a response delay")
+	}
+	return nil
+}
+
+// attemptResponseDefinitions returns the response definitions for each attempt
+// that are defined in the given test case's request message, if any.
+func attemptResponseDefinitions(testCase *conformancev1.TestCase) ([]*conformancev1.UnaryResponseDefinition, error) {
+	if len(testCase.Request.RequestMessages) == 0 {
+		return nil, nil
+	}
+	msg, err := testCase.Request.RequestMessages[0].UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	req, ok := msg.(*conformancev1.UnaryRequest)
+	if !ok || len(req.AttemptResponseDefinitions) == 0 {
+		return nil, nil
+	}
+	if req.ResponseDefinition != nil {
+		return nil, errors.New("defines responses for each attempt, but also defines a response definition")
+	}
+	return req.AttemptResponseDefinitions, nil
+}
+
+// finalAttempt returns the number of the attempt whose response is expected
+// to be returned by a client that uses the given retry policy, when each
+// attempt gets a response per the given definitions.
+func finalAttempt(policy *conformancev1.ClientCompatRequest_RetryPolicy, attemptDefs []*conformancev1.UnaryResponseDefinition) int {
+	maxAttempts := int(policy.MaxAttempts)
+	for attempt := 1; attempt < maxAttempts; attempt++ {
+		def := attemptDefs[min(attempt, len(attemptDefs))-1]
+		if def.GetError() == nil || !slices.Contains(policy.RetryableCodes, def.GetError().Code) {
+			return attempt
+		}
+	}
+	return maxAttempts
+}
+
 // populateExpectedResponse populates the response we expected to get back from the server
 // by examining the requests we sent.
 func populateExpectedResponse(testCase *conformancev1.TestCase) error {
-	// If an expected response was already provided, return and use that.
-	// This allows for overriding this function with explicit values in the yaml file.
-	if testCase.ExpectedResponse != nil {
-		return nil
+	// If an expected response was already provided, use that. This allows
+	// for overriding this function with explicit values in the yaml file.
+	if testCase.ExpectedResponse == nil {
+		if err := populateExpectedRPCResponse(testCase); err != nil {
+			return err
+		}
+	}
+	if testCase.Request.FetchServerObservation && testCase.ExpectedResponse.ServerObservation == nil {
+		populateExpectedServerObservation(testCase)
+	}
+	if testCase.ExpectIncrementalResponses && len(testCase.ExpectedResponse.PayloadReceiveTimesMs) == 0 {
+		if err := populateExpectedReceiveTimes(testCase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// populateExpectedRPCResponse computes the expected result of the RPC itself.
+func populateExpectedRPCResponse(testCase *conformancev1.TestCase) error {
+	if testCase.Request.GetCancel().GetAfterNumRequests() != nil {
+		return populateExpectedMidStreamCancelResponse(testCase)
+	}
+	if expandMd := oversizedMetadata(testCase); expandMd != nil {
+		return populateExpectedOversizedMetadataResponse(testCase, expandMd.Kind)
 	}
 
 	switch testCase.Request.StreamType {
@@ -576,6 +1219,109 @@ func populateExpectedResponse(testCase *conformancev1.TestCase) error {
 	}
 }
 
+// populateExpectedServerObservation computes what the server is expected to
+// observe while handling the RPC, based on the error that the RPC is expected
+// to fail with. The handler's context should be canceled if the client cancels
+// the RPC or if its deadline elapses.
+func populateExpectedServerObservation(testCase *conformancev1.TestCase) {
+	observation := &conformancev1.ServerObservation{}
+	switch code := testCase.ExpectedResponse.GetError().GetCode(); code { //nolint:exhaustive
+	case conformancev1.Code_CODE_CANCELED:
+		observation.Canceled = true
+		observation.Code = code
+	case conformancev1.Code_CODE_DEADLINE_EXCEEDED:
+		observation.Canceled = true
+		observation.Code = code
+		observation.CanceledAfterMs = testCase.Request.GetTimeoutMs()
+	}
+	testCase.ExpectedResponse.ServerObservation = observation
+}
+
+// populateExpectedReceiveTimes computes when the client is expected to receive
+// each response message, based on the delay before the server sends each one.
+func populateExpectedReceiveTimes(testCase *conformancev1.TestCase) error {
+	def, err := streamResponseDefinition(testCase)
+	if err != nil {
+		return err
+	}
+	receiveTimes := make([]uint32, len(testCase.ExpectedResponse.Payloads))
+	for idx := range receiveTimes {
+		receiveTimes[idx] = uint32(idx+1) * def.GetResponseDelayMs()
+	}
+	testCase.ExpectedResponse.PayloadReceiveTimesMs = receiveTimes
+	return nil
+}
+
+// populateExpectedMidStreamCancelResponse computes the expected response for
+// a test case where the client cancels in the middle of the request stream.
+// The RPC fails with a CANCELED error, and the client reports the requests
+// that it did not send. With a full-duplex stream, the client also receives
+// the responses to the requests that it sent before canceling.
+func populateExpectedMidStreamCancelResponse(testCase *conformancev1.TestCase) error {
+	numSent := int(testCase.Request.GetCancel().GetAfterNumRequests().GetNumRequests())
+	var payloads []*conformancev1.ConformancePayload
+	if testCase.Request.StreamType == conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM {
+		if err := populateExpectedStreamResponse(testCase); err != nil {
+			return err
+		}
+		payloads = testCase.ExpectedResponse.Payloads
+		payloads = payloads[:min(numSent, len(payloads))]
+	}
+	testCase.ExpectedResponse = &conformancev1.ClientResponseResult{
+		Payloads:          payloads,
+		Error:             &conformancev1.Error{Code: conformancev1.Code_CODE_CANCELED},
+		NumUnsentRequests: int32(len(testCase.Request.RequestMessages) - numSent),
+	}
+	return nil
+}
+
+// populateExpectedOversizedMetadataResponse computes the expected response for
+// a test case whose metadata, of the given kind, exceeds the header size limit.
+func populateExpectedOversizedMetadataResponse(testCase *conformancev1.TestCase, kind conformancev1.TestCase_ExpandedMetadata_Kind) error {
+	switch kind { //nolint:exhaustive
+	case conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS:
+		// The server should reject the request with a 431 status, which
+		// the client reports as an UNKNOWN error.
+		testCase.ExpectedResponse = &conformancev1.ClientResponseResult{
+			Error:          &conformancev1.Error{Code: conformancev1.Code_CODE_UNKNOWN},
+			HttpStatusCode: proto.Int32(http.StatusRequestHeaderFieldsTooLarge),
+		}
+		if testCase.Request.HttpVersion != conformancev1.HTTPVersion_HTTP_VERSION_1 {
+			// With HTTP/2 and HTTP/3, the server may instead reset the
+			// stream, which the client reports as an INTERNAL error (or as
+			// RESOURCE_EXHAUSTED, if the error code is ENHANCE_YOUR_CALM).
+			// A gRPC server may also respond with either of those codes.
+			testCase.OtherAllowedErrorCodes = append(testCase.OtherAllowedErrorCodes,
+				conformancev1.Code_CODE_RESOURCE_EXHAUSTED, conformancev1.Code_CODE_INTERNAL)
+		}
+		return nil
+	case conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS:
+		// The client should reject the response. So it never receives any
+		// response messages or trailers.
+		testCase.ExpectedResponse = &conformancev1.ClientResponseResult{
+			Error: &conformancev1.Error{Code: conformancev1.Code_CODE_RESOURCE_EXHAUSTED},
+		}
+	default:
+		switch testCase.Request.StreamType { //nolint:exhaustive
+		case conformancev1.StreamType_STREAM_TYPE_UNARY, conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM:
+			// The client doesn't return the response message if the RPC fails.
+			testCase.ExpectedResponse = &conformancev1.ClientResponseResult{
+				Error: &conformancev1.Error{Code: conformancev1.Code_CODE_RESOURCE_EXHAUSTED},
+			}
+		default:
+			// The client should receive the response headers and messages,
+			// and then reject the trailers.
+			if err := populateExpectedStreamResponse(testCase); err != nil {
+				return err
+			}
+			testCase.ExpectedResponse.ResponseTrailers = nil
+			testCase.ExpectedResponse.Error = &conformancev1.Error{Code: conformancev1.Code_CODE_RESOURCE_EXHAUSTED}
+		}
+	}
+	testCase.OtherAllowedErrorCodes = append(testCase.OtherAllowedErrorCodes, conformancev1.Code_CODE_INTERNAL)
+	return nil
+}
+
 // Converts a pointer to a uint32 value into a pointer to an int64.
 // If the pointer is nil, function returns nil.
 func convertToInt64Ptr(num *uint32) *int64 {
@@ -615,6 +1361,48 @@ func hasRawResponse(reqs []*anypb.Any) bool {
 	return false
 }
 
+func hasConnectionDrain(reqs []*anypb.Any) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	msg, err := reqs[0].UnmarshalNew()
+	if err != nil {
+		return false // we'll deal with this error later
+	}
+	switch msg := msg.(type) {
+	case unaryResponseDefiner:
+		if msg.GetResponseDefinition().GetConnectionDrain() != nil {
+			return true
+		}
+	case streamResponseDefiner:
+		if msg.GetResponseDefinition().GetConnectionDrain() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInflatedResponse(reqs []*anypb.Any) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	msg, err := reqs[0].UnmarshalNew()
+	if err != nil {
+		return false // we'll deal with this error later
+	}
+	switch msg := msg.(type) {
+	case unaryResponseDefiner:
+		if msg.GetResponseDefinition().GetInflatedResponseSize() > 0 {
+			return true
+		}
+	case streamResponseDefiner:
+		if len(msg.GetResponseDefinition().GetInflatedResponseSizes()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // populates the expected response for a unary test case.
 func populateExpectedUnaryResponse(testCase *conformancev1.TestCase) error {
 	reqInfo := &conformancev1.ConformancePayload_RequestInfo{
@@ -675,6 +1463,23 @@ func populateExpectedUnaryResponse(testCase *conformancev1.TestCase) error {
 		}
 
 		def = definer.GetResponseDefinition()
+
+		// If responses are defined for each attempt, use the one for the
+		// attempt whose response the client should return.
+		attemptDefs, err := attemptResponseDefinitions(testCase)
+		if err != nil {
+			return err
+		}
+		if retryPolicy := testCase.Request.GetRetryPolicy(); retryPolicy != nil && len(attemptDefs) > 0 {
+			attempt := finalAttempt(retryPolicy, attemptDefs)
+			def = attemptDefs[min(attempt, len(attemptDefs))-1]
+			if attempt > 1 && testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_GRPC {
+				reqInfo.RequestHeaders = append(slices.Clip(reqInfo.RequestHeaders), &conformancev1.Header{
+					Name:  "grpc-previous-rpc-attempts",
+					Value: []string{strconv.Itoa(attempt - 1)},
+				})
+			}
+		}
 	}
 	if def == nil {
 		testCase.ExpectedResponse = &conformancev1.ClientResponseResult{
@@ -705,6 +1510,9 @@ func populateExpectedUnaryResponse(testCase *conformancev1.TestCase) error {
 			return connect.NewError(connect.CodeInternal, err)
 		}
 		respType.Error.Details = append(respType.Error.Details, reqInfoAny)
+		if testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING {
+			expected.HttpStatusCode = proto.Int32(int32(transcoding.HTTPStatusFromCode(connect.Code(respType.Error.Code))))
+		}
 	case *conformancev1.UnaryResponseDefinition_ResponseData, nil:
 		// If response data was specified for the response (or nothing at all),
 		// the server should echo back the request message and headers in the response
@@ -713,39 +1521,37 @@ func populateExpectedUnaryResponse(testCase *conformancev1.TestCase) error {
 		}
 		// If response data was specified for the response, it should be returned
 		if respType, ok := respType.(*conformancev1.UnaryResponseDefinition_ResponseData); ok {
-			payload.Data = respType.ResponseData
+			payload.Data = internal.Inflate(respType.ResponseData, def.InflatedResponseSize)
 		}
 		expected.Payloads = []*conformancev1.ConformancePayload{payload}
+		if testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING {
+			expected.HttpStatusCode = proto.Int32(http.StatusOK)
+		}
 	default:
 		return fmt.Errorf("provided UnaryRequest.Response has an unexpected type %T", respType)
 	}
 
+	if firstOversizedResponse(testCase) == 0 {
+		// The client should reject the response. Since it never received the
+		// response message, it may not have received any metadata either.
+		expected = &conformancev1.ClientResponseResult{
+			Error: &conformancev1.Error{
+				Code: conformancev1.Code_CODE_RESOURCE_EXHAUSTED,
+			},
+		}
+	}
+
 	testCase.ExpectedResponse = expected
 	return nil
 }
 
 // populates the expected response for a streaming test case.
 func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
-	var def *conformancev1.StreamResponseDefinition
-	if len(testCase.Request.RequestMessages) > 0 {
-		req := testCase.Request.RequestMessages[0]
-		// First, find the response definition that the client instructed the
-		// server to return
-		concreteReq, err := req.UnmarshalNew()
-		if err != nil {
-			return err
-		}
-
-		definer, ok := concreteReq.(streamResponseDefiner)
-		if !ok {
-			return fmt.Errorf(
-				"TestCase %s contains a request message of type %T, which is not a streaming request",
-				testCase.Request.TestName,
-				concreteReq,
-			)
-		}
-
-		def = definer.GetResponseDefinition()
+	// First, find the response definition that the client instructed the
+	// server to return
+	def, err := streamResponseDefinition(testCase)
+	if err != nil {
+		return err
 	}
 	// Streaming endpoints don't 'return' a response and instead send responses
 	// to a client via sending on a stream. So, if no responses are specified in
@@ -783,6 +1589,9 @@ func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
 	}
 
 	for idx, data := range def.ResponseData {
+		if idx < len(def.InflatedResponseSizes) {
+			data = internal.Inflate(data, def.InflatedResponseSizes[idx])
+		}
 		expected.Payloads[idx] = &conformancev1.ConformancePayload{
 			Data: data,
 		}
@@ -801,6 +1610,11 @@ func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
 		case conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
 			// For a full duplex stream, the first request should be echoed back in the first
 			// payload. The second should be echoed back in the second payload, etc. (i.e. a ping pong interaction)
+			if idx >= len(testCase.Request.RequestMessages) {
+				// If there are more responses than requests, the extra responses are
+				// sent after the requests are done, so they have no request info.
+				break
+			}
 			expected.Payloads[idx].RequestInfo = &conformancev1.ConformancePayload_RequestInfo{
 				Requests: []*anypb.Any{testCase.Request.RequestMessages[idx]},
 			}
@@ -810,10 +1624,40 @@ func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
 			}
 		}
 	}
+	if idx := firstOversizedResponse(testCase); idx >= 0 {
+		// The client should receive the responses before the oversized one
+		// and then reject the stream. So it never receives the trailers.
+		expected.Payloads = expected.Payloads[:idx]
+		expected.ResponseTrailers = nil
+		expected.Error = &conformancev1.Error{
+			Code: conformancev1.Code_CODE_RESOURCE_EXHAUSTED,
+		}
+	}
 	testCase.ExpectedResponse = expected
 	return nil
 }
 
+// streamResponseDefinition returns the response definition in the first
+// request message of the given streaming test case, if any.
+func streamResponseDefinition(testCase *conformancev1.TestCase) (*conformancev1.StreamResponseDefinition, error) {
+	if len(testCase.Request.RequestMessages) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	concreteReq, err := testCase.Request.RequestMessages[0].UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	definer, ok := concreteReq.(streamResponseDefiner)
+	if !ok {
+		return nil, fmt.Errorf(
+			"TestCase %s contains a request message of type %T, which is not a streaming request",
+			testCase.Request.TestName,
+			concreteReq,
+		)
+	}
+	return definer.GetResponseDefinition(), nil
+}
+
 func generateTestCasePrefix(suite *conformancev1.TestSuite, cfgCase configCase) []string {
 	components := make([]string, 1, 5)
 	components = append(components, suite.Name)
@@ -829,6 +1673,9 @@ func generateTestCasePrefix(suite *conformancev1.TestSuite, cfgCase configCase)
 	if len(suite.RelevantCompressions) != 1 {
 		components = append(components, fmt.Sprintf("Compression:%s", cfgCase.Compression))
 	}
+	if suite.ReliesOnAsymmetricCompression {
+		components = append(components, fmt.Sprintf("ResponseCompression:%s", cfgCase.ResponseCompression))
+	}
 	if !suite.ReliesOnTls {
 		components = append(components, fmt.Sprintf("TLS:%v", cfgCase.UseTLS))
 	}
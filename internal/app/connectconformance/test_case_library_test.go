@@ -15,6 +15,7 @@
 package connectconformance
 
 import (
+	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -23,6 +24,7 @@ import (
 
 	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/connect"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -178,68 +180,76 @@ func TestNewTestCaseLibrary(t *testing.T) {
 			name: "client mode",
 			config: []configCase{
 				{
-					Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:       conformancev1.Codec_CODEC_PROTO,
-					Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
 				},
 				{
-					Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:       conformancev1.Codec_CODEC_PROTO,
-					Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseTLS:      true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseTLS:              true,
 				},
 				{
-					Version:           conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:          conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:             conformancev1.Codec_CODEC_PROTO,
-					Compression:       conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:        conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseTLS:            true,
-					UseTLSClientCerts: true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseTLS:              true,
+					UseTLSClientCerts:   true,
 				},
 				{
-					Version:       conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:      conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:         conformancev1.Codec_CODEC_PROTO,
-					Compression:   conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:    conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseConnectGET: true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseConnectGET:       true,
 				},
 				{
-					Version:       conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:      conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:         conformancev1.Codec_CODEC_PROTO,
-					Compression:   conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:    conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseConnectGET: true,
-					UseTLS:        true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseConnectGET:       true,
+					UseTLS:              true,
 				},
 				{
-					Version:            conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:           conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:              conformancev1.Codec_CODEC_PROTO,
-					Compression:        conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:         conformancev1.StreamType_STREAM_TYPE_UNARY,
-					ConnectVersionMode: conformancev1.TestSuite_CONNECT_VERSION_MODE_REQUIRE,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					ConnectVersionMode:  conformancev1.TestSuite_CONNECT_VERSION_MODE_REQUIRE,
 				},
 				{
 					Version:                conformancev1.HTTPVersion_HTTP_VERSION_1,
 					Protocol:               conformancev1.Protocol_PROTOCOL_CONNECT,
 					Codec:                  conformancev1.Codec_CODEC_PROTO,
 					Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 					StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 					UseMessageReceiveLimit: true,
 				},
 				{
-					Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
-					Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
-					Codec:       conformancev1.Codec_CODEC_PROTO,
-					Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:  conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
 				},
 			},
 			mode: conformancev1.TestSuite_TEST_MODE_CLIENT,
@@ -284,68 +294,76 @@ func TestNewTestCaseLibrary(t *testing.T) {
 			name: "server mode",
 			config: []configCase{
 				{
-					Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:       conformancev1.Codec_CODEC_PROTO,
-					Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
 				},
 				{
-					Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:       conformancev1.Codec_CODEC_PROTO,
-					Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseTLS:      true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseTLS:              true,
 				},
 				{
-					Version:           conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:          conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:             conformancev1.Codec_CODEC_PROTO,
-					Compression:       conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:        conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseTLS:            true,
-					UseTLSClientCerts: true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseTLS:              true,
+					UseTLSClientCerts:   true,
 				},
 				{
-					Version:       conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:      conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:         conformancev1.Codec_CODEC_PROTO,
-					Compression:   conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:    conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseConnectGET: true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseConnectGET:       true,
 				},
 				{
-					Version:       conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:      conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:         conformancev1.Codec_CODEC_PROTO,
-					Compression:   conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:    conformancev1.StreamType_STREAM_TYPE_UNARY,
-					UseConnectGET: true,
-					UseTLS:        true,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					UseConnectGET:       true,
+					UseTLS:              true,
 				},
 				{
-					Version:            conformancev1.HTTPVersion_HTTP_VERSION_1,
-					Protocol:           conformancev1.Protocol_PROTOCOL_CONNECT,
-					Codec:              conformancev1.Codec_CODEC_PROTO,
-					Compression:        conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:         conformancev1.StreamType_STREAM_TYPE_UNARY,
-					ConnectVersionMode: conformancev1.TestSuite_CONNECT_VERSION_MODE_IGNORE,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_1,
+					Protocol:            conformancev1.Protocol_PROTOCOL_CONNECT,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_UNARY,
+					ConnectVersionMode:  conformancev1.TestSuite_CONNECT_VERSION_MODE_IGNORE,
 				},
 				{
 					Version:                conformancev1.HTTPVersion_HTTP_VERSION_1,
 					Protocol:               conformancev1.Protocol_PROTOCOL_CONNECT,
 					Codec:                  conformancev1.Codec_CODEC_PROTO,
 					Compression:            conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression:    conformancev1.Compression_COMPRESSION_IDENTITY,
 					StreamType:             conformancev1.StreamType_STREAM_TYPE_UNARY,
 					UseMessageReceiveLimit: true,
 				},
 				{
-					Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
-					Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
-					Codec:       conformancev1.Codec_CODEC_PROTO,
-					Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
-					StreamType:  conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
+					Version:             conformancev1.HTTPVersion_HTTP_VERSION_2,
+					Protocol:            conformancev1.Protocol_PROTOCOL_GRPC,
+					Codec:               conformancev1.Codec_CODEC_PROTO,
+					Compression:         conformancev1.Compression_COMPRESSION_IDENTITY,
+					ResponseCompression: conformancev1.Compression_COMPRESSION_IDENTITY,
+					StreamType:          conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
 				},
 			},
 			mode: conformancev1.TestSuite_TEST_MODE_SERVER,
@@ -845,7 +863,7 @@ func TestExpandRequestData(t *testing.T) {
 			for i, req := range reqs {
 				initialSizes[i] = len(req.Value)
 			}
-			err = expandRequestData(&testCaseProto)
+			err = expandRequestData(&testCaseProto, serverReceiveLimit)
 			if testCase.expectErr != "" {
 				require.ErrorContains(t, err, testCase.expectErr)
 				return
@@ -1226,6 +1244,37 @@ func TestPopulateExpectedResponse(t *testing.T) {
 				ResponseTrailers: responseTrailers,
 			},
 		},
+		{
+			testName: "client stream canceled after requests",
+			request: &conformancev1.ClientCompatRequest{
+				StreamType: conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM,
+				RequestMessages: asAnySlice(t, &conformancev1.ClientStreamRequest{
+					ResponseDefinition: &conformancev1.UnaryResponseDefinition{
+						Response: &conformancev1.UnaryResponseDefinition_ResponseData{
+							ResponseData: data1,
+						},
+					},
+					RequestData: data1,
+				}, &conformancev1.ClientStreamRequest{
+					RequestData: data1,
+				}, &conformancev1.ClientStreamRequest{
+					RequestData: data1,
+				}),
+				Cancel: &conformancev1.ClientCompatRequest_Cancel{
+					CancelTiming: &conformancev1.ClientCompatRequest_Cancel_AfterNumRequests_{
+						AfterNumRequests: &conformancev1.ClientCompatRequest_Cancel_AfterNumRequests{
+							NumRequests: 1,
+						},
+					},
+				},
+			},
+			expected: &conformancev1.ClientResponseResult{
+				Error: &conformancev1.Error{
+					Code: conformancev1.Code_CODE_CANCELED,
+				},
+				NumUnsentRequests: 2,
+			},
+		},
 		{
 			testName: "client stream error",
 			request: &conformancev1.ClientCompatRequest{
@@ -1904,6 +1953,36 @@ func TestPopulateExpectedResponse(t *testing.T) {
 	}
 }
 
+func TestPopulateExpectedResponse_IncrementalResponses(t *testing.T) {
+	t.Parallel()
+	testCase := &conformancev1.TestCase{
+		Request: &conformancev1.ClientCompatRequest{
+			StreamType: conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM,
+			RequestMessages: asAnySlice(t, &conformancev1.ServerStreamRequest{
+				ResponseDefinition: &conformancev1.StreamResponseDefinition{
+					ResponseData:    [][]byte{[]byte("abc"), []byte("def"), []byte("ghi")},
+					ResponseDelayMs: 200,
+				},
+			}),
+		},
+		ExpectIncrementalResponses: true,
+	}
+	require.NoError(t, checkIncrementalResponses(testCase))
+	require.NoError(t, populateExpectedResponse(testCase))
+	assert.Len(t, testCase.ExpectedResponse.Payloads, 3)
+	assert.Equal(t, []uint32{200, 400, 600}, testCase.ExpectedResponse.PayloadReceiveTimesMs)
+
+	testCase.Request.StreamType = conformancev1.StreamType_STREAM_TYPE_CLIENT_STREAM
+	require.ErrorContains(t, checkIncrementalResponses(testCase), "has no response stream")
+	testCase.Request.StreamType = conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM
+	testCase.Request.RequestMessages = asAnySlice(t, &conformancev1.ServerStreamRequest{
+		ResponseDefinition: &conformancev1.StreamResponseDefinition{
+			ResponseData: [][]byte{[]byte("abc"), []byte("def")},
+		},
+	})
+	require.ErrorContains(t, checkIncrementalResponses(testCase), "does not specify at least two responses and a response delay")
+}
+
 // asAnySlice converts the given variadic arg of proto messages to a slice of Any protos
 // and verifies there are no errors during the conversion.
 func asAnySlice(t *testing.T, msgs ...proto.Message) []*anypb.Any {
@@ -1916,3 +1995,359 @@ func asAnySlice(t *testing.T, msgs ...proto.Message) []*anypb.Any {
 	}
 	return arr
 }
+
+func TestExpandResponseData(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		testCaseJSON string
+		expectErr    string
+		// negative means the response is not expanded
+		expectSizes    []int
+		expectPayloads int
+		expectError    bool
+	}{
+		{
+			name: "unary-expand",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandResponses":[{"size_relative_to_limit":-123}]
+			}`,
+			expectSizes:    []int{1024*1024 - 123},
+			expectPayloads: 1,
+		},
+		{
+			name: "unary-exceeds-limit",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandResponses":[{"size_relative_to_limit":10}]
+			}`,
+			expectSizes: []int{1024*1024 + 10},
+			expectError: true,
+		},
+		{
+			name: "server-stream-expand",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_SERVER_STREAM",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ServerStreamRequest",
+							"responseDefinition": {"responseData": ["abcdefgh", "abcdefgh", "abcdefgh"]}
+						}
+					]
+				},
+				"expandResponses":[{}, {"size_relative_to_limit":0}]
+			}`,
+			expectSizes:    []int{-1, 1024 * 1024},
+			expectPayloads: 3,
+		},
+		{
+			name: "server-stream-exceeds-limit",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_SERVER_STREAM",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ServerStreamRequest",
+							"responseDefinition": {"responseData": ["abcdefgh", "abcdefgh", "abcdefgh"]}
+						}
+					]
+				},
+				"expandResponses":[{"size_relative_to_limit":-1000}, {"size_relative_to_limit":1}]
+			}`,
+			expectSizes:    []int{1024*1024 - 1000, 1024*1024 + 1},
+			expectPayloads: 1,
+			expectError:    true,
+		},
+		{
+			name: "too-many-directives",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_SERVER_STREAM",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ServerStreamRequest",
+							"responseDefinition": {"responseData": ["abcdefgh"]}
+						}
+					]
+				},
+				"expandResponses":[{"size_relative_to_limit":0}, {"size_relative_to_limit":0}]
+			}`,
+			expectErr: "expand directives indicate 2 messages, but there are only 1 responses",
+		},
+		{
+			name: "unary-error",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"error": {"code": "CODE_INTERNAL"}}
+						}
+					]
+				},
+				"expandResponses":[{"size_relative_to_limit":0}]
+			}`,
+			expectErr: "response definition indicates an error",
+		},
+		{
+			name: "invalid-size",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandResponses":[{"size_relative_to_limit":-2000000}]
+			}`,
+			expectErr: "results in an invalid response size",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var testCaseProto conformancev1.TestCase
+			err := protojson.Unmarshal([]byte(testCase.testCaseJSON), &testCaseProto)
+			require.NoError(t, err)
+			err = expandResponseData(&testCaseProto)
+			if testCase.expectErr != "" {
+				require.ErrorContains(t, err, testCase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			req, err := testCaseProto.Request.RequestMessages[0].UnmarshalNew()
+			require.NoError(t, err)
+			var inflatedSizes []uint32
+			switch req := req.(type) {
+			case unaryResponseDefiner:
+				inflatedSizes = []uint32{req.GetResponseDefinition().GetInflatedResponseSize()}
+			case streamResponseDefiner:
+				inflatedSizes = req.GetResponseDefinition().GetInflatedResponseSizes()
+			}
+			require.Len(t, inflatedSizes, len(testCase.expectSizes))
+			for i, expectedSize := range testCase.expectSizes {
+				if expectedSize < 0 {
+					assert.Zero(t, inflatedSizes[i])
+					continue
+				}
+				resp := &conformancev1.ServerStreamResponse{
+					Payload: &conformancev1.ConformancePayload{Data: make([]byte, inflatedSizes[i])},
+				}
+				assert.Equal(t, expectedSize, proto.Size(resp))
+			}
+
+			require.NoError(t, populateExpectedResponse(&testCaseProto))
+			assert.Len(t, testCaseProto.ExpectedResponse.Payloads, testCase.expectPayloads)
+			if testCase.expectError {
+				assert.Equal(t, conformancev1.Code_CODE_RESOURCE_EXHAUSTED, testCaseProto.ExpectedResponse.GetError().GetCode())
+			} else {
+				assert.Nil(t, testCaseProto.ExpectedResponse.Error)
+			}
+			for i, payload := range testCaseProto.ExpectedResponse.Payloads {
+				if i < len(inflatedSizes) && inflatedSizes[i] > 0 {
+					assert.Len(t, payload.Data, int(inflatedSizes[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestExpandMetadata(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		testCaseJSON string
+		expectErr    string
+		expectKind   conformancev1.TestCase_ExpandedMetadata_Kind
+		expectSize   int
+		expectFields int
+		expectBinary bool
+		expectError  bool
+	}{
+		{
+			name: "request-headers",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandMetadata":[{"kind":"KIND_REQUEST_HEADERS", "size_relative_to_limit":-2048, "num_fields":100}]
+			}`,
+			expectKind:   conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS,
+			expectSize:   16*1024 - 2048,
+			expectFields: 100,
+		},
+		{
+			name: "response-headers-exceed-limit",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_SERVER_STREAM",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ServerStreamRequest",
+							"responseDefinition": {"responseData": ["abcdefgh", "abcdefgh"]}
+						}
+					]
+				},
+				"expandMetadata":[{"kind":"KIND_RESPONSE_HEADERS", "size_relative_to_limit":8192, "num_fields":256}]
+			}`,
+			expectKind:   conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS,
+			expectSize:   16*1024 + 8192,
+			expectFields: 256,
+			expectError:  true,
+		},
+		{
+			name: "binary-response-trailers",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandMetadata":[{"kind":"KIND_RESPONSE_TRAILERS", "size_relative_to_limit":-2047, "num_fields":4, "binary":true}]
+			}`,
+			expectKind:   conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_TRAILERS,
+			expectSize:   16*1024 - 2047,
+			expectFields: 4,
+			expectBinary: true,
+		},
+		{
+			name: "single-field",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandMetadata":[{"kind":"KIND_RESPONSE_HEADERS"}]
+			}`,
+			expectKind:   conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS,
+			expectSize:   16 * 1024,
+			expectFields: 1,
+		},
+		{
+			name: "no-response-definition",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest"
+						}
+					]
+				},
+				"expandMetadata":[{"kind":"KIND_RESPONSE_HEADERS"}]
+			}`,
+			expectErr: "request message #1 has no response definition",
+		},
+		{
+			name: "invalid-size",
+			testCaseJSON: `{
+				"request": {
+					"streamType": "STREAM_TYPE_UNARY",
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"responseDefinition": {"responseData": "abcdefgh"}
+						}
+					]
+				},
+				"expandMetadata":[{"kind":"KIND_RESPONSE_HEADERS", "size_relative_to_limit":-16000, "num_fields":10}]
+			}`,
+			expectErr: "is too small for 10 fields",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var testCaseProto conformancev1.TestCase
+			err := protojson.Unmarshal([]byte(testCase.testCaseJSON), &testCaseProto)
+			require.NoError(t, err)
+			err = expandMetadata(&testCaseProto)
+			if testCase.expectErr != "" {
+				require.ErrorContains(t, err, testCase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			var headers []*conformancev1.Header
+			if testCase.expectKind == conformancev1.TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS {
+				headers = testCaseProto.Request.RequestHeaders
+			} else {
+				req, err := testCaseProto.Request.RequestMessages[0].UnmarshalNew()
+				require.NoError(t, err)
+				var respHeaders, respTrailers []*conformancev1.Header
+				switch req := req.(type) {
+				case unaryResponseDefiner:
+					respHeaders = req.GetResponseDefinition().GetResponseHeaders()
+					respTrailers = req.GetResponseDefinition().GetResponseTrailers()
+				case streamResponseDefiner:
+					respHeaders = req.GetResponseDefinition().GetResponseHeaders()
+					respTrailers = req.GetResponseDefinition().GetResponseTrailers()
+				}
+				if testCase.expectKind == conformancev1.TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS {
+					headers = respHeaders
+					assert.Empty(t, respTrailers)
+				} else {
+					headers = respTrailers
+					assert.Empty(t, respHeaders)
+				}
+			}
+			require.Len(t, headers, testCase.expectFields)
+			var size int
+			for i, hdr := range headers {
+				require.Len(t, hdr.Value, 1)
+				size += headerFieldSize(hdr.Name, hdr.Value[0])
+				if testCase.expectBinary {
+					assert.Equal(t, fmt.Sprintf("x-padding-%d-bin", i+1), hdr.Name)
+					_, err := connect.DecodeBinaryHeader(hdr.Value[0])
+					assert.NoError(t, err)
+				} else {
+					assert.Equal(t, fmt.Sprintf("x-padding-%d", i+1), hdr.Name)
+				}
+			}
+			assert.Equal(t, testCase.expectSize, size)
+
+			require.NoError(t, populateExpectedResponse(&testCaseProto))
+			if testCase.expectError {
+				assert.Equal(t, conformancev1.Code_CODE_RESOURCE_EXHAUSTED, testCaseProto.ExpectedResponse.GetError().GetCode())
+			} else {
+				assert.Nil(t, testCaseProto.ExpectedResponse.Error)
+			}
+		})
+	}
+}
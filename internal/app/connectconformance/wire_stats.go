@@ -0,0 +1,236 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// wireStats accumulates statistics, computed from HTTP traces, about what
+// was put on the wire for each test case. The statistics are reported in
+// aggregate, grouped by protocol, codec, and compression. They may also be
+// compared against a baseline, which is typically recorded from a run that
+// uses the reference implementations.
+type wireStats struct {
+	baseline map[string]tracer.Stats
+
+	mu     sync.Mutex
+	groups map[string]wireStatsGroup
+	stats  map[string]tracer.Stats
+}
+
+// wireStatsGroup identifies a group of test cases whose statistics are
+// aggregated together.
+type wireStatsGroup struct {
+	protocol    conformancev1.Protocol
+	codec       conformancev1.Codec
+	compression conformancev1.Compression
+}
+
+func (g wireStatsGroup) String() string {
+	return fmt.Sprintf("%s, %s, %s",
+		strings.TrimPrefix(g.protocol.String(), "PROTOCOL_"),
+		strings.TrimPrefix(g.codec.String(), "CODEC_"),
+		strings.TrimPrefix(g.compression.String(), "COMPRESSION_"))
+}
+
+func (g wireStatsGroup) less(other wireStatsGroup) bool {
+	if g.protocol != other.protocol {
+		return g.protocol < other.protocol
+	}
+	if g.codec != other.codec {
+		return g.codec < other.codec
+	}
+	return g.compression < other.compression
+}
+
+// newWireStats creates a new wireStats. If baselineFile is not empty, it is
+// loaded and used as the baseline for comparison.
+func newWireStats(baselineFile string) (*wireStats, error) {
+	stats := &wireStats{
+		groups: map[string]wireStatsGroup{},
+		stats:  map[string]tracer.Stats{},
+	}
+	if baselineFile != "" {
+		data, err := os.ReadFile(baselineFile)
+		if err != nil {
+			return nil, internal.EnsureFileName(err, baselineFile)
+		}
+		if err := json.Unmarshal(data, &stats.baseline); err != nil {
+			return nil, internal.EnsureFileName(err, baselineFile)
+		}
+	}
+	return stats, nil
+}
+
+// register records the group for each of the given test cases.
+func (w *wireStats) register(testCases []*conformancev1.TestCase) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, testCase := range testCases {
+		w.groups[testCase.Request.TestName] = wireStatsGroup{
+			protocol:    testCase.Request.Protocol,
+			codec:       testCase.Request.Codec,
+			compression: testCase.Request.Compression,
+		}
+	}
+}
+
+// add records the statistics for the given test case. If both client and
+// server traces are available, the client trace is used.
+func (w *wireStats) add(testCase string, traces *testTraces) {
+	if w == nil {
+		return
+	}
+	trace := traces.client
+	if trace == nil {
+		trace = traces.server
+	}
+	if trace == nil {
+		return
+	}
+	stats := trace.Stats()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stats[testCase] = stats
+}
+
+// writeFile writes the statistics for all test cases to the given file,
+// in JSON format. The file can later be used as a baseline.
+func (w *wireStats) writeFile(fileName string) error {
+	w.mu.Lock()
+	data, err := json.MarshalIndent(w.stats, "", "  ")
+	w.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(fileName, data, 0o644); err != nil { //nolint:gosec // not sensitive
+		return internal.EnsureFileName(err, fileName)
+	}
+	return nil
+}
+
+// report prints the aggregate statistics, grouped by protocol, codec,
+// and compression.
+func (w *wireStats) report(printer internal.Printer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	type groupStats struct {
+		current, matched, baseline []tracer.Stats
+	}
+	byGroup := map[wireStatsGroup]*groupStats{}
+	for testCase, stats := range w.stats {
+		group, ok := w.groups[testCase]
+		if !ok {
+			continue
+		}
+		entry := byGroup[group]
+		if entry == nil {
+			entry = &groupStats{}
+			byGroup[group] = entry
+		}
+		entry.current = append(entry.current, stats)
+		if baseline, ok := w.baseline[testCase]; ok {
+			entry.matched = append(entry.matched, stats)
+			entry.baseline = append(entry.baseline, baseline)
+		}
+	}
+	groups := make([]wireStatsGroup, 0, len(byGroup))
+	for group := range byGroup {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].less(groups[j])
+	})
+
+	printer.Printf("Wire statistics (averages per test case):")
+	for _, group := range groups {
+		entry := byGroup[group]
+		if w.baseline == nil {
+			printer.Printf("  %s (%d test cases):", group, len(entry.current))
+			for _, metric := range wireStatsMetrics {
+				printer.Printf("    %-28s %10.1f", metric.name+":", average(entry.current, metric.value))
+			}
+			continue
+		}
+		printer.Printf("  %s (%d test cases, %d compared to baseline):", group, len(entry.current), len(entry.matched))
+		for _, metric := range wireStatsMetrics {
+			if len(entry.matched) == 0 {
+				printer.Printf("    %-28s %10.1f", metric.name+":", average(entry.current, metric.value))
+				continue
+			}
+			actual := average(entry.matched, metric.value)
+			baseline := average(entry.baseline, metric.value)
+			printer.Printf("    %-28s %10.1f (baseline %10.1f, %s)", metric.name+":", actual, baseline, formatDelta(actual, baseline))
+		}
+	}
+}
+
+type wireStatsMetric struct {
+	name  string
+	value func(tracer.Stats) float64
+}
+
+//nolint:gochecknoglobals
+var wireStatsMetrics = []wireStatsMetric{
+	{"request header bytes", func(s tracer.Stats) float64 { return float64(s.Request.HeaderBytes) }},
+	{"request body bytes", func(s tracer.Stats) float64 { return float64(s.Request.BodyBytes) }},
+	{"request messages", func(s tracer.Stats) float64 { return float64(s.Request.Messages) }},
+	{"request compressed msgs", func(s tracer.Stats) float64 { return float64(s.Request.CompressedMessages) }},
+	{"request message bytes", func(s tracer.Stats) float64 { return float64(s.Request.MessageBytes) }},
+	{"request uncompressed bytes", func(s tracer.Stats) float64 { return float64(s.Request.UncompressedMessageBytes) }},
+	{"request data chunks", func(s tracer.Stats) float64 { return float64(s.Request.Chunks) }},
+	{"response header bytes", func(s tracer.Stats) float64 { return float64(s.Response.HeaderBytes) }},
+	{"response trailer bytes", func(s tracer.Stats) float64 { return float64(s.Response.TrailerBytes) }},
+	{"response body bytes", func(s tracer.Stats) float64 { return float64(s.Response.BodyBytes) }},
+	{"response messages", func(s tracer.Stats) float64 { return float64(s.Response.Messages) }},
+	{"response compressed msgs", func(s tracer.Stats) float64 { return float64(s.Response.CompressedMessages) }},
+	{"response message bytes", func(s tracer.Stats) float64 { return float64(s.Response.MessageBytes) }},
+	{"response uncompressed bytes", func(s tracer.Stats) float64 { return float64(s.Response.UncompressedMessageBytes) }},
+	{"response data chunks", func(s tracer.Stats) float64 { return float64(s.Response.Chunks) }},
+	{"time to first byte (ms)", func(s tracer.Stats) float64 { return float64(s.TimeToFirstByte) / float64(time.Millisecond) }},
+}
+
+func average(stats []tracer.Stats, value func(tracer.Stats) float64) float64 {
+	if len(stats) == 0 {
+		return 0
+	}
+	var total float64
+	for _, stat := range stats {
+		total += value(stat)
+	}
+	return total / float64(len(stats))
+}
+
+func formatDelta(actual, baseline float64) string {
+	delta := actual - baseline
+	if baseline == 0 {
+		return fmt.Sprintf("%+.1f", delta)
+	}
+	return fmt.Sprintf("%+.1f, %+.1f%%", delta, delta*100/baseline)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireStats(t *testing.T) {
+	t.Parallel()
+	testCases := []*conformancev1.TestCase{
+		{Request: &conformancev1.ClientCompatRequest{
+			TestName:    "foo/gzip/1",
+			Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
+			Codec:       conformancev1.Codec_CODEC_PROTO,
+			Compression: conformancev1.Compression_COMPRESSION_GZIP,
+		}},
+		{Request: &conformancev1.ClientCompatRequest{
+			TestName:    "foo/gzip/2",
+			Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
+			Codec:       conformancev1.Codec_CODEC_PROTO,
+			Compression: conformancev1.Compression_COMPRESSION_GZIP,
+		}},
+		{Request: &conformancev1.ClientCompatRequest{
+			TestName:    "foo/identity/1",
+			Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+			Codec:       conformancev1.Codec_CODEC_JSON,
+			Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+		}},
+	}
+	statsWithBodyBytes := func(reqBytes, respBytes uint64) tracer.Stats {
+		return tracer.Stats{
+			Request:  tracer.BodyStats{BodyBytes: reqBytes},
+			Response: tracer.BodyStats{BodyBytes: respBytes},
+		}
+	}
+
+	// Record a baseline.
+	baseline, err := newWireStats("")
+	require.NoError(t, err)
+	baseline.register(testCases)
+	baseline.stats["foo/gzip/1"] = statsWithBodyBytes(100, 200)
+	baseline.stats["foo/gzip/2"] = statsWithBodyBytes(100, 400)
+	baselineFile := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, baseline.writeFile(baselineFile))
+
+	// Compare to that baseline.
+	stats, err := newWireStats(baselineFile)
+	require.NoError(t, err)
+	require.Equal(t, baseline.stats, stats.baseline)
+	stats.register(testCases)
+	stats.stats["foo/gzip/1"] = statsWithBodyBytes(150, 200)
+	stats.stats["foo/gzip/2"] = statsWithBodyBytes(150, 400)
+	stats.stats["foo/identity/1"] = statsWithBodyBytes(10, 20)
+	stats.stats["not/registered"] = statsWithBodyBytes(1, 1)
+
+	var printer internal.SimplePrinter
+	stats.report(&printer)
+	var lines []string
+	for _, msg := range printer.Messages {
+		lines = append(lines, strings.TrimSuffix(msg, "\n"))
+	}
+	assert.Contains(t, lines, "  CONNECT, JSON, IDENTITY (1 test cases, 0 compared to baseline):")
+	assert.Contains(t, lines, "    request body bytes:                10.0")
+	assert.Contains(t, lines, "  GRPC, PROTO, GZIP (2 test cases, 2 compared to baseline):")
+	assert.Contains(t, lines, "    request body bytes:               150.0 (baseline      100.0, +50.0, +50.0%)")
+	assert.Contains(t, lines, "    response body bytes:              300.0 (baseline      300.0, +0.0, +0.0%)")
+	// Groups are sorted by protocol.
+	assert.Less(t,
+		slices.Index(lines, "  CONNECT, JSON, IDENTITY (1 test cases, 0 compared to baseline):"),
+		slices.Index(lines, "  GRPC, PROTO, GZIP (2 test cases, 2 compared to baseline):"),
+	)
+
+	_, err = newWireStats(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	require.ErrorContains(t, err, "does-not-exist.json")
+}
@@ -16,6 +16,7 @@ package grpcclient
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -172,6 +173,22 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, trace *
 	if req.Compression == conformancev1.Compression_COMPRESSION_GZIP {
 		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
 	}
+	if req.MessageReceiveLimit > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(req.MessageReceiveLimit))))
+	}
+	if req.MessageSendLimit > 0 {
+		dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(int(req.MessageSendLimit))))
+	}
+	if req.HeaderSizeLimit > 0 {
+		dialOpts = append(dialOpts, grpc.WithMaxHeaderListSize(req.HeaderSizeLimit))
+	}
+	if req.RetryPolicy != nil {
+		serviceConfig, err := retryServiceConfig(req, req.RetryPolicy)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
 
 	clientConn, err := grpc.NewClient(
 		net.JoinHostPort(req.Host, strconv.FormatUint(uint64(req.Port), 10)),
@@ -205,8 +222,53 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, trace *
 
 	switch req.GetService() {
 	case internal.ConformanceServiceName:
-		return newInvoker(clientConn).Invoke(ctx, req)
+		invoker := newInvoker(clientConn)
+		// Any RPCs before the final one use the same ClientConn, so
+		// they should all use the same connection.
+		for range max(req.SequentialRpcCount, 1) - 1 {
+			result, err := invoker.Invoke(ctx, req)
+			if err != nil || result.GetError() != nil {
+				return result, err
+			}
+		}
+		return invoker.Invoke(ctx, req)
 	default:
 		return nil, fmt.Errorf("service name %s is not a valid service", req.GetService())
 	}
 }
+
+// retryServiceConfig returns a gRPC service config, in JSON format, that
+// applies the given retry policy to the method invoked by the given request.
+func retryServiceConfig(req *conformancev1.ClientCompatRequest, policy *conformancev1.ClientCompatRequest_RetryPolicy) (string, error) {
+	retryableCodes := make([]int32, len(policy.RetryableCodes))
+	for i, code := range policy.RetryableCodes {
+		retryableCodes[i] = int32(code)
+	}
+	config := map[string]any{
+		"methodConfig": []any{
+			map[string]any{
+				"name": []any{
+					map[string]any{"service": req.GetService(), "method": req.GetMethod()},
+				},
+				"retryPolicy": map[string]any{
+					"maxAttempts":          policy.MaxAttempts,
+					"initialBackoff":       durationString(policy.InitialBackoffMs),
+					"maxBackoff":           durationString(policy.MaxBackoffMs),
+					"backoffMultiplier":    policy.BackoffMultiplier,
+					"retryableStatusCodes": retryableCodes,
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// durationString formats the given number of milliseconds in the form
+// used by durations in a gRPC service config, such as "0.25s".
+func durationString(millis uint32) string {
+	return strconv.FormatFloat(float64(millis)/1000, 'f', -1, 64) + "s"
+}
@@ -125,9 +125,13 @@ func (i *invoker) serverStream(
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	start := time.Now()
 	stream, err := i.client.ServerStream(ctx, req)
 	if err != nil {
-		return nil, err
+		// The request could not be sent, such as when it exceeds
+		// the send limit, so the RPC failed.
+		result.Error = grpcutil.ConvertGrpcToProtoError(err)
+		return result, nil
 	}
 
 	if timing.AfterCloseSendMs >= 0 {
@@ -160,6 +164,7 @@ func (i *invoker) serverStream(
 		}
 		// On successful receive, get the returned payload.
 		result.Payloads = append(result.Payloads, msg.Payload)
+		result.PayloadReceiveTimesMs = append(result.PayloadReceiveTimesMs, uint32(time.Since(start).Milliseconds()))
 
 		// If AfterNumResponses is specified, it will be a number > 0 here.
 		// If it wasn't specified, it will be -1, which means the totalRcvd
@@ -189,7 +194,21 @@ func (i *invoker) clientStream(
 		return nil, err
 	}
 
-	for _, msg := range ccr.RequestMessages {
+	// Cancellation timing
+	timing, err := internal.GetCancelTiming(ccr.Cancel)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, msg := range ccr.RequestMessages {
+		if i == timing.AfterNumRequests {
+			// Cancel in the middle of the request stream. The remaining
+			// messages are never sent.
+			time.Sleep(time.Duration(timing.AfterNumRequestsDelayMs) * time.Millisecond)
+			cancel()
+			result.NumUnsentRequests = int32(len(ccr.RequestMessages) - i)
+			break
+		}
 		csr := &conformancev1.ClientStreamRequest{}
 		if err := msg.UnmarshalTo(csr); err != nil {
 			return nil, err
@@ -203,11 +222,6 @@ func (i *invoker) clientStream(
 		}
 	}
 
-	// Cancellation timing
-	timing, err := internal.GetCancelTiming(ccr.Cancel)
-	if err != nil {
-		return nil, err
-	}
 	if timing.BeforeCloseSend != nil {
 		cancel()
 	} else if timing.AfterCloseSendMs >= 0 {
@@ -248,6 +262,7 @@ func (i *invoker) bidiStream(
 	// Add the specified request headers to the request
 	ctx = grpcutil.AppendToOutgoingContext(ctx, ccr.RequestHeaders)
 
+	start := time.Now()
 	stream, err := i.client.BidiStream(ctx)
 	if err != nil {
 		return nil, err
@@ -263,13 +278,25 @@ func (i *invoker) bidiStream(
 
 	var protoErr *conformancev1.Error
 	totalRcvd := 0
-	for _, msg := range ccr.RequestMessages {
+	for i, msg := range ccr.RequestMessages {
+		if i == timing.AfterNumRequests {
+			// Cancel in the middle of the request stream. The remaining
+			// messages are never sent.
+			time.Sleep(time.Duration(timing.AfterNumRequestsDelayMs) * time.Millisecond)
+			cancel()
+			result.NumUnsentRequests = int32(len(ccr.RequestMessages) - i)
+			break
+		}
 		bsr := &conformancev1.BidiStreamRequest{}
 		if err := msg.UnmarshalTo(bsr); err != nil {
 			// Return the error and nil result because this is an
 			// unmarshalling error unrelated to the RPC
 			return nil, err
 		}
+
+		// Sleep for any specified delay
+		time.Sleep(time.Duration(ccr.RequestDelayMs) * time.Millisecond)
+
 		if err := stream.Send(bsr); err != nil && errors.Is(err, io.EOF) {
 			// Call receive to get the error and convert it to a proto error
 			if _, recvErr := stream.Recv(); recvErr != nil {
@@ -299,6 +326,7 @@ func (i *invoker) bidiStream(
 			}
 			// On successful receive, get the returned payload.
 			result.Payloads = append(result.Payloads, msg.Payload)
+			result.PayloadReceiveTimesMs = append(result.PayloadReceiveTimesMs, uint32(time.Since(start).Milliseconds()))
 			totalRcvd++
 			if totalRcvd == timing.AfterNumResponses {
 				cancel()
@@ -356,6 +384,7 @@ func (i *invoker) bidiStream(
 		}
 		// On successful receive, get the returned payload.
 		result.Payloads = append(result.Payloads, msg.Payload)
+		result.PayloadReceiveTimesMs = append(result.PayloadReceiveTimesMs, uint32(time.Since(start).Milliseconds()))
 		totalRcvd++
 		if totalRcvd == timing.AfterNumResponses {
 			cancel()
@@ -34,13 +34,15 @@ import (
 
 const serverName = "connectconformance-grpcserver"
 
-// NewConformanceServiceServer creates a new Conformance Service server.
-func NewConformanceServiceServer() conformancev1.ConformanceServiceServer {
-	return &conformanceServiceServer{}
+// NewConformanceServiceServer creates a new Conformance Service server. It
+// reports the given observations from its GetServerObservation method.
+func NewConformanceServiceServer(observations *internal.ServerObservations) conformancev1.ConformanceServiceServer {
+	return &conformanceServiceServer{observations: observations}
 }
 
 type conformanceServiceServer struct {
 	conformancev1.UnimplementedConformanceServiceServer
+	observations *internal.ServerObservations
 }
 
 func (c *conformanceServiceServer) Unary(
@@ -411,6 +413,19 @@ func asAny(msg proto.Message) (*anypb.Any, error) {
 	return msgAsAny, nil
 }
 
+func (c *conformanceServiceServer) GetServerObservation(
+	ctx context.Context,
+	req *conformancev1.GetServerObservationRequest,
+) (*conformancev1.GetServerObservationResponse, error) {
+	observation, err := c.observations.Get(ctx, req.TestName)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return &conformancev1.GetServerObservationResponse{
+		Observation: observation,
+	}, nil
+}
+
 func serverNameUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
 	_ = grpc.SetHeader(ctx, serverNameMetadata())
 	return handler(ctx, req)
@@ -421,6 +436,45 @@ func serverNameStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamSe
 	return handler(srv, ss)
 }
 
+// serverObserver provides interceptors that record whether the context of
+// each handler is canceled before the handler returns, for RPCs whose
+// requests indicate that the observation will be fetched. These
+// observations are reported by the GetServerObservation method.
+type serverObserver struct {
+	observations *internal.ServerObservations
+}
+
+func (o serverObserver) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	testCaseName := observedTestCaseName(ctx)
+	if testCaseName == "" || info.FullMethod == conformancev1.ConformanceService_GetServerObservation_FullMethodName {
+		return handler(ctx, req)
+	}
+	err = o.observations.Handle(ctx, testCaseName, func() (err error) {
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func (o serverObserver) streamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return o.observations.Handle(ss.Context(), observedTestCaseName(ss.Context()), func() error {
+		return handler(srv, ss)
+	})
+}
+
+// observedTestCaseName returns the name of the test case for the RPC with the
+// given context, or the empty string if the RPC should not be observed.
+func observedTestCaseName(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(internal.ExpectServerObservationHeader)) == 0 {
+		return ""
+	}
+	if vals := md.Get("x-test-case-name"); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
 func serverNameMetadata() metadata.MD {
 	server := fmt.Sprintf("%s/%s", serverName, internal.Version)
 	return metadata.Pairs("Server", server)
@@ -69,7 +69,8 @@ func RunWithTrace(ctx context.Context, args []string, inReader io.ReadCloser, ou
 	if req.UseTls {
 		return fmt.Errorf("%s: TLS is not supported", args[0])
 	}
-	if req.Protocol != conformancev1.Protocol_PROTOCOL_GRPC && req.Protocol != conformancev1.Protocol_PROTOCOL_GRPC_WEB {
+	if req.Protocol != conformancev1.Protocol_PROTOCOL_GRPC && req.Protocol != conformancev1.Protocol_PROTOCOL_GRPC_WEB &&
+		req.Protocol != conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
 		return fmt.Errorf("%s: protocol %s is not supported", args[0], req.Protocol)
 	}
 	if req.Protocol == conformancev1.Protocol_PROTOCOL_GRPC && req.HttpVersion != conformancev1.HTTPVersion_HTTP_VERSION_2 {
@@ -77,7 +78,7 @@ func RunWithTrace(ctx context.Context, args []string, inReader io.ReadCloser, ou
 	}
 
 	// Create a gRPC server based on the request
-	server, err := createServer(req.MessageReceiveLimit)
+	server, err := createServer(req.MessageReceiveLimit, req.HeaderSizeLimit)
 	if err != nil {
 		return err
 	}
@@ -102,19 +103,26 @@ func RunWithTrace(ctx context.Context, args []string, inReader io.ReadCloser, ou
 	}
 
 	// Finally, start the server
-	if req.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB {
-		return runGRPCWebServer(ctx, server, listener, trace)
+	if req.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB || req.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
+		// The gRPC-Web wrapper handles both the binary and text variants.
+		return runGRPCWebServer(ctx, server, listener, int(req.HeaderSizeLimit), trace)
 	}
 	return runGRPCServer(ctx, server, listener, trace)
 }
 
-func createServer(recvLimit uint32) (*grpc.Server, error) { //nolint:unparam
-	server := grpc.NewServer(
-		grpc.UnaryInterceptor(serverNameUnaryInterceptor),
-		grpc.StreamInterceptor(serverNameStreamInterceptor),
+func createServer(recvLimit, headerLimit uint32) (*grpc.Server, error) { //nolint:unparam
+	observations := internal.NewServerObservations()
+	observer := serverObserver{observations: observations}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(serverNameUnaryInterceptor, observer.unaryInterceptor),
+		grpc.ChainStreamInterceptor(serverNameStreamInterceptor, observer.streamInterceptor),
 		grpc.MaxRecvMsgSize(int(recvLimit)),
-	)
-	conformancev1.RegisterConformanceServiceServer(server, NewConformanceServiceServer())
+	}
+	if headerLimit > 0 {
+		opts = append(opts, grpc.MaxHeaderListSize(headerLimit))
+	}
+	server := grpc.NewServer(opts...)
+	conformancev1.RegisterConformanceServiceServer(server, NewConformanceServiceServer(observations))
 	return server, nil
 }
 
@@ -137,7 +145,7 @@ func runGRPCServer(ctx context.Context, server *grpc.Server, listener net.Listen
 	}
 }
 
-func runGRPCWebServer(ctx context.Context, server *grpc.Server, listener net.Listener, trace *tracer.Tracer) error {
+func runGRPCWebServer(ctx context.Context, server *grpc.Server, listener net.Listener, maxHeaderBytes int, trace *tracer.Tracer) error {
 	grpcWebServer := http.Handler(grpcweb.WrapServer(server,
 		// The server needs a lenient cors setup so that it can handle testing
 		// browser clients.
@@ -156,6 +164,7 @@ func runGRPCWebServer(ctx context.Context, server *grpc.Server, listener net.Lis
 	httpServer := http.Server{
 		Handler:           grpcWebServer,
 		ReadHeaderTimeout: 5 * time.Second,
+		MaxHeaderBytes:    maxHeaderBytes,
 		Protocols:         &protocols,
 	}
 
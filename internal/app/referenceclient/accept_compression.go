@@ -0,0 +1,69 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/conformance/internal/compression"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// acceptCompressionTransport sets the request header that advertises the
+// compressions that the client accepts for responses. Connect-go advertises
+// every compression that it can decompress, which always includes the one
+// used for requests. But the client may be asked to accept a different set.
+type acceptCompressionTransport struct {
+	transport http.RoundTripper
+	header    string
+	value     string
+}
+
+func newAcceptCompressionTransport(transport http.RoundTripper, req *conformancev1.ClientCompatRequest) (http.RoundTripper, error) {
+	var header string
+	switch req.Protocol {
+	case conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_GRPC_WEB, conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT:
+		header = "Grpc-Accept-Encoding"
+	case conformancev1.Protocol_PROTOCOL_CONNECT:
+		if req.StreamType == conformancev1.StreamType_STREAM_TYPE_UNARY {
+			header = "Accept-Encoding"
+		} else {
+			header = "Connect-Accept-Encoding"
+		}
+	default:
+		return nil, fmt.Errorf("%s does not support accepting other compressions for responses", req.Protocol)
+	}
+	names := make([]string, len(req.AcceptCompressions))
+	for i, acceptCompression := range req.AcceptCompressions {
+		name, err := compression.Name(acceptCompression)
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+	return &acceptCompressionTransport{
+		transport: transport,
+		header:    header,
+		value:     strings.Join(names, ","),
+	}, nil
+}
+
+func (t *acceptCompressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.header, t.value)
+	return t.transport.RoundTrip(req)
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -133,6 +134,8 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 					// clear out reference-mode-specific details
 					result.HttpStatusCode = nil
 					result.Feedback = nil
+					result.Http2Outcome = nil
+					result.ElapsedMs = nil
 				}
 				resp.Result = &conformancev1.ClientCompatResponse_Response{
 					Response: result,
@@ -155,21 +158,90 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 // returned from this function indicates a runtime/unexpected internal error and is not indicative of a
 // Connect error returned from calling an RPC. Any error (i.e. a Connect error) that _is_ returned from
 // the actual RPC invocation will be present in the returned ClientResponseResult.
-func invoke(ctx context.Context, transports *transports, req *conformancev1.ClientCompatRequest, referenceMode bool, trace *tracer.Tracer) (*conformancev1.ClientResponseResult, error) {
+func invoke(ctx context.Context, transports *transports, req *conformancev1.ClientCompatRequest, referenceMode bool, trace *tracer.Tracer) (result *conformancev1.ClientResponseResult, err error) {
 	transport, serverURL, err := transports.get(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if referenceMode {
+	switch {
+	case referenceMode && req.IgnoreMaxHeaderListSize:
+		// The frames are traced as they are written, which also captures
+		// the values on the wire. So this replaces the wire interceptor.
+		if req.HttpVersion != conformancev1.HTTPVersion_HTTP_VERSION_2 {
+			return nil, errors.New("ignoring the max header list size can only be used with HTTP/2")
+		}
+		tlsConf, err := createTLSConfig(req)
+		if err != nil {
+			return nil, err
+		}
+		outcome := newFrameScriptOutcome()
+		transport = &directFrameSender{sender: &frameScriptSender{
+			tlsConfig: tlsConf,
+			trace:     trace,
+			outcome:   outcome,
+		}}
+		defer func() {
+			if result != nil {
+				result.Http2Outcome = outcome.await()
+			}
+		}()
+	case referenceMode:
 		// Wrap the transport with a wire interceptor and an optional tracer.
 		// The wire interceptor wraps a TracingRoundTripper and intercepts values on the
 		// wire using the tracer framework. Note that 'trace' could be nil, in which case,
 		// any error traces will simply not be printed. The trace itself will still be built.
 		transport = newWireCaptureTransport(transport, trace)
-		if req.RawRequest != nil {
-			transport = &rawRequestSender{transport: transport, rawRequest: req.RawRequest}
+	}
+	if req.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
+		// This wraps the tracing transport, so that the trace shows
+		// the base64-encoded body that is actually on the wire.
+		transport = &grpcWebTextTransport{transport: transport}
+	}
+	if len(req.AcceptCompressions) > 0 && req.RawRequest == nil {
+		// This wraps the tracing transport, so that the trace shows
+		// the accepted compressions that are actually on the wire.
+		transport, err = newAcceptCompressionTransport(transport, req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if referenceMode && req.RawRequest != nil {
+		sender := &rawRequestSender{transport: transport, rawRequest: req.RawRequest}
+		if script := req.RawRequest.GetFrameScript(); script != nil {
+			if req.HttpVersion != conformancev1.HTTPVersion_HTTP_VERSION_2 {
+				return nil, errors.New("raw request with frame script can only be used with HTTP/2")
+			}
+			if err := internal.ValidateHTTP2FrameScript(script); err != nil {
+				return nil, err
+			}
+			tlsConf, err := createTLSConfig(req)
+			if err != nil {
+				return nil, err
+			}
+			outcome := newFrameScriptOutcome()
+			sender.frameScript = &frameScriptSender{
+				script:    script,
+				tlsConfig: tlsConf,
+				trace:     trace,
+				outcome:   outcome,
+			}
+			defer func() {
+				if result != nil {
+					result.Http2Outcome = outcome.await()
+				}
+			}()
+		}
+		transport = sender
+	}
+
+	if req.Protocol == conformancev1.Protocol_PROTOCOL_REST_TRANSCODING {
+		// REST-style requests are not sent using a Connect client,
+		// so none of the client options below apply.
+		if req.GetService() != conformancev1connect.TranscodingServiceName {
+			return nil, fmt.Errorf("service name %s does not support %s", req.GetService(), req.Protocol)
 		}
+		return newTranscodingInvoker(transport, referenceMode, serverURL).Invoke(ctx, req)
 	}
 
 	// Create client options based on protocol of the implementation
@@ -177,10 +249,13 @@ func invoke(ctx context.Context, transports *transports, req *conformancev1.Clie
 	switch req.Protocol {
 	case conformancev1.Protocol_PROTOCOL_GRPC:
 		clientOptions = append(clientOptions, connect.WithGRPC())
-	case conformancev1.Protocol_PROTOCOL_GRPC_WEB:
+	case conformancev1.Protocol_PROTOCOL_GRPC_WEB, conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT:
+		// For the text variant, the transport converts to and from binary.
 		clientOptions = append(clientOptions, connect.WithGRPCWeb())
 	case conformancev1.Protocol_PROTOCOL_CONNECT:
 		// Do nothing
+	case conformancev1.Protocol_PROTOCOL_REST_TRANSCODING:
+		// Handled above
 	case conformancev1.Protocol_PROTOCOL_UNSPECIFIED:
 		return nil, errors.New("a protocol must be specified")
 	}
@@ -196,70 +271,120 @@ func invoke(ctx context.Context, transports *transports, req *conformancev1.Clie
 		return nil, errors.New("a codec must be specified")
 	}
 
-	if req.Compression != conformancev1.Compression_COMPRESSION_GZIP {
+	if req.RetryPolicy != nil {
+		// connect-go does not automatically retry RPCs.
+		return nil, errors.New("retry policies are not supported")
+	}
+
+	// The client can always decompress responses that use the same compression
+	// as requests. If the request indicates other compressions to accept, the
+	// transport created above advertises only those.
+	acceptCompressions := append([]conformancev1.Compression{req.Compression}, req.AcceptCompressions...)
+	if !slices.Contains(acceptCompressions, conformancev1.Compression_COMPRESSION_GZIP) {
 		// Gzip is supported by default. So if we're not using it, disable it.
 		clientOptions = append(clientOptions,
 			connect.WithAcceptCompression(compression.Gzip, nil, nil),
 		)
 	}
 
+	for _, acceptCompression := range acceptCompressions {
+		switch acceptCompression {
+		case conformancev1.Compression_COMPRESSION_BR:
+			clientOptions = append(
+				clientOptions,
+				connect.WithAcceptCompression(
+					compression.Brotli,
+					compression.NewBrotliDecompressor,
+					compression.NewBrotliCompressor,
+				),
+			)
+		case conformancev1.Compression_COMPRESSION_DEFLATE:
+			clientOptions = append(
+				clientOptions,
+				connect.WithAcceptCompression(
+					compression.Deflate,
+					compression.NewDeflateDecompressor,
+					compression.NewDeflateCompressor,
+				),
+			)
+		case conformancev1.Compression_COMPRESSION_SNAPPY:
+			clientOptions = append(
+				clientOptions,
+				connect.WithAcceptCompression(
+					compression.Snappy,
+					compression.NewSnappyDecompressor,
+					compression.NewSnappyCompressor,
+				),
+			)
+		case conformancev1.Compression_COMPRESSION_ZSTD:
+			clientOptions = append(
+				clientOptions,
+				connect.WithAcceptCompression(
+					compression.Zstd,
+					compression.NewZstdDecompressor,
+					compression.NewZstdCompressor,
+				),
+			)
+		case conformancev1.Compression_COMPRESSION_LZ4:
+			clientOptions = append(
+				clientOptions,
+				connect.WithAcceptCompression(
+					compression.LZ4,
+					compression.NewLZ4Decompressor,
+					compression.NewLZ4Compressor,
+				),
+			)
+		case conformancev1.Compression_COMPRESSION_GZIP,
+			conformancev1.Compression_COMPRESSION_IDENTITY,
+			conformancev1.Compression_COMPRESSION_UNSPECIFIED:
+			// Gzip is supported by default, and identity needs no decompressor.
+		}
+	}
+
 	switch req.Compression {
-	case conformancev1.Compression_COMPRESSION_BR:
-		clientOptions = append(
-			clientOptions,
-			connect.WithAcceptCompression(
-				compression.Brotli,
-				compression.NewBrotliDecompressor,
-				compression.NewBrotliCompressor,
-			),
-			connect.WithSendCompression(compression.Brotli),
-		)
-	case conformancev1.Compression_COMPRESSION_DEFLATE:
-		clientOptions = append(
-			clientOptions,
-			connect.WithAcceptCompression(
-				compression.Deflate,
-				compression.NewDeflateDecompressor,
-				compression.NewDeflateCompressor,
-			),
-			connect.WithSendCompression(compression.Deflate),
-		)
 	case conformancev1.Compression_COMPRESSION_GZIP:
 		// Connect clients send uncompressed requests and ask for gzipped responses by default
 		// As a result, specifying a compression of gzip for a client indicates it should also
 		// send gzipped requests
 		clientOptions = append(clientOptions, connect.WithSendGzip())
-	case conformancev1.Compression_COMPRESSION_SNAPPY:
-		clientOptions = append(
-			clientOptions,
-			connect.WithAcceptCompression(
-				compression.Snappy,
-				compression.NewSnappyDecompressor,
-				compression.NewSnappyCompressor,
-			),
-			connect.WithSendCompression(compression.Snappy),
-		)
-	case conformancev1.Compression_COMPRESSION_ZSTD:
-		clientOptions = append(
-			clientOptions,
-			connect.WithAcceptCompression(
-				compression.Zstd,
-				compression.NewZstdDecompressor,
-				compression.NewZstdCompressor,
-			),
-			connect.WithSendCompression(compression.Zstd),
-		)
 	case conformancev1.Compression_COMPRESSION_IDENTITY, conformancev1.Compression_COMPRESSION_UNSPECIFIED:
 		// No compression; do nothing
+	default:
+		name, err := compression.Name(req.Compression)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions = append(clientOptions, connect.WithSendCompression(name))
 	}
 
 	if req.MessageReceiveLimit > 0 {
 		clientOptions = append(clientOptions, connect.WithReadMaxBytes(int(req.MessageReceiveLimit)))
 	}
+	if req.MessageSendLimit > 0 {
+		clientOptions = append(clientOptions, connect.WithSendMaxBytes(int(req.MessageSendLimit)))
+	}
 
 	switch req.GetService() {
 	case conformancev1connect.ConformanceServiceName:
-		return newInvoker(transport, referenceMode, serverURL, clientOptions).Invoke(ctx, req)
+		if referenceMode && len(req.InflatedRequestSizes) > 0 {
+			if err := inflateRequests(req); err != nil {
+				return nil, err
+			}
+		}
+		invoker := newInvoker(transport, referenceMode, serverURL, clientOptions)
+		// Any RPCs before the final one use the same transport, so
+		// they should all use the same connection.
+		for range max(req.SequentialRpcCount, 1) - 1 {
+			result, err := invoker.Invoke(ctx, req)
+			if err != nil || result.GetError() != nil {
+				return result, err
+			}
+		}
+		result, err := invoker.Invoke(ctx, req)
+		if err == nil && referenceMode && req.FetchServerObservation {
+			invoker.fetchServerObservation(ctx, req, result)
+		}
+		return result, err
 	default:
 		return nil, fmt.Errorf("service name %s is not a valid service", req.GetService())
 	}
@@ -0,0 +1,638 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// The ID of the stream used by a frame-scripted request. Since
+	// each such request uses a new connection, this is the first
+	// stream ID a client can use.
+	frameScriptStreamID = 1
+	// How long to wait, after a script completes, for the server
+	// to finish the stream.
+	frameScriptStreamTimeout = 2 * time.Second
+	// How long to wait for the server to acknowledge a PING, to
+	// see if the connection is still alive.
+	frameScriptPingTimeout = time.Second
+	// This is the default size of the HPACK dynamic table, which
+	// the client does not change.
+	http2HeaderTableSize = 4096
+	// This is the initial flow control window for a stream, unless
+	// the server's settings indicate otherwise.
+	http2InitialWindowSize = 65535
+)
+
+//nolint:gochecknoglobals
+var (
+	frameScriptPingData = [8]byte{'c', 'o', 'n', 'f', 'o', 'r', 'm', '!'}
+	errFromPeer         = errors.New("received from peer")
+)
+
+// frameScriptSender sends a request as an HTTP/2 frame script. Each
+// request is sent on its own connection.
+type frameScriptSender struct {
+	script    *conformancev1.HTTP2FrameScript
+	tlsConfig *tls.Config
+	trace     *tracer.Tracer
+	outcome   *frameScriptOutcome
+}
+
+// frameScriptOutcome records how the server handled a frame-scripted
+// request. It is populated from the frames read from the server.
+type frameScriptOutcome struct {
+	done   chan struct{}
+	result *conformancev1.HTTP2StreamOutcome
+}
+
+func newFrameScriptOutcome() *frameScriptOutcome {
+	return &frameScriptOutcome{done: make(chan struct{})}
+}
+
+// await returns the outcome, once it is available. It returns nil if the
+// outcome is not available within a few seconds, which can happen if the
+// request was never actually sent.
+func (o *frameScriptOutcome) await() *conformancev1.HTTP2StreamOutcome {
+	select {
+	case <-o.done:
+		return o.result
+	case <-time.After(frameScriptStreamTimeout + frameScriptPingTimeout):
+		return nil
+	}
+}
+
+// roundTrip sends the frame script for the given request. The given
+// leading fields are included in the request's header block.
+func (s *frameScriptSender) roundTrip(orig *http.Request, leadingFields []hpack.HeaderField) (*http.Response, error) {
+	ctx := orig.Context()
+	collector := &frameScriptCollector{ctx: ctx, tracer: s.trace}
+	conn, err := dialHTTP2(ctx, orig.URL.Host, s.tlsConfig)
+	if err != nil {
+		collector.finish()
+		close(s.outcome.done)
+		return nil, err
+	}
+	session := &frameScriptSession{
+		conn:       tracer.TracingHTTP2Conn(conn, false, collector),
+		collector:  collector,
+		outcome:    s.outcome,
+		result:     &conformancev1.HTTP2StreamOutcome{},
+		respReady:  make(chan struct{}),
+		streamDone: make(chan struct{}),
+		pingAcked:  make(chan struct{}),
+		connDone:   make(chan struct{}),
+	}
+	session.body = &frameScriptBody{notify: make(chan struct{}, 1)}
+	if err := session.start(); err != nil {
+		session.close()
+		return nil, err
+	}
+	go session.run(ctx, s.script, leadingFields)
+	select {
+	case <-session.respReady:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if session.respErr != nil {
+		return nil, session.respErr
+	}
+	session.resp.Request = orig
+	return session.resp, nil
+}
+
+// requestLeadingFields returns the pseudo-headers and headers that make up
+// the header block for the given raw request.
+func requestLeadingFields(orig *http.Request, rawRequest *conformancev1.RawHTTPRequest, uri string) []hpack.HeaderField {
+	fields := []hpack.HeaderField{
+		{Name: ":method", Value: rawRequest.Verb},
+		{Name: ":scheme", Value: orig.URL.Scheme},
+		{Name: ":authority", Value: orig.URL.Host},
+		{Name: ":path", Value: uri},
+	}
+	for _, hdr := range rawRequest.Headers {
+		for _, val := range hdr.Value {
+			fields = append(fields, hpack.HeaderField{Name: strings.ToLower(hdr.Name), Value: val})
+		}
+	}
+	return fields
+}
+
+// directFrameSender sends a request by writing HTTP/2 frames directly to a
+// new connection, using a frame script made from the request. Unlike the
+// HTTP/2 transport, it sends request headers even if they are larger than
+// the limit that the server advertised. Since the whole request body is read
+// before anything is sent, it can't be used for client or bidi streams.
+type directFrameSender struct {
+	// The script of this sender is ignored.
+	sender *frameScriptSender
+}
+
+func (d *directFrameSender) RoundTrip(orig *http.Request) (*http.Response, error) {
+	var body []byte
+	if orig.Body != nil {
+		var err error
+		body, err = io.ReadAll(orig.Body)
+		_ = orig.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(body) > http2InitialWindowSize {
+		// The script does not wait for the server to replenish the flow
+		// control window.
+		return nil, fmt.Errorf("request body is too large to send as HTTP/2 frames directly: %d bytes", len(body))
+	}
+	frames := []*conformancev1.HTTP2Frame{
+		{Frame: &conformancev1.HTTP2Frame_Headers{Headers: &conformancev1.HTTP2Frame_HeadersFrame{
+			EndStream: len(body) == 0,
+		}}},
+	}
+	if len(body) > 0 {
+		frames = append(frames, &conformancev1.HTTP2Frame{Frame: &conformancev1.HTTP2Frame_Data{Data: &conformancev1.HTTP2Frame_DataFrame{
+			Contents:  &conformancev1.HTTP2Frame_DataFrame_Unary{Unary: &conformancev1.MessageContents{Data: &conformancev1.MessageContents_Binary{Binary: body}}},
+			EndStream: true,
+		}}})
+	}
+	sender := *d.sender
+	sender.script = &conformancev1.HTTP2FrameScript{Frames: frames}
+	return sender.roundTrip(orig, directLeadingFields(orig))
+}
+
+// directLeadingFields returns the pseudo-headers and headers that make up
+// the header block for the given request.
+func directLeadingFields(orig *http.Request) []hpack.HeaderField {
+	fields := []hpack.HeaderField{
+		{Name: ":method", Value: orig.Method},
+		{Name: ":scheme", Value: orig.URL.Scheme},
+		{Name: ":authority", Value: orig.URL.Host},
+		{Name: ":path", Value: orig.URL.RequestURI()},
+	}
+	names := make([]string, 0, len(orig.Header))
+	for name := range orig.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, val := range orig.Header[name] {
+			fields = append(fields, hpack.HeaderField{Name: strings.ToLower(name), Value: val})
+		}
+	}
+	return fields
+}
+
+func dialHTTP2(ctx context.Context, addr string, tlsConfig *tls.Config) (net.Conn, error) {
+	if tlsConfig == nil {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{http2.NextProtoTLS}
+	dialer := tls.Dialer{Config: tlsConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok && tlsConn.ConnectionState().NegotiatedProtocol != http2.NextProtoTLS {
+		_ = conn.Close()
+		return nil, errors.New("server did not negotiate HTTP/2")
+	}
+	return conn, nil
+}
+
+// frameScriptSession is a single connection, on which a frame script is
+// sent and the server's response frames are read.
+type frameScriptSession struct {
+	conn      net.Conn
+	collector *frameScriptCollector
+	outcome   *frameScriptOutcome
+
+	writeMu sync.Mutex
+	framer  *http2.Framer
+
+	// These are set before respReady is closed.
+	respReady chan struct{}
+	resp      *http.Response
+	respErr   error
+	body      *frameScriptBody
+
+	mu          sync.Mutex
+	result      *conformancev1.HTTP2StreamOutcome
+	gotResponse bool
+	streamDone  chan struct{}
+	streamErr   error
+	pingAcked   chan struct{}
+	connDone    chan struct{}
+	closeOnce   sync.Once
+}
+
+// start writes the client connection preface and initial settings.
+func (s *frameScriptSession) start() error {
+	s.framer = http2.NewFramer(s.conn, s.conn)
+	s.framer.AllowIllegalWrites = true
+	s.framer.ReadMetaHeaders = hpack.NewDecoder(http2HeaderTableSize, nil)
+	s.framer.MaxHeaderListSize = 0 // no limit
+	if _, err := io.WriteString(s.conn, http2.ClientPreface); err != nil {
+		return err
+	}
+	return s.framer.WriteSettings()
+}
+
+func (s *frameScriptSession) run(ctx context.Context, script *conformancev1.HTTP2FrameScript, leadingFields []hpack.HeaderField) {
+	defer s.close()
+	go s.readFrames()
+
+	clientReset, err := s.writeScript(ctx, script, leadingFields)
+	switch {
+	case err != nil:
+		s.finishStream(err)
+	case clientReset != nil && clientReset.Code == http2.ErrCodeCancel:
+		s.finishStream(context.Canceled)
+	case clientReset != nil:
+		s.finishStream(*clientReset)
+	}
+	timer := time.NewTimer(frameScriptStreamTimeout)
+	defer timer.Stop()
+	select {
+	case <-s.streamDone:
+	case <-s.connDone:
+	case <-timer.C:
+		s.withWriteLock(func() {
+			_ = s.framer.WriteRSTStream(frameScriptStreamID, http2.ErrCodeCancel)
+		})
+		s.finishStream(errors.New("timed out waiting for server to finish the stream"))
+	case <-ctx.Done():
+		s.finishStream(ctx.Err())
+	}
+
+	// Now see if the connection is still usable.
+	s.withWriteLock(func() {
+		_ = s.framer.WritePing(false, frameScriptPingData)
+	})
+	pingTimer := time.NewTimer(frameScriptPingTimeout)
+	defer pingTimer.Stop()
+	select {
+	case <-s.pingAcked:
+		s.mu.Lock()
+		s.result.ConnectionAlive = s.result.GoAwayErrorCode == nil
+		s.mu.Unlock()
+	case <-s.connDone:
+	case <-pingTimer.C:
+	}
+}
+
+// writeScript writes the frames in the given script. If the script resets
+// the stream, it returns a non-nil error with the reset code.
+func (s *frameScriptSession) writeScript(ctx context.Context, script *conformancev1.HTTP2FrameScript, leadingFields []hpack.HeaderField) (*http2.StreamError, error) {
+	var clientReset *http2.StreamError
+	for i, frame := range script.Frames {
+		if frame.DelayMs > 0 {
+			timer := time.NewTimer(time.Duration(frame.DelayMs) * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return clientReset, ctx.Err()
+			case <-s.connDone:
+				timer.Stop()
+				return clientReset, nil
+			case <-timer.C:
+			}
+		}
+		var fields []hpack.HeaderField
+		if headers := frame.GetHeaders(); headers != nil && !headers.Trailers && leadingFields != nil {
+			fields, leadingFields = leadingFields, nil
+		}
+		data, _, err := internal.EncodeHTTP2Frame(frame, frameScriptStreamID, fields)
+		if err != nil {
+			return clientReset, fmt.Errorf("frame script: frame #%d: %w", i+1, err)
+		}
+		s.withWriteLock(func() {
+			_, err = s.conn.Write(data)
+		})
+		if err != nil {
+			// The connection failed, which the reader will notice.
+			return clientReset, nil
+		}
+		if rst := frame.GetRstStream(); rst != nil && clientReset == nil {
+			clientReset = &http2.StreamError{StreamID: frameScriptStreamID, Code: http2.ErrCode(rst.ErrorCode)}
+		}
+	}
+	return clientReset, nil
+}
+
+func (s *frameScriptSession) readFrames() {
+	defer s.closeConnDone()
+	for {
+		frame, err := s.framer.ReadFrame()
+		if err != nil {
+			s.finishStream(fmt.Errorf("failed to read frame from server: %w", err))
+			return
+		}
+		switch frame := frame.(type) {
+		case *http2.SettingsFrame:
+			if !frame.IsAck() {
+				s.withWriteLock(func() {
+					_ = s.framer.WriteSettingsAck()
+				})
+			}
+		case *http2.PingFrame:
+			switch {
+			case !frame.IsAck():
+				s.withWriteLock(func() {
+					_ = s.framer.WritePing(true, frame.Data)
+				})
+			case frame.Data == frameScriptPingData:
+				close(s.pingAcked)
+			}
+		case *http2.GoAwayFrame:
+			s.mu.Lock()
+			s.result.GoAwayErrorCode = proto.Uint32(uint32(frame.ErrCode))
+			s.mu.Unlock()
+			if frame.LastStreamID < frameScriptStreamID {
+				s.finishStream(fmt.Errorf("server sent GOAWAY (%v) without processing stream", frame.ErrCode))
+			}
+		case *http2.RSTStreamFrame:
+			if frame.StreamID != frameScriptStreamID {
+				continue
+			}
+			s.mu.Lock()
+			if s.result.RstStreamErrorCode == nil {
+				s.result.RstStreamErrorCode = proto.Uint32(uint32(frame.ErrCode))
+			}
+			s.mu.Unlock()
+			// This mirrors the error returned by the net/http transport.
+			s.finishStream(http2.StreamError{StreamID: frame.StreamID, Code: frame.ErrCode, Cause: errFromPeer})
+		case *http2.MetaHeadersFrame:
+			if frame.StreamID != frameScriptStreamID {
+				continue
+			}
+			s.receiveHeaders(frame)
+		case *http2.DataFrame:
+			if frame.StreamID != frameScriptStreamID {
+				continue
+			}
+			if length := frame.Header().Length; length > 0 {
+				// Replenish flow control windows, so the server can keep sending.
+				s.withWriteLock(func() {
+					_ = s.framer.WriteWindowUpdate(0, length)
+					_ = s.framer.WriteWindowUpdate(frame.StreamID, length)
+				})
+			}
+			s.body.write(frame.Data())
+			if frame.StreamEnded() {
+				s.endStream()
+			}
+		}
+	}
+}
+
+func (s *frameScriptSession) receiveHeaders(frame *http2.MetaHeadersFrame) {
+	s.mu.Lock()
+	gotResponse := s.gotResponse
+	s.mu.Unlock()
+	if !gotResponse {
+		status := frame.PseudoValue("status")
+		statusCode, err := strconv.Atoi(status)
+		if err != nil {
+			s.finishStream(fmt.Errorf("server sent invalid status %q", status))
+			return
+		}
+		if statusCode >= 100 && statusCode < 200 {
+			return // informational response
+		}
+		s.mu.Lock()
+		s.gotResponse = true
+		s.mu.Unlock()
+		resp := &http.Response{
+			Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+			StatusCode: statusCode,
+			Proto:      "HTTP/2.0",
+			ProtoMajor: 2,
+			Header:     makeHeader(frame),
+			Body:       s.body,
+			Trailer:    http.Header{},
+		}
+		if contentLength, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+			resp.ContentLength = contentLength
+		} else {
+			resp.ContentLength = -1
+		}
+		s.body.setResponse(resp)
+		s.resp = resp
+		close(s.respReady)
+	} else {
+		s.body.setTrailers(makeHeader(frame))
+	}
+	if frame.StreamEnded() {
+		s.endStream()
+	}
+}
+
+func (s *frameScriptSession) endStream() {
+	s.mu.Lock()
+	s.result.EndStream = true
+	s.mu.Unlock()
+	s.finishStream(nil)
+}
+
+// finishStream marks the stream as complete. If err is nil, the stream
+// completed successfully.
+func (s *frameScriptSession) finishStream(err error) {
+	s.mu.Lock()
+	select {
+	case <-s.streamDone:
+		s.mu.Unlock()
+		return
+	default:
+	}
+	s.streamErr = err
+	close(s.streamDone)
+	gotResponse := s.gotResponse
+	s.gotResponse = true
+	s.mu.Unlock()
+
+	if !gotResponse {
+		if err == nil {
+			err = errors.New("server ended stream without sending response headers")
+		}
+		s.respErr = err
+		close(s.respReady)
+	}
+	s.body.finish(err)
+}
+
+func (s *frameScriptSession) closeConnDone() {
+	close(s.connDone)
+}
+
+func (s *frameScriptSession) close() {
+	s.closeOnce.Do(func() {
+		_ = s.conn.Close()
+		s.finishStream(errors.New("connection closed"))
+		s.mu.Lock()
+		s.outcome.result = proto.Clone(s.result).(*conformancev1.HTTP2StreamOutcome) //nolint:errcheck,forcetypeassert
+		s.mu.Unlock()
+		close(s.outcome.done)
+		s.collector.finish()
+	})
+}
+
+func (s *frameScriptSession) withWriteLock(action func()) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	action()
+}
+
+// frameScriptBody is the body of a response to a frame-scripted request.
+// Unlike an io.Pipe, writes never block, so the goroutine reading frames
+// never waits on the consumer of the body.
+type frameScriptBody struct {
+	notify chan struct{}
+
+	mu       sync.Mutex
+	resp     *http.Response
+	buf      bytes.Buffer
+	trailers http.Header
+	err      error // io.EOF when the stream ended successfully
+	closed   bool
+}
+
+func (b *frameScriptBody) write(data []byte) {
+	b.mu.Lock()
+	b.buf.Write(data)
+	b.mu.Unlock()
+	b.signal()
+}
+
+func (b *frameScriptBody) setResponse(resp *http.Response) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resp = resp
+}
+
+func (b *frameScriptBody) setTrailers(trailers http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trailers = trailers
+}
+
+func (b *frameScriptBody) finish(err error) {
+	b.mu.Lock()
+	if b.err == nil {
+		if err == nil {
+			err = io.EOF
+			// Trailers must be visible to the consumer once it sees EOF.
+			if b.resp != nil {
+				for k, v := range b.trailers {
+					b.resp.Trailer[k] = v
+				}
+			}
+		}
+		b.err = err
+	}
+	b.mu.Unlock()
+	b.signal()
+}
+
+func (b *frameScriptBody) signal() {
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (b *frameScriptBody) Read(data []byte) (int, error) {
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return 0, errors.New("read on closed body")
+		}
+		if b.buf.Len() > 0 {
+			n, _ := b.buf.Read(data)
+			b.mu.Unlock()
+			return n, nil
+		}
+		if b.err != nil {
+			err := b.err
+			b.mu.Unlock()
+			return 0, err
+		}
+		b.mu.Unlock()
+		<-b.notify
+	}
+}
+
+func (b *frameScriptBody) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.signal()
+	return nil
+}
+
+// frameScriptCollector collects the trace of a frame-scripted request,
+// making it available for examining wire details.
+type frameScriptCollector struct {
+	ctx    context.Context //nolint:containedctx
+	tracer *tracer.Tracer
+	once   sync.Once
+}
+
+func (c *frameScriptCollector) Complete(trace tracer.Trace) {
+	c.once.Do(func() {
+		setWireTrace(c.ctx, trace)
+	})
+	if c.tracer != nil {
+		c.tracer.Complete(trace)
+	}
+}
+
+// finish makes sure that a trace is available for examining wire
+// details, even if the script never started a stream that could be
+// traced.
+func (c *frameScriptCollector) finish() {
+	c.once.Do(func() {
+		setWireTrace(c.ctx, tracer.Trace{})
+	})
+}
+
+func makeHeader(frame *http2.MetaHeadersFrame) http.Header {
+	header := http.Header{}
+	for _, field := range frame.RegularFields() {
+		header.Add(field.Name, field.Value)
+	}
+	return header
+}
@@ -0,0 +1,245 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestFrameScriptSender(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(respWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		respWriter.Header().Set("Trailer", "X-Echo-Trailer")
+		respWriter.Header().Set("X-Echo-Header", req.Header.Get("X-Foo"))
+		_, _ = respWriter.Write(body)
+		respWriter.Header().Set("X-Echo-Trailer", req.URL.Path)
+	}))
+	svr.EnableHTTP2 = true
+	svr.StartTLS()
+	t.Cleanup(svr.Close)
+	tlsConfig := svr.Client().Transport.(*http.Transport).TLSClientConfig //nolint:forcetypeassert,errcheck
+
+	testCases := []struct {
+		name            string
+		frames          []*conformancev1.HTTP2Frame
+		expectedBody    string
+		expectedErr     string
+		expectedOutcome *conformancev1.HTTP2StreamOutcome
+	}{
+		{
+			name: "complete-request",
+			frames: []*conformancev1.HTTP2Frame{
+				{Frame: &conformancev1.HTTP2Frame_Headers{Headers: &conformancev1.HTTP2Frame_HeadersFrame{
+					Headers: []*conformancev1.Header{{Name: "x-foo", Value: []string{"bar"}}},
+				}}},
+				{Frame: &conformancev1.HTTP2Frame_Data{Data: &conformancev1.HTTP2Frame_DataFrame{
+					Contents:     &conformancev1.HTTP2Frame_DataFrame_Unary{Unary: &conformancev1.MessageContents{Data: &conformancev1.MessageContents_Text{Text: "abcdef"}}},
+					MaxFrameSize: 2,
+					PadLength:    3,
+					EndStream:    true,
+				}}},
+			},
+			expectedBody:    "abcdef",
+			expectedOutcome: &conformancev1.HTTP2StreamOutcome{EndStream: true, ConnectionAlive: true},
+		},
+		{
+			name: "client-cancel",
+			frames: []*conformancev1.HTTP2Frame{
+				{Frame: &conformancev1.HTTP2Frame_Headers{Headers: &conformancev1.HTTP2Frame_HeadersFrame{}}},
+				{Frame: &conformancev1.HTTP2Frame_RstStream{RstStream: &conformancev1.HTTP2Frame_RstStreamFrame{
+					ErrorCode: uint32(http2.ErrCodeCancel),
+				}}},
+			},
+			expectedErr:     context.Canceled.Error(),
+			expectedOutcome: &conformancev1.HTTP2StreamOutcome{ConnectionAlive: true},
+		},
+		{
+			name: "data-before-headers",
+			frames: []*conformancev1.HTTP2Frame{
+				{Frame: &conformancev1.HTTP2Frame_Data{Data: &conformancev1.HTTP2Frame_DataFrame{EndStream: true}}},
+			},
+			// The server sends a GOAWAY and then closes the connection.
+			expectedErr:     "failed to read frame from server: EOF",
+			expectedOutcome: &conformancev1.HTTP2StreamOutcome{GoAwayErrorCode: proto.Uint32(uint32(http2.ErrCodeProtocol))},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, svr.URL+"/foo/bar", http.NoBody)
+			require.NoError(t, err)
+			rawRequest := &conformancev1.RawHTTPRequest{
+				Verb:    http.MethodPost,
+				Headers: []*conformancev1.Header{{Name: "X-Test-Case-Name", Value: []string{testCase.name}}},
+			}
+			sender := &frameScriptSender{
+				script:    &conformancev1.HTTP2FrameScript{Frames: testCase.frames},
+				tlsConfig: tlsConfig,
+				outcome:   newFrameScriptOutcome(),
+			}
+			resp, err := sender.roundTrip(req, requestLeadingFields(req, rawRequest, "/foo/bar"))
+			if err == nil {
+				var body []byte
+				body, err = io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if err == nil {
+					assert.Equal(t, testCase.expectedBody, string(body))
+					assert.Equal(t, "bar", resp.Header.Get("X-Echo-Header"))
+					assert.Equal(t, "/foo/bar", resp.Trailer.Get("X-Echo-Trailer"))
+				}
+			}
+			if testCase.expectedErr != "" {
+				require.ErrorContains(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Empty(t, cmp.Diff(testCase.expectedOutcome, sender.outcome.await(), protocmp.Transform()))
+		})
+	}
+}
+
+func TestRequestLeadingFields(t *testing.T) {
+	t.Parallel()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://127.0.0.1:1234/abc", http.NoBody)
+	require.NoError(t, err)
+	rawRequest := &conformancev1.RawHTTPRequest{
+		Verb: http.MethodPut,
+		Headers: []*conformancev1.Header{
+			{Name: "Content-Type", Value: []string{"application/grpc"}},
+			{Name: "X-Multi", Value: []string{"a", "b"}},
+		},
+	}
+	fields := requestLeadingFields(req, rawRequest, "/foo/bar?baz=1")
+	assert.Equal(t, []hpack.HeaderField{
+		{Name: ":method", Value: "PUT"},
+		{Name: ":scheme", Value: "https"},
+		{Name: ":authority", Value: "127.0.0.1:1234"},
+		{Name: ":path", Value: "/foo/bar?baz=1"},
+		{Name: "content-type", Value: "application/grpc"},
+		{Name: "x-multi", Value: "a"},
+		{Name: "x-multi", Value: "b"},
+	}, fields)
+}
+
+func TestDirectFrameSender(t *testing.T) {
+	t.Parallel()
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(respWriter, err.Error(), http.StatusBadRequest)
+			return
+		}
+		respWriter.Header().Set("X-Echo-Header", req.Header.Get("X-Foo"))
+		_, _ = respWriter.Write(body)
+	}))
+	svr.EnableHTTP2 = true
+	svr.Config.MaxHeaderBytes = 1024
+	svr.StartTLS()
+	t.Cleanup(svr.Close)
+	tlsConfig := svr.Client().Transport.(*http.Transport).TLSClientConfig //nolint:forcetypeassert,errcheck
+
+	testCases := []struct {
+		name           string
+		headers        http.Header
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "with-body",
+			headers:        http.Header{"X-Foo": []string{"bar"}},
+			body:           "abcdef",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "abcdef",
+		},
+		{
+			name:           "without-body",
+			headers:        http.Header{"X-Foo": []string{"bar"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			// The HTTP/2 transport refuses to send these, since they are
+			// larger than the limit that the server advertised. They are
+			// divided among many fields, since a single field that is
+			// larger than the limit is a connection error.
+			name:           "oversized-headers",
+			headers:        http.Header{"X-Foo": []string{"bar"}, "X-Many": slices.Repeat([]string{strings.Repeat("a", 32)}, 48)},
+			expectedStatus: http.StatusRequestHeaderFieldsTooLarge,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, svr.URL+"/foo/bar", strings.NewReader(testCase.body))
+			require.NoError(t, err)
+			req.Header = testCase.headers
+			sender := &directFrameSender{sender: &frameScriptSender{
+				tlsConfig: tlsConfig,
+				outcome:   newFrameScriptOutcome(),
+			}}
+			resp, err := sender.RoundTrip(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatus, resp.StatusCode)
+			if testCase.expectedStatus == http.StatusOK {
+				assert.Equal(t, testCase.expectedBody, string(body))
+				assert.Equal(t, "bar", resp.Header.Get("X-Echo-Header"))
+			}
+			assert.True(t, sender.sender.outcome.await().GetEndStream())
+		})
+	}
+}
+
+func TestDirectLeadingFields(t *testing.T) {
+	t.Parallel()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://127.0.0.1:1234/foo/bar?baz=1", http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("X-Multi", "b")
+	req.Header.Add("X-Multi", "a")
+	req.Header.Set("Content-Type", "application/grpc")
+	assert.Equal(t, []hpack.HeaderField{
+		{Name: ":method", Value: "POST"},
+		{Name: ":scheme", Value: "https"},
+		{Name: ":authority", Value: "127.0.0.1:1234"},
+		{Name: ":path", Value: "/foo/bar?baz=1"},
+		{Name: "content-type", Value: "application/grpc"},
+		{Name: "x-multi", Value: "b"},
+		{Name: "x-multi", Value: "a"},
+	}, directLeadingFields(req))
+}
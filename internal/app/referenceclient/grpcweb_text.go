@@ -0,0 +1,60 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"net/http"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// grpcWebTextTransport is a round-tripper that converts binary gRPC-Web
+// requests into gRPC-Web text requests, and converts gRPC-Web text
+// responses back into binary ones. Since connect-go does not support the
+// text variant, this lets a connect-go client (configured to use gRPC-Web)
+// use the text variant on the wire.
+type grpcWebTextTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *grpcWebTextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	contentType := req.Header.Get("Content-Type")
+	if !internal.IsGRPCWebContentType(contentType) {
+		return t.transport.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Content-Type", internal.GRPCWebToTextContentType(contentType))
+	// Indicate that the response should also use the text variant.
+	req.Header.Set("Accept", internal.GRPCWebTextContentType)
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = internal.NewGRPCWebTextEncodingReader(req.Body)
+		// Each chunk is encoded separately, so the length is not known.
+		req.ContentLength = -1
+		req.Header.Del("Content-Length")
+		req.GetBody = nil
+	}
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respContentType := resp.Header.Get("Content-Type")
+	if internal.IsGRPCWebTextContentType(respContentType) {
+		resp.Header.Set("Content-Type", internal.GRPCWebFromTextContentType(respContentType))
+		resp.Body = internal.NewGRPCWebTextDecodingReader(resp.Body)
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+	}
+	return resp, nil
+}
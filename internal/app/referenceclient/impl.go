@@ -31,7 +31,12 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-const clientName = "connectconformance-referenceclient"
+const (
+	clientName = "connectconformance-referenceclient"
+	// serverObservationTimeout is how long to wait for the server to
+	// report its observation of an RPC.
+	serverObservationTimeout = 5 * time.Second
+)
 
 type invoker struct {
 	client        conformancev1connect.ConformanceServiceClient
@@ -58,11 +63,24 @@ func (i *invoker) Invoke(
 ) (*conformancev1.ClientResponseResult, error) {
 	// If a timeout was specified, create a derived context with that deadline
 	if req.TimeoutMs != nil {
-		deadlineCtx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Duration(*req.TimeoutMs)*time.Millisecond))
-		ctx = deadlineCtx
+		start := time.Now()
+		deadlineCtx, cancel := context.WithDeadline(ctx, start.Add(time.Duration(*req.TimeoutMs)*time.Millisecond))
 		defer cancel()
+		resp, err := i.invokeMethod(deadlineCtx, req)
+		if resp != nil {
+			// Record how long it took for the RPC to terminate, so the
+			// test runner can check it against the timeout.
+			resp.ElapsedMs = proto.Uint32(uint32(time.Since(start).Milliseconds()))
+		}
+		return resp, err
 	}
+	return i.invokeMethod(ctx, req)
+}
 
+func (i *invoker) invokeMethod(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+) (*conformancev1.ClientResponseResult, error) {
 	switch req.GetMethod() {
 	case "Unary":
 		if len(req.RequestMessages) != 1 {
@@ -231,6 +249,7 @@ func (i *invoker) serverStream(
 
 	ctx = i.withWireCapture(ctx)
 
+	start := time.Now()
 	stream, err := i.client.ServerStream(ctx, request)
 	if err != nil {
 		// If an error was returned, first convert it to a Connect error
@@ -275,6 +294,7 @@ func (i *invoker) serverStream(
 		totalRcvd++
 		// On successful receive, get the returned payload.
 		result.Payloads = append(result.Payloads, stream.Msg().Payload)
+		result.PayloadReceiveTimesMs = append(result.PayloadReceiveTimesMs, uint32(time.Since(start).Milliseconds()))
 
 		// If AfterNumResponses is specified, it will be a number > 0 here.
 		// If it wasn't specified, it will be -1, which means the totalRcvd
@@ -304,7 +324,21 @@ func (i *invoker) clientStream(
 	// Add the specified request headers to the request
 	internal.AddHeaders(req.RequestHeaders, stream.RequestHeader())
 
+	// Cancellation timing
+	timing, err := internal.GetCancelTiming(req.Cancel)
+	if err != nil {
+		return nil, err
+	}
+
 	for i, msg := range req.RequestMessages {
+		if i == timing.AfterNumRequests {
+			// Cancel in the middle of the request stream. The remaining
+			// messages are never sent.
+			time.Sleep(time.Duration(timing.AfterNumRequestsDelayMs) * time.Millisecond)
+			cancel()
+			numUnsent = len(req.RequestMessages) - i
+			break
+		}
 		csr := &conformancev1.ClientStreamRequest{}
 		if err := msg.UnmarshalTo(csr); err != nil {
 			return nil, err
@@ -313,8 +347,20 @@ func (i *invoker) clientStream(
 		// Sleep for any specified delay
 		time.Sleep(time.Duration(req.RequestDelayMs) * time.Millisecond)
 
-		if err := stream.Send(csr); err != nil && errors.Is(err, io.EOF) {
+		if err := stream.Send(csr); err != nil {
 			numUnsent = len(req.RequestMessages) - i
+			if !errors.Is(err, io.EOF) {
+				// The message could not be sent, such as when it exceeds the
+				// send limit. So we finish the RPC without sending anything
+				// else and report the error. We don't cancel the RPC since,
+				// with HTTP/3, that can also abort other RPCs that are waiting
+				// on the same connection to be established.
+				_, _ = stream.CloseAndReceive()
+				return &conformancev1.ClientResponseResult{
+					NumUnsentRequests: int32(numUnsent),
+					Error:             internal.ConvertErrorToProtoError(err),
+				}, nil
+			}
 			break
 		}
 	}
@@ -324,11 +370,6 @@ func (i *invoker) clientStream(
 	var trailers []*conformancev1.Header
 	payloads := make([]*conformancev1.ConformancePayload, 0, 1)
 
-	// Cancellation timing
-	timing, err := internal.GetCancelTiming(req.Cancel)
-	if err != nil {
-		return nil, err
-	}
 	if timing.BeforeCloseSend != nil {
 		cancel()
 	} else if timing.AfterCloseSendMs >= 0 {
@@ -374,6 +415,7 @@ func (i *invoker) bidiStream(
 
 	ctx = i.withWireCapture(ctx)
 
+	start := time.Now()
 	stream := i.client.BidiStream(ctx)
 	defer func() {
 		// Always make sure stream is closed on exit.
@@ -406,6 +448,14 @@ func (i *invoker) bidiStream(
 	var protoErr *conformancev1.Error
 	totalRcvd := 0
 	for i, msg := range req.RequestMessages {
+		if i == timing.AfterNumRequests {
+			// Cancel in the middle of the request stream. The remaining
+			// messages are never sent.
+			time.Sleep(time.Duration(timing.AfterNumRequestsDelayMs) * time.Millisecond)
+			cancel()
+			result.NumUnsentRequests = int32(len(req.RequestMessages) - i)
+			break
+		}
 		bsr := &conformancev1.BidiStreamRequest{}
 		if err := msg.UnmarshalTo(bsr); err != nil {
 			// Return the error and nil result because this is an
@@ -416,7 +466,18 @@ func (i *invoker) bidiStream(
 		// Sleep for any specified delay
 		time.Sleep(time.Duration(req.RequestDelayMs) * time.Millisecond)
 
-		if err := stream.Send(bsr); err != nil && errors.Is(err, io.EOF) {
+		if err := stream.Send(bsr); err != nil && !errors.Is(err, io.EOF) {
+			// The message could not be sent, such as when it exceeds the
+			// send limit. So we finish the RPC without sending anything
+			// else and report the error. (See clientStream for why we
+			// don't cancel the RPC.) If nothing has been sent yet, this
+			// also makes sure the request is started, so that the stream
+			// can be closed without blocking.
+			_ = stream.CloseRequest()
+			result.Error = internal.ConvertErrorToProtoError(err)
+			result.NumUnsentRequests = int32(len(req.RequestMessages) - i)
+			return result, nil
+		} else if err != nil {
 			// Call receive to get the error and convert it to a proto error
 			if _, recvErr := stream.Receive(); recvErr != nil {
 				protoErr = internal.ConvertErrorToProtoError(recvErr)
@@ -446,6 +507,7 @@ func (i *invoker) bidiStream(
 			}
 			// On successful receive, get the returned payload.
 			result.Payloads = append(result.Payloads, msg.Payload)
+			result.PayloadReceiveTimesMs = append(result.PayloadReceiveTimesMs, uint32(time.Since(start).Milliseconds()))
 			totalRcvd++
 			if totalRcvd == timing.AfterNumResponses {
 				cancel()
@@ -487,6 +549,7 @@ func (i *invoker) bidiStream(
 		}
 		// On successful receive, get the returned payload.
 		result.Payloads = append(result.Payloads, msg.Payload)
+		result.PayloadReceiveTimesMs = append(result.PayloadReceiveTimesMs, uint32(time.Since(start).Milliseconds()))
 		totalRcvd++
 		if totalRcvd == timing.AfterNumResponses {
 			cancel()
@@ -509,6 +572,30 @@ func (i *invoker) unimplemented(
 		})
 }
 
+// fetchServerObservation asks the server what it observed while handling
+// the RPC for the given request and records it in the given result.
+func (i *invoker) fetchServerObservation(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+	result *conformancev1.ClientResponseResult,
+) {
+	ctx, cancel := context.WithTimeout(ctx, serverObservationTimeout)
+	defer cancel()
+	resp, err := i.client.GetServerObservation(ctx, connect.NewRequest(&conformancev1.GetServerObservationRequest{
+		TestName: req.TestName,
+	}))
+	if connect.CodeOf(err) == connect.CodeUnimplemented {
+		result.Feedback = append(result.Feedback, "server does not implement GetServerObservation; "+
+			"if that is expected, set supports_server_observations to false in the config to skip this test case")
+		return
+	}
+	if err != nil {
+		result.Feedback = append(result.Feedback, fmt.Sprintf("could not get the server's observation of the RPC: %v", err))
+		return
+	}
+	result.ServerObservation = resp.Msg.Observation
+}
+
 func (i *invoker) withWireCapture(ctx context.Context) context.Context {
 	if !i.referenceMode {
 		return ctx
@@ -0,0 +1,54 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"fmt"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// inflateRequests pads the request_data field of the request messages in
+// req with zeros, per the sizes in its inflated_request_sizes field.
+func inflateRequests(req *conformancev1.ClientCompatRequest) error {
+	if len(req.InflatedRequestSizes) > len(req.RequestMessages) {
+		return fmt.Errorf("inflated request sizes indicate %d messages, but there are only %d requests",
+			len(req.InflatedRequestSizes), len(req.RequestMessages))
+	}
+	for i, size := range req.InflatedRequestSizes {
+		if size == 0 {
+			// Zero means do not inflate this one.
+			continue
+		}
+		msg, err := req.RequestMessages[i].UnmarshalNew()
+		if err != nil {
+			return fmt.Errorf("request message #%d: %w", i+1, err)
+		}
+		reflectMsg := msg.ProtoReflect()
+		field := reflectMsg.Descriptor().Fields().ByName("request_data")
+		if field == nil || field.Cardinality() != protoreflect.Optional || field.Kind() != protoreflect.BytesKind {
+			return fmt.Errorf("request message #%d: message type %s has no request_data field for padding",
+				i+1, reflectMsg.Descriptor().FullName())
+		}
+		data := reflectMsg.Get(field).Bytes()
+		reflectMsg.Set(field, protoreflect.ValueOfBytes(internal.Inflate(data, size)))
+		if err := req.RequestMessages[i].MarshalFrom(msg); err != nil {
+			return fmt.Errorf("request message #%d: %w", i+1, err)
+		}
+	}
+	return nil
+}
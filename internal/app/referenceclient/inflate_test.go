@@ -0,0 +1,111 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"bytes"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestInflateRequests(t *testing.T) {
+	t.Parallel()
+
+	asAny := func(msg proto.Message) *anypb.Any {
+		msgAsAny, err := anypb.New(msg)
+		require.NoError(t, err)
+		return msgAsAny
+	}
+
+	testCases := []struct {
+		name         string
+		requests     []proto.Message
+		sizes        []uint32
+		expectedData [][]byte
+		expectErr    string
+	}{
+		{
+			name: "inflate first",
+			requests: []proto.Message{
+				&conformancev1.ClientStreamRequest{RequestData: []byte("abc")},
+				&conformancev1.ClientStreamRequest{RequestData: []byte("def")},
+			},
+			sizes:        []uint32{10},
+			expectedData: [][]byte{[]byte("abc\x00\x00\x00\x00\x00\x00\x00"), []byte("def")},
+		},
+		{
+			name: "inflate subsequent",
+			requests: []proto.Message{
+				&conformancev1.BidiStreamRequest{RequestData: []byte("abc")},
+				&conformancev1.BidiStreamRequest{},
+			},
+			sizes:        []uint32{0, 1024},
+			expectedData: [][]byte{[]byte("abc"), make([]byte, 1024)},
+		},
+		{
+			name: "already large enough",
+			requests: []proto.Message{
+				&conformancev1.UnaryRequest{RequestData: bytes.Repeat([]byte("abc"), 10)},
+			},
+			sizes:        []uint32{10},
+			expectedData: [][]byte{bytes.Repeat([]byte("abc"), 10)},
+		},
+		{
+			name: "too many sizes",
+			requests: []proto.Message{
+				&conformancev1.UnaryRequest{},
+			},
+			sizes:     []uint32{10, 10},
+			expectErr: "inflated request sizes indicate 2 messages, but there are only 1 requests",
+		},
+		{
+			name: "no request data field",
+			requests: []proto.Message{
+				&emptypb.Empty{},
+			},
+			sizes:     []uint32{10},
+			expectErr: "request message #1: message type google.protobuf.Empty has no request_data field for padding",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			req := &conformancev1.ClientCompatRequest{InflatedRequestSizes: testCase.sizes}
+			for _, msg := range testCase.requests {
+				req.RequestMessages = append(req.RequestMessages, asAny(msg))
+			}
+			err := inflateRequests(req)
+			if testCase.expectErr != "" {
+				require.EqualError(t, err, testCase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, req.RequestMessages, len(testCase.expectedData))
+			for i, msgAsAny := range req.RequestMessages {
+				msg, err := msgAsAny.UnmarshalNew()
+				require.NoError(t, err)
+				reqData, ok := msg.(interface{ GetRequestData() []byte })
+				require.True(t, ok)
+				assert.Equal(t, testCase.expectedData[i], reqData.GetRequestData())
+			}
+		})
+	}
+}
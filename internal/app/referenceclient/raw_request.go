@@ -30,6 +30,9 @@ import (
 type rawRequestSender struct {
 	transport  http.RoundTripper
 	rawRequest *conformancev1.RawHTTPRequest
+	// If non-nil, the request is sent as an HTTP/2 frame script,
+	// instead of using the above transport.
+	frameScript *frameScriptSender
 }
 
 func (r *rawRequestSender) RoundTrip(orig *http.Request) (*http.Response, error) {
@@ -66,6 +69,17 @@ func (r *rawRequestSender) RoundTrip(orig *http.Request) (*http.Response, error)
 		uri = reqURL.String()
 	}
 
+	if r.frameScript != nil {
+		// Drain the original request body and close it.
+		go func() {
+			defer func() {
+				_ = orig.Body.Close()
+			}()
+			_, _ = io.Copy(io.Discard, orig.Body)
+		}()
+		return r.frameScript.roundTrip(orig, requestLeadingFields(orig, r.rawRequest, uri))
+	}
+
 	pipeReader, pipeWriter := io.Pipe()
 	req, err := http.NewRequestWithContext(
 		orig.Context(),
@@ -94,7 +108,7 @@ func (r *rawRequestSender) RoundTrip(orig *http.Request) (*http.Response, error)
 		case *conformancev1.RawHTTPRequest_Unary:
 			_ = internal.WriteRawMessageContents(contents.Unary, pipeWriter)
 		case *conformancev1.RawHTTPRequest_Stream:
-			_ = internal.WriteRawStreamContents(contents.Stream, pipeWriter)
+			_ = internal.WriteRawStreamContents(orig.Context(), contents.Stream, pipeWriter)
 		}
 	}()
 
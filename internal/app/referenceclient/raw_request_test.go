@@ -425,7 +425,7 @@ func TestRawRequestSender(t *testing.T) {
 			case *conformancev1.RawHTTPRequest_Unary:
 				err = internal.WriteRawMessageContents(contents.Unary, &expected)
 			case *conformancev1.RawHTTPRequest_Stream:
-				err = internal.WriteRawStreamContents(contents.Stream, &expected)
+				err = internal.WriteRawStreamContents(context.Background(), contents.Stream, &expected)
 			}
 			require.NoError(t, err)
 			assert.Equal(t, expected.Bytes(), actual.Bytes())
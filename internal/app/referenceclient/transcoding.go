@@ -0,0 +1,177 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/transcoding"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// transcodingInvoker invokes methods of the TranscodingService using
+// REST-style HTTP requests, as described by the methods' google.api.http
+// annotations.
+type transcodingInvoker struct {
+	client        *http.Client
+	serverURL     *url.URL
+	referenceMode bool
+}
+
+func newTranscodingInvoker(transport http.RoundTripper, referenceMode bool, serverURL *url.URL) *transcodingInvoker {
+	return &transcodingInvoker{
+		client:        &http.Client{Transport: transport},
+		serverURL:     serverURL,
+		referenceMode: referenceMode,
+	}
+}
+
+func (i *transcodingInvoker) Invoke(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+) (*conformancev1.ClientResponseResult, error) {
+	if req.Codec != conformancev1.Codec_CODEC_JSON {
+		return nil, fmt.Errorf("%s only supports %s", req.Protocol, conformancev1.Codec_CODEC_JSON)
+	}
+	if req.Compression != conformancev1.Compression_COMPRESSION_IDENTITY &&
+		req.Compression != conformancev1.Compression_COMPRESSION_UNSPECIFIED {
+		return nil, fmt.Errorf("%s does not support %s", req.Protocol, req.Compression)
+	}
+	if len(req.RequestMessages) != 1 {
+		return nil, errors.New("unary calls must specify exactly one request message")
+	}
+	rule, err := transcoding.RuleForProcedure(req.GetService(), req.GetMethod())
+	if err != nil {
+		return nil, err
+	}
+	msg, err := req.RequestMessages[0].UnmarshalNew()
+	if err != nil {
+		return nil, err
+	}
+	if msg.ProtoReflect().Descriptor() != rule.MethodDescriptor().Input() {
+		return nil, fmt.Errorf("request message is %s, but method %s expects %s",
+			msg.ProtoReflect().Descriptor().FullName(), req.GetMethod(), rule.MethodDescriptor().Input().FullName())
+	}
+	timing, err := internal.GetCancelTiming(req.Cancel)
+	if err != nil {
+		return nil, err
+	}
+
+	// If a timeout was specified, create a derived context with that deadline.
+	// There is no way to send the timeout to the server in a REST-style request,
+	// so it is only enforced by the client.
+	if req.TimeoutMs != nil {
+		deadlineCtx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Duration(*req.TimeoutMs)*time.Millisecond))
+		ctx = deadlineCtx
+		defer cancel()
+	}
+	if timing.AfterCloseSendMs >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		time.AfterFunc(time.Duration(timing.AfterCloseSendMs)*time.Millisecond, cancel)
+	}
+
+	path, query, body, err := rule.EncodeRequest(msg)
+	if err != nil {
+		return nil, err
+	}
+	// The path is already escaped, so it is parsed, instead of being
+	// assigned to reqURL.Path, to preserve the escaping.
+	reqURL, err := url.Parse(strings.TrimSuffix(i.serverURL.String(), "/") + path)
+	if err != nil {
+		return nil, err
+	}
+	reqURL.RawQuery = query.Encode()
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, rule.Method, reqURL.String(), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	internal.AddHeaders(req.RequestHeaders, httpReq.Header)
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("User-Agent", fmt.Sprintf("%s/%s", clientName, internal.Version))
+
+	result := &conformancev1.ClientResponseResult{}
+	resp, err := i.client.Do(httpReq)
+	if err != nil {
+		result.Error = internal.ConvertConnectToProtoError(transcodingTransportError(ctx, err))
+		return result, nil
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		result.Error = internal.ConvertConnectToProtoError(transcodingTransportError(ctx, err))
+		return result, nil
+	}
+	result.ResponseHeaders = internal.ConvertToProtoHeader(resp.Header)
+
+	var feedback []string
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "application/json" {
+		feedback = append(feedback, fmt.Sprintf("response content-type should be %q but instead got %q",
+			"application/json", resp.Header.Get("Content-Type")))
+	}
+	if resp.StatusCode == http.StatusOK {
+		var respMsg conformancev1.UnaryResponse
+		if err := protojson.Unmarshal(respBody, &respMsg); err != nil {
+			result.Error = internal.ConvertConnectToProtoError(connect.NewError(connect.CodeInternal,
+				fmt.Errorf("could not parse response body: %w", err)))
+		} else {
+			result.Payloads = []*conformancev1.ConformancePayload{respMsg.Payload}
+		}
+	} else {
+		connectErr := transcoding.UnmarshalError(resp.StatusCode, respBody)
+		if expected := transcoding.HTTPStatusFromCode(connectErr.Code()); expected != resp.StatusCode {
+			feedback = append(feedback, fmt.Sprintf("HTTP status for error code %v should be %d but instead got %d",
+				connectErr.Code(), expected, resp.StatusCode))
+		}
+		result.Error = internal.ConvertConnectToProtoError(connectErr)
+	}
+	if i.referenceMode {
+		result.HttpStatusCode = proto.Int32(int32(resp.StatusCode))
+		result.Feedback = feedback
+	}
+	return result, nil
+}
+
+// transcodingTransportError converts an error from sending a request or
+// receiving a response into a Connect error.
+func transcodingTransportError(ctx context.Context, err error) *connect.Error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return connect.NewError(connect.CodeDeadlineExceeded, err)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return connect.NewError(connect.CodeCanceled, err)
+	default:
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+}
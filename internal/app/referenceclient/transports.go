@@ -31,6 +31,7 @@ import (
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/connect"
 	"github.com/quic-go/quic-go"
 	"github.com/quic-go/quic-go/http3"
 )
@@ -40,6 +41,8 @@ type transportSpec struct {
 	serverTLSCert string
 	clientTLSCert string
 	clientTLSKey  string
+	// The limit on the size of response headers and trailers.
+	headerSizeLimit uint32
 }
 
 type transports struct {
@@ -68,6 +71,8 @@ func (t *transports) get(req *conformancev1.ClientCompatRequest) (http.RoundTrip
 		serverTLSCert: string(req.GetServerTlsCert()),
 		clientTLSCert: string(req.GetClientTlsCreds().GetCert()),
 		clientTLSKey:  string(req.GetClientTlsCreds().GetKey()),
+
+		headerSizeLimit: req.GetHeaderSizeLimit(),
 	}
 
 	// Optimistically skip logic if it's already cached. We will still do an
@@ -83,8 +88,9 @@ func (t *transports) get(req *conformancev1.ClientCompatRequest) (http.RoundTrip
 			tlsConf.NextProtos = []string{"http/1.1"}
 		}
 		tx := &http.Transport{
-			DisableCompression: true,
-			TLSClientConfig:    tlsConf,
+			DisableCompression:     true,
+			TLSClientConfig:        tlsConf,
+			MaxResponseHeaderBytes: int64(spec.headerSizeLimit),
 		}
 		transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			resp, err := tx.RoundTrip(req)
@@ -107,20 +113,22 @@ func (t *transports) get(req *conformancev1.ClientCompatRequest) (http.RoundTrip
 			prots = &http.Protocols{}
 			prots.SetUnencryptedHTTP2(true)
 		}
-		transport = &http.Transport{
-			DisableCompression: true,
-			TLSClientConfig:    tlsConf,
-			ForceAttemptHTTP2:  forceAttemptHTTP2,
-			Protocols:          prots,
-		}
+		transport = &closeBodyOnDoneTransport{&http.Transport{
+			DisableCompression:     true,
+			TLSClientConfig:        tlsConf,
+			ForceAttemptHTTP2:      forceAttemptHTTP2,
+			Protocols:              prots,
+			MaxResponseHeaderBytes: int64(spec.headerSizeLimit),
+		}}
 	case conformancev1.HTTPVersion_HTTP_VERSION_3:
 		if tlsConf == nil {
 			return nil, nil, errors.New("HTTP/3 indicated in request but no TLS info provided")
 		}
 		transport = &contextFixTransport{http3.Transport{
-			DisableCompression: true,
-			TLSClientConfig:    tlsConf,
-			QUICConfig:         &quic.Config{MaxIdleTimeout: 20 * time.Second, KeepAlivePeriod: 5 * time.Second},
+			DisableCompression:     true,
+			TLSClientConfig:        tlsConf,
+			QUICConfig:             &quic.Config{MaxIdleTimeout: 20 * time.Second, KeepAlivePeriod: 5 * time.Second},
+			MaxResponseHeaderBytes: int(spec.headerSizeLimit),
 		}}
 	case conformancev1.HTTPVersion_HTTP_VERSION_UNSPECIFIED:
 		return nil, nil, errors.New("an HTTP version must be specified")
@@ -128,6 +136,8 @@ func (t *transports) get(req *conformancev1.ClientCompatRequest) (http.RoundTrip
 		return nil, nil, fmt.Errorf("unknown HTTP version specified :%d", req.HttpVersion)
 	}
 
+	transport = &headerLimitTransport{transport}
+
 	// Even if two requests for the same spec make it here, they will use the same connection.
 	actual, _ := t.cache.LoadOrStore(spec, transport)
 	return actual.(http.RoundTripper), serverURL, nil //nolint:errcheck,forcetypeassert
@@ -195,6 +205,93 @@ func (e *contextFixError) Is(err error) bool {
 		(!e.timeout && err == context.Canceled)
 }
 
+// closeBodyOnDoneTransport wraps an HTTP/2 transport so that the request body
+// is closed when the request context is done. Without this, reading the
+// response body of a full-duplex stream, whose request body is still open,
+// blocks until the server sends more data, even after the deadline has
+// passed or the RPC has been canceled.
+type closeBodyOnDoneTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *closeBodyOnDoneTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		body := req.Body
+		stop := context.AfterFunc(req.Context(), func() {
+			_ = body.Close()
+		})
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil {
+			stop()
+		}
+		return resp, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// headerLimitTransport wraps a transport so that errors that result from
+// headers or trailers that exceed a size limit are reported as
+// RESOURCE_EXHAUSTED errors. Otherwise, connect-go reports them as
+// UNAVAILABLE or UNKNOWN errors, since the HTTP libraries don't
+// export them.
+type headerLimitTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *headerLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, wrapIfHeaderLimitError(err)
+	}
+	resp.Body = &headerLimitReader{r: resp.Body}
+	return resp, nil
+}
+
+type headerLimitReader struct {
+	r io.ReadCloser
+}
+
+func (r *headerLimitReader) Read(data []byte) (int, error) {
+	n, err := r.r.Read(data)
+	return n, wrapIfHeaderLimitError(err)
+}
+
+func (r *headerLimitReader) Close() error {
+	return r.r.Close()
+}
+
+// headerLimitErrorMessages are substrings of the errors that the HTTP
+// libraries return when response headers or trailers exceed the client's
+// limit. The libraries don't export these errors, so they are matched by
+// message. TestHeaderLimitTransport verifies each of them. Errors for
+// request headers that exceed the server's limit are deliberately not
+// included: those are up to the server to report.
+//
+//nolint:gochecknoglobals
+var headerLimitErrorMessages = []string{
+	// HTTP/1.1
+	"server response headers exceeded",
+	// HTTP/2
+	"response header list larger than advertised limit",
+	// HTTP/3, when the encoded header block is too large
+	"HEADERS frame too large",
+	// HTTP/3, when the decoded header list is too large
+	"http3: headers too large",
+}
+
+func wrapIfHeaderLimitError(err error) error {
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+	msg := err.Error()
+	for _, limitMsg := range headerLimitErrorMessages {
+		if strings.Contains(msg, limitMsg) {
+			return connect.NewError(connect.CodeResourceExhausted, err)
+		}
+	}
+	return err
+}
+
 func createTLSConfig(req *conformancev1.ClientCompatRequest) (*tls.Config, error) {
 	if req.ServerTlsCert == nil {
 		if req.ClientTlsCreds != nil {
@@ -0,0 +1,166 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/connect"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHeaderLimitTransport provokes each of the errors in
+// headerLimitErrorMessages from the real HTTP libraries, so that a change
+// to their messages is caught here instead of in the conformance suites.
+func TestHeaderLimitTransport(t *testing.T) {
+	t.Parallel()
+
+	const headerSizeLimit = 1024
+	certBytes, keyBytes, err := internal.NewServerCert()
+	require.NoError(t, err)
+	cert, err := internal.ParseServerCert(certBytes, keyBytes)
+	require.NoError(t, err)
+	newTLSConfig := func() *tls.Config {
+		tlsConf, err := internal.NewServerTLSConfig(cert, tls.NoClientCert, nil)
+		require.NoError(t, err)
+		return tlsConf
+	}
+
+	handler := http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/large":
+			// A single field that is larger than the limit, which also
+			// makes the encoded header block larger than the limit.
+			respWriter.Header().Set("X-Large", strings.Repeat("a", 2*headerSizeLimit))
+		case "/many":
+			// Many small fields. Each one counts for an extra 32 bytes
+			// against the limit, so the decoded header list is larger
+			// than the limit even though the encoded block is not.
+			for range headerSizeLimit / 32 {
+				respWriter.Header().Add("X-Many", "a")
+			}
+		}
+		respWriter.WriteHeader(http.StatusOK)
+	})
+
+	h1Server := httptest.NewUnstartedServer(handler)
+	h1Server.TLS = newTLSConfig()
+	h1Server.StartTLS()
+	t.Cleanup(h1Server.Close)
+
+	h2Server := httptest.NewUnstartedServer(handler)
+	h2Server.EnableHTTP2 = true
+	h2Server.TLS = newTLSConfig()
+	h2Server.StartTLS()
+	t.Cleanup(h2Server.Close)
+
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	h3Server := &http3.Server{Handler: handler, TLSConfig: http3.ConfigureTLSConfig(newTLSConfig())}
+	go func() {
+		_ = h3Server.Serve(udpConn)
+	}()
+	t.Cleanup(func() {
+		_ = h3Server.Close()
+		_ = udpConn.Close()
+	})
+
+	testCases := []struct {
+		name        string
+		httpVersion conformancev1.HTTPVersion
+		addr        string
+		path        string
+		expectedMsg string
+	}{
+		{
+			name:        "http1",
+			httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1,
+			addr:        h1Server.Listener.Addr().String(),
+			path:        "/large",
+			expectedMsg: headerLimitErrorMessages[0],
+		},
+		{
+			name:        "http2",
+			httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_2,
+			addr:        h2Server.Listener.Addr().String(),
+			// A single field that is larger than the limit is a connection
+			// error (COMPRESSION_ERROR) with HTTP/2, so the client header
+			// size suites divide oversized metadata among many fields.
+			path:        "/many",
+			expectedMsg: headerLimitErrorMessages[1],
+		},
+		{
+			name:        "http3-frame-too-large",
+			httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_3,
+			addr:        udpConn.LocalAddr().String(),
+			path:        "/large",
+			expectedMsg: headerLimitErrorMessages[2],
+		},
+		{
+			name:        "http3-headers-too-large",
+			httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_3,
+			addr:        udpConn.LocalAddr().String(),
+			path:        "/many",
+			expectedMsg: headerLimitErrorMessages[3],
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			host, port, err := net.SplitHostPort(testCase.addr)
+			require.NoError(t, err)
+			portNum, err := net.LookupPort("tcp", port)
+			require.NoError(t, err)
+			var trs transports
+			transport, serverURL, err := trs.get(&conformancev1.ClientCompatRequest{
+				HttpVersion:     testCase.httpVersion,
+				Host:            host,
+				Port:            uint32(portNum), //nolint:gosec // port numbers fit in uint32
+				ServerTlsCert:   certBytes,
+				HeaderSizeLimit: headerSizeLimit,
+			})
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL.String()+testCase.path, http.NoBody)
+			require.NoError(t, err)
+			resp, err := transport.RoundTrip(req)
+			if err == nil {
+				// Some transports only report the error when the body is read.
+				_, err = io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+			}
+			require.ErrorContains(t, err, testCase.expectedMsg)
+			assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+			// Headers within the limit must work.
+			req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, serverURL.String()+"/small", http.NoBody)
+			require.NoError(t, err)
+			resp, err = transport.RoundTrip(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
@@ -216,6 +216,8 @@ func examineWireDetails(ctx context.Context, printer internal.Printer) (statusCo
 		}
 	}
 
+	checkResponseCompression(trace, printer)
+
 	if contentType != "application/grpc" && !strings.HasPrefix(contentType, "application/grpc+") {
 		// It's not gRPC protocol, so there should be no HTTP trailers.
 		if len(trace.Response.Trailer) > 0 {
@@ -226,6 +228,37 @@ func examineWireDetails(ctx context.Context, printer internal.Printer) (statusCo
 	return trace.Response.StatusCode, true
 }
 
+// checkResponseCompression verifies that the server compressed the response
+// using a compression that the client advertised that it accepts.
+func checkResponseCompression(trace *tracer.Trace, printer internal.Printer) {
+	var encodingHeader, acceptHeader string
+	contentType := trace.Response.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/grpc"):
+		encodingHeader, acceptHeader = "Grpc-Encoding", "Grpc-Accept-Encoding"
+	case strings.HasPrefix(contentType, "application/connect+"):
+		encodingHeader, acceptHeader = "Connect-Content-Encoding", "Connect-Accept-Encoding"
+	default:
+		encodingHeader, acceptHeader = "Content-Encoding", "Accept-Encoding"
+	}
+	encoding := trace.Response.Header.Get(encodingHeader)
+	acceptVals := trace.Request.Header.Values(acceptHeader)
+	if encoding == "" || encoding == "identity" || len(acceptVals) == 0 {
+		// If the client does not advertise any compressions, it's
+		// up to the HTTP client or RPC library to complain.
+		return
+	}
+	for _, acceptVal := range acceptVals {
+		for _, accepted := range strings.Split(acceptVal, ",") {
+			if strings.TrimSpace(accepted) == encoding {
+				return
+			}
+		}
+	}
+	printer.Printf("response is compressed using %q, but the client only accepts %q",
+		encoding, strings.Join(acceptVals, ","))
+}
+
 type wireTracer struct {
 	tracer *tracer.Tracer
 }
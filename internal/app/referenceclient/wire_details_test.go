@@ -31,6 +31,7 @@ import (
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/conformance/internal/tracer"
 	"connectrpc.com/connect"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -1022,6 +1023,72 @@ func TestCheckNoDuplicateKeys(t *testing.T) {
 	}
 }
 
+func TestCheckResponseCompression(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name            string
+		requestHeaders  http.Header
+		responseHeaders http.Header
+		expectedErr     string
+	}{
+		{
+			name:            "gRPC accepted",
+			requestHeaders:  http.Header{"Grpc-Accept-Encoding": []string{"zstd, gzip"}},
+			responseHeaders: http.Header{"Content-Type": []string{"application/grpc"}, "Grpc-Encoding": []string{"gzip"}},
+		},
+		{
+			name:            "gRPC not accepted",
+			requestHeaders:  http.Header{"Grpc-Accept-Encoding": []string{"zstd"}},
+			responseHeaders: http.Header{"Content-Type": []string{"application/grpc+proto"}, "Grpc-Encoding": []string{"gzip"}},
+			expectedErr:     `response is compressed using "gzip", but the client only accepts "zstd"`,
+		},
+		{
+			name:            "gRPC-Web not accepted",
+			requestHeaders:  http.Header{"Grpc-Accept-Encoding": []string{"br"}},
+			responseHeaders: http.Header{"Content-Type": []string{"application/grpc-web+proto"}, "Grpc-Encoding": []string{"snappy"}},
+			expectedErr:     `response is compressed using "snappy", but the client only accepts "br"`,
+		},
+		{
+			name:            "Connect stream not accepted",
+			requestHeaders:  http.Header{"Accept-Encoding": []string{"gzip"}, "Connect-Accept-Encoding": []string{"br"}},
+			responseHeaders: http.Header{"Content-Type": []string{"application/connect+proto"}, "Connect-Content-Encoding": []string{"gzip"}},
+			expectedErr:     `response is compressed using "gzip", but the client only accepts "br"`,
+		},
+		{
+			name:            "Connect unary accepted",
+			requestHeaders:  http.Header{"Accept-Encoding": []string{"br", "deflate"}},
+			responseHeaders: http.Header{"Content-Type": []string{"application/proto"}, "Content-Encoding": []string{"deflate"}},
+		},
+		{
+			name:            "identity always accepted",
+			requestHeaders:  http.Header{"Grpc-Accept-Encoding": []string{"zstd"}},
+			responseHeaders: http.Header{"Content-Type": []string{"application/grpc"}, "Grpc-Encoding": []string{"identity"}},
+		},
+		{
+			name:            "nothing advertised",
+			requestHeaders:  http.Header{},
+			responseHeaders: http.Header{"Content-Type": []string{"application/grpc"}, "Grpc-Encoding": []string{"gzip"}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			printer := &internal.SimplePrinter{}
+			checkResponseCompression(&tracer.Trace{
+				Request:  &http.Request{Header: testCase.requestHeaders},
+				Response: &http.Response{Header: testCase.responseHeaders},
+			}, printer)
+			if testCase.expectedErr == "" {
+				assert.Empty(t, printer.Messages)
+				return
+			}
+			if assert.Len(t, printer.Messages, 1) {
+				assert.Contains(t, printer.Messages[0], testCase.expectedErr)
+			}
+		})
+	}
+}
+
 func writeStreamFrame(data []byte, compressed bool, writer io.Writer) {
 	if compressed {
 		var buf bytes.Buffer
@@ -0,0 +1,198 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+const (
+	// attemptSpacingGracePeriod is the amount of leeway allowed when checking
+	// the time between attempts of an RPC, to account for latency between the
+	// client sending an attempt and the server receiving it.
+	attemptSpacingGracePeriod = 250 * time.Millisecond
+	// defaultAttemptRetention is how long the attempts for a test case are
+	// remembered after the last one completes, in case the client makes
+	// another. This is far longer than any backoff in the test suites.
+	defaultAttemptRetention = time.Minute
+)
+
+type attemptContextKey struct{}
+
+// attemptTracker keeps track of the requests received for each test case.
+// When a client retries an RPC, each attempt is another request for the
+// same test case. A test case is forgotten once it has had no attempts in
+// progress for the retention period.
+type attemptTracker struct {
+	retention time.Duration
+
+	mu       sync.Mutex
+	attempts map[string]*testCaseAttempts
+}
+
+type testCaseAttempts struct {
+	count int
+	// The number of attempts that are still in progress.
+	active int
+	// The time that the most recently completed attempt finished.
+	lastFinish time.Time
+}
+
+func newAttemptTracker() *attemptTracker {
+	return &attemptTracker{
+		retention: defaultAttemptRetention,
+		attempts:  map[string]*testCaseAttempts{},
+	}
+}
+
+// start records the start of an attempt for the given test case. It returns
+// the attempt number (starting at one) along with the state of the test case
+// before this attempt.
+func (t *attemptTracker) start(testCaseName string) (int, testCaseAttempts) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	attempts := t.attempts[testCaseName]
+	if attempts == nil {
+		attempts = &testCaseAttempts{}
+		t.attempts[testCaseName] = attempts
+	}
+	prev := *attempts
+	attempts.count++
+	attempts.active++
+	return attempts.count, prev
+}
+
+// finish records that an attempt for the given test case has completed.
+func (t *attemptTracker) finish(testCaseName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	attempts := t.attempts[testCaseName]
+	attempts.active--
+	attempts.lastFinish = time.Now()
+	if attempts.active == 0 {
+		time.AfterFunc(t.retention, func() {
+			t.forget(testCaseName, attempts)
+		})
+	}
+}
+
+// forget removes the given attempts for a test case, unless another attempt
+// has started or completed since the last one that was being waited on.
+func (t *attemptTracker) forget(testCaseName string, attempts *testCaseAttempts) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.attempts[testCaseName] != attempts || attempts.active > 0 || time.Since(attempts.lastFinish) < t.retention {
+		return
+	}
+	delete(t.attempts, testCaseName)
+}
+
+// checkAttempt verifies that the given attempt of an RPC is allowed by the
+// client's retry policy, if any, as described by "X-Expect-*"
+// request headers.
+func checkAttempt(attempt int, prev testCaseAttempts, req *http.Request, feedback *feedbackPrinter) {
+	maxAttempts := 1
+	if val := req.Header.Get("X-Expect-Max-Attempts"); val != "" {
+		intVal, err := strconv.Atoi(val)
+		if err != nil || intVal < 1 {
+			feedback.Printf("invalid value for %q header: %q", "X-Expect-Max-Attempts", val)
+		} else {
+			maxAttempts = intVal
+		}
+	}
+	if maxAttempts == 1 {
+		// Without a retry policy, there is one request for each
+		// RPC that the client is asked to issue in sequence.
+		if attempt > sequentialRPCCount(req.Header, feedback) {
+			feedback.Printf("client sent another request (#%d) for the same test case", attempt)
+		}
+		return
+	}
+	if attempt > maxAttempts {
+		feedback.Printf("client sent another attempt (#%d) for the same test case, but its policy allows at most %d attempts", attempt, maxAttempts)
+	}
+
+	contentType := requestContentType(req)
+	if contentType == grpcContentType || strings.HasPrefix(contentType, grpcContentTypePrefix) {
+		prevAttempts := req.Header.Get("Grpc-Previous-Rpc-Attempts")
+		switch {
+		case attempt == 1 && prevAttempts != "":
+			feedback.Printf("first attempt should not include %q header, but it had value %q", "grpc-previous-rpc-attempts", prevAttempts)
+		case attempt > 1 && prevAttempts != strconv.Itoa(attempt-1):
+			feedback.Printf("attempt #%d should include %q header with value %q; instead got %q",
+				attempt, "grpc-previous-rpc-attempts", strconv.Itoa(attempt-1), prevAttempts)
+		}
+	}
+
+	if attempt == 1 {
+		return
+	}
+	now := time.Now()
+	if val := req.Header.Get("X-Expect-Retry-Backoff-Ms"); val != "" {
+		if prev.active > 0 {
+			feedback.Printf("attempt #%d was sent before the previous attempt completed, but retry attempts should be sequential", attempt)
+			return
+		}
+		backoffs := strings.Split(val, ",")
+		if attempt-2 >= len(backoffs) {
+			return
+		}
+		backoffMillis, err := strconv.Atoi(strings.TrimSpace(backoffs[attempt-2]))
+		if err != nil {
+			feedback.Printf("invalid value for %q header: %q", "X-Expect-Retry-Backoff-Ms", val)
+			return
+		}
+		maxBackoff := time.Duration(backoffMillis) * time.Millisecond
+		if elapsed := now.Sub(prev.lastFinish); elapsed > maxBackoff+attemptSpacingGracePeriod {
+			feedback.Printf("attempt #%d was sent %v after the previous attempt completed, but the retry policy's backoff is at most %v",
+				attempt, elapsed.Round(time.Millisecond), maxBackoff)
+		}
+	}
+}
+
+func contextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptContextKey{}, attempt)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	attempt, ok := ctx.Value(attemptContextKey{}).(int)
+	if !ok {
+		return 1
+	}
+	return attempt
+}
+
+// responseDefinitionForAttempt returns the response definition to use for
+// the given request. If the request defines responses for each attempt, the
+// one for the current attempt is returned.
+func responseDefinitionForAttempt(ctx context.Context, msg interface {
+	GetResponseDefinition() *conformancev1.UnaryResponseDefinition
+}) *conformancev1.UnaryResponseDefinition {
+	attemptMsg, ok := msg.(interface {
+		GetAttemptResponseDefinitions() []*conformancev1.UnaryResponseDefinition
+	})
+	if !ok || len(attemptMsg.GetAttemptResponseDefinitions()) == 0 {
+		return msg.GetResponseDefinition()
+	}
+	defs := attemptMsg.GetAttemptResponseDefinitions()
+	return defs[min(attemptFromContext(ctx), len(defs))-1]
+}
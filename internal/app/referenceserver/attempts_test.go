@@ -0,0 +1,318 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCheckAttempt(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	testCases := []struct {
+		name          string
+		attempt       int
+		prev          testCaseAttempts
+		headers       http.Header
+		expectedError string
+	}{
+		{
+			name:    "single attempt without policy",
+			attempt: 1,
+			headers: http.Header{"Content-Type": []string{"application/proto"}},
+		},
+		{
+			name:          "second attempt without policy",
+			attempt:       2,
+			prev:          testCaseAttempts{count: 1, lastFinish: now},
+			headers:       http.Header{"Content-Type": []string{"application/proto"}},
+			expectedError: "client sent another request (#2) for the same test case",
+		},
+		{
+			name:    "sequential RPCs without policy",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":             []string{"application/proto"},
+				"X-Expect-Sequential-Rpcs": []string{"2"},
+			},
+		},
+		{
+			name:    "invalid max attempts",
+			attempt: 1,
+			headers: http.Header{
+				"Content-Type":          []string{"application/proto"},
+				"X-Expect-Max-Attempts": []string{"zero"},
+			},
+			expectedError: `invalid value for "X-Expect-Max-Attempts" header: "zero"`,
+		},
+		{
+			name:    "last allowed attempt",
+			attempt: 3,
+			prev:    testCaseAttempts{count: 2, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":          []string{"application/proto"},
+				"X-Expect-Max-Attempts": []string{"3"},
+			},
+		},
+		{
+			name:    "too many attempts",
+			attempt: 4,
+			prev:    testCaseAttempts{count: 3, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":          []string{"application/proto"},
+				"X-Expect-Max-Attempts": []string{"3"},
+			},
+			expectedError: "client sent another attempt (#4) for the same test case, but its policy allows at most 3 attempts",
+		},
+		{
+			name:    "gRPC first attempt without previous attempts",
+			attempt: 1,
+			headers: http.Header{
+				"Content-Type":          []string{"application/grpc"},
+				"X-Expect-Max-Attempts": []string{"3"},
+			},
+		},
+		{
+			name:    "gRPC first attempt with previous attempts",
+			attempt: 1,
+			headers: http.Header{
+				"Content-Type":               []string{"application/grpc"},
+				"X-Expect-Max-Attempts":      []string{"3"},
+				"Grpc-Previous-Rpc-Attempts": []string{"1"},
+			},
+			expectedError: `first attempt should not include "grpc-previous-rpc-attempts" header, but it had value "1"`,
+		},
+		{
+			name:    "gRPC retry with previous attempts",
+			attempt: 3,
+			prev:    testCaseAttempts{count: 2, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":               []string{"application/grpc+proto"},
+				"X-Expect-Max-Attempts":      []string{"3"},
+				"Grpc-Previous-Rpc-Attempts": []string{"2"},
+			},
+		},
+		{
+			name:    "gRPC retry without previous attempts",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":          []string{"application/grpc+proto"},
+				"X-Expect-Max-Attempts": []string{"3"},
+			},
+			expectedError: `attempt #2 should include "grpc-previous-rpc-attempts" header with value "1"; instead got ""`,
+		},
+		{
+			name:    "gRPC retry with wrong previous attempts",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":               []string{"application/grpc"},
+				"X-Expect-Max-Attempts":      []string{"3"},
+				"Grpc-Previous-Rpc-Attempts": []string{"2"},
+			},
+			expectedError: `attempt #2 should include "grpc-previous-rpc-attempts" header with value "1"; instead got "2"`,
+		},
+		{
+			name:    "previous attempts not checked for Connect",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":          []string{"application/proto"},
+				"X-Expect-Max-Attempts": []string{"3"},
+			},
+		},
+		{
+			name:    "retry within backoff",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":              []string{"application/proto"},
+				"X-Expect-Max-Attempts":     []string{"3"},
+				"X-Expect-Retry-Backoff-Ms": []string{"100, 200"},
+			},
+		},
+		{
+			name:    "retry after backoff",
+			attempt: 3,
+			prev:    testCaseAttempts{count: 2, lastFinish: now.Add(-time.Second)},
+			headers: http.Header{
+				"Content-Type":              []string{"application/proto"},
+				"X-Expect-Max-Attempts":     []string{"3"},
+				"X-Expect-Retry-Backoff-Ms": []string{"100, 200"},
+			},
+			expectedError: "but the retry policy's backoff is at most 200ms",
+		},
+		{
+			name:    "retry beyond listed backoffs",
+			attempt: 3,
+			prev:    testCaseAttempts{count: 2, lastFinish: now.Add(-time.Second)},
+			headers: http.Header{
+				"Content-Type":              []string{"application/proto"},
+				"X-Expect-Max-Attempts":     []string{"3"},
+				"X-Expect-Retry-Backoff-Ms": []string{"100"},
+			},
+		},
+		{
+			name:    "retry before previous attempt completed",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, active: 1},
+			headers: http.Header{
+				"Content-Type":              []string{"application/proto"},
+				"X-Expect-Max-Attempts":     []string{"3"},
+				"X-Expect-Retry-Backoff-Ms": []string{"100"},
+			},
+			expectedError: "attempt #2 was sent before the previous attempt completed, but retry attempts should be sequential",
+		},
+		{
+			name:    "invalid backoff",
+			attempt: 2,
+			prev:    testCaseAttempts{count: 1, lastFinish: now},
+			headers: http.Header{
+				"Content-Type":              []string{"application/proto"},
+				"X-Expect-Max-Attempts":     []string{"3"},
+				"X-Expect-Retry-Backoff-Ms": []string{"soon"},
+			},
+			expectedError: `invalid value for "X-Expect-Retry-Backoff-Ms" header: "soon"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			printer := &internal.SimplePrinter{}
+			feedback := &feedbackPrinter{p: printer, testCaseName: testCase.name}
+			req := &http.Request{Header: testCase.headers}
+			checkAttempt(testCase.attempt, testCase.prev, req, feedback)
+			if testCase.expectedError == "" {
+				assert.Empty(t, printer.Messages)
+				return
+			}
+			if assert.Len(t, printer.Messages, 1) {
+				assert.Contains(t, printer.Messages[0], testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestAttemptTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newAttemptTracker()
+	tracker.retention = 50 * time.Millisecond
+
+	attempt, prev := tracker.start("foo")
+	assert.Equal(t, 1, attempt)
+	assert.Zero(t, prev)
+	attempt, prev = tracker.start("foo")
+	assert.Equal(t, 2, attempt)
+	assert.Equal(t, 1, prev.active)
+	attempt, _ = tracker.start("bar")
+	assert.Equal(t, 1, attempt)
+
+	tracker.finish("foo")
+	tracker.finish("bar")
+	attempt, prev = tracker.start("foo")
+	assert.Equal(t, 3, attempt)
+	assert.Equal(t, 1, prev.active)
+	assert.False(t, prev.lastFinish.IsZero())
+
+	// Test cases are forgotten once they have no attempts in progress.
+	require.Eventually(t, func() bool {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		_, ok := tracker.attempts["bar"]
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(2 * tracker.retention)
+	tracker.mu.Lock()
+	assert.Contains(t, tracker.attempts, "foo")
+	tracker.mu.Unlock()
+
+	tracker.finish("foo")
+	tracker.finish("foo")
+	require.Eventually(t, func() bool {
+		tracker.mu.Lock()
+		defer tracker.mu.Unlock()
+		return len(tracker.attempts) == 0
+	}, time.Second, 10*time.Millisecond)
+	attempt, _ = tracker.start("foo")
+	assert.Equal(t, 1, attempt)
+}
+
+func TestResponseDefinitionForAttempt(t *testing.T) {
+	t.Parallel()
+
+	defaultDef := &conformancev1.UnaryResponseDefinition{
+		Response: &conformancev1.UnaryResponseDefinition_ResponseData{ResponseData: []byte("default")},
+	}
+	attemptDefs := []*conformancev1.UnaryResponseDefinition{
+		{Response: &conformancev1.UnaryResponseDefinition_ResponseData{ResponseData: []byte("first")}},
+		{Response: &conformancev1.UnaryResponseDefinition_ResponseData{ResponseData: []byte("second")}},
+	}
+	testCases := []struct {
+		name string
+		msg  interface {
+			GetResponseDefinition() *conformancev1.UnaryResponseDefinition
+		}
+		attempt  int
+		expected *conformancev1.UnaryResponseDefinition
+	}{
+		{
+			name:     "no attempt definitions",
+			msg:      &conformancev1.UnaryRequest{ResponseDefinition: defaultDef},
+			attempt:  2,
+			expected: defaultDef,
+		},
+		{
+			name:     "first attempt",
+			msg:      &conformancev1.UnaryRequest{ResponseDefinition: defaultDef, AttemptResponseDefinitions: attemptDefs},
+			attempt:  1,
+			expected: attemptDefs[0],
+		},
+		{
+			name:     "second attempt",
+			msg:      &conformancev1.UnaryRequest{ResponseDefinition: defaultDef, AttemptResponseDefinitions: attemptDefs},
+			attempt:  2,
+			expected: attemptDefs[1],
+		},
+		{
+			name:     "later attempts reuse the last definition",
+			msg:      &conformancev1.UnaryRequest{ResponseDefinition: defaultDef, AttemptResponseDefinitions: attemptDefs},
+			attempt:  5,
+			expected: attemptDefs[1],
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := contextWithAttempt(context.Background(), testCase.attempt)
+			def := responseDefinitionForAttempt(ctx, testCase.msg)
+			assert.True(t, proto.Equal(testCase.expected, def), "got %v", def)
+		})
+	}
+}
@@ -0,0 +1,418 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/connect"
+	"golang.org/x/net/http2"
+)
+
+// defaultCancelWait is how long the server waits, after the point at which
+// a client should have canceled an RPC, for the cancellation to reach it,
+// if no other duration is configured.
+const defaultCancelWait = time.Second
+
+type cancelObserverKey struct{}
+
+type cancelTiming int
+
+const (
+	cancelBeforeCloseSend cancelTiming = iota + 1
+	cancelAfterCloseSend
+	cancelAfterNumResponses
+	cancelAfterNumRequests
+)
+
+// cancelExpectation describes when a client should cancel an RPC. It is
+// indicated by the "X-Expect-Cancel" header.
+type cancelExpectation struct {
+	timing cancelTiming
+	// For cancelAfterCloseSend, this is the delay in milliseconds. For
+	// cancelAfterNumResponses, this is the number of responses. And for
+	// cancelAfterNumRequests, this is the number of requests.
+	param int
+	// For cancelAfterNumRequests, this is the delay in milliseconds after
+	// the last request is sent.
+	delayMs int
+}
+
+func parseCancelExpectation(val string) (cancelExpectation, bool) {
+	val, delay, hasDelay := strings.Cut(val, ";")
+	name, param, hasParam := strings.Cut(val, "=")
+	var timing cancelTiming
+	switch {
+	case name == "before-close-send" && !hasParam:
+		return cancelExpectation{timing: cancelBeforeCloseSend}, true
+	case name == "after-close-send-ms" && hasParam:
+		timing = cancelAfterCloseSend
+	case name == "after-num-responses" && hasParam:
+		timing = cancelAfterNumResponses
+	case name == "after-num-requests" && hasParam:
+		timing = cancelAfterNumRequests
+	default:
+		return cancelExpectation{}, false
+	}
+	if hasDelay && timing != cancelAfterNumRequests {
+		return cancelExpectation{}, false
+	}
+	intVal, err := strconv.Atoi(param)
+	if err != nil || intVal < 0 {
+		return cancelExpectation{}, false
+	}
+	expect := cancelExpectation{timing: timing, param: intVal}
+	if hasDelay {
+		delay, ok := strings.CutPrefix(delay, "delay-ms=")
+		if !ok {
+			return cancelExpectation{}, false
+		}
+		expect.delayMs, err = strconv.Atoi(delay)
+		if err != nil || expect.delayMs < 0 {
+			return cancelExpectation{}, false
+		}
+	}
+	return expect, true
+}
+
+func (e cancelExpectation) String() string {
+	switch e.timing {
+	case cancelBeforeCloseSend:
+		return "before half-closing the stream"
+	case cancelAfterCloseSend:
+		return fmt.Sprintf("%dms after half-closing the stream", e.param)
+	case cancelAfterNumResponses:
+		return fmt.Sprintf("after receiving %d response(s)", e.param)
+	default:
+		if e.delayMs > 0 {
+			return fmt.Sprintf("%dms after sending %d request(s)", e.delayMs, e.param)
+		}
+		return fmt.Sprintf("after sending %d request(s)", e.param)
+	}
+}
+
+// cancellationObserver returns a handler that observes how the request
+// stream ends for RPCs that the client is expected to cancel. A client
+// that cancels an RPC must tell the server: with HTTP/2, it resets the
+// stream with an RST_STREAM frame; with HTTP/3, it resets the QUIC stream;
+// and with HTTP/1.1, it closes the connection. Feedback is printed if the
+// client keeps the stream open or keeps sending instead. The given wait is
+// how long the client has, after it should have canceled, for the
+// cancellation to reach the server.
+func cancellationObserver(handler http.Handler, wait time.Duration, errPrinter internal.Printer) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		val := req.Header.Get("X-Expect-Cancel")
+		testCaseName := req.Header.Get(testCaseNameHeader)
+		if val == "" || testCaseName == "" {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		feedback := &feedbackPrinter{p: errPrinter, testCaseName: testCaseName}
+		expect, ok := parseCancelExpectation(val)
+		if !ok {
+			feedback.Printf("invalid value for %q header: %q", "X-Expect-Cancel", val)
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		obs := newStreamObserver(req, testCaseName, expect, wait, feedback)
+		// The request context is canceled when the client resets the
+		// stream or closes the connection. It is also canceled once the
+		// handler returns, so we stop watching it before then.
+		stop := context.AfterFunc(req.Context(), obs.reset)
+		req = req.WithContext(context.WithValue(req.Context(), cancelObserverKey{}, obs))
+		if req.Method == http.MethodGet {
+			// There is no request body, so the stream is already half-closed.
+			obs.halfClose()
+		} else {
+			req.Body = &observedBody{ReadCloser: req.Body, obs: obs}
+		}
+		handler.ServeHTTP(respWriter, req)
+		stop()
+		obs.finish(req.Context())
+	})
+}
+
+// streamObserver records when the request stream for an RPC is half-closed
+// and when it is reset, and compares that to when the client should have
+// canceled the RPC.
+type streamObserver struct {
+	testCaseName string
+	expect       cancelExpectation
+	wait         time.Duration
+	feedback     *feedbackPrinter
+	protoMajor   int
+	scriptConn   *frameScriptConn // only set for HTTP/2
+	start        time.Time
+
+	mu           sync.Mutex
+	halfClosedAt time.Time
+	resetAt      time.Time
+	// The time at which the client should have canceled the RPC. This is
+	// zero until it is known.
+	cancelAt     time.Time
+	numRequests  int
+	numResponses int
+	// Set once feedback has been printed about the stream not being reset,
+	// so that it isn't printed more than once.
+	reported    bool
+	keptSending bool
+}
+
+func newStreamObserver(req *http.Request, testCaseName string, expect cancelExpectation, wait time.Duration, feedback *feedbackPrinter) *streamObserver {
+	scriptConn, _ := req.Context().Value(frameScriptConnKey{}).(*frameScriptConn)
+	return &streamObserver{
+		testCaseName: testCaseName,
+		expect:       expect,
+		wait:         wait,
+		feedback:     feedback,
+		protoMajor:   req.ProtoMajor,
+		scriptConn:   scriptConn,
+		start:        time.Now(),
+	}
+}
+
+// read records the result of reading from the request body.
+func (o *streamObserver) read(n int, err error) {
+	switch {
+	case err == nil || errors.Is(err, os.ErrDeadlineExceeded):
+		// A read deadline is only set to interrupt reads once the
+		// RPC is done. See interruptibleReadsHandler.
+	case errors.Is(err, io.EOF):
+		o.halfClose()
+	default:
+		o.reset()
+	}
+	if n == 0 {
+		return
+	}
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	switch o.expect.timing {
+	case cancelBeforeCloseSend:
+		// The client should cancel after it sends its last message.
+		o.cancelAt = now
+	case cancelAfterNumResponses, cancelAfterNumRequests:
+		if !o.keptSending && !o.cancelAt.IsZero() && now.Sub(o.cancelAt) > o.wait {
+			o.keptSending = true
+			o.feedback.Printf("client should have canceled the RPC %v, but it was still sending request data %v later",
+				o.expect, now.Sub(o.cancelAt).Round(time.Millisecond))
+		}
+	case cancelAfterCloseSend:
+		// Nothing to do: the client can't send after half-closing.
+	}
+}
+
+// halfClose records that the client half-closed the request stream.
+func (o *streamObserver) halfClose() {
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.halfClosedAt.IsZero() || !o.resetAt.IsZero() {
+		return
+	}
+	o.halfClosedAt = now
+	if o.expect.timing == cancelAfterCloseSend {
+		o.cancelAt = now.Add(time.Duration(o.expect.param) * time.Millisecond)
+	}
+	// Otherwise, there's nothing to do. Even when the client cancels
+	// before half-closing, its transport may still half-close the stream
+	// just before resetting it.
+}
+
+// responseSent records that the server sent a response message.
+func (o *streamObserver) responseSent() {
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.numResponses++
+	if o.expect.timing == cancelAfterNumResponses && o.numResponses == o.expect.param {
+		o.cancelAt = now
+	}
+}
+
+// requestReceived records that the server received a request message.
+func (o *streamObserver) requestReceived() {
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.numRequests++
+	if o.expect.timing != cancelAfterNumRequests {
+		return
+	}
+	switch {
+	case o.numRequests == o.expect.param:
+		o.cancelAt = now.Add(time.Duration(o.expect.delayMs) * time.Millisecond)
+	case o.numRequests > o.expect.param && !o.keptSending:
+		o.keptSending = true
+		o.feedback.Printf("client should have canceled the RPC %v, but it sent request #%d", o.expect, o.numRequests)
+	}
+}
+
+// reset records that the client reset the request stream (or closed
+// the connection, for HTTP/1.1).
+func (o *streamObserver) reset() {
+	now := time.Now()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.resetAt.IsZero() {
+		return
+	}
+	o.resetAt = now
+	if o.protoMajor != 2 || o.scriptConn == nil {
+		return
+	}
+	// The frame is inspected when it is read from the connection, before
+	// the HTTP/2 server acts on it, so the code is already recorded.
+	code, ok := o.scriptConn.streamResetCode(o.testCaseName)
+	switch {
+	case !ok:
+		o.feedback.Printf("client closed the connection %v after the RPC started, instead of resetting the stream with an RST_STREAM frame",
+			now.Sub(o.start).Round(time.Millisecond))
+	case code != http2.ErrCodeCancel:
+		o.feedback.Printf("client reset the stream with error code %v; it should use %v", code, http2.ErrCodeCancel)
+	}
+}
+
+// finish is called when the handler for the RPC returns, with the request
+// context. A client that has not canceled the RPC by then is only reported
+// if it should already have canceled, and only after waiting a while for
+// the cancellation to reach the server, since the handler may finish
+// first. For example, if the client half-closed the stream just before
+// resetting it, the handler may have already received all requests and
+// sent the response. If the handler finished before the client was
+// supposed to cancel, then the client never had a chance to.
+func (o *streamObserver) finish(ctx context.Context) {
+	if ctx.Err() != nil {
+		o.reset()
+	}
+	o.mu.Lock()
+	var waitUntil time.Time
+	if now := time.Now(); o.resetAt.IsZero() && !o.reported && !o.cancelAt.IsZero() && !now.Before(o.cancelAt) {
+		waitUntil = o.cancelAt.Add(o.wait)
+		if now.Add(o.wait).After(waitUntil) {
+			// The handler finished a while after the client should have
+			// canceled. But the reset may have been delayed along with
+			// the handler, so we still wait a little for it.
+			waitUntil = now.Add(o.wait)
+		}
+	}
+	o.mu.Unlock()
+	if waitUntil.IsZero() {
+		return
+	}
+	timer := time.NewTimer(time.Until(waitUntil))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		o.reset()
+	case <-timer.C:
+		o.checkReset()
+	}
+}
+
+// checkReset is called when the client should have canceled the RPC
+// some time ago.
+func (o *streamObserver) checkReset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.resetAt.IsZero() {
+		return
+	}
+	o.reportLocked("client should have canceled the RPC %v, but there was no sign of the %s by the time the RPC finished (waited %v)",
+		o.expect, o.resetDescription(), o.wait)
+}
+
+func (o *streamObserver) reportLocked(format string, args ...any) {
+	if o.reported {
+		return
+	}
+	o.reported = true
+	o.feedback.Printf(format, args...)
+}
+
+func (o *streamObserver) resetDescription() string {
+	if o.protoMajor == 1 {
+		return "connection being closed"
+	}
+	return "stream being reset"
+}
+
+// observedBody reports the results of reads to a streamObserver.
+type observedBody struct {
+	io.ReadCloser
+	obs *streamObserver
+}
+
+func (b *observedBody) Read(data []byte) (int, error) {
+	n, err := b.ReadCloser.Read(data)
+	b.obs.read(n, err)
+	return n, err
+}
+
+// cancellationInterceptor reports the request messages received and the
+// response messages sent by streaming handlers to the streamObserver for the
+// RPC, if any.
+type cancellationInterceptor struct{}
+
+func (i cancellationInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+func (i cancellationInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i cancellationInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, stream connect.StreamingHandlerConn) error {
+		if obs, ok := ctx.Value(cancelObserverKey{}).(*streamObserver); ok {
+			stream = &observedHandlerConn{StreamingHandlerConn: stream, obs: obs}
+		}
+		return next(ctx, stream)
+	}
+}
+
+type observedHandlerConn struct {
+	connect.StreamingHandlerConn
+	obs *streamObserver
+}
+
+func (c *observedHandlerConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	c.obs.requestReceived()
+	return nil
+}
+
+func (c *observedHandlerConn) Send(msg any) error {
+	if err := c.StreamingHandlerConn.Send(msg); err != nil {
+		return err
+	}
+	if msg != nil {
+		c.obs.responseSent()
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+func TestParseCancelExpectation(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		val      string
+		expected cancelExpectation
+		ok       bool
+	}{
+		{val: "before-close-send", expected: cancelExpectation{timing: cancelBeforeCloseSend}, ok: true},
+		{val: "after-close-send-ms=5", expected: cancelExpectation{timing: cancelAfterCloseSend, param: 5}, ok: true},
+		{val: "after-num-responses=2", expected: cancelExpectation{timing: cancelAfterNumResponses, param: 2}, ok: true},
+		{val: "after-num-requests=2", expected: cancelExpectation{timing: cancelAfterNumRequests, param: 2}, ok: true},
+		{val: "after-num-requests=1;delay-ms=50", expected: cancelExpectation{timing: cancelAfterNumRequests, param: 1, delayMs: 50}, ok: true},
+		{val: "after-num-responses=1;delay-ms=50"},
+		{val: "after-num-requests=1;delay=50"},
+		{val: "before-close-send=1"},
+		{val: "after-close-send-ms"},
+		{val: "after-num-responses=-1"},
+		{val: "after-num-responses=abc"},
+		{val: "whenever"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.val, func(t *testing.T) {
+			t.Parallel()
+			expect, ok := parseCancelExpectation(testCase.val)
+			assert.Equal(t, testCase.ok, ok)
+			assert.Equal(t, testCase.expected, expect)
+		})
+	}
+}
+
+func TestStreamObserver(t *testing.T) {
+	t.Parallel()
+	const testCaseName = "test-case"
+	const wait = 100 * time.Millisecond
+	errReset := errors.New("stream reset")
+	testCases := []struct {
+		name          string
+		expect        cancelExpectation
+		protoMajor    int
+		resetCode     *http2.ErrCode
+		observe       func(*streamObserver)
+		canceled      bool
+		expectedError string
+	}{
+		{
+			name:       "after close send",
+			expect:     cancelExpectation{timing: cancelAfterCloseSend, param: 5},
+			protoMajor: 1,
+			observe: func(obs *streamObserver) {
+				obs.read(10, io.EOF)
+				obs.read(0, errReset)
+			},
+		},
+		{
+			name:       "before close send",
+			expect:     cancelExpectation{timing: cancelBeforeCloseSend},
+			protoMajor: 3,
+			observe: func(obs *streamObserver) {
+				obs.read(10, nil)
+			},
+			canceled: true,
+		},
+		{
+			name:       "after responses",
+			expect:     cancelExpectation{timing: cancelAfterNumResponses, param: 1},
+			protoMajor: 2,
+			resetCode:  ptr(http2.ErrCodeCancel),
+			observe: func(obs *streamObserver) {
+				obs.read(10, io.EOF)
+				obs.responseSent()
+				obs.reset()
+			},
+		},
+		{
+			name:       "not reset",
+			expect:     cancelExpectation{timing: cancelAfterNumResponses, param: 1},
+			protoMajor: 2,
+			observe: func(obs *streamObserver) {
+				obs.read(10, io.EOF)
+				obs.responseSent()
+			},
+			expectedError: "client should have canceled the RPC after receiving 1 response(s), but there was no sign of the stream being reset by the time the RPC finished (waited 100ms)",
+		},
+		{
+			name:       "connection not closed",
+			expect:     cancelExpectation{timing: cancelAfterCloseSend, param: 0},
+			protoMajor: 1,
+			observe: func(obs *streamObserver) {
+				obs.read(10, io.EOF)
+			},
+			expectedError: "client should have canceled the RPC 0ms after half-closing the stream, but there was no sign of the connection being closed by the time the RPC finished (waited 100ms)",
+		},
+		{
+			name:       "finished before cancel",
+			expect:     cancelExpectation{timing: cancelAfterNumResponses, param: 3},
+			protoMajor: 2,
+			observe: func(obs *streamObserver) {
+				obs.read(10, io.EOF)
+				obs.responseSent()
+				obs.responseSent()
+			},
+		},
+		{
+			name:       "kept sending",
+			expect:     cancelExpectation{timing: cancelAfterNumResponses, param: 1},
+			protoMajor: 3,
+			observe: func(obs *streamObserver) {
+				obs.responseSent()
+				obs.reset()
+				obs.mu.Lock()
+				obs.cancelAt = time.Now().Add(-2 * wait)
+				obs.mu.Unlock()
+				obs.read(10, nil)
+			},
+			expectedError: "client should have canceled the RPC after receiving 1 response(s), but it was still sending request data 200ms later",
+		},
+		{
+			// The client should have canceled a while ago, but the reset
+			// arrived before the handler finished, which is fine.
+			name:       "reset after wait but before finish",
+			expect:     cancelExpectation{timing: cancelAfterNumResponses, param: 1},
+			protoMajor: 3,
+			observe: func(obs *streamObserver) {
+				obs.responseSent()
+				obs.mu.Lock()
+				obs.cancelAt = time.Now().Add(-2 * wait)
+				obs.mu.Unlock()
+				obs.reset()
+			},
+		},
+		{
+			name:       "after requests",
+			expect:     cancelExpectation{timing: cancelAfterNumRequests, param: 2, delayMs: 10},
+			protoMajor: 3,
+			observe: func(obs *streamObserver) {
+				obs.requestReceived()
+				obs.requestReceived()
+				obs.read(0, errReset)
+			},
+		},
+		{
+			name:       "sent too many requests",
+			expect:     cancelExpectation{timing: cancelAfterNumRequests, param: 1},
+			protoMajor: 1,
+			observe: func(obs *streamObserver) {
+				obs.requestReceived()
+				obs.requestReceived()
+				obs.requestReceived()
+				obs.reset()
+			},
+			expectedError: "client should have canceled the RPC after sending 1 request(s), but it sent request #2",
+		},
+		{
+			name:       "wrong reset code",
+			expect:     cancelExpectation{timing: cancelBeforeCloseSend},
+			protoMajor: 2,
+			resetCode:  ptr(http2.ErrCodeInternal),
+			observe: func(obs *streamObserver) {
+				obs.read(10, nil)
+			},
+			canceled:      true,
+			expectedError: "client reset the stream with error code INTERNAL_ERROR; it should use CANCEL",
+		},
+		{
+			name:       "connection closed instead of reset",
+			expect:     cancelExpectation{timing: cancelBeforeCloseSend},
+			protoMajor: 2,
+			observe: func(obs *streamObserver) {
+				obs.read(10, nil)
+			},
+			canceled:      true,
+			expectedError: "instead of resetting the stream with an RST_STREAM frame",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if testCase.protoMajor == 2 {
+				scriptConn := newFrameScriptConn(nil, true)
+				scriptConn.streamsByTestCase[testCaseName] = 1
+				if testCase.resetCode != nil {
+					scriptConn.resetCodes[1] = *testCase.resetCode
+				}
+				ctx = context.WithValue(ctx, frameScriptConnKey{}, scriptConn)
+			}
+			req := (&http.Request{ProtoMajor: testCase.protoMajor}).WithContext(ctx)
+			printer := &internal.SimplePrinter{}
+			feedback := &feedbackPrinter{p: printer, testCaseName: testCaseName}
+			obs := newStreamObserver(req, testCaseName, testCase.expect, wait, feedback)
+			testCase.observe(obs)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			if testCase.canceled {
+				cancel()
+			}
+			obs.finish(ctx)
+			if testCase.expectedError == "" {
+				assert.Empty(t, printer.Messages)
+				return
+			}
+			require.Len(t, printer.Messages, 1)
+			assert.Contains(t, printer.Messages[0], testCase.expectedError)
+		})
+	}
+}
+
+func ptr[T any](val T) *T {
+	return &val
+}
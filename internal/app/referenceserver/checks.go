@@ -24,7 +24,6 @@ import (
 	"slices"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"connectrpc.com/conformance/internal"
@@ -39,9 +38,12 @@ const (
 	grpcContentTypePrefix          = grpcContentType + "+"
 	grpcWebContentType             = "application/grpc-web"
 	grpcWebContentTypePrefix       = grpcWebContentType + "+"
+	grpcWebTextContentType         = "application/grpc-web-text"
+	grpcWebTextContentTypePrefix   = grpcWebTextContentType + "+"
 	connectUnaryContentTypePrefix  = "application/"
 	connectStreamContentTypePrefix = "application/connect+"
 	connectContentTypePrefix       = connectUnaryContentTypePrefix
+	restContentType                = "application/json"
 
 	connectTimeoutHeader = "Connect-Timeout-Ms"
 	grpcTimeoutHeader    = "Grpc-Timeout"
@@ -84,9 +86,8 @@ type timeoutContextKey struct{}
 // TODO - We should add a check for the Connect version header and/or query param to the reference server checks
 // to verify that conformant client implementations always include it (to maximize inter-op, just in case a server is
 // configured to require it).
-func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) http.HandlerFunc {
-	var callsMu sync.Mutex
-	calls := map[string]int{}
+func referenceServerChecks(handler http.Handler, conns *connectionTracker, errPrinter internal.Printer) http.HandlerFunc {
+	attempts := newAttemptTracker()
 	return func(respWriter http.ResponseWriter, req *http.Request) {
 		testCaseName, ok := getTestCaseName(respWriter, req)
 		if !ok {
@@ -96,17 +97,18 @@ func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) ht
 		}
 		feedback := &feedbackPrinter{p: errPrinter, testCaseName: testCaseName}
 
-		callsMu.Lock()
-		count := calls[testCaseName]
-		calls[testCaseName] = count + 1
-		callsMu.Unlock()
-		if count > 0 {
-			feedback.Printf("client sent another request (#%d) for the same test case", count+1)
-		}
+		attempt, prev := attempts.start(testCaseName)
+		defer attempts.finish(testCaseName)
+		checkAttempt(attempt, prev, req, feedback)
+		conns.checkConnectionReuse(testCaseName, req, feedback)
+		// We record the attempt number in a context value, so the handler can
+		// pick the response for the attempt when they are defined per attempt.
+		req = req.WithContext(contextWithAttempt(req.Context(), attempt))
 
 		if httpVersion, ok := enumValue("X-Expect-Http-Version", req.Header, conformancev1.HTTPVersion(0), feedback); ok {
 			checkHTTPVersion(httpVersion, req, feedback)
 		}
+		var deadline *deadlineObserver
 		if protocol, ok := enumValue("X-Expect-Protocol", req.Header, conformancev1.Protocol(0), feedback); ok {
 			checkProtocol(protocol, req, feedback)
 			if protocol == conformancev1.Protocol_PROTOCOL_CONNECT {
@@ -118,6 +120,7 @@ func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) ht
 				// We record the timeout in a context value, so that we can correctly include it in the
 				// RPC response's request info.
 				req = req.WithContext(contextWithTimeout(req.Context(), timeout))
+				deadline = newDeadlineObserver(req.Context())
 			}
 		}
 		if codec, ok := enumValue("X-Expect-Codec", req.Header, conformancev1.Codec(0), feedback); ok {
@@ -126,6 +129,11 @@ func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) ht
 		if compress, ok := enumValue("X-Expect-Compression", req.Header, conformancev1.Compression(0), feedback); ok {
 			checkCompression(compress, req, feedback)
 		}
+		if expectVals := req.Header.Values("X-Expect-Accept-Compression"); len(expectVals) > 0 {
+			// This header is only present when the client should accept
+			// a different compression than it uses for the request.
+			checkAcceptCompression(expectVals, req, feedback)
+		}
 
 		checkTLS(req, feedback)
 
@@ -135,6 +143,12 @@ func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) ht
 
 		handler.ServeHTTP(respWriter, req)
 
+		if deadline != nil {
+			// Report how long the client took to give up on the RPC, so
+			// the test runner can check it against the timeout.
+			errPrinter.Printf("%s%d %d %s", internal.DeadlineElapsedPrefix, deadline.finish().Milliseconds(), attempt, testCaseName)
+		}
+
 		// Make sure request body is drained so we can look for any trailers.
 		// This is just best effort since the operation could have already been canceled.
 		_, _ = io.Copy(io.Discard, req.Body)
@@ -178,10 +192,14 @@ func checkHTTPVersion(expected conformancev1.HTTPVersion, req *http.Request, fee
 
 func checkProtocol(expected conformancev1.Protocol, req *http.Request, feedback *feedbackPrinter) {
 	var actual conformancev1.Protocol
-	contentType := req.Header.Get("Content-Type")
+	contentType := requestContentType(req)
 	switch {
+	case isTranscodingRequest(req):
+		actual = conformancev1.Protocol_PROTOCOL_REST_TRANSCODING
 	case contentType == grpcContentType || strings.HasPrefix(contentType, grpcContentTypePrefix):
 		actual = conformancev1.Protocol_PROTOCOL_GRPC
+	case contentType == grpcWebTextContentType || strings.HasPrefix(contentType, grpcWebTextContentTypePrefix):
+		actual = conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT
 	case contentType == grpcWebContentType || strings.HasPrefix(contentType, grpcWebContentTypePrefix):
 		actual = conformancev1.Protocol_PROTOCOL_GRPC_WEB
 	case strings.HasPrefix(contentType, connectContentTypePrefix) || req.Method == http.MethodGet:
@@ -209,8 +227,18 @@ func checkCodec(expected conformancev1.Codec, req *http.Request, feedback *feedb
 		return
 	}
 	contentType, hasContentType := getHeader(req.Header, "Content-Type", feedback)
+	if hasContentType {
+		contentType = requestContentType(req)
+	}
 	var actual string
 	switch {
+	case isTranscodingRequest(req):
+		// REST-style requests always use JSON, and only have
+		// a Content-Type header if they have a body.
+		if mediaType, _, _ := strings.Cut(contentType, ";"); hasContentType && strings.TrimSpace(mediaType) != restContentType {
+			feedback.Printf("expected content-type %q; instead got %q", restContentType, contentType)
+		}
+		actual = codecJSON
 	case req.Method == http.MethodGet:
 		// GET requests should not have a Content-Type header
 		if hasContentType {
@@ -228,12 +256,14 @@ func checkCodec(expected conformancev1.Codec, req *http.Request, feedback *feedb
 			feedback.Printf("encoding query parameter is missing")
 			return
 		}
-	case contentType == "application/grpc" || contentType == "application/grpc-web":
+	case contentType == "application/grpc" || contentType == "application/grpc-web" || contentType == "application/grpc-web-text":
 		actual = codecProto // these protocols default to proto if they have no "+codec" suffix
 	case strings.HasPrefix(contentType, "application/grpc+"):
 		actual = strings.TrimPrefix(contentType, "application/grpc+")
 	case strings.HasPrefix(contentType, "application/grpc-web+"):
 		actual = strings.TrimPrefix(contentType, "application/grpc-web+")
+	case strings.HasPrefix(contentType, "application/grpc-web-text+"):
+		actual = strings.TrimPrefix(contentType, "application/grpc-web-text+")
 	case strings.HasPrefix(contentType, "application/connect+"):
 		actual = strings.TrimPrefix(contentType, "application/connect+")
 	case strings.HasPrefix(contentType, "application/"):
@@ -248,41 +278,21 @@ func checkCodec(expected conformancev1.Codec, req *http.Request, feedback *feedb
 }
 
 func checkCompression(expected conformancev1.Compression, req *http.Request, feedback *feedbackPrinter) {
-	var expect string
-	switch expected {
-	case conformancev1.Compression_COMPRESSION_IDENTITY:
-		expect = compression.Identity
-	case conformancev1.Compression_COMPRESSION_GZIP:
-		expect = compression.Gzip
-	case conformancev1.Compression_COMPRESSION_BR:
-		expect = compression.Brotli
-	case conformancev1.Compression_COMPRESSION_ZSTD:
-		expect = compression.Zstd
-	case conformancev1.Compression_COMPRESSION_DEFLATE:
-		expect = compression.Deflate
-	case conformancev1.Compression_COMPRESSION_SNAPPY:
-		expect = compression.Snappy
-	default:
+	expect, err := compression.Name(expected)
+	if err != nil {
 		feedback.Printf("invalid expected compression %d", expected)
 		return
 	}
 	var actual string
 	var hasActual bool
-	if req.Method == http.MethodGet {
+	switch {
+	case isTranscodingRequest(req):
+		actual, hasActual = getHeader(req.Header, "Content-Encoding", feedback)
+	case req.Method == http.MethodGet:
 		actual, hasActual = getQueryParam(req.URL.Query(), "compression", feedback)
-	} else {
-		contentType := req.Header.Get("Content-Type")
-		var encodingHeader string
-		switch {
-		case contentType == grpcContentType || contentType == grpcWebContentType ||
-			strings.HasPrefix(contentType, grpcContentTypePrefix) ||
-			strings.HasPrefix(contentType, grpcWebContentTypePrefix):
-			encodingHeader = "Grpc-Encoding"
-		case strings.HasPrefix(contentType, connectStreamContentTypePrefix):
-			encodingHeader = "Connect-Content-Encoding"
-		case strings.HasPrefix(contentType, connectUnaryContentTypePrefix):
-			encodingHeader = "Content-Encoding"
-		default:
+	default:
+		encodingHeader, _ := compressionHeaders(requestContentType(req))
+		if encodingHeader == "" {
 			// We already complained about bad content-type when checking protocol.
 			return
 		}
@@ -298,6 +308,70 @@ func checkCompression(expected conformancev1.Compression, req *http.Request, fee
 	}
 }
 
+// checkAcceptCompression verifies that the client advertised that it
+// accepts all the expected compression algorithms for the response. The
+// expected values are the numeric values of conformancev1.Compression.
+func checkAcceptCompression(expectVals []string, req *http.Request, feedback *feedbackPrinter) {
+	var acceptHeader string
+	if req.Method == http.MethodGet {
+		acceptHeader = "Accept-Encoding"
+	} else {
+		_, acceptHeader = compressionHeaders(requestContentType(req))
+		if acceptHeader == "" {
+			// We already complained about bad content-type when checking protocol.
+			return
+		}
+	}
+	accepted := parseAcceptCompression(req.Header.Values(acceptHeader))
+	for _, expectVal := range expectVals {
+		intVal, err := strconv.ParseInt(expectVal, 10, 32)
+		if err != nil {
+			feedback.Printf("invalid value for %q header: %q: %v", "X-Expect-Accept-Compression", expectVal, err)
+			continue
+		}
+		expect, err := compression.Name(conformancev1.Compression(intVal))
+		if err != nil {
+			feedback.Printf("invalid expected accept compression %d", intVal)
+			continue
+		}
+		if expect != compression.Identity && !slices.Contains(accepted, expect) {
+			feedback.Printf("expected %s header to include %v; instead got %q", acceptHeader, expect, strings.Join(accepted, ", "))
+		}
+	}
+}
+
+// compressionHeaders returns the names of the headers that indicate the
+// compression of the request and the accepted compressions for the response,
+// based on the given content-type. It returns empty strings if the
+// content-type is not for a known protocol.
+func compressionHeaders(contentType string) (encodingHeader, acceptHeader string) {
+	switch {
+	case contentType == grpcContentType || contentType == grpcWebContentType || contentType == grpcWebTextContentType ||
+		strings.HasPrefix(contentType, grpcContentTypePrefix) ||
+		strings.HasPrefix(contentType, grpcWebContentTypePrefix) ||
+		strings.HasPrefix(contentType, grpcWebTextContentTypePrefix):
+		return "Grpc-Encoding", "Grpc-Accept-Encoding"
+	case strings.HasPrefix(contentType, connectStreamContentTypePrefix):
+		return "Connect-Content-Encoding", "Connect-Accept-Encoding"
+	case strings.HasPrefix(contentType, connectUnaryContentTypePrefix):
+		return "Content-Encoding", "Accept-Encoding"
+	default:
+		return "", ""
+	}
+}
+
+// parseAcceptCompression returns the compression names in the given values
+// of an accept-encoding header, in order.
+func parseAcceptCompression(headerVals []string) []string {
+	var names []string
+	for _, val := range headerVals {
+		names = append(names, strings.FieldsFunc(val, func(r rune) bool {
+			return r == ',' || r == ' '
+		})...)
+	}
+	return names
+}
+
 // checkConnectGetQueryParamOrder verifies that a Connect Unary-Get request
 // orders its Connect-defined query parameters per the protocol recommendation.
 // Unknown parameters are ignored for ordering purposes.
@@ -408,7 +482,7 @@ func extractTimeout(headers http.Header, protocol conformancev1.Protocol, feedba
 			timeout = time.Duration(math.MaxInt64)
 		}
 		return timeout, true
-	case conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_GRPC_WEB:
+	case conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_GRPC_WEB, conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT:
 		val, ok := getHeader(headers, grpcTimeoutHeader, feedback)
 		if !ok {
 			break
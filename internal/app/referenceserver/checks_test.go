@@ -108,3 +108,101 @@ func TestCheckConnectGetQueryParamOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckAcceptCompression(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		method        string
+		headers       http.Header
+		expect        []string
+		expectedError string
+	}{
+		{
+			name:   "gRPC accepts expected",
+			method: http.MethodPost,
+			headers: http.Header{
+				"Content-Type":         []string{"application/grpc+proto"},
+				"Grpc-Accept-Encoding": []string{"zstd, gzip"},
+			},
+			expect: []string{"4"}, // COMPRESSION_ZSTD
+		},
+		{
+			name:   "gRPC missing expected",
+			method: http.MethodPost,
+			headers: http.Header{
+				"Content-Type":         []string{"application/grpc"},
+				"Grpc-Accept-Encoding": []string{"gzip"},
+			},
+			expect:        []string{"4"},
+			expectedError: `expected Grpc-Accept-Encoding header to include zstd; instead got "gzip"`,
+		},
+		{
+			name:   "Connect stream uses connect header",
+			method: http.MethodPost,
+			headers: http.Header{
+				"Content-Type":            []string{"application/connect+proto"},
+				"Accept-Encoding":         []string{"br"},
+				"Connect-Accept-Encoding": []string{"gzip"},
+			},
+			expect:        []string{"3"}, // COMPRESSION_BR
+			expectedError: `expected Connect-Accept-Encoding header to include br; instead got "gzip"`,
+		},
+		{
+			name:   "Connect unary accepts multiple header values",
+			method: http.MethodPost,
+			headers: http.Header{
+				"Content-Type":    []string{"application/proto"},
+				"Accept-Encoding": []string{"gzip", "br"},
+			},
+			expect: []string{"3"},
+		},
+		{
+			name:   "Connect GET",
+			method: http.MethodGet,
+			headers: http.Header{
+				"Accept-Encoding": []string{"deflate"},
+			},
+			expect: []string{"5"}, // COMPRESSION_DEFLATE
+		},
+		{
+			name:   "identity need not be advertised",
+			method: http.MethodPost,
+			headers: http.Header{
+				"Content-Type": []string{"application/grpc"},
+			},
+			expect: []string{"1"}, // COMPRESSION_IDENTITY
+		},
+		{
+			name:   "invalid expectation",
+			method: http.MethodPost,
+			headers: http.Header{
+				"Content-Type": []string{"application/grpc"},
+			},
+			expect:        []string{"zstd"},
+			expectedError: `invalid value for "X-Expect-Accept-Compression" header`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			printer := &internal.SimplePrinter{}
+			feedback := &feedbackPrinter{p: printer, testCaseName: testCase.name}
+			req := &http.Request{
+				Method: testCase.method,
+				URL:    &url.URL{},
+				Header: testCase.headers,
+			}
+			checkAcceptCompression(testCase.expect, req, feedback)
+			if testCase.expectedError == "" {
+				assert.Empty(t, printer.Messages)
+				return
+			}
+			if assert.Len(t, printer.Messages, 1) {
+				assert.Contains(t, printer.Messages[0], testCase.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/quic-go/quic-go"
+)
+
+// connectionIDKey is used to store the ID of the connection, on which a
+// request arrived, in the request context.
+type connectionIDKey struct{}
+
+// connectionTracker assigns an ID to each connection that the server accepts.
+// It also records the connections used by the RPCs for each test case, so it
+// can verify that clients reuse connections.
+type connectionTracker struct {
+	count atomic.Uint64
+
+	mu              sync.Mutex
+	connsByTestCase map[string][]uint64
+}
+
+func newConnectionTracker() *connectionTracker {
+	return &connectionTracker{connsByTestCase: map[string][]uint64{}}
+}
+
+// connContext returns a context for a newly accepted connection. It is
+// used as the ConnContext of an HTTP/1.1 or HTTP/2 server.
+func (t *connectionTracker) connContext(ctx context.Context, _ net.Conn) context.Context {
+	return context.WithValue(ctx, connectionIDKey{}, t.count.Add(1))
+}
+
+// quicConnContext is like connContext, but for an HTTP/3 server.
+func (t *connectionTracker) quicConnContext(ctx context.Context, conn *quic.Conn) context.Context {
+	return t.connContext(http3ConnContext(ctx, conn), nil)
+}
+
+// numConnections returns the number of connections accepted so far.
+func (t *connectionTracker) numConnections() uint64 {
+	return t.count.Load()
+}
+
+// checkConnectionReuse records the connection used by the given request. If
+// the client is expected to issue multiple RPCs in sequence for the test case,
+// then this verifies, once the last one arrives, that they all used the same
+// connection.
+func (t *connectionTracker) checkConnectionReuse(testCaseName string, req *http.Request, feedback *feedbackPrinter) {
+	numRPCs := sequentialRPCCount(req.Header, feedback)
+	if numRPCs <= 1 {
+		return
+	}
+	connID, ok := req.Context().Value(connectionIDKey{}).(uint64)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	conns := append(t.connsByTestCase[testCaseName], connID)
+	if len(conns) >= numRPCs {
+		// This is the last RPC for the test case, so we no longer
+		// need to keep track of its connections.
+		delete(t.connsByTestCase, testCaseName)
+	} else {
+		t.connsByTestCase[testCaseName] = conns
+	}
+	t.mu.Unlock()
+
+	// HTTP/1.1 cannot multiplex RPCs over one connection, so clients may
+	// reasonably use more than one. So we only check HTTP/2 and HTTP/3.
+	if len(conns) != numRPCs || req.ProtoMajor < 2 {
+		return
+	}
+	slices.Sort(conns)
+	if numConns := len(slices.Compact(conns)); numConns > 1 {
+		feedback.Printf("client used %d connections for %d sequential RPCs; they should all use the same connection", numConns, numRPCs)
+	}
+}
+
+// sequentialRPCCount returns the number of RPCs that the client is expected
+// to issue in sequence for a test case, as indicated by the given headers.
+func sequentialRPCCount(headers http.Header, feedback *feedbackPrinter) int {
+	val := headers.Get("X-Expect-Sequential-Rpcs")
+	if val == "" {
+		return 1
+	}
+	intVal, err := strconv.Atoi(val)
+	if err != nil || intVal < 1 {
+		feedback.Printf("invalid value for %q header: %q", "X-Expect-Sequential-Rpcs", val)
+		return 1
+	}
+	return intVal
+}
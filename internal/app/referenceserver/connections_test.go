@@ -0,0 +1,90 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionTracker_CheckConnectionReuse(t *testing.T) {
+	t.Parallel()
+
+	tracker := newConnectionTracker()
+	conns := []context.Context{
+		tracker.connContext(context.Background(), nil),
+		tracker.connContext(context.Background(), nil),
+	}
+	assert.Equal(t, uint64(2), tracker.numConnections())
+
+	testCases := []struct {
+		name          string
+		protoMajor    int
+		connIndexes   []int
+		expectedError string
+	}{
+		{
+			name:        "same connection",
+			protoMajor:  2,
+			connIndexes: []int{0, 0, 0},
+		},
+		{
+			name:          "different connections",
+			protoMajor:    2,
+			connIndexes:   []int{0, 1, 0},
+			expectedError: "client used 2 connections for 3 sequential RPCs",
+		},
+		{
+			name:          "different connections with HTTP/3",
+			protoMajor:    3,
+			connIndexes:   []int{1, 1, 0},
+			expectedError: "client used 2 connections for 3 sequential RPCs",
+		},
+		{
+			name:        "different connections with HTTP/1.1",
+			protoMajor:  1,
+			connIndexes: []int{0, 1, 0},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			printer := &internal.SimplePrinter{}
+			feedback := &feedbackPrinter{p: printer, testCaseName: testCase.name}
+			for _, connIndex := range testCase.connIndexes {
+				req := (&http.Request{
+					ProtoMajor: testCase.protoMajor,
+					Header:     http.Header{"X-Expect-Sequential-Rpcs": []string{"3"}},
+				}).WithContext(conns[connIndex])
+				tracker.checkConnectionReuse(testCase.name, req, feedback)
+			}
+			tracker.mu.Lock()
+			_, tracked := tracker.connsByTestCase[testCase.name]
+			tracker.mu.Unlock()
+			assert.False(t, tracked, "connections should no longer be tracked after last RPC")
+			if testCase.expectedError == "" {
+				assert.Empty(t, printer.Messages)
+				return
+			}
+			if assert.Len(t, printer.Messages, 1) {
+				assert.Contains(t, printer.Messages[0], testCase.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"time"
+)
+
+// deadlineObserver measures how long it takes for an RPC with a timeout to
+// be terminated. Since the reference server does not enforce timeouts, this
+// is when the client gives up on the RPC, which cancels the request context.
+// If the handler returns first, it is when the handler returns.
+type deadlineObserver struct {
+	start      time.Time
+	stop       func() bool
+	canceled   chan struct{}
+	canceledAt time.Time
+}
+
+func newDeadlineObserver(ctx context.Context) *deadlineObserver {
+	obs := &deadlineObserver{start: time.Now(), canceled: make(chan struct{})}
+	obs.stop = context.AfterFunc(ctx, func() {
+		obs.canceledAt = time.Now()
+		close(obs.canceled)
+	})
+	return obs
+}
+
+// finish is called, once, when the handler returns. It returns the time
+// between the start of the RPC and its termination.
+func (o *deadlineObserver) finish() time.Duration {
+	if o.stop() {
+		return time.Since(o.start)
+	}
+	<-o.canceled
+	return o.canceledAt.Sub(o.start)
+}
@@ -0,0 +1,370 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/connect"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"golang.org/x/net/http2"
+)
+
+const (
+	// drainGracePeriod is how long a client may continue to start RPCs on a
+	// connection after the server drains it. The client may have started
+	// them before it received the GOAWAY frame.
+	drainGracePeriod = time.Second
+	// http3IdleCloseDelay is how long an HTTP/3 connection that is being
+	// drained must be idle before it is closed. This gives the last response
+	// on the connection time to reach the client.
+	http3IdleCloseDelay = 200 * time.Millisecond
+)
+
+// goAwayFrame is the frame that gracefully drains an HTTP/2 connection. Since
+// it indicates the maximum stream ID, streams already started by the client
+// can complete, but the client must not start any more.
+var goAwayFrame = func() []byte {
+	var buf bytes.Buffer
+	_ = http2.NewFramer(&buf, nil).WriteGoAway(math.MaxInt32, http2.ErrCodeNo, nil)
+	return buf.Bytes()
+}()
+
+// connectionDrainer is an interceptor that gracefully drains the connection on
+// which a request arrived, if the request's response definition says to. It
+// also prints feedback if the client starts an RPC on an HTTP/2 connection
+// that it was already told is draining.
+type connectionDrainer struct {
+	errPrinter internal.Printer
+}
+
+func (d connectionDrainer) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		testCaseName := req.Header().Get(testCaseNameHeader)
+		d.checkConnection(ctx, testCaseName)
+		msg, ok := req.Any().(interface {
+			GetResponseDefinition() *conformancev1.UnaryResponseDefinition
+		})
+		if ok {
+			drain := responseDefinitionForAttempt(ctx, msg).GetConnectionDrain()
+			if err := checkUnaryDrain(drain); err != nil {
+				return nil, err
+			}
+			startDrain(ctx, testCaseName, drain, 0)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (d connectionDrainer) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (d connectionDrainer) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, stream connect.StreamingHandlerConn) error {
+		testCaseName := stream.RequestHeader().Get(testCaseNameHeader)
+		d.checkConnection(ctx, testCaseName)
+		return next(ctx, &drainingHandlerConn{
+			StreamingHandlerConn: stream,
+			ctx:                  ctx,
+			testCaseName:         testCaseName,
+		})
+	}
+}
+
+func (d connectionDrainer) checkConnection(ctx context.Context, testCaseName string) {
+	conn, ok := ctx.Value(frameScriptConnKey{}).(*frameScriptConn)
+	if !ok || testCaseName == "" {
+		return
+	}
+	if elapsed, drainedFor, late := conn.startedAfterDrain(testCaseName); late {
+		feedback := &feedbackPrinter{p: d.errPrinter, testCaseName: testCaseName}
+		feedback.Printf("RPC was started on a connection %v after the server drained it (for test case %q), but it should have been started on a new connection",
+			elapsed.Round(time.Millisecond), drainedFor)
+	}
+}
+
+// drainingHandlerConn drains the connection once the number of responses
+// indicated by the response definition in the first request have been sent.
+type drainingHandlerConn struct {
+	connect.StreamingHandlerConn
+	ctx          context.Context //nolint:containedctx
+	testCaseName string
+	received     bool
+	drain        *conformancev1.ConnectionDrain
+	sent         uint32
+}
+
+func (c *drainingHandlerConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	if c.received {
+		return nil
+	}
+	c.received = true
+	switch msg := msg.(type) {
+	case interface {
+		GetResponseDefinition() *conformancev1.UnaryResponseDefinition
+	}:
+		c.drain = msg.GetResponseDefinition().GetConnectionDrain()
+		if err := checkUnaryDrain(c.drain); err != nil {
+			return err
+		}
+	case interface {
+		GetResponseDefinition() *conformancev1.StreamResponseDefinition
+	}:
+		c.drain = msg.GetResponseDefinition().GetConnectionDrain()
+	}
+	if startDrain(c.ctx, c.testCaseName, c.drain, c.sent) {
+		c.drain = nil
+	}
+	return nil
+}
+
+func (c *drainingHandlerConn) Send(msg any) error {
+	if err := c.StreamingHandlerConn.Send(msg); err != nil {
+		return err
+	}
+	if msg == nil {
+		// This only sends headers.
+		return nil
+	}
+	c.sent++
+	if startDrain(c.ctx, c.testCaseName, c.drain, c.sent) {
+		c.drain = nil
+	}
+	return nil
+}
+
+func checkUnaryDrain(drain *conformancev1.ConnectionDrain) error {
+	if drain.GetAfterNumResponses() > 0 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf(
+			"connection drain for a single response cannot be after %d responses", drain.GetAfterNumResponses()))
+	}
+	return nil
+}
+
+// startDrain drains the connection for the given context if the given drain
+// timing indicates that it should happen now or after the RPC completes. It
+// returns true if it did so.
+func startDrain(ctx context.Context, testCaseName string, drain *conformancev1.ConnectionDrain, numSent uint32) bool {
+	switch timing := drain.GetTiming().(type) {
+	case *conformancev1.ConnectionDrain_AfterNumResponses:
+		if numSent != timing.AfterNumResponses {
+			return false
+		}
+		drainConnection(ctx, testCaseName, false)
+		return true
+	case *conformancev1.ConnectionDrain_AfterCompletion:
+		drainConnection(ctx, testCaseName, true)
+		return true
+	default:
+		return false
+	}
+}
+
+// drainConnection gracefully drains the connection on which the request for
+// the given context arrived. For HTTP/2, a GOAWAY frame is sent, either now or
+// after the stream for the given test case completes. For HTTP/3, the
+// connection is closed once it is idle.
+func drainConnection(ctx context.Context, testCaseName string, afterCompletion bool) {
+	if conn, ok := ctx.Value(frameScriptConnKey{}).(*frameScriptConn); ok {
+		if afterCompletion {
+			conn.drainAfterStream(testCaseName)
+		} else {
+			// If this fails, the connection is broken, which
+			// the client will notice without our help.
+			_ = conn.drain(testCaseName)
+		}
+		return
+	}
+	if conn, ok := ctx.Value(http3ConnKey{}).(*http3Conn); ok {
+		conn.closeWhenIdle()
+	}
+}
+
+// drain sends a GOAWAY frame, unless one was already sent.
+func (c *frameScriptConn) drain(testCaseName string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	frame := c.goAway(testCaseName)
+	if frame == nil {
+		return nil
+	}
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+// drainAfterStream arranges for a GOAWAY frame to be sent after the server
+// ends the stream for the given test case.
+func (c *frameScriptConn) drainAfterStream(testCaseName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if streamID, ok := c.streamsByTestCase[testCaseName]; ok {
+		c.drainAfterStreams[streamID] = testCaseName
+	}
+}
+
+// drainAfterFrame returns a GOAWAY frame to write after the given frame from
+// the server, if the frame ends a stream after which the connection should be
+// drained. Otherwise, it returns nil. The caller must hold writeMu.
+func (c *frameScriptConn) drainAfterFrame(frame []byte) []byte {
+	frameType, flags, streamID := parseFrameHeader(frame)
+	switch frameType { //nolint:exhaustive
+	case http2.FrameData:
+		if !flags.Has(http2.FlagDataEndStream) {
+			return nil
+		}
+	case http2.FrameHeaders:
+		// If the header block continues in a CONTINUATION frame, we
+		// can't interrupt it. So the GOAWAY frame is sent after the
+		// next stream ends instead.
+		if !flags.Has(http2.FlagHeadersEndStream) || !flags.Has(http2.FlagHeadersEndHeaders) {
+			return nil
+		}
+	case http2.FrameRSTStream:
+	default:
+		return nil
+	}
+	c.mu.Lock()
+	testCaseName, ok := c.drainAfterStreams[streamID]
+	delete(c.drainAfterStreams, streamID)
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return c.goAway(testCaseName)
+}
+
+// goAway records that the connection was drained for the given test case
+// and returns the GOAWAY frame to write. If it was already drained, this
+// returns nil. The caller must hold writeMu.
+func (c *frameScriptConn) goAway(testCaseName string) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.drainedAt.IsZero() {
+		return nil
+	}
+	c.drainedAt = time.Now()
+	c.drainedFor = testCaseName
+	return goAwayFrame
+}
+
+// noteStreamForDrain records if the stream for the given test case was started
+// too long after the connection was drained. The caller must hold mu.
+func (c *frameScriptConn) noteStreamForDrain(testCaseName string) {
+	if c.drainedAt.IsZero() {
+		return
+	}
+	if elapsed := time.Since(c.drainedAt); elapsed > drainGracePeriod {
+		c.lateStreams[testCaseName] = elapsed
+	}
+}
+
+// startedAfterDrain returns how long after the connection was drained that
+// the stream for the given test case started, along with the name of the test
+// case for which it was drained. If the stream did not start too long after
+// the connection was drained, this returns false.
+func (c *frameScriptConn) startedAfterDrain(testCaseName string) (time.Duration, string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elapsed, ok := c.lateStreams[testCaseName]
+	delete(c.lateStreams, testCaseName)
+	return elapsed, c.drainedFor, ok
+}
+
+// http3ConnKey is used to store the *http3Conn, on which a request arrived,
+// in the request context.
+type http3ConnKey struct{}
+
+// http3Conn tracks the requests in progress on an HTTP/3 connection, so that
+// it can be closed once it is idle.
+type http3Conn struct {
+	conn *quic.Conn
+
+	mu         sync.Mutex
+	active     int
+	draining   bool
+	closeTimer *time.Timer
+}
+
+// http3ConnContext returns a context for the given HTTP/3 connection. It is
+// used as the ConnContext of an HTTP/3 server.
+func http3ConnContext(ctx context.Context, conn *quic.Conn) context.Context {
+	return context.WithValue(ctx, http3ConnKey{}, &http3Conn{conn: conn})
+}
+
+// http3ConnTrackingHandler returns a handler that tracks the requests that are
+// in progress on each HTTP/3 connection.
+func http3ConnTrackingHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		conn, ok := req.Context().Value(http3ConnKey{}).(*http3Conn)
+		if ok {
+			conn.begin()
+			defer conn.end()
+		}
+		handler.ServeHTTP(respWriter, req)
+	})
+}
+
+func (c *http3Conn) begin() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active++
+	if c.closeTimer != nil {
+		c.closeTimer.Stop()
+		c.closeTimer = nil
+	}
+}
+
+func (c *http3Conn) end() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active--
+	c.scheduleCloseLocked()
+}
+
+// closeWhenIdle closes the connection once there are no requests in progress.
+func (c *http3Conn) closeWhenIdle() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draining = true
+	c.scheduleCloseLocked()
+}
+
+func (c *http3Conn) scheduleCloseLocked() {
+	if !c.draining || c.active > 0 || c.closeTimer != nil {
+		return
+	}
+	c.closeTimer = time.AfterFunc(http3IdleCloseDelay, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.active > 0 {
+			// A request started before the timer could be stopped.
+			return
+		}
+		_ = c.conn.CloseWithError(quic.ApplicationErrorCode(http3.ErrCodeNoError), "")
+	})
+}
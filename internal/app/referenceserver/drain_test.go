@@ -0,0 +1,76 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+func TestFrameScriptConn_Drain(t *testing.T) {
+	t.Parallel()
+
+	conn := newFrameScriptConn(&fakeConn{}, true)
+	conn.streamsByTestCase["foo"] = 3
+	conn.drainAfterStream("foo")
+
+	// The GOAWAY frame is sent after the stream ends.
+	var fromServer bytes.Buffer
+	serverFramer := http2.NewFramer(&fromServer, nil)
+	require.NoError(t, serverFramer.WriteData(3, false, []byte("abc")))
+	require.NoError(t, serverFramer.WriteData(5, true, []byte("def")))
+	require.NoError(t, serverFramer.WriteHeaders(http2.HeadersFrameParam{StreamID: 3, BlockFragment: []byte{0x88}, EndHeaders: true, EndStream: true}))
+	require.NoError(t, serverFramer.WriteData(7, false, []byte("ghi")))
+	_, err := conn.Write(fromServer.Bytes())
+	require.NoError(t, err)
+	// Once drained, it is not drained again.
+	require.NoError(t, conn.drain("bar"))
+
+	fake, ok := conn.Conn.(*fakeConn)
+	require.True(t, ok)
+	framer := http2.NewFramer(nil, &fake.written)
+	var frameTypes []http2.FrameType
+	for {
+		frame, err := framer.ReadFrame()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		frameTypes = append(frameTypes, frame.Header().Type)
+		if goAway, ok := frame.(*http2.GoAwayFrame); ok {
+			assert.Equal(t, uint32(math.MaxInt32), goAway.LastStreamID)
+			assert.Equal(t, http2.ErrCodeNo, goAway.ErrCode)
+		}
+	}
+	assert.Equal(t, []http2.FrameType{http2.FrameData, http2.FrameData, http2.FrameHeaders, http2.FrameGoAway, http2.FrameData}, frameTypes)
+
+	// Streams that start long after the connection was drained are noted.
+	_, _, late := conn.startedAfterDrain("baz")
+	assert.False(t, late)
+	conn.drainedAt = time.Now().Add(-2 * drainGracePeriod)
+	conn.noteStreamForDrain("baz")
+	elapsed, drainedFor, late := conn.startedAfterDrain("baz")
+	assert.True(t, late)
+	assert.Greater(t, elapsed, drainGracePeriod)
+	assert.Equal(t, "foo", drainedFor)
+}
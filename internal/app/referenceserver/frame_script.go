@@ -0,0 +1,422 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	http2FrameHeaderLen = 9
+	// This is the default size of the HPACK dynamic table, which the
+	// HTTP/2 server does not change.
+	http2HeaderTableSize = 4096
+	// This is the key that net/http uses in TLSNextProto for HTTP/2
+	// connections that do not use TLS.
+	nextProtoUnencryptedHTTP2 = "unencrypted_http2"
+)
+
+// testCaseNameHeader is the request header that identifies the test case.
+const testCaseNameHeader = "X-Test-Case-Name"
+
+var errFrameScriptRequiresHTTP2 = errors.New("reference server needs to use frame script but request did not use HTTP/2")
+
+// frameScriptConnKey is used to store the *frameScriptConn, on which a request
+// arrived, in the request context.
+type frameScriptConnKey struct{}
+
+// frameScriptNextProtos returns TLSNextProto functions that serve HTTP/2
+// connections such that frame scripts can write frames directly to them.
+// Both "h2" and the pseudo-protocol for unencrypted HTTP/2 are handled.
+func frameScriptNextProtos() map[string]func(*http.Server, *tls.Conn, http.Handler) {
+	h2Server := &http2.Server{}
+	serve := func(httpServer *http.Server, conn net.Conn, scriptConn *frameScriptConn, handler http.Handler, sawClientPreface bool) {
+		// The net/http package passes down its per-connection base
+		// context via an unadvertised method on the handler.
+		ctx := context.Background()
+		if baseCtx, ok := handler.(interface{ BaseContext() context.Context }); ok {
+			ctx = baseCtx.BaseContext()
+		}
+		h2Server.ServeConn(conn, &http2.ServeConnOpts{
+			Context:          context.WithValue(ctx, frameScriptConnKey{}, scriptConn),
+			Handler:          handler,
+			BaseConfig:       httpServer,
+			SawClientPreface: sawClientPreface,
+		})
+	}
+	return map[string]func(*http.Server, *tls.Conn, http.Handler){
+		http2.NextProtoTLS: func(httpServer *http.Server, tlsConn *tls.Conn, handler http.Handler) {
+			scriptConn := newFrameScriptConn(tlsConn, false)
+			serve(httpServer, &tlsFrameScriptConn{frameScriptConn: scriptConn, tlsConn: tlsConn}, scriptConn, handler, false)
+		},
+		nextProtoUnencryptedHTTP2: func(httpServer *http.Server, tlsConn *tls.Conn, handler http.Handler) {
+			// For unencrypted connections, the *tls.Conn is just a
+			// vehicle for the underlying connection.
+			unencrypted, ok := tlsConn.NetConn().(interface{ UnencryptedNetConn() net.Conn })
+			if !ok {
+				_ = tlsConn.Close()
+				return
+			}
+			// The server has already read the client preface.
+			scriptConn := newFrameScriptConn(unencrypted.UnencryptedNetConn(), true)
+			serve(httpServer, scriptConn, scriptConn, handler, true)
+		},
+	}
+}
+
+// frameScriptConn wraps an HTTP/2 connection so that frame scripts can write
+// frames directly to it, alongside the frames written by the HTTP/2 server.
+//
+// Frames are exchanged whole, so that scripted frames are never interleaved
+// with partial frames from the server. Frames from the client are inspected
+// to learn which stream is used by each test case. Frames from the server
+// for a stream that is being scripted are discarded, except for those that
+// carry header blocks, since HPACK state must be kept in sync with the client.
+type frameScriptConn struct {
+	net.Conn
+
+	// These are only used by the goroutine that reads from the connection.
+	readBuf       []byte
+	readIn        []byte
+	readOut       []byte
+	readErr       error
+	prefaceLeft   int
+	hpackDecoder  *hpack.Decoder
+	headersStream uint32
+
+	// This serializes writes to the underlying connection.
+	writeMu sync.Mutex
+	writeIn []byte
+
+	mu                sync.Mutex
+	streamsByTestCase map[string]uint32
+	scriptedStreams   map[uint32]struct{}
+	// The number of bytes in DATA frames written by scripts that are
+	// not yet accounted for in connection-level WINDOW_UPDATE frames
+	// from the client. The server did not send these bytes, so such
+	// updates would otherwise give it more send window than it has.
+	injectedFlow uint32
+	// These are used to gracefully drain the connection. See drain.go.
+	drainedAt         time.Time
+	drainedFor        string
+	drainAfterStreams map[uint32]string
+	lateStreams       map[string]time.Duration
+	// The error codes in RST_STREAM frames from the client, by stream.
+	// See cancellation.go.
+	resetCodes map[uint32]http2.ErrCode
+}
+
+func newFrameScriptConn(conn net.Conn, sawClientPreface bool) *frameScriptConn {
+	scriptConn := &frameScriptConn{
+		Conn:              conn,
+		readBuf:           make([]byte, 4096),
+		streamsByTestCase: map[string]uint32{},
+		scriptedStreams:   map[uint32]struct{}{},
+		drainAfterStreams: map[uint32]string{},
+		lateStreams:       map[string]time.Duration{},
+		resetCodes:        map[uint32]http2.ErrCode{},
+	}
+	if !sawClientPreface {
+		scriptConn.prefaceLeft = len(http2.ClientPreface)
+	}
+	scriptConn.hpackDecoder = hpack.NewDecoder(http2HeaderTableSize, func(field hpack.HeaderField) {
+		if http.CanonicalHeaderKey(field.Name) == testCaseNameHeader {
+			scriptConn.mu.Lock()
+			scriptConn.streamsByTestCase[field.Value] = scriptConn.headersStream
+			scriptConn.noteStreamForDrain(field.Value)
+			scriptConn.mu.Unlock()
+		}
+	})
+	return scriptConn
+}
+
+func (c *frameScriptConn) Read(data []byte) (int, error) {
+	for len(c.readOut) == 0 {
+		if c.readErr != nil {
+			return 0, c.readErr
+		}
+		n, err := c.Conn.Read(c.readBuf)
+		c.readIn = append(c.readIn, c.readBuf[:n]...)
+		c.processIncoming()
+		c.readErr = err
+	}
+	n := copy(data, c.readOut)
+	c.readOut = c.readOut[n:]
+	return n, nil
+}
+
+func (c *frameScriptConn) processIncoming() {
+	if c.prefaceLeft > 0 {
+		n := min(c.prefaceLeft, len(c.readIn))
+		c.readOut = append(c.readOut, c.readIn[:n]...)
+		c.readIn = c.readIn[n:]
+		c.prefaceLeft -= n
+		if c.prefaceLeft > 0 {
+			return
+		}
+	}
+	for {
+		frame, ok := nextFrame(c.readIn)
+		if !ok {
+			return
+		}
+		c.readIn = c.readIn[len(frame):]
+		if frame = c.inspectIncoming(frame); frame != nil {
+			c.readOut = append(c.readOut, frame...)
+		}
+	}
+}
+
+// inspectIncoming examines a frame from the client, returning the frame
+// that should be passed to the server (which is nil if it should be
+// discarded).
+func (c *frameScriptConn) inspectIncoming(frame []byte) []byte {
+	frameType, flags, streamID := parseFrameHeader(frame)
+	payload := frame[http2FrameHeaderLen:]
+	switch frameType { //nolint:exhaustive
+	case http2.FrameHeaders:
+		if flags.Has(http2.FlagHeadersPadded) {
+			if len(payload) == 0 || int(payload[0]) >= len(payload) {
+				c.hpackDecoder = nil // malformed, so stop decoding
+				return frame
+			}
+			payload = payload[1 : len(payload)-int(payload[0])]
+		}
+		if flags.Has(http2.FlagHeadersPriority) {
+			if len(payload) < 5 {
+				c.hpackDecoder = nil // malformed, so stop decoding
+				return frame
+			}
+			payload = payload[5:]
+		}
+		c.headersStream = streamID
+		c.decodeHeaders(payload, flags.Has(http2.FlagHeadersEndHeaders))
+	case http2.FrameContinuation:
+		c.decodeHeaders(payload, flags.Has(http2.FlagContinuationEndHeaders))
+	case http2.FrameWindowUpdate:
+		if streamID == 0 && len(payload) == 4 {
+			return c.adjustWindowUpdate(frame)
+		}
+	case http2.FrameRSTStream:
+		if len(payload) == 4 {
+			c.mu.Lock()
+			c.resetCodes[streamID] = http2.ErrCode(binary.BigEndian.Uint32(payload))
+			c.mu.Unlock()
+		}
+	}
+	return frame
+}
+
+func (c *frameScriptConn) decodeHeaders(fragment []byte, endHeaders bool) {
+	if c.hpackDecoder == nil {
+		return
+	}
+	if _, err := c.hpackDecoder.Write(fragment); err != nil {
+		// The server will reject the header block, too, so there's
+		// no point in decoding any more.
+		c.hpackDecoder = nil
+		return
+	}
+	if endHeaders {
+		if err := c.hpackDecoder.Close(); err != nil {
+			c.hpackDecoder = nil
+		}
+	}
+}
+
+func (c *frameScriptConn) adjustWindowUpdate(frame []byte) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	increment := binary.BigEndian.Uint32(frame[http2FrameHeaderLen:]) & (1<<31 - 1)
+	if c.injectedFlow == 0 || increment == 0 {
+		return frame
+	}
+	deduct := min(increment, c.injectedFlow)
+	c.injectedFlow -= deduct
+	if deduct == increment {
+		return nil
+	}
+	binary.BigEndian.PutUint32(frame[http2FrameHeaderLen:], increment-deduct)
+	return frame
+}
+
+func (c *frameScriptConn) Write(data []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.writeIn = append(c.writeIn, data...)
+	var out []byte
+	for {
+		frame, ok := nextFrame(c.writeIn)
+		if !ok {
+			break
+		}
+		c.writeIn = c.writeIn[len(frame):]
+		if c.isFromServerAllowed(frame) {
+			out = append(out, frame...)
+			out = append(out, c.drainAfterFrame(frame)...)
+		}
+	}
+	if len(out) > 0 {
+		if _, err := c.Conn.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// isFromServerAllowed returns true if the given frame, written by the
+// server, should be sent to the client.
+func (c *frameScriptConn) isFromServerAllowed(frame []byte) bool {
+	frameType, _, streamID := parseFrameHeader(frame)
+	switch frameType { //nolint:exhaustive
+	case http2.FrameHeaders, http2.FrameContinuation, http2.FramePushPromise:
+		return true
+	}
+	if streamID == 0 {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, scripted := c.scriptedStreams[streamID]
+	return !scripted
+}
+
+// claimStream returns the ID of the stream used by the given test case and
+// marks it as scripted, so that the server can no longer write to it.
+func (c *frameScriptConn) claimStream(testCaseName string) (uint32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	streamID, ok := c.streamsByTestCase[testCaseName]
+	if !ok {
+		return 0, fmt.Errorf("could not determine HTTP/2 stream for test case %q", testCaseName)
+	}
+	delete(c.streamsByTestCase, testCaseName)
+	c.scriptedStreams[streamID] = struct{}{}
+	return streamID, nil
+}
+
+// streamResetCode returns the error code of the RST_STREAM frame that the
+// client sent for the stream used by the given test case, if it sent one.
+func (c *frameScriptConn) streamResetCode(testCaseName string) (http2.ErrCode, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	streamID, ok := c.streamsByTestCase[testCaseName]
+	if !ok {
+		return 0, false
+	}
+	code, ok := c.resetCodes[streamID]
+	delete(c.resetCodes, streamID)
+	return code, ok
+}
+
+func (c *frameScriptConn) writeScripted(data []byte, flow uint32) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.mu.Lock()
+	c.injectedFlow += flow
+	c.mu.Unlock()
+	_, err := c.Conn.Write(data)
+	return err
+}
+
+// tlsFrameScriptConn is a frameScriptConn for a TLS connection. It provides
+// the connection state, so the HTTP/2 server knows that TLS is in use.
+type tlsFrameScriptConn struct {
+	*frameScriptConn
+	tlsConn *tls.Conn
+}
+
+func (c *tlsFrameScriptConn) ConnectionState() tls.ConnectionState {
+	return c.tlsConn.ConnectionState()
+}
+
+// runFrameScript writes the frames in the given script to the connection
+// on which the given request arrived, for the request's stream.
+func runFrameScript(req *http.Request, script *conformancev1.HTTP2FrameScript) error {
+	conn, ok := req.Context().Value(frameScriptConnKey{}).(*frameScriptConn)
+	if !ok {
+		return errFrameScriptRequiresHTTP2
+	}
+	streamID, err := conn.claimStream(req.Header.Get(testCaseNameHeader))
+	if err != nil {
+		return err
+	}
+	for i, frame := range script.Frames {
+		if frame.DelayMs > 0 {
+			timer := time.NewTimer(time.Duration(frame.DelayMs) * time.Millisecond)
+			select {
+			case <-req.Context().Done():
+				// The client reset the stream or the connection closed.
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+		}
+		data, flow, err := internal.EncodeHTTP2Frame(frame, streamID, responsePseudoHeaders(frame))
+		if err != nil {
+			return fmt.Errorf("frame #%d: %w", i+1, err)
+		}
+		if err := conn.writeScripted(data, flow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// responsePseudoHeaders returns the pseudo-headers to include in the given
+// frame, if it is a HEADERS frame that starts a response.
+func responsePseudoHeaders(frame *conformancev1.HTTP2Frame) []hpack.HeaderField {
+	headers := frame.GetHeaders()
+	if headers == nil || headers.Trailers {
+		return nil
+	}
+	statusCode := headers.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	return []hpack.HeaderField{{Name: ":status", Value: strconv.Itoa(int(statusCode))}}
+}
+
+func nextFrame(data []byte) ([]byte, bool) {
+	if len(data) < http2FrameHeaderLen {
+		return nil, false
+	}
+	length := int(data[0])<<16 | int(data[1])<<8 | int(data[2])
+	if len(data) < http2FrameHeaderLen+length {
+		return nil, false
+	}
+	return data[:http2FrameHeaderLen+length], true
+}
+
+func parseFrameHeader(frame []byte) (http2.FrameType, http2.Flags, uint32) {
+	return http2.FrameType(frame[3]), http2.Flags(frame[4]), binary.BigEndian.Uint32(frame[5:]) & (1<<31 - 1)
+}
+
+var _ io.ReadWriter = (*frameScriptConn)(nil)
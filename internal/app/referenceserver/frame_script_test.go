@@ -0,0 +1,135 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+func TestFrameScriptConn(t *testing.T) {
+	t.Parallel()
+
+	var fromClient bytes.Buffer
+	fromClient.WriteString(http2.ClientPreface)
+	clientFramer := http2.NewFramer(&fromClient, nil)
+	require.NoError(t, clientFramer.WriteSettings())
+	var block bytes.Buffer
+	encoder := hpack.NewEncoder(&block)
+	require.NoError(t, encoder.WriteField(hpack.HeaderField{Name: ":method", Value: "POST"}))
+	require.NoError(t, encoder.WriteField(hpack.HeaderField{Name: "x-test-case-name", Value: "foo"}))
+	blockBytes := block.Bytes()
+	// Split the header block, to make sure CONTINUATION frames are decoded, too.
+	require.NoError(t, clientFramer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      3,
+		BlockFragment: blockBytes[:4],
+		PadLength:     2,
+	}))
+	require.NoError(t, clientFramer.WriteContinuation(3, true, blockBytes[4:]))
+	clientBytes := bytes.Clone(fromClient.Bytes())
+
+	fake := &fakeConn{reader: bytes.NewReader(clientBytes)}
+	conn := newFrameScriptConn(fake, false)
+	// Read a little at a time, so that frames are reassembled.
+	var received bytes.Buffer
+	readBuf := make([]byte, 5)
+	for {
+		n, err := conn.Read(readBuf)
+		received.Write(readBuf[:n])
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+	}
+	assert.Equal(t, clientBytes, received.Bytes())
+
+	_, err := conn.claimStream("bar")
+	require.ErrorContains(t, err, `could not determine HTTP/2 stream for test case "bar"`)
+	streamID, err := conn.claimStream("foo")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), streamID)
+
+	// Frames from the server for the scripted stream are dropped,
+	// except for those with header blocks.
+	var fromServer bytes.Buffer
+	serverFramer := http2.NewFramer(&fromServer, nil)
+	require.NoError(t, serverFramer.WriteSettingsAck())
+	require.NoError(t, serverFramer.WriteData(3, false, []byte("abc")))
+	require.NoError(t, serverFramer.WriteHeaders(http2.HeadersFrameParam{StreamID: 3, BlockFragment: []byte{0x88}, EndHeaders: true}))
+	require.NoError(t, serverFramer.WriteData(5, true, []byte("def")))
+	require.NoError(t, serverFramer.WriteRSTStream(3, http2.ErrCodeInternal))
+	serverBytes := fromServer.Bytes()
+	for len(serverBytes) > 0 {
+		chunk := serverBytes[:min(len(serverBytes), 7)]
+		serverBytes = serverBytes[len(chunk):]
+		n, err := conn.Write(chunk)
+		require.NoError(t, err)
+		assert.Equal(t, len(chunk), n)
+	}
+	framer := http2.NewFramer(nil, &fake.written)
+	var frameTypes []http2.FrameType
+	var streamIDs []uint32
+	for {
+		frame, err := framer.ReadFrame()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		frameTypes = append(frameTypes, frame.Header().Type)
+		streamIDs = append(streamIDs, frame.Header().StreamID)
+	}
+	assert.Equal(t, []http2.FrameType{http2.FrameSettings, http2.FrameHeaders, http2.FrameData}, frameTypes)
+	assert.Equal(t, []uint32{0, 3, 5}, streamIDs)
+}
+
+func TestFrameScriptConn_AdjustWindowUpdate(t *testing.T) {
+	t.Parallel()
+
+	windowUpdate := func(increment uint32) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, http2.NewFramer(&buf, nil).WriteWindowUpdate(0, increment))
+		return buf.Bytes()
+	}
+	conn := newFrameScriptConn(&fakeConn{}, true)
+	assert.Equal(t, windowUpdate(100), conn.adjustWindowUpdate(windowUpdate(100)))
+
+	conn.injectedFlow = 150
+	assert.Nil(t, conn.adjustWindowUpdate(windowUpdate(100)))
+	assert.Equal(t, uint32(50), conn.injectedFlow)
+	assert.Equal(t, windowUpdate(30), conn.adjustWindowUpdate(windowUpdate(80)))
+	assert.Equal(t, uint32(0), conn.injectedFlow)
+}
+
+type fakeConn struct {
+	net.Conn
+	reader  io.Reader
+	written bytes.Buffer
+}
+
+func (c *fakeConn) Read(data []byte) (int, error) {
+	return c.reader.Read(data)
+}
+
+func (c *fakeConn) Write(data []byte) (int, error) {
+	return c.written.Write(data)
+}
@@ -0,0 +1,112 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/conformance/internal"
+)
+
+type grpcWebTextContextKey struct{}
+
+// grpcWebTextHandler returns a handler that converts gRPC-Web text requests
+// into binary gRPC-Web requests for the given handler, and converts the
+// binary gRPC-Web responses back into text. Since connect-go does not
+// support the text variant, this lets a connect-go handler serve it.
+//
+// The original content-type of the request is stored in the context. The
+// reference server checks use it to verify the protocol. See
+// requestContentType.
+func grpcWebTextHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		contentType := req.Header.Get("Content-Type")
+		if !internal.IsGRPCWebTextContentType(contentType) {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		req = req.WithContext(context.WithValue(req.Context(), grpcWebTextContextKey{}, contentType))
+		// Clone the headers, so that the change below is not visible to any
+		// middleware that has already captured them, like the tracer.
+		req.Header = req.Header.Clone()
+		req.Header.Set("Content-Type", internal.GRPCWebFromTextContentType(contentType))
+		req.Header.Del("Content-Length")
+		req.ContentLength = -1
+		req.Body = internal.NewGRPCWebTextDecodingReader(req.Body)
+		handler.ServeHTTP(&grpcWebTextResponseWriter{ResponseWriter: respWriter}, req)
+	})
+}
+
+// requestContentType returns the content-type of the given request. If the
+// request was converted by grpcWebTextHandler, this returns the original
+// content-type sent by the client.
+func requestContentType(req *http.Request) string {
+	if contentType, ok := req.Context().Value(grpcWebTextContextKey{}).(string); ok {
+		return contentType
+	}
+	return req.Header.Get("Content-Type")
+}
+
+// grpcWebTextResponseWriter base64-encodes the response body if the
+// response is a binary gRPC-Web response, and changes the content-type
+// to the text variant. Other responses are written as is.
+type grpcWebTextResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+	encode      bool
+}
+
+func (w *grpcWebTextResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	headers := w.Header()
+	if contentType := headers.Get("Content-Type"); internal.IsGRPCWebContentType(contentType) {
+		w.encode = true
+		headers.Set("Content-Type", internal.GRPCWebToTextContentType(contentType))
+		headers.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *grpcWebTextResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.encode || len(data) == 0 {
+		return w.ResponseWriter.Write(data)
+	}
+	// Each write is encoded separately, with padding, so that the
+	// data can be flushed immediately.
+	if _, err := w.ResponseWriter.Write(internal.GRPCWebTextEncode(data)); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *grpcWebTextResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *grpcWebTextResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -47,17 +47,14 @@ type ConformanceRequest interface {
 type conformanceServer struct {
 	conformancev1connect.UnimplementedConformanceServiceHandler
 	referenceMode bool
+	observations  *internal.ServerObservations
 }
 
 func (s *conformanceServer) Unary(
 	ctx context.Context,
 	req *connect.Request[conformancev1.UnaryRequest],
 ) (*connect.Response[conformancev1.UnaryResponse], error) {
-	return doUnary(ctx, req, s.referenceMode, func(payload *conformancev1.ConformancePayload) *conformancev1.UnaryResponse {
-		return &conformancev1.UnaryResponse{
-			Payload: payload,
-		}
-	})
+	return doUnary(ctx, req, s.referenceMode, newUnaryResponse)
 }
 
 func (s *conformanceServer) IdempotentUnary(
@@ -71,6 +68,65 @@ func (s *conformanceServer) IdempotentUnary(
 	})
 }
 
+func (s *conformanceServer) GetServerObservation(
+	ctx context.Context,
+	req *connect.Request[conformancev1.GetServerObservationRequest],
+) (*connect.Response[conformancev1.GetServerObservationResponse], error) {
+	observation, err := s.observations.Get(ctx, req.Msg.TestName)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&conformancev1.GetServerObservationResponse{
+		Observation: observation,
+	}), nil
+}
+
+type transcodingServer struct {
+	conformancev1connect.UnimplementedTranscodingServiceHandler
+	referenceMode bool
+}
+
+func (s *transcodingServer) GetResource(
+	ctx context.Context,
+	req *connect.Request[conformancev1.GetResourceRequest],
+) (*connect.Response[conformancev1.UnaryResponse], error) {
+	return doUnary(ctx, req, s.referenceMode, newUnaryResponse)
+}
+
+func (s *transcodingServer) CreateResource(
+	ctx context.Context,
+	req *connect.Request[conformancev1.CreateResourceRequest],
+) (*connect.Response[conformancev1.UnaryResponse], error) {
+	return doUnary(ctx, req, s.referenceMode, newUnaryResponse)
+}
+
+func (s *transcodingServer) UpdateResource(
+	ctx context.Context,
+	req *connect.Request[conformancev1.UpdateResourceRequest],
+) (*connect.Response[conformancev1.UnaryResponse], error) {
+	return doUnary(ctx, req, s.referenceMode, newUnaryResponse)
+}
+
+func (s *transcodingServer) DeleteResource(
+	ctx context.Context,
+	req *connect.Request[conformancev1.DeleteResourceRequest],
+) (*connect.Response[conformancev1.UnaryResponse], error) {
+	return doUnary(ctx, req, s.referenceMode, newUnaryResponse)
+}
+
+func (s *transcodingServer) SearchResources(
+	ctx context.Context,
+	req *connect.Request[conformancev1.SearchResourcesRequest],
+) (*connect.Response[conformancev1.UnaryResponse], error) {
+	return doUnary(ctx, req, s.referenceMode, newUnaryResponse)
+}
+
+func newUnaryResponse(payload *conformancev1.ConformancePayload) *conformancev1.UnaryResponse {
+	return &conformancev1.UnaryResponse{
+		Payload: payload,
+	}
+}
+
 type hasUnaryResponseDefinition[T any] interface {
 	*T
 	proto.Message
@@ -88,10 +144,11 @@ func doUnary[ReqT, RespT any, Req hasUnaryResponseDefinition[ReqT]](
 	if err != nil {
 		return nil, err
 	}
+	responseDefinition := responseDefinitionForAttempt(ctx, msg)
 	payload, connectErr := parseUnaryResponseDefinition(
 		ctx,
 		referenceMode,
-		msg.GetResponseDefinition(),
+		responseDefinition,
 		req.Header(),
 		req.Peer().Query,
 		req.Peer().Protocol,
@@ -105,12 +162,12 @@ func doUnary[ReqT, RespT any, Req hasUnaryResponseDefinition[ReqT]](
 
 	resp := connect.NewResponse(makeResp(payload))
 
-	if msg.GetResponseDefinition() != nil {
-		internal.AddHeaders(msg.GetResponseDefinition().ResponseHeaders, resp.Header())
-		internal.AddHeaders(msg.GetResponseDefinition().ResponseTrailers, resp.Trailer())
+	if responseDefinition != nil {
+		internal.AddHeaders(responseDefinition.ResponseHeaders, resp.Header())
+		internal.AddHeaders(responseDefinition.ResponseTrailers, resp.Trailer())
 
 		// If a response delay was specified, sleep for that amount of ms before responding
-		responseDelay := time.Duration(msg.GetResponseDefinition().ResponseDelayMs) * time.Millisecond
+		responseDelay := time.Duration(responseDefinition.ResponseDelayMs) * time.Millisecond
 		time.Sleep(responseDelay)
 	}
 
@@ -204,10 +261,10 @@ func (s *conformanceServer) ServerStream(
 		// Calculate the response delay if specified
 		responseDelay := time.Duration(responseDefinition.ResponseDelayMs) * time.Millisecond
 
-		for _, data := range responseDefinition.ResponseData {
+		for range responseDefinition.ResponseData {
 			resp := &conformancev1.ServerStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: data,
+					Data: streamResponseData(s.referenceMode, responseDefinition, respNum),
 				},
 			}
 
@@ -311,7 +368,7 @@ func (s *conformanceServer) BidiStream(
 
 			resp := &conformancev1.BidiStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: responseDefinition.ResponseData[respNum],
+					Data: streamResponseData(s.referenceMode, responseDefinition, respNum),
 				},
 			}
 			var requestInfo *conformancev1.ConformancePayload_RequestInfo
@@ -357,7 +414,7 @@ func (s *conformanceServer) BidiStream(
 			}
 			resp := &conformancev1.BidiStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: responseDefinition.ResponseData[respNum],
+					Data: streamResponseData(s.referenceMode, responseDefinition, respNum),
 				},
 			}
 			// Only set the request info if this is the first response being sent back
@@ -516,12 +573,26 @@ func parseUnaryResponseDefinition(
 		if respType, ok := respType.(*conformancev1.UnaryResponseDefinition_ResponseData); ok {
 			payload.Data = respType.ResponseData
 		}
+		if referenceMode {
+			payload.Data = internal.Inflate(payload.Data, def.InflatedResponseSize)
+		}
 		return payload, nil
 	default:
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("provided UnaryRequest.Response has an unexpected type %T", respType))
 	}
 }
 
+// Returns the response data at the given index in the given stream response
+// definition. In reference mode, the data is inflated per the definition's
+// inflated_response_sizes field.
+func streamResponseData(referenceMode bool, def *conformancev1.StreamResponseDefinition, index int) []byte {
+	data := def.ResponseData[index]
+	if referenceMode && index < len(def.InflatedResponseSizes) {
+		data = internal.Inflate(data, def.InflatedResponseSizes[index])
+	}
+	return data
+}
+
 // Creates request info for a conformance payload.
 func createRequestInfo(
 	ctx context.Context,
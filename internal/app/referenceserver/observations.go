@@ -0,0 +1,69 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/connect"
+)
+
+// serverObserver is an interceptor that records whether the context of each
+// handler is canceled before the handler returns, for RPCs whose requests
+// indicate that the observation will be fetched. These observations are
+// reported by the GetServerObservation method.
+type serverObserver struct {
+	observations *internal.ServerObservations
+}
+
+func (o serverObserver) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		testCaseName := observedTestCaseName(req.Header())
+		if testCaseName == "" || req.Spec().Procedure == conformancev1connect.ConformanceServiceGetServerObservationProcedure {
+			return next(ctx, req)
+		}
+		var resp connect.AnyResponse
+		err := o.observations.Handle(ctx, testCaseName, func() (err error) {
+			resp, err = next(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+}
+
+func (o serverObserver) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (o serverObserver) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, stream connect.StreamingHandlerConn) error {
+		return o.observations.Handle(ctx, observedTestCaseName(stream.RequestHeader()), func() error {
+			return next(ctx, stream)
+		})
+	}
+}
+
+// observedTestCaseName returns the name of the test case for an RPC with the
+// given request headers, or the empty string if the RPC should not be
+// observed.
+func observedTestCaseName(headers http.Header) string {
+	if headers.Get(internal.ExpectServerObservationHeader) == "" {
+		return ""
+	}
+	return headers.Get(testCaseNameHeader)
+}
@@ -48,7 +48,7 @@ func rawResponder(handler http.Handler) http.Handler {
 		ctx := context.WithValue(req.Context(), rawResponseKey{}, rawResponder)
 		req = req.WithContext(ctx)
 		handler.ServeHTTP(rawResponder, req)
-		rawResponder.finish(snapshotHeaders)
+		rawResponder.finish(req, snapshotHeaders)
 	})
 }
 
@@ -123,11 +123,20 @@ func (r *rawResponseWriter) Unwrap() http.ResponseWriter {
 	return r.respWriter
 }
 
-func (r *rawResponseWriter) finish(snapshotHeaders http.Header) {
+func (r *rawResponseWriter) finish(req *http.Request, snapshotHeaders http.Header) {
 	resp := r.rawResponse()
 	if resp == nil {
 		return
 	}
+	if script := resp.GetFrameScript(); script != nil {
+		// Errors were already checked by setRawResponse. Any that remain
+		// are from writing to the connection, which we can't report.
+		_ = runFrameScript(req, script)
+		// Abort the handler, so that the HTTP/2 server only resets the
+		// stream (which is discarded since the stream was scripted)
+		// instead of trying to finish the response.
+		panic(http.ErrAbortHandler) //nolint:forbidigo
+	}
 
 	// clean any headers that may have been set by the handler
 	// and restore to the snapshot we initially took (which
@@ -156,7 +165,7 @@ func (r *rawResponseWriter) finish(snapshotHeaders http.Header) {
 	case *conformancev1.RawHTTPResponse_Unary:
 		_ = internal.WriteRawMessageContents(contents.Unary, r.respWriter)
 	case *conformancev1.RawHTTPResponse_Stream:
-		_ = internal.WriteRawStreamContents(contents.Stream, r.respWriter)
+		_ = internal.WriteRawStreamContents(req.Context(), contents.Stream, r.respWriter)
 	}
 	internal.AddTrailers(resp.Trailers, r.respWriter.Header())
 }
@@ -165,16 +174,44 @@ type rawResponseRecorder struct{}
 
 func (r rawResponseRecorder) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-		if msg, ok := req.Any().(*conformancev1.UnaryRequest); ok {
-			rawResponse := msg.GetResponseDefinition().GetRawResponse()
-			if rawResponse != nil {
-				if err := setRawResponse(ctx, rawResponse); err != nil {
-					return nil, err
-				}
-				return nil, connect.NewError(connect.CodeAborted, errors.New("use raw response instead"))
+		msg, ok := req.Any().(*conformancev1.UnaryRequest)
+		if !ok {
+			return next(ctx, req)
+		}
+		rawResponse := responseDefinitionForAttempt(ctx, msg).GetRawResponse()
+		if rawResponse != nil {
+			if err := setRawResponse(ctx, rawResponse); err != nil {
+				return nil, err
+			}
+			return nil, connect.NewError(connect.CodeAborted, errors.New("use raw response instead"))
+		}
+		resp, err := next(ctx, req)
+		var connectErr *connect.Error
+		if len(msg.GetAttemptResponseDefinitions()) > 0 &&
+			req.Peer().Protocol == connect.ProtocolGRPC &&
+			errors.As(err, &connectErr) {
+			// gRPC clients only retry an RPC when the error is sent in a
+			// "trailers-only" response. But connect-go always sends headers
+			// and trailers separately. So we send the error as a raw response.
+			if err := setRawResponse(ctx, trailersOnlyResponse(req.Header().Get("Content-Type"), connectErr, responseDefinitionForAttempt(ctx, msg))); err != nil {
+				return nil, err
 			}
+			return nil, connect.NewError(connect.CodeAborted, errors.New("use raw response instead"))
 		}
-		return next(ctx, req)
+		return resp, err
+	}
+}
+
+// trailersOnlyResponse returns a gRPC "trailers-only" response for the
+// given error. Such a response has no body and puts the status in headers.
+func trailersOnlyResponse(contentType string, err *connect.Error, def *conformancev1.UnaryResponseDefinition) *conformancev1.RawHTTPResponse {
+	headers := []*conformancev1.Header{{Name: "content-type", Value: []string{contentType}}}
+	headers = append(headers, grpcStatusTrailers(err)...)
+	headers = append(headers, def.GetResponseHeaders()...)
+	headers = append(headers, def.GetResponseTrailers()...)
+	return &conformancev1.RawHTTPResponse{
+		StatusCode: http.StatusOK,
+		Headers:    headers,
 	}
 }
 
@@ -221,7 +258,9 @@ func (r rawResponseRecorder) WrapStreamingHandler(next connect.StreamingHandlerF
 				// before sending back the raw response.
 				// NOTE: This means that raw responses cannot be used with full-duplex
 				//       request definitions.
-				for {
+				// Frame scripts, however, are run immediately, so that they can
+				// control when and how the stream ends.
+				for rawResponse.GetFrameScript() == nil {
 					if err := stream.Receive(req); err != nil {
 						break
 					}
@@ -273,6 +312,14 @@ func setRawResponse(ctx context.Context, resp *conformancev1.RawHTTPResponse) er
 	if !ok {
 		return errNoRawResponseHolder
 	}
+	if script := resp.GetFrameScript(); script != nil {
+		if _, ok := ctx.Value(frameScriptConnKey{}).(*frameScriptConn); !ok {
+			return errFrameScriptRequiresHTTP2
+		}
+		if err := internal.ValidateHTTP2FrameScript(script); err != nil {
+			return err
+		}
+	}
 	if !respWriter.setRawResponse(resp) {
 		return errNonRawResponseStarted
 	}
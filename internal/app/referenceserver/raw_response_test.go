@@ -309,7 +309,7 @@ func TestRawResponseRecorder(t *testing.T) {
 					case *conformancev1.RawHTTPResponse_Unary:
 						err = internal.WriteRawMessageContents(contents.Unary, expectedBody)
 					case *conformancev1.RawHTTPResponse_Stream:
-						err = internal.WriteRawStreamContents(contents.Stream, expectedBody)
+						err = internal.WriteRawStreamContents(context.Background(), contents.Stream, expectedBody)
 					}
 					require.NoError(t, err)
 					assert.Equal(t, expectedBody.Bytes(), body.Bytes())
@@ -0,0 +1,82 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+type interruptReadsKey struct{}
+
+// interruptibleReadsHandler returns a handler that lets the given handler
+// interrupt reads of the request body. Otherwise, a read can block
+// indefinitely, even after the RPC deadline has passed, if the client
+// stalls in the middle of a message.
+//
+// This must be applied to the response writer provided by the HTTP server,
+// which is what supports setting a read deadline. The function that
+// interrupts reads is stored in the context. See readDeadlineInterceptor.
+func interruptibleReadsHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		// The response writer must not be used after the handler returns,
+		// so this guards against an interrupt that races with that.
+		var mu sync.Mutex
+		var finished bool
+		interrupt := func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if finished {
+				return
+			}
+			// Not all servers support this (for example, HTTP/3), in which
+			// case reads just aren't interrupted.
+			_ = http.NewResponseController(respWriter).SetReadDeadline(time.Now())
+		}
+		defer func() {
+			mu.Lock()
+			defer mu.Unlock()
+			finished = true
+		}()
+		ctx := context.WithValue(req.Context(), interruptReadsKey{}, interrupt)
+		handler.ServeHTTP(respWriter, req.WithContext(ctx))
+	})
+}
+
+// readDeadlineInterceptor interrupts reads of the request body when a
+// streaming handler's context is done, such as when its deadline passes.
+type readDeadlineInterceptor struct{}
+
+func (i readDeadlineInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return next
+}
+
+func (i readDeadlineInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i readDeadlineInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, stream connect.StreamingHandlerConn) error {
+		if interrupt, ok := ctx.Value(interruptReadsKey{}).(func()); ok {
+			stop := context.AfterFunc(ctx, interrupt)
+			defer stop()
+		}
+		return next(ctx, stream)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// requestPacingTolerance is the fraction of the client's delay between
+// request messages by which a message may arrive earlier than the delay
+// allows, relative to the first message. Network and scheduling latency
+// can delay some messages more than others, so they may arrive closer
+// together than they were sent. The allowance does not grow with the
+// number of messages, so a client that sends several of them together
+// is still noticed.
+const requestPacingTolerance = 0.5
+
+// requestPacingObserver returns a handler that records when each request
+// message arrives, for RPCs whose client waits between sending request
+// messages, as indicated by the "X-Expect-Request-Delay-Ms" header. Feedback
+// is printed if the messages arrive bunched together, which happens when a
+// client buffers request messages until the request stream is closed instead
+// of sending each one when the application provides it.
+func requestPacingObserver(handler http.Handler, errPrinter internal.Printer) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		val := req.Header.Get("X-Expect-Request-Delay-Ms")
+		testCaseName := req.Header.Get(testCaseNameHeader)
+		// If the whole body is compressed, the envelopes can't be seen
+		// as the body arrives.
+		if val == "" || testCaseName == "" || req.Method == http.MethodGet || req.Header.Get("Content-Encoding") != "" {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		feedback := &feedbackPrinter{p: errPrinter, testCaseName: testCaseName}
+		delayMs, err := strconv.Atoi(val)
+		if err != nil || delayMs <= 0 {
+			feedback.Printf("invalid value for %q header: %q", "X-Expect-Request-Delay-Ms", val)
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		body := &envelopeTimingBody{ReadCloser: req.Body, start: time.Now()}
+		if internal.IsGRPCWebTextContentType(req.Header.Get("Content-Type")) {
+			body.base64 = &internal.GRPCWebTextDecoder{}
+		}
+		req.Body = body
+		handler.ServeHTTP(respWriter, req)
+		arrivals, endedAt := body.result()
+		checkRequestPacing(arrivals, endedAt, time.Duration(delayMs)*time.Millisecond, feedback)
+	})
+}
+
+// checkRequestPacing examines when each request message arrived, relative to
+// the start of the RPC, and prints feedback if they arrived closer together
+// than the client's delay between sending them allows, less the
+// requestPacingTolerance. If non-negative, endedAt is when the request
+// stream ended.
+func checkRequestPacing(arrivals []time.Duration, endedAt time.Duration, delay time.Duration, feedback *feedbackPrinter) {
+	slack := time.Duration(requestPacingTolerance * float64(delay))
+	for i := 1; i < len(arrivals); i++ {
+		expected := time.Duration(i) * delay
+		actual := arrivals[i] - arrivals[0]
+		if actual >= expected-slack {
+			continue
+		}
+		if endedAt >= 0 && endedAt-arrivals[i] < slack {
+			feedback.Printf("request #%d arrived %v after the first, along with the end of the request stream, but the client should wait %v before sending each request; "+
+				"it appears to buffer request messages until the stream is closed",
+				i+1, actual.Round(time.Millisecond), delay)
+		} else {
+			feedback.Printf("request #%d arrived %v after the first, but the client should wait %v before sending each request; "+
+				"it appears to buffer request messages instead of sending each one when it is provided",
+				i+1, actual.Round(time.Millisecond), delay)
+		}
+		return
+	}
+}
+
+// envelopeTimingBody records when each message in an enveloped request stream
+// starts to arrive. The messages are found with the same scanner that the
+// tracer uses.
+type envelopeTimingBody struct {
+	io.ReadCloser
+	start time.Time
+	// If non-nil, the body is base64-encoded (gRPC-Web text)
+	// and is decoded before it is traced.
+	base64 *internal.GRPCWebTextDecoder
+
+	mu sync.Mutex
+	// Set if the body could not be decoded, after which
+	// the rest of it is not traced.
+	base64Failed bool
+	envelopes    tracer.EnvelopeScanner
+	arrivals     []time.Duration
+	endedAt      time.Duration
+	ended        bool
+}
+
+func (b *envelopeTimingBody) Read(data []byte) (int, error) {
+	n, err := b.ReadCloser.Read(data)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Since(b.start)
+	b.decodeAndTraceLocked(data[:n], now)
+	if errors.Is(err, io.EOF) && !b.ended {
+		b.ended = true
+		b.endedAt = now
+	}
+	return n, err
+}
+
+func (b *envelopeTimingBody) decodeAndTraceLocked(data []byte, now time.Duration) {
+	if b.base64 != nil {
+		if b.base64Failed {
+			return
+		}
+		decoded, err := b.base64.Decode(data)
+		if err != nil {
+			b.base64Failed = true
+		}
+		data = decoded
+	}
+	b.traceLocked(data, now)
+}
+
+func (b *envelopeTimingBody) traceLocked(data []byte, now time.Duration) {
+	for len(data) > 0 {
+		n, chunk := b.envelopes.Next(data)
+		if chunk.Start {
+			b.arrivals = append(b.arrivals, now)
+		}
+		data = data[n:]
+	}
+}
+
+// result returns when each message started to arrive and when the request
+// stream ended, relative to the start of the RPC. If the stream has not
+// ended, endedAt is negative.
+func (b *envelopeTimingBody) result() (arrivals []time.Duration, endedAt time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.ended {
+		return b.arrivals, -1
+	}
+	return b.arrivals, b.endedAt
+}
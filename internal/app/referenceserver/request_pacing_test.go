@@ -0,0 +1,110 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelopeTimingBody(t *testing.T) {
+	t.Parallel()
+	body := &envelopeTimingBody{}
+	// Two messages, with three and zero bytes of data, followed by the
+	// start of a third message. They are split across reads at arbitrary
+	// points, including in the middle of a prefix.
+	body.traceLocked([]byte{0, 0, 0}, 10*time.Millisecond)
+	body.traceLocked([]byte{0, 3, 'a'}, 20*time.Millisecond)
+	body.traceLocked([]byte{'b', 'c', 0, 0, 0, 0}, 30*time.Millisecond)
+	body.traceLocked([]byte{0, 1}, 40*time.Millisecond)
+	arrivals, endedAt := body.result()
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 30 * time.Millisecond, 40 * time.Millisecond}, arrivals)
+	assert.Negative(t, endedAt)
+}
+
+func TestEnvelopeTimingBody_GRPCWebText(t *testing.T) {
+	t.Parallel()
+	body := &envelopeTimingBody{base64: &internal.GRPCWebTextDecoder{}}
+	// The same messages as above, base64-encoded and split across reads
+	// in the middle of a base64 quantum. Read as raw bytes, the "AAAA" at
+	// the start would be taken as a prefix for a message of about 1GiB.
+	encoded := string(internal.GRPCWebTextEncode([]byte{0, 0, 0, 0, 3, 'a', 'b', 'c', 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}))
+	body.decodeAndTraceLocked([]byte(encoded[:6]), 10*time.Millisecond)
+	body.decodeAndTraceLocked([]byte(encoded[6:13]), 20*time.Millisecond)
+	body.decodeAndTraceLocked([]byte(encoded[13:]), 30*time.Millisecond)
+	arrivals, endedAt := body.result()
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}, arrivals)
+	assert.Negative(t, endedAt)
+}
+
+func TestCheckRequestPacing(t *testing.T) {
+	t.Parallel()
+	const delay = 200 * time.Millisecond
+	testCases := []struct {
+		name          string
+		arrivals      []time.Duration
+		endedAt       time.Duration
+		expectedError string
+	}{
+		{
+			name:     "paced",
+			arrivals: []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 600 * time.Millisecond},
+			endedAt:  800 * time.Millisecond,
+		},
+		{
+			name:     "jitter",
+			arrivals: []time.Duration{300 * time.Millisecond, 420 * time.Millisecond, 610 * time.Millisecond},
+			endedAt:  -1,
+		},
+		{
+			name:          "buffered until close",
+			arrivals:      []time.Duration{600 * time.Millisecond, 601 * time.Millisecond, 601 * time.Millisecond},
+			endedAt:       602 * time.Millisecond,
+			expectedError: "request #2 arrived 1ms after the first, along with the end of the request stream",
+		},
+		{
+			name:          "bunched",
+			arrivals:      []time.Duration{200 * time.Millisecond, 205 * time.Millisecond, 600 * time.Millisecond},
+			endedAt:       -1,
+			expectedError: "request #2 arrived 5ms after the first, but the client should wait 200ms before sending each request",
+		},
+		{
+			// Half of the delay allowed for the second message, but the
+			// allowance does not grow for later messages.
+			name:          "later messages bunched",
+			arrivals:      []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 410 * time.Millisecond, 800 * time.Millisecond},
+			endedAt:       -1,
+			expectedError: "request #3 arrived 210ms after the first, but the client should wait 200ms before sending each request",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			printer := &internal.SimplePrinter{}
+			feedback := &feedbackPrinter{p: printer, testCaseName: testCase.name}
+			checkRequestPacing(testCase.arrivals, testCase.endedAt, delay, feedback)
+			if testCase.expectedError == "" {
+				assert.Empty(t, printer.Messages)
+				return
+			}
+			require.Len(t, printer.Messages, 1)
+			assert.Contains(t, printer.Messages[0], testCase.expectedError)
+		})
+	}
+}
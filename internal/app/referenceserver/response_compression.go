@@ -0,0 +1,215 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"connectrpc.com/conformance/internal/compression"
+	"connectrpc.com/connect"
+)
+
+// responseCompressionHandler returns a handler that makes sure the given
+// handler compresses responses using an encoding that the client accepts.
+//
+// When a request is compressed, connect-go always compresses the response
+// using the same encoding, even if the client did not advertise that it
+// accepts it. So when the client advertises other encodings, this handler
+// decompresses the request first. That way, connect-go sees an uncompressed
+// request and chooses from the encodings that the client advertised.
+//
+// If readMaxBytes is positive, it limits how much of each message will be
+// decompressed. Anything beyond that is discarded, since the handler will
+// reject the message anyway.
+func responseCompressionHandler(handler http.Handler, readMaxBytes int64) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		var encodingHeader, acceptHeader, sent string
+		if req.Method == http.MethodGet {
+			acceptHeader = "Accept-Encoding"
+			sent = req.URL.Query().Get("compression")
+		} else {
+			encodingHeader, acceptHeader = compressionHeaders(req.Header.Get("Content-Type"))
+			if encodingHeader != "" {
+				sent = req.Header.Get(encodingHeader)
+			}
+		}
+		accepted := parseAcceptCompression(req.Header.Values(acceptHeader))
+		if sent == "" || sent == compression.Identity || len(accepted) == 0 || slices.Contains(accepted, sent) {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		comp, ok := compression.FromName(sent)
+		if !ok {
+			// Let the handler reject the unsupported encoding.
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		decomp, err := compression.GetDecompressor(comp)
+		if err != nil {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+
+		// Clone the request, so that the changes below are not visible
+		// to any middleware that has already captured it, like the tracer.
+		req = req.Clone(req.Context())
+		switch {
+		case req.Method == http.MethodGet:
+			if err := decompressQueryMessage(req, decomp, readMaxBytes); err != nil {
+				_ = connect.NewErrorWriter().Write(respWriter, req, connect.NewError(connect.CodeInvalidArgument, err))
+				return
+			}
+		case encodingHeader == "Content-Encoding":
+			// Connect unary requests compress the entire body.
+			req.Body = &decompressingReader{body: req.Body, decomp: decomp}
+		default:
+			req.Body = &decompressingEnvelopeReader{body: req.Body, decomp: decomp, readMaxBytes: readMaxBytes}
+		}
+		if encodingHeader != "" {
+			req.Header.Del(encodingHeader)
+			req.Header.Del("Content-Length")
+			req.ContentLength = -1
+		}
+		handler.ServeHTTP(respWriter, req)
+	})
+}
+
+// decompressQueryMessage decompresses the message in the query string of
+// a Connect GET request and removes the compression query param.
+func decompressQueryMessage(req *http.Request, decomp connect.Decompressor, readMaxBytes int64) error {
+	query := req.URL.Query()
+	msg := []byte(query.Get("message"))
+	if query.Get("base64") == "1" {
+		var err error
+		msg, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(string(msg), "="))
+		if err != nil {
+			return err
+		}
+	}
+	if err := decomp.Reset(bytes.NewReader(msg)); err != nil {
+		return err
+	}
+	data, err := readDecompressed(decomp, readMaxBytes)
+	if err != nil {
+		return err
+	}
+	query.Del("compression")
+	query.Set("base64", "1")
+	query.Set("message", base64.RawURLEncoding.EncodeToString(data))
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
+
+func readDecompressed(decomp connect.Decompressor, readMaxBytes int64) ([]byte, error) {
+	var reader io.Reader = decomp
+	if readMaxBytes > 0 {
+		reader = io.LimitReader(decomp, readMaxBytes+1)
+	}
+	return io.ReadAll(reader)
+}
+
+// decompressingReader decompresses the entire body, for a Connect unary
+// request. The decompressor is initialized on the first read.
+type decompressingReader struct {
+	body        io.ReadCloser
+	decomp      connect.Decompressor
+	initialized bool
+	err         error
+}
+
+func (r *decompressingReader) Read(data []byte) (int, error) {
+	if !r.initialized {
+		r.initialized = true
+		r.err = r.decomp.Reset(r.body)
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.decomp.Read(data)
+}
+
+func (r *decompressingReader) Close() error {
+	return r.body.Close()
+}
+
+// decompressingEnvelopeReader decompresses each compressed message in an
+// enveloped body and clears the compressed flag for the message. Other
+// messages, including a truncated message at the end of the body, are
+// passed through as is.
+type decompressingEnvelopeReader struct {
+	body         io.ReadCloser
+	decomp       connect.Decompressor
+	readMaxBytes int64
+	buf          bytes.Buffer
+	err          error
+}
+
+func (r *decompressingEnvelopeReader) Read(data []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.err = r.next()
+	}
+	return r.buf.Read(data)
+}
+
+func (r *decompressingEnvelopeReader) Close() error {
+	return r.body.Close()
+}
+
+// next reads the next message from the body into buf.
+func (r *decompressingEnvelopeReader) next() error {
+	var prefix [5]byte
+	if n, err := io.ReadFull(r.body, prefix[:]); err != nil {
+		r.buf.Write(prefix[:n])
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.EOF
+		}
+		return err
+	}
+	size := int64(binary.BigEndian.Uint32(prefix[1:]))
+	if prefix[0]&1 == 0 {
+		r.buf.Write(prefix[:])
+		// If the body ends early, this returns io.EOF, so the
+		// handler sees the truncated message.
+		_, err := io.CopyN(&r.buf, r.body, size)
+		return err
+	}
+	compressed := io.LimitReader(r.body, size)
+	if err := r.decomp.Reset(compressed); err != nil {
+		return err
+	}
+	msg, err := readDecompressed(r.decomp, r.readMaxBytes)
+	if err != nil {
+		return err
+	}
+	// Discard anything that was not decompressed.
+	if _, err := io.Copy(io.Discard, compressed); err != nil {
+		return err
+	}
+	prefix[0] &^= 1
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	r.buf.Write(prefix[:])
+	r.buf.Write(msg)
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/conformance/internal/compression"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCompressionHandler(t *testing.T) {
+	t.Parallel()
+
+	_, handler := conformancev1connect.NewConformanceServiceHandler(
+		&conformanceServer{},
+		connect.WithCompression(compression.Zstd, compression.NewZstdDecompressor, compression.NewZstdCompressor),
+	)
+	svr := httptest.NewServer(responseCompressionHandler(handler, 0))
+	t.Cleanup(svr.Close)
+
+	testCases := []struct {
+		name           string
+		acceptHeader   string
+		accept         string
+		useGet         bool
+		streaming      bool
+		expectHeader   string
+		expectEncoding string
+	}{
+		{
+			name:           "unary, request compression not accepted",
+			acceptHeader:   "Accept-Encoding",
+			accept:         "zstd",
+			expectHeader:   "Content-Encoding",
+			expectEncoding: "zstd",
+		},
+		{
+			name:           "unary, request compression accepted",
+			acceptHeader:   "Accept-Encoding",
+			accept:         "zstd, gzip",
+			expectHeader:   "Content-Encoding",
+			expectEncoding: "gzip",
+		},
+		{
+			name:           "unary, identity accepted",
+			acceptHeader:   "Accept-Encoding",
+			accept:         "identity",
+			expectHeader:   "Content-Encoding",
+			expectEncoding: "",
+		},
+		{
+			name:           "get, request compression not accepted",
+			acceptHeader:   "Accept-Encoding",
+			accept:         "zstd",
+			useGet:         true,
+			expectHeader:   "Content-Encoding",
+			expectEncoding: "zstd",
+		},
+		{
+			name:           "stream, request compression not accepted",
+			acceptHeader:   "Connect-Accept-Encoding",
+			accept:         "zstd",
+			streaming:      true,
+			expectHeader:   "Connect-Content-Encoding",
+			expectEncoding: "zstd",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var respEncoding string
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req = req.Clone(req.Context())
+				req.Header.Set(testCase.acceptHeader, testCase.accept)
+				resp, err := svr.Client().Transport.RoundTrip(req)
+				if err == nil {
+					respEncoding = resp.Header.Get(testCase.expectHeader)
+				}
+				return resp, err
+			})
+			client := conformancev1connect.NewConformanceServiceClient(
+				&http.Client{Transport: transport},
+				svr.URL,
+				connect.WithSendGzip(),
+				connect.WithCompressMinBytes(1),
+				connect.WithHTTPGet(),
+				connect.WithHTTPGetMaxURLSize(10000, false),
+				connect.WithAcceptCompression(compression.Zstd, compression.NewZstdDecompressor, compression.NewZstdCompressor),
+			)
+			requestData := bytes.Repeat([]byte("abc"), 100)
+			if testCase.streaming {
+				stream := client.ClientStream(context.Background())
+				require.NoError(t, stream.Send(&conformancev1.ClientStreamRequest{RequestData: requestData}))
+				require.NoError(t, stream.Send(&conformancev1.ClientStreamRequest{RequestData: requestData}))
+				resp, err := stream.CloseAndReceive()
+				require.NoError(t, err)
+				assert.Len(t, resp.Msg.GetPayload().GetRequestInfo().GetRequests(), 2)
+			} else {
+				req := connect.NewRequest(&conformancev1.UnaryRequest{RequestData: requestData})
+				var err error
+				if testCase.useGet {
+					_, err = client.IdempotentUnary(context.Background(), connect.NewRequest(&conformancev1.IdempotentUnaryRequest{RequestData: requestData}))
+				} else {
+					_, err = client.Unary(context.Background(), req)
+				}
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectEncoding, respEncoding)
+		})
+	}
+}
+
+func TestDecompressingEnvelopeReader(t *testing.T) {
+	t.Parallel()
+
+	msg := bytes.Repeat([]byte("abc"), 100)
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	_, err := gzipWriter.Write(msg)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	envelope := func(flags byte, data []byte) []byte {
+		result := make([]byte, 5, 5+len(data))
+		result[0] = flags
+		binary.BigEndian.PutUint32(result[1:], uint32(len(data)))
+		return append(result, data...)
+	}
+	join := func(parts ...[]byte) []byte {
+		return bytes.Join(parts, nil)
+	}
+
+	testCases := []struct {
+		name         string
+		body         []byte
+		readMaxBytes int64
+		expected     []byte
+	}{
+		{
+			name:     "compressed",
+			body:     join(envelope(1, compressed.Bytes()), envelope(1, compressed.Bytes())),
+			expected: join(envelope(0, msg), envelope(0, msg)),
+		},
+		{
+			name:     "uncompressed",
+			body:     join(envelope(0, []byte("foo")), envelope(1, compressed.Bytes())),
+			expected: join(envelope(0, []byte("foo")), envelope(0, msg)),
+		},
+		{
+			name:     "truncated message",
+			body:     join(envelope(1, compressed.Bytes()), envelope(0, []byte("foobar"))[:8]),
+			expected: join(envelope(0, msg), envelope(0, []byte("foobar"))[:8]),
+		},
+		{
+			name:     "truncated prefix",
+			body:     join(envelope(1, compressed.Bytes()), []byte{0, 0}),
+			expected: join(envelope(0, msg), []byte{0, 0}),
+		},
+		{
+			name:         "limited",
+			body:         envelope(1, compressed.Bytes()),
+			readMaxBytes: 10,
+			expected:     envelope(0, msg[:11]),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			reader := &decompressingEnvelopeReader{
+				body:         io.NopCloser(bytes.NewReader(testCase.body)),
+				decomp:       &gzip.Reader{},
+				readMaxBytes: testCase.readMaxBytes,
+			}
+			data, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, data)
+		})
+	}
+}
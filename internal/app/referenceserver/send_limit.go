@@ -0,0 +1,106 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+)
+
+// messageSendLimitChecker is an interceptor that verifies that the client
+// did not send any request message that is larger than the client's send
+// limit, which is indicated by the "X-Expect-Message-Send-Limit" header.
+// If it did, feedback is printed about the oversized message.
+//
+// The size of a message is computed using the Protobuf binary format. The
+// test cases that rely on a send limit use the proto codec and do not use
+// compression, so this is the size of the message on the wire.
+type messageSendLimitChecker struct {
+	errPrinter internal.Printer
+}
+
+func (c messageSendLimitChecker) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if limit, feedback, ok := c.sendLimit(req.Header()); ok {
+			checkMessageSize(req.Any(), 1, limit, feedback)
+		}
+		return next(ctx, req)
+	}
+}
+
+func (c messageSendLimitChecker) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (c messageSendLimitChecker) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, stream connect.StreamingHandlerConn) error {
+		if limit, feedback, ok := c.sendLimit(stream.RequestHeader()); ok {
+			stream = &sendLimitCheckingHandlerConn{StreamingHandlerConn: stream, limit: limit, feedback: feedback}
+		}
+		return next(ctx, stream)
+	}
+}
+
+func (c messageSendLimitChecker) sendLimit(headers http.Header) (int, *feedbackPrinter, bool) {
+	testCaseName := headers.Get("X-Test-Case-Name")
+	if testCaseName == "" {
+		// The reference server checks already complained about this.
+		return 0, nil, false
+	}
+	feedback := &feedbackPrinter{p: c.errPrinter, testCaseName: testCaseName}
+	val, ok := getHeader(headers, "X-Expect-Message-Send-Limit", feedback)
+	if !ok {
+		return 0, nil, false
+	}
+	limit, err := strconv.Atoi(val)
+	if err != nil || limit <= 0 {
+		feedback.Printf("invalid value for %q header: %q", "X-Expect-Message-Send-Limit", val)
+		return 0, nil, false
+	}
+	return limit, feedback, true
+}
+
+func checkMessageSize(msg any, msgNum int, limit int, feedback *feedbackPrinter) {
+	protoMsg, ok := msg.(proto.Message)
+	if !ok {
+		return
+	}
+	if size := proto.Size(protoMsg); size > limit {
+		feedback.Printf("request #%d is %d bytes, which exceeds the client's message send limit of %d bytes", msgNum, size, limit)
+	}
+}
+
+// sendLimitCheckingHandlerConn checks the size of each request message
+// that it receives.
+type sendLimitCheckingHandlerConn struct {
+	connect.StreamingHandlerConn
+	limit    int
+	feedback *feedbackPrinter
+	count    int
+}
+
+func (c *sendLimitCheckingHandlerConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	c.count++
+	checkMessageSize(msg, c.count, c.limit, c.feedback)
+	return nil
+}
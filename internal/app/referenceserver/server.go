@@ -61,6 +61,7 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 	tlsCert := flags.String("cert", "", "the path to a PEM-encoded TLS certificate file to use instead of generating self-signed")
 	tlsKey := flags.String("key", "", "the path to a PEM-encoded TLS key file to use instead of generating self-signed")
 	showVersion := flags.Bool("version", false, "show version and exit")
+	cancelWait := flags.Duration("cancel-wait", defaultCancelWait, "in reference mode, how long to wait for a client's cancellation of an RPC to arrive, after the client should have canceled it")
 
 	if err := flags.Parse(args[1:]); err != nil {
 		return err
@@ -86,7 +87,11 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 
 	// Create an HTTP server based on the request
 	errPrinter := internal.NewPrinter(errWriter)
-	server, certBytes, err := createServer(req, net.JoinHostPort(*host, strconv.Itoa(*port)), *tlsCert, *tlsKey, referenceMode, errPrinter, tracer)
+	var conns *connectionTracker
+	if referenceMode {
+		conns = newConnectionTracker()
+	}
+	server, certBytes, err := createServer(req, net.JoinHostPort(*host, strconv.Itoa(*port)), *tlsCert, *tlsKey, conns, *cancelWait, errPrinter, tracer)
 	if err != nil {
 		return err
 	}
@@ -143,6 +148,11 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 				return fmt.Errorf("failed to gracefully shutdown HTTP server: %w", err)
 			}
 		}
+		if conns != nil {
+			// Report the number of connections, so the test runner
+			// can tell how well clients reuse connections.
+			errPrinter.Printf("%s%d", internal.ConnectionCountPrefix, conns.numConnections())
+		}
 		return nil
 	}
 }
@@ -179,16 +189,30 @@ func (s *stdHTTPServer) Addr() string {
 }
 
 // Creates an HTTP server using the provided ServerCompatRequest.
-func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFile, tlsKeyFile string, referenceMode bool, errPrinter internal.Printer, trace *tracer.Tracer) (httpServer, []byte, error) {
+//
+// If conns is non-nil, the server runs in reference mode, and conns tracks
+// the connections that it accepts.
+func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFile, tlsKeyFile string, conns *connectionTracker, cancelWait time.Duration, errPrinter internal.Printer, trace *tracer.Tracer) (httpServer, []byte, error) {
+	referenceMode := conns != nil
+	if _, err := transcodingRules(); err != nil {
+		return nil, nil, fmt.Errorf("could not compute transcoding rules: %w", err)
+	}
 	mux := http.NewServeMux()
-	interceptors := []connect.Interceptor{serverNameHandlerInterceptor{}}
+	observations := internal.NewServerObservations()
+	interceptors := []connect.Interceptor{serverNameHandlerInterceptor{}, readDeadlineInterceptor{}, serverObserver{observations: observations}}
 	if referenceMode {
-		interceptors = append(interceptors, rawResponseRecorder{})
+		interceptors = append(interceptors,
+			rawResponseRecorder{},
+			messageSendLimitChecker{errPrinter: errPrinter},
+			connectionDrainer{errPrinter: errPrinter},
+			cancellationInterceptor{},
+		)
 	}
 	opts := []connect.HandlerOption{
 		connect.WithCompression(compression.Brotli, compression.NewBrotliDecompressor, compression.NewBrotliCompressor),
 		connect.WithCompression(compression.Deflate, compression.NewDeflateDecompressor, compression.NewDeflateCompressor),
 		connect.WithCompression(compression.Snappy, compression.NewSnappyDecompressor, compression.NewSnappyCompressor),
+		connect.WithCompression(compression.LZ4, compression.NewLZ4Decompressor, compression.NewLZ4Compressor),
 		connect.WithCompression(compression.Zstd, compression.NewZstdDecompressor, compression.NewZstdCompressor),
 		connect.WithCodec(internal.StrictJSONCodec{}),
 		connect.WithInterceptors(interceptors...),
@@ -198,7 +222,11 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 	}
 
 	mux.Handle(conformancev1connect.NewConformanceServiceHandler(
-		&conformanceServer{referenceMode: referenceMode},
+		&conformanceServer{referenceMode: referenceMode, observations: observations},
+		opts...,
+	))
+	mux.Handle(conformancev1connect.NewTranscodingServiceHandler(
+		&transcodingServer{referenceMode: referenceMode},
 		opts...,
 	))
 	handler := http.Handler(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
@@ -211,8 +239,18 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 		}
 		mux.ServeHTTP(respWriter, req)
 	}))
+	// This must be inside the reference server checks, so that they
+	// can examine the request as it was compressed by the client.
+	handler = responseCompressionHandler(handler, int64(req.MessageReceiveLimit))
+	// This must be inside the reference server checks, so that they
+	// can examine the REST-style request sent by the client.
+	handler = transcodingHandler(handler)
 	if referenceMode {
-		handler = referenceServerChecks(handler, errPrinter)
+		// These must be inside the reference server checks, so that they
+		// stop observing the request before it drains the request body.
+		handler = cancellationObserver(handler, cancelWait, errPrinter)
+		handler = requestPacingObserver(handler, errPrinter)
+		handler = referenceServerChecks(handler, conns, errPrinter)
 		handler = rawResponder(handler)
 	} else {
 		// When in reference mode, checking requests from a client-under-test, we make sure that the
@@ -237,6 +275,10 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 			orig.ServeHTTP(respWriter, req)
 		})
 	}
+	// This must wrap rawResponder, so that raw responses are also
+	// encoded for gRPC-Web text, but be inside the tracer, so that
+	// traces show the encoded body that is actually on the wire.
+	handler = grpcWebTextHandler(handler)
 	if trace != nil {
 		handler = tracer.TracingHandler(handler, trace)
 	}
@@ -266,6 +308,7 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 		// Expose all headers
 		ExposedHeaders: []string{"*"},
 	}).Handler(handler)
+	handler = interruptibleReadsHandler(handler)
 
 	// Create servers
 	var tlsConf *tls.Config
@@ -310,11 +353,11 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 	var err error
 	switch req.HttpVersion {
 	case conformancev1.HTTPVersion_HTTP_VERSION_1:
-		server, err = newH1Server(handler, listenAddr, tlsConf)
+		server, err = newH1Server(handler, listenAddr, tlsConf, int(req.HeaderSizeLimit), conns)
 	case conformancev1.HTTPVersion_HTTP_VERSION_2:
-		server, err = newH2Server(handler, listenAddr, tlsConf)
+		server, err = newH2Server(handler, listenAddr, tlsConf, int(req.HeaderSizeLimit), conns)
 	case conformancev1.HTTPVersion_HTTP_VERSION_3:
-		server, err = newH3Server(handler, listenAddr, tlsConf)
+		server, err = newH3Server(handler, listenAddr, tlsConf, int(req.HeaderSizeLimit), conns)
 	case conformancev1.HTTPVersion_HTTP_VERSION_UNSPECIFIED:
 		err = errors.New("an HTTP version must be specified")
 	}
@@ -326,16 +369,20 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 }
 
 // newH1Server creates a new HTTP/1.1 server.
-func newH1Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (httpServer, error) {
+func newH1Server(handler http.Handler, listenAddr string, tlsConf *tls.Config, maxHeaderBytes int, conns *connectionTracker) (httpServer, error) {
 	h1Server := &http.Server{
 		Addr:              listenAddr,
 		Handler:           handler,
 		TLSConfig:         tlsConf,
 		ReadHeaderTimeout: 5 * time.Second,
+		MaxHeaderBytes:    maxHeaderBytes,
 		ErrorLog:          nopLogger(),
 		// We disable automatic HTTP/2 support by setting this to non-nil
 		TLSNextProto: map[string]func(*http.Server, *tls.Conn, http.Handler){},
 	}
+	if conns != nil {
+		h1Server.ConnContext = conns.connContext
+	}
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return nil, err
@@ -344,18 +391,25 @@ func newH1Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (
 }
 
 // newH2Server creates a new HTTP/2 server.
-func newH2Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (httpServer, error) {
+func newH2Server(handler http.Handler, listenAddr string, tlsConf *tls.Config, maxHeaderBytes int, conns *connectionTracker) (httpServer, error) {
 	h2Server := &http.Server{
 		Addr:              listenAddr,
 		Handler:           handler,
 		TLSConfig:         tlsConf,
 		ReadHeaderTimeout: 5 * time.Second,
+		MaxHeaderBytes:    maxHeaderBytes,
 		ErrorLog:          nopLogger(),
 	}
 	var protocols http.Protocols
 	protocols.SetUnencryptedHTTP2(true)
 	protocols.SetHTTP2(true)
 	h2Server.Protocols = &protocols
+	if conns != nil {
+		// The reference server serves HTTP/2 itself, so that raw
+		// responses can write frames directly to the connection.
+		h2Server.TLSNextProto = frameScriptNextProtos()
+		h2Server.ConnContext = conns.connContext
+	}
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return nil, err
@@ -364,15 +418,22 @@ func newH2Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (
 }
 
 // Create a new HTTP/3 server.
-func newH3Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (httpServer, error) {
+func newH3Server(handler http.Handler, listenAddr string, tlsConf *tls.Config, maxHeaderBytes int, conns *connectionTracker) (httpServer, error) {
 	if tlsConf == nil {
 		return nil, errors.New("request indicated HTTP/3 without TLS, which is not possible")
 	}
 	tlsConf = http3.ConfigureTLSConfig(tlsConf)
 	h3Server := &http3.Server{
-		Addr:      listenAddr,
-		Handler:   handler,
-		TLSConfig: tlsConf,
+		Addr:           listenAddr,
+		Handler:        handler,
+		TLSConfig:      tlsConf,
+		MaxHeaderBytes: maxHeaderBytes,
+	}
+	if conns != nil {
+		// The reference server tracks the requests on each connection,
+		// so that it can close a connection once it is idle.
+		h3Server.ConnContext = conns.quicConnContext
+		h3Server.Handler = http3ConnTrackingHandler(handler)
 	}
 	lis, err := quic.ListenAddrEarly(listenAddr, tlsConf, &quic.Config{MaxIdleTimeout: 20 * time.Second, KeepAlivePeriod: 5 * time.Second})
 	if err != nil {
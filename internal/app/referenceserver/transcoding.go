@@ -0,0 +1,230 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/transcoding"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// transcodingRules returns the rules for all methods of the TranscodingService.
+//
+//nolint:gochecknoglobals
+var transcodingRules = sync.OnceValues(func() ([]*transcoding.Rule, error) {
+	methods := conformancev1.File_connectrpc_conformance_v1_transcoding_proto.
+		Services().ByName("TranscodingService").Methods()
+	rules := make([]*transcoding.Rule, methods.Len())
+	for i := range methods.Len() {
+		rule, err := transcoding.RuleForMethod(methods.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+})
+
+// matchTranscodingRule returns the rule that matches the given request, along
+// with the values of the variables in the request's URL path. If the request
+// is not a REST-style request for the TranscodingService, it returns nil.
+func matchTranscodingRule(req *http.Request) (*transcoding.Rule, map[string]string) {
+	rules, err := transcodingRules()
+	if err != nil {
+		return nil, nil
+	}
+	for _, rule := range rules {
+		if pathVars, ok := rule.Match(req.Method, req.URL.EscapedPath()); ok {
+			return rule, pathVars
+		}
+	}
+	return nil, nil
+}
+
+// transcodingHandler returns a handler that translates REST-style requests
+// for the TranscodingService, as described by its google.api.http annotations,
+// into Connect unary requests for the given handler. The Connect responses are
+// then translated back into REST-style responses. Other requests are passed to
+// the given handler as is. Since connect-go does not support transcoding, this
+// lets a connect-go handler serve it, much like a transcoding gateway would.
+func transcodingHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		rule, pathVars := matchTranscodingRule(req)
+		if rule == nil {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		method := rule.MethodDescriptor()
+		msgType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+		if err != nil {
+			writeTranscodingError(respWriter, connect.NewError(connect.CodeInternal, err))
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			writeTranscodingError(respWriter, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("could not read request body: %w", err)))
+			return
+		}
+		msg := msgType.New().Interface()
+		if err := rule.DecodeRequest(pathVars, req.URL.Query(), body, msg); err != nil {
+			writeTranscodingError(respWriter, connect.NewError(connect.CodeInvalidArgument, err))
+			return
+		}
+		reqData, err := protojson.Marshal(msg)
+		if err != nil {
+			writeTranscodingError(respWriter, connect.NewError(connect.CodeInternal, err))
+			return
+		}
+
+		// Clone the request, so that the changes below are not visible to any
+		// middleware that has already captured it, like the tracer.
+		connectReq := req.Clone(req.Context())
+		connectReq.Method = http.MethodPost
+		connectReq.URL.Path = "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+		connectReq.URL.RawPath = ""
+		connectReq.URL.RawQuery = ""
+		connectReq.RequestURI = connectReq.URL.RequestURI()
+		connectReq.Header.Set("Content-Type", "application/json")
+		// The body has already been decoded above, and we need to examine
+		// the response body below, so don't let compression get involved.
+		connectReq.Header.Del("Content-Encoding")
+		connectReq.Header.Del("Accept-Encoding")
+		connectReq.Header.Del("Content-Length")
+		connectReq.ContentLength = int64(len(reqData))
+		connectReq.Body = io.NopCloser(bytes.NewReader(reqData))
+
+		recorder := &transcodingResponseRecorder{header: http.Header{}, statusCode: http.StatusOK}
+		handler.ServeHTTP(recorder, connectReq)
+
+		headers := respWriter.Header()
+		for key, vals := range recorder.header {
+			if key == "Content-Length" {
+				continue
+			}
+			headers[key] = vals
+		}
+		if recorder.statusCode == http.StatusOK {
+			respWriter.WriteHeader(http.StatusOK)
+			_, _ = respWriter.Write(recorder.body.Bytes())
+			return
+		}
+		connectErr, err := parseConnectError(recorder.body.Bytes())
+		if err != nil {
+			// Not a Connect error, so send it as is.
+			respWriter.WriteHeader(recorder.statusCode)
+			_, _ = respWriter.Write(recorder.body.Bytes())
+			return
+		}
+		writeTranscodingError(respWriter, connectErr)
+	})
+}
+
+// isTranscodingRequest returns true if the given request is a REST-style
+// request for the TranscodingService.
+func isTranscodingRequest(req *http.Request) bool {
+	rule, _ := matchTranscodingRule(req)
+	return rule != nil
+}
+
+// writeTranscodingError writes the given error as a REST-style response: the
+// HTTP status is derived from the error code, and the body is a JSON-encoded
+// google.rpc.Status message.
+func writeTranscodingError(respWriter http.ResponseWriter, connectErr *connect.Error) {
+	data, err := transcoding.MarshalError(connectErr)
+	if err != nil {
+		http.Error(respWriter, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respWriter.Header().Set("Content-Type", "application/json")
+	respWriter.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	respWriter.WriteHeader(transcoding.HTTPStatusFromCode(connectErr.Code()))
+	_, _ = respWriter.Write(data)
+}
+
+// parseConnectError parses the JSON body of a Connect unary error response.
+func parseConnectError(data []byte) (*connect.Error, error) {
+	var wireErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(data, &wireErr); err != nil {
+		return nil, err
+	}
+	var code connect.Code
+	if err := code.UnmarshalText([]byte(wireErr.Code)); err != nil {
+		return nil, err
+	}
+	connectErr := connect.NewWireError(code, errors.New(wireErr.Message))
+	for _, detail := range wireErr.Details {
+		// Connect uses base64 without padding, but accepts it either way.
+		value, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(detail.Value, "="))
+		if err != nil {
+			return nil, err
+		}
+		errDetail, err := connect.NewErrorDetail(&anypb.Any{
+			TypeUrl: "type.googleapis.com/" + detail.Type,
+			Value:   value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		connectErr.AddDetail(errDetail)
+	}
+	return connectErr, nil
+}
+
+// transcodingResponseRecorder buffers a Connect unary response, so that it
+// can be translated into a REST-style response.
+type transcodingResponseRecorder struct {
+	header     http.Header
+	statusCode int
+	wroteHead  bool
+	body       bytes.Buffer
+}
+
+func (r *transcodingResponseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *transcodingResponseRecorder) WriteHeader(statusCode int) {
+	if r.wroteHead {
+		return
+	}
+	r.wroteHead = true
+	r.statusCode = statusCode
+}
+
+func (r *transcodingResponseRecorder) Write(data []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(data)
+}
@@ -0,0 +1,375 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replay implements a command that replays an HTTP exchange that
+// was recorded by the conformance runner. The recorded request can be sent
+// to a server, or the recorded response can be served to a client. Either
+// way, what the other party actually sends is compared to the recording.
+package replay
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// errDifferences is returned from Run when the replayed exchange does
+// not match the recording.
+var errDifferences = errors.New("replayed exchange does not match the recording")
+
+// hopByHopHeaders are headers in a recording that are not replayed
+// verbatim since they are managed by the HTTP implementation.
+//
+//nolint:gochecknoglobals
+var hopByHopHeaders = map[string]struct{}{
+	"Connection":        {},
+	"Content-Length":    {},
+	"Host":              {},
+	"Keep-Alive":        {},
+	"Proxy-Connection":  {},
+	"Trailer":           {},
+	"Transfer-Encoding": {},
+	"Upgrade":           {},
+}
+
+// Run runs the replay command. The only positional argument is the path
+// to a recording file.
+func Run(ctx context.Context, args []string, _ io.ReadCloser, outWriter, _ io.WriteCloser) error {
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	sendTo := flags.String("send", "", "the base URL of a server to which the recorded request is sent, e.g. http://127.0.0.1:8080")
+	serve := flags.Bool("serve", false, "if true, the recorded response is served to the first client that connects")
+	host := flags.String("bind", internal.DefaultHost, "when serving, the bind address on which to listen")
+	port := flags.Int("port", internal.DefaultPort, "when serving, the port on which to listen")
+	insecure := flags.Bool("insecure", false, "when sending to an https URL, do not verify the server's certificate")
+	showVersion := flags.Bool("version", false, "show version and exit")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *showVersion {
+		_, _ = fmt.Fprintf(outWriter, "%s %s\n", filepath.Base(args[0]), internal.Version)
+		return nil
+	}
+	if flags.NArg() != 1 {
+		return errors.New("exactly one positional argument, the path to a recording file, is required")
+	}
+	if (*sendTo == "") == !*serve {
+		return errors.New("exactly one of -send or -serve must be provided")
+	}
+
+	fileName := flags.Arg(0)
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+	var rec tracer.Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+
+	var diffs []string
+	if *serve {
+		listener, err := net.Listen("tcp", net.JoinHostPort(*host, strconv.Itoa(*port)))
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(outWriter, "Listening on http://%s\n", listener.Addr())
+		actual, err := serveRecording(ctx, listener, &rec)
+		if err != nil {
+			return err
+		}
+		diffs = rec.Request.Diff(actual)
+	} else {
+		actual, err := sendRecording(ctx, *sendTo, *insecure, &rec)
+		if err != nil {
+			return err
+		}
+		diffs = rec.Response.Diff(actual)
+	}
+	if len(diffs) == 0 {
+		_, _ = fmt.Fprintln(outWriter, "Replayed exchange matches the recording.")
+		return nil
+	}
+	_, _ = fmt.Fprintln(outWriter, "Replayed exchange differs from the recording:")
+	for _, diff := range diffs {
+		_, _ = fmt.Fprintf(outWriter, "  %s\n", diff)
+	}
+	return errDifferences
+}
+
+// sendRecording sends the recorded request to the server at the given
+// base URL, reproducing the recorded timing of request body data. It
+// returns the response received from the server.
+func sendRecording(ctx context.Context, baseURL string, insecure bool, rec *tracer.Recording) (*tracer.RecordedResponse, error) {
+	transport := &http.Transport{
+		// We want to see the response exactly as sent, and we don't
+		// want the transport to add an Accept-Encoding header.
+		DisableCompression: true,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: insecure, //nolint:gosec // only when explicitly requested
+		},
+		Protocols: &http.Protocols{},
+	}
+	defer transport.CloseIdleConnections()
+	isTLS := strings.HasPrefix(baseURL, "https:")
+	switch rec.Request.Proto {
+	case "HTTP/1.0", "HTTP/1.1":
+		transport.Protocols.SetHTTP1(true)
+	case "HTTP/2.0":
+		if isTLS {
+			transport.Protocols.SetHTTP2(true)
+		} else {
+			transport.Protocols.SetUnencryptedHTTP2(true)
+		}
+	default:
+		return nil, fmt.Errorf("replaying %s requests is not supported", rec.Request.Proto)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	bodyReader, bodyWriter := io.Pipe()
+	req, err := http.NewRequestWithContext(ctx, rec.Request.Method, strings.TrimSuffix(baseURL, "/")+rec.Request.URI, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = replayHeaders(rec.Request.Headers)
+	if host := rec.Request.Headers.Get("Host"); host != "" {
+		req.Host = host
+	}
+	req.ContentLength = -1
+	if contentLength, err := strconv.ParseInt(rec.Request.Headers.Get("Content-Length"), 10, 64); err == nil {
+		req.ContentLength = contentLength
+	}
+	if len(rec.Request.Trailers) > 0 {
+		req.Trailer = rec.Request.Trailers.Clone()
+	}
+
+	start := time.Now()
+	go func() {
+		_ = bodyWriter.CloseWithError(writeChunks(ctx, start, rec.Request.Body, bodyWriter.Write, nil))
+	}()
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	actual := &tracer.RecordedResponse{
+		Offset:     time.Since(start),
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+	}
+	actual.Body, err = readChunks(start, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	actual.Trailers = resp.Trailer
+	return actual, nil
+}
+
+// serveRecording serves the recorded response to the first request
+// received on the given listener, reproducing the recorded timing of
+// the response. It returns the request received from the client.
+func serveRecording(ctx context.Context, listener net.Listener, rec *tracer.Recording) (*tracer.RecordedRequest, error) {
+	type result struct {
+		req *tracer.RecordedRequest
+		err error
+	}
+	results := make(chan result, 1)
+	var handled atomic.Bool
+	handler := http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		if !handled.CompareAndSwap(false, true) {
+			http.Error(respWriter, "recorded response already served", http.StatusServiceUnavailable)
+			return
+		}
+		actual, err := replayResponse(req.Context(), respWriter, req, rec.Response)
+		results <- result{req: actual, err: err}
+	})
+	protocols := &http.Protocols{}
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{
+		Handler:           handler,
+		Protocols:         protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	defer func() {
+		// Shut down gracefully, so the response is completed
+		// before the connection is closed.
+		shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			_ = server.Close()
+		}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-serveErr:
+		return nil, err
+	case res := <-results:
+		return res.req, res.err
+	}
+}
+
+// replayResponse writes the given recorded response, while concurrently
+// reading the request body. It returns the request that was received.
+func replayResponse(ctx context.Context, respWriter http.ResponseWriter, req *http.Request, resp *tracer.RecordedResponse) (*tracer.RecordedRequest, error) {
+	start := time.Now()
+	actual := &tracer.RecordedRequest{
+		Method:  req.Method,
+		URI:     req.RequestURI,
+		Proto:   req.Proto,
+		Headers: req.Header,
+	}
+	// Bidirectional streams may require reading the request body
+	// and writing the response body at the same time.
+	_ = http.NewResponseController(respWriter).EnableFullDuplex()
+	readErr := make(chan error, 1)
+	go func() {
+		var err error
+		actual.Body, err = readChunks(start, req.Body)
+		readErr <- err
+	}()
+
+	var writeErr error
+	if resp != nil {
+		writeErr = writeResponse(ctx, start, respWriter, resp)
+	}
+	select {
+	case err := <-readErr:
+		if err != nil {
+			return nil, err
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if writeErr != nil {
+		return nil, writeErr
+	}
+	actual.Trailers = req.Trailer
+	return actual, nil
+}
+
+func writeResponse(ctx context.Context, start time.Time, respWriter http.ResponseWriter, resp *tracer.RecordedResponse) error {
+	if err := sleepUntil(ctx, start.Add(resp.Offset)); err != nil {
+		return err
+	}
+	headers := respWriter.Header()
+	for name, vals := range replayHeaders(resp.Headers) {
+		headers[name] = vals
+	}
+	if contentLength := resp.Headers.Get("Content-Length"); contentLength != "" && len(resp.Trailers) == 0 {
+		headers.Set("Content-Length", contentLength)
+	}
+	respWriter.WriteHeader(resp.StatusCode)
+	flusher, _ := respWriter.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	if err := writeChunks(ctx, start, resp.Body, respWriter.Write, flusher); err != nil {
+		return err
+	}
+	for name, vals := range resp.Trailers {
+		headers[http.TrailerPrefix+name] = vals
+	}
+	return nil
+}
+
+// writeChunks writes the given chunks via the given function, each one
+// at the time indicated by its offset relative to start.
+func writeChunks(ctx context.Context, start time.Time, chunks []tracer.RecordedData, write func([]byte) (int, error), flusher http.Flusher) error {
+	for _, chunk := range chunks {
+		if err := sleepUntil(ctx, start.Add(chunk.Offset)); err != nil {
+			return err
+		}
+		if _, err := write(chunk.Data); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	return nil
+}
+
+// readChunks reads the given body until EOF, recording each chunk
+// of data read along with when it was read, relative to start.
+func readChunks(start time.Time, body io.Reader) ([]tracer.RecordedData, error) {
+	var chunks []tracer.RecordedData
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			chunks = append(chunks, tracer.RecordedData{
+				Offset: time.Since(start),
+				Data:   append([]byte(nil), buf[:n]...),
+			})
+		}
+		if errors.Is(err, io.EOF) {
+			return chunks, nil
+		}
+		if err != nil {
+			return chunks, err
+		}
+	}
+}
+
+func sleepUntil(ctx context.Context, deadline time.Time) error {
+	delay := time.Until(deadline)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// replayHeaders returns a copy of the given recorded headers, without
+// those that are managed by the HTTP implementation.
+func replayHeaders(recorded http.Header) http.Header {
+	headers := make(http.Header, len(recorded))
+	for name, vals := range recorded {
+		if _, skip := hopByHopHeaders[http.CanonicalHeaderKey(name)]; skip {
+			continue
+		}
+		headers[name] = append([]string(nil), vals...)
+	}
+	return headers
+}
@@ -0,0 +1,135 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	rec := &tracer.Recording{
+		TestName: "foo/bar",
+		Request: tracer.RecordedRequest{
+			Method:  http.MethodPost,
+			URI:     "/foo.Service/Echo",
+			Proto:   "HTTP/2.0",
+			Headers: http.Header{"Content-Type": {"application/grpc"}, "Te": {"trailers"}, "User-Agent": {"test"}},
+			Body: []tracer.RecordedData{
+				{Data: []byte{0, 0, 0, 0, 3, 'a', 'b', 'c'}},
+				{Offset: 10 * time.Millisecond, Data: []byte{0, 0, 0, 0, 1, 'd'}},
+			},
+		},
+		Response: &tracer.RecordedResponse{
+			StatusCode: http.StatusOK,
+			Headers:    http.Header{"Content-Type": {"application/grpc"}},
+			Body: []tracer.RecordedData{
+				{Data: []byte{0, 0, 0, 0, 3, 'a', 'b', 'c'}},
+				{Offset: 10 * time.Millisecond, Data: []byte{0, 0, 0, 0, 1, 'd'}},
+			},
+			Trailers: http.Header{"Grpc-Status": {"0"}},
+		},
+	}
+	recFile := filepath.Join(t.TempDir(), "foo.json")
+	data, err := json.Marshal(rec)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(recFile, data, 0o600))
+
+	t.Run("send", func(t *testing.T) {
+		t.Parallel()
+		// This server behaves like the recorded one, except for the
+		// given trailer value.
+		newServer := func(grpcStatus string) *httptest.Server {
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+				respWriter.Header().Set("Content-Type", req.Header.Get("Content-Type"))
+				respWriter.Header().Set("Trailer", "Grpc-Status")
+				_, _ = io.Copy(respWriter, req.Body)
+				respWriter.Header().Set("Grpc-Status", grpcStatus)
+			}))
+			server.Config.Protocols = &http.Protocols{}
+			server.Config.Protocols.SetUnencryptedHTTP2(true)
+			server.Start()
+			t.Cleanup(server.Close)
+			return server
+		}
+
+		var out bytes.Buffer
+		err := Run(context.Background(), []string{"replay", "-send", newServer("0").URL, recFile}, nil, nopCloser{&out}, nopCloser{io.Discard})
+		require.NoError(t, err)
+		assert.Equal(t, "Replayed exchange matches the recording.\n", out.String())
+
+		out.Reset()
+		err = Run(context.Background(), []string{"replay", "-send", newServer("13").URL, recFile}, nil, nopCloser{&out}, nopCloser{io.Discard})
+		require.ErrorIs(t, err, errDifferences)
+		assert.Contains(t, out.String(), `response trailer "Grpc-Status" differs: recorded ["0"], got ["13"]`)
+	})
+
+	t.Run("serve", func(t *testing.T) {
+		t.Parallel()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		clientDone := make(chan struct{})
+		go func() {
+			defer close(clientDone)
+			transport := &http.Transport{DisableCompression: true, Protocols: &http.Protocols{}}
+			transport.Protocols.SetUnencryptedHTTP2(true)
+			defer transport.CloseIdleConnections()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+				"http://"+listener.Addr().String()+"/foo.Service/Echo", strings.NewReader("\x00\x00\x00\x00\x03abc"))
+			if !assert.NoError(t, err) {
+				return
+			}
+			req.Header = http.Header{"Content-Type": {"application/grpc"}, "Te": {"trailers"}, "User-Agent": {"test"}}
+			resp, err := transport.RoundTrip(req)
+			if !assert.NoError(t, err) {
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, "\x00\x00\x00\x00\x03abc\x00\x00\x00\x00\x01d", string(body))
+			assert.Equal(t, "0", resp.Trailer.Get("Grpc-Status"))
+			_ = resp.Body.Close()
+		}()
+		actual, err := serveRecording(context.Background(), listener, rec)
+		require.NoError(t, err)
+		<-clientDone
+		assert.Equal(t, []string{
+			"request body differs: recorded 14 bytes, got 8 bytes; first difference at offset 8",
+		}, rec.Request.Diff(actual))
+	})
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -22,9 +22,11 @@ import (
 )
 
 type CancelTiming struct {
-	BeforeCloseSend   *emptypb.Empty
-	AfterCloseSendMs  int
-	AfterNumResponses int
+	BeforeCloseSend         *emptypb.Empty
+	AfterCloseSendMs        int
+	AfterNumResponses       int
+	AfterNumRequests        int
+	AfterNumRequestsDelayMs int
 }
 
 // GetCancelTiming evaluates a Cancel setting and returns a struct with the
@@ -33,6 +35,8 @@ func GetCancelTiming(cancel *conformancev1.ClientCompatRequest_Cancel) (*CancelT
 	var beforeCloseSend *emptypb.Empty
 	afterCloseSendMs := -1
 	afterNumResponses := -1
+	afterNumRequests := -1
+	var afterNumRequestsDelayMs int
 	if cancel != nil {
 		switch cancelTiming := cancel.CancelTiming.(type) {
 		case *conformancev1.ClientCompatRequest_Cancel_BeforeCloseSend:
@@ -41,6 +45,9 @@ func GetCancelTiming(cancel *conformancev1.ClientCompatRequest_Cancel) (*CancelT
 			afterCloseSendMs = int(cancelTiming.AfterCloseSendMs)
 		case *conformancev1.ClientCompatRequest_Cancel_AfterNumResponses:
 			afterNumResponses = int(cancelTiming.AfterNumResponses)
+		case *conformancev1.ClientCompatRequest_Cancel_AfterNumRequests_:
+			afterNumRequests = int(cancelTiming.AfterNumRequests.GetNumRequests())
+			afterNumRequestsDelayMs = int(cancelTiming.AfterNumRequests.GetDelayMs())
 		case nil:
 			// If cancel is non-nil, but none of timing values are set, it should
 			// be treated as if afterCloseSendMs was set to 0
@@ -50,8 +57,10 @@ func GetCancelTiming(cancel *conformancev1.ClientCompatRequest_Cancel) (*CancelT
 		}
 	}
 	return &CancelTiming{
-		BeforeCloseSend:   beforeCloseSend,
-		AfterCloseSendMs:  afterCloseSendMs,
-		AfterNumResponses: afterNumResponses,
+		BeforeCloseSend:         beforeCloseSend,
+		AfterCloseSendMs:        afterCloseSendMs,
+		AfterNumResponses:       afterNumResponses,
+		AfterNumRequests:        afterNumRequests,
+		AfterNumRequestsDelayMs: afterNumRequestsDelayMs,
 	}, nil
 }
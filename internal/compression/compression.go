@@ -31,8 +31,50 @@ const (
 	Deflate  = "deflate"
 	Snappy   = "snappy"
 	Zstd     = "zstd"
+	LZ4      = "lz4"
 )
 
+// Name returns the IANA name for the given compression algorithm.
+func Name(compression conformancev1.Compression) (string, error) {
+	switch compression {
+	case conformancev1.Compression_COMPRESSION_IDENTITY:
+		return Identity, nil
+	case conformancev1.Compression_COMPRESSION_GZIP:
+		return Gzip, nil
+	case conformancev1.Compression_COMPRESSION_BR:
+		return Brotli, nil
+	case conformancev1.Compression_COMPRESSION_ZSTD:
+		return Zstd, nil
+	case conformancev1.Compression_COMPRESSION_DEFLATE:
+		return Deflate, nil
+	case conformancev1.Compression_COMPRESSION_SNAPPY:
+		return Snappy, nil
+	case conformancev1.Compression_COMPRESSION_LZ4:
+		return LZ4, nil
+	default:
+		return "", fmt.Errorf("unsupported compression scheme %v", compression)
+	}
+}
+
+// FromName returns the compression algorithm with the given IANA name. It
+// returns false if the name is not a supported compression algorithm.
+func FromName(name string) (conformancev1.Compression, bool) {
+	for _, compression := range []conformancev1.Compression{
+		conformancev1.Compression_COMPRESSION_IDENTITY,
+		conformancev1.Compression_COMPRESSION_GZIP,
+		conformancev1.Compression_COMPRESSION_BR,
+		conformancev1.Compression_COMPRESSION_ZSTD,
+		conformancev1.Compression_COMPRESSION_DEFLATE,
+		conformancev1.Compression_COMPRESSION_SNAPPY,
+		conformancev1.Compression_COMPRESSION_LZ4,
+	} {
+		if compressionName, _ := Name(compression); compressionName == name {
+			return compression, true
+		}
+	}
+	return conformancev1.Compression_COMPRESSION_UNSPECIFIED, false
+}
+
 // GetCompressor returns a compressor for the given compression algorithm.
 func GetCompressor(compression conformancev1.Compression) (connect.Compressor, error) {
 	switch compression {
@@ -48,6 +90,8 @@ func GetCompressor(compression conformancev1.Compression) (connect.Compressor, e
 		return NewDeflateCompressor(), nil
 	case conformancev1.Compression_COMPRESSION_SNAPPY:
 		return NewSnappyCompressor(), nil
+	case conformancev1.Compression_COMPRESSION_LZ4:
+		return NewLZ4Compressor(), nil
 	default:
 		return nil, fmt.Errorf("unsupported compression scheme %v", compression)
 	}
@@ -68,6 +112,8 @@ func GetDecompressor(compression conformancev1.Compression) (connect.Decompresso
 		return NewDeflateDecompressor(), nil
 	case conformancev1.Compression_COMPRESSION_SNAPPY:
 		return NewSnappyDecompressor(), nil
+	case conformancev1.Compression_COMPRESSION_LZ4:
+		return NewLZ4Decompressor(), nil
 	default:
 		return nil, fmt.Errorf("unsupported compression scheme %v", compression)
 	}
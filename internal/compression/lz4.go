@@ -0,0 +1,52 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/pierrec/lz4/v4"
+)
+
+// lz4Decompressor is a thin wrapper around an lz4 Reader.
+type lz4Decompressor struct {
+	reader *lz4.Reader
+}
+
+func (c *lz4Decompressor) Read(bytes []byte) (int, error) {
+	return c.reader.Read(bytes)
+}
+func (c *lz4Decompressor) Reset(rdr io.Reader) error {
+	c.reader.Reset(rdr)
+	// lz4's Reader does not return an error on Reset
+	return nil
+}
+func (c *lz4Decompressor) Close() error {
+	// lz4's Reader does not expose a Close function
+	return nil
+}
+
+// NewLZ4Decompressor returns a new LZ4 Decompressor.
+func NewLZ4Decompressor() connect.Decompressor {
+	return &lz4Decompressor{
+		reader: lz4.NewReader(nil),
+	}
+}
+
+// NewLZ4Compressor returns a new LZ4 Compressor.
+func NewLZ4Compressor() connect.Compressor {
+	return lz4.NewWriter(nil)
+}
@@ -26,4 +26,16 @@ const (
 	ConformanceServiceName = conformancev1connect.ConformanceServiceName
 	// The prefix for type URLs used in Any messages.
 	DefaultAnyResolverPrefix = "type.googleapis.com/"
+	// The prefix of a line that the reference server prints to stderr, when
+	// it shuts down, to report the number of connections that it accepted.
+	ConnectionCountPrefix = "connections accepted: "
+	// The prefix of a line that the reference server prints to stderr to
+	// report how long it took for an RPC with a timeout to be terminated.
+	// It is followed by the number of milliseconds, a space, the attempt
+	// number (starting at one), another space, and the name of the test case.
+	DeadlineElapsedPrefix = "deadline elapsed ms: "
+	// The request header that indicates that the server's observation of
+	// the RPC will be fetched via GetServerObservation. Servers only need
+	// to observe RPCs whose requests include it.
+	ExpectServerObservationHeader = "X-Expect-Server-Observation"
 )
@@ -127,6 +127,84 @@ type ClientCompatRequest struct {
 	// provided and valid so that the reference client knows how it
 	// should try to interpret the server's response.
 	RawRequest *RawHTTPRequest `protobuf:"bytes,20,opt,name=raw_request,json=rawRequest,proto3" json:"raw_request,omitempty"`
+	// Like fields 2 - 10 above, test suite YAML definitions should NOT set
+	// this field. It is automatically populated by the test runner.
+	//
+	// If non-empty, the compression algorithms that the client should
+	// advertise that it accepts for responses, in order of preference.
+	// These may not include the compression algorithm used for requests.
+	// If empty, the client should accept the same compression algorithm
+	// that it uses for requests.
+	AcceptCompressions []Compression `protobuf:"varint,21,rep,packed,name=accept_compressions,json=acceptCompressions,proto3,enum=connectrpc.conformance.v1.Compression" json:"accept_compressions,omitempty"`
+	// The following field is only used by the reference client. If
+	// you are implementing a client under test, you may ignore it.
+	//
+	// When non-empty, this should have no more entries than there are
+	// messages in the request stream. The first value is applied to the
+	// first request message, and so on. For each entry that is non-zero,
+	// the request_data field of the request message is padded with zeros
+	// until it is that many bytes. Since the padding is highly compressible,
+	// a compressed message will be much smaller on the wire than when it
+	// is decompressed. This is used to verify that servers enforce their
+	// message receive limit on the decompressed size of a message. Since
+	// the padded messages are held in memory, these sizes should be kept
+	// small. To send a message that inflates to far more, use a raw request
+	// with MessageContents.inflated_size instead.
+	InflatedRequestSizes []uint32 `protobuf:"varint,22,rep,packed,name=inflated_request_sizes,json=inflatedRequestSizes,proto3" json:"inflated_request_sizes,omitempty"`
+	// Like fields 2 - 10 above, test suite YAML definitions should NOT set
+	// this field. It is automatically populated by the test runner.
+	//
+	// If non-zero, indicates the maximum size in bytes for a request message.
+	// If asked to send anything larger, the client should fail the RPC with
+	// a RESOURCE_EXHAUSTED error, without sending that message to the server.
+	MessageSendLimit uint32 `protobuf:"varint,23,opt,name=message_send_limit,json=messageSendLimit,proto3" json:"message_send_limit,omitempty"`
+	// If present, the client should be configured to automatically retry
+	// a failed attempt of the RPC, after a backoff delay, if it failed with
+	// one of the retryable codes. This is modeled after the retry policy in
+	// gRPC's service config. For the gRPC protocol, each attempt after the
+	// first must include a "grpc-previous-rpc-attempts" request header, whose
+	// value is the number of preceding attempts.
+	//
+	// This applies only to unary RPCs.
+	RetryPolicy *ClientCompatRequest_RetryPolicy `protobuf:"bytes,24,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// If greater than one, the client should issue the RPC this many times,
+	// one after the other, using the same client. This verifies that the
+	// client reuses connections: when HTTP/2 or HTTP/3 is used, the RPCs
+	// are expected to all use the same connection. The client should then
+	// report the result of the final RPC, unless an earlier one fails, in
+	// which case it should report the result of the failed RPC instead.
+	SequentialRpcCount uint32 `protobuf:"varint,26,opt,name=sequential_rpc_count,json=sequentialRpcCount,proto3" json:"sequential_rpc_count,omitempty"`
+	// The following field is only used by the reference client. If
+	// you are implementing a client under test, you may ignore it.
+	//
+	// If true, after the RPC completes, the client should call the
+	// server's GetServerObservation method for this test case and
+	// report the result in the server_observation field of the result.
+	// The test runner also adds an "x-expect-server-observation" header
+	// to the request, so that the server knows to observe the RPC.
+	FetchServerObservation bool `protobuf:"varint,27,opt,name=fetch_server_observation,json=fetchServerObservation,proto3" json:"fetch_server_observation,omitempty"`
+	// Like fields 2 - 10 above, test suite YAML definitions should NOT set
+	// this field. It is automatically populated by the test runner.
+	//
+	// If non-zero, indicates the maximum total size in bytes of the response
+	// headers, and separately of the response trailers, that the client will
+	// accept. The size of each field is computed the same way as for HTTP/2's
+	// SETTINGS_MAX_HEADER_LIST_SIZE: the length of the name, plus the length
+	// of the value, plus 32. If the server sends anything larger, the client
+	// should fail the RPC with a RESOURCE_EXHAUSTED or INTERNAL error.
+	HeaderSizeLimit uint32 `protobuf:"varint,28,opt,name=header_size_limit,json=headerSizeLimit,proto3" json:"header_size_limit,omitempty"`
+	// The following field is only used by the reference client. If
+	// you are implementing a client under test, you may ignore it.
+	//
+	// If true, the request is sent by writing HTTP/2 frames directly to a new
+	// connection, like a frame script in a RawHTTPRequest, instead of using an
+	// HTTP/2 library. The frames are made from the request that the client
+	// would otherwise send. Unlike HTTP/2 libraries, this does not refuse to
+	// send request headers that are larger than the SETTINGS_MAX_HEADER_LIST_SIZE
+	// advertised by the server, so it can verify how the server handles them.
+	// The observed outcome is reported in ClientResponseResult.http2_outcome.
+	// This can only be used with HTTP/2, for unary and server-stream RPCs.
+	IgnoreMaxHeaderListSize bool `protobuf:"varint,29,opt,name=ignore_max_header_list_size,json=ignoreMaxHeaderListSize,proto3" json:"ignore_max_header_list_size,omitempty"`
 }
 
 func (x *ClientCompatRequest) Reset() {
@@ -301,6 +379,62 @@ func (x *ClientCompatRequest) GetRawRequest() *RawHTTPRequest {
 	return nil
 }
 
+func (x *ClientCompatRequest) GetAcceptCompressions() []Compression {
+	if x != nil {
+		return x.AcceptCompressions
+	}
+	return nil
+}
+
+func (x *ClientCompatRequest) GetInflatedRequestSizes() []uint32 {
+	if x != nil {
+		return x.InflatedRequestSizes
+	}
+	return nil
+}
+
+func (x *ClientCompatRequest) GetMessageSendLimit() uint32 {
+	if x != nil {
+		return x.MessageSendLimit
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest) GetRetryPolicy() *ClientCompatRequest_RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *ClientCompatRequest) GetSequentialRpcCount() uint32 {
+	if x != nil {
+		return x.SequentialRpcCount
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest) GetFetchServerObservation() bool {
+	if x != nil {
+		return x.FetchServerObservation
+	}
+	return false
+}
+
+func (x *ClientCompatRequest) GetHeaderSizeLimit() uint32 {
+	if x != nil {
+		return x.HeaderSizeLimit
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest) GetIgnoreMaxHeaderListSize() bool {
+	if x != nil {
+		return x.IgnoreMaxHeaderListSize
+	}
+	return false
+}
+
 // The outcome of one ClientCompatRequest.
 type ClientCompatResponse struct {
 	state         protoimpl.MessageState
@@ -437,6 +571,33 @@ type ClientResponseResult struct {
 	// If you are implementing a client-under-test, you should ignore this field
 	// and leave it unset.
 	Feedback []string `protobuf:"bytes,7,rep,name=feedback,proto3" json:"feedback,omitempty"`
+	// The following field is only set by the reference client, when the request
+	// is sent using an HTTP/2 frame script. It describes how the server handled
+	// the stream, as observed in the frames that it sent back.
+	// If you are implementing a client-under-test, you should ignore this field
+	// and leave it unset.
+	Http2Outcome *HTTP2StreamOutcome `protobuf:"bytes,8,opt,name=http2_outcome,json=http2Outcome,proto3" json:"http2_outcome,omitempty"`
+	// The following field is only set by the reference client, when the
+	// request asks it to fetch the server's observation of the RPC. It
+	// describes whether the server observed the RPC being canceled.
+	// If you are implementing a client-under-test, you should ignore this field
+	// and leave it unset.
+	ServerObservation *ServerObservation `protobuf:"bytes,9,opt,name=server_observation,json=serverObservation,proto3" json:"server_observation,omitempty"`
+	// The time at which each message in payloads was received, in milliseconds
+	// since the RPC was started. There should be one entry for each entry in
+	// payloads. This is used to verify that the client surfaces each message in
+	// a response stream to the application as soon as it arrives, instead of
+	// buffering the stream. Clients-under-test should populate this for
+	// server-stream and bidi-stream RPCs. It is only checked by test cases that
+	// set expect_incremental_responses.
+	PayloadReceiveTimesMs []uint32 `protobuf:"varint,10,rep,packed,name=payload_receive_times_ms,json=payloadReceiveTimesMs,proto3" json:"payload_receive_times_ms,omitempty"`
+	// The following field is only set by the reference client, for RPCs that
+	// have a timeout. It is the time, in milliseconds, between starting the
+	// RPC and its termination. This is used to verify that the server does not
+	// terminate an RPC too early when its deadline elapses.
+	// If you are implementing a client-under-test, you should ignore this field
+	// and leave it unset.
+	ElapsedMs *uint32 `protobuf:"varint,11,opt,name=elapsed_ms,json=elapsedMs,proto3,oneof" json:"elapsed_ms,omitempty"`
 }
 
 func (x *ClientResponseResult) Reset() {
@@ -520,6 +681,115 @@ func (x *ClientResponseResult) GetFeedback() []string {
 	return nil
 }
 
+func (x *ClientResponseResult) GetHttp2Outcome() *HTTP2StreamOutcome {
+	if x != nil {
+		return x.Http2Outcome
+	}
+	return nil
+}
+
+func (x *ClientResponseResult) GetServerObservation() *ServerObservation {
+	if x != nil {
+		return x.ServerObservation
+	}
+	return nil
+}
+
+func (x *ClientResponseResult) GetPayloadReceiveTimesMs() []uint32 {
+	if x != nil {
+		return x.PayloadReceiveTimesMs
+	}
+	return nil
+}
+
+func (x *ClientResponseResult) GetElapsedMs() uint32 {
+	if x != nil && x.ElapsedMs != nil {
+		return *x.ElapsedMs
+	}
+	return 0
+}
+
+// Describes how a server handled an HTTP/2 stream, as observed by the
+// reference client. This is used to verify the behavior of servers when
+// a request is sent using an HTTP/2 frame script.
+type HTTP2StreamOutcome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// True if the server ended the stream, by setting the END_STREAM flag.
+	EndStream bool `protobuf:"varint,1,opt,name=end_stream,json=endStream,proto3" json:"end_stream,omitempty"`
+	// If present, the server reset the stream with a RST_STREAM frame that
+	// had this error code.
+	RstStreamErrorCode *uint32 `protobuf:"varint,2,opt,name=rst_stream_error_code,json=rstStreamErrorCode,proto3,oneof" json:"rst_stream_error_code,omitempty"`
+	// If present, the server sent a GOAWAY frame with this error code.
+	GoAwayErrorCode *uint32 `protobuf:"varint,3,opt,name=go_away_error_code,json=goAwayErrorCode,proto3,oneof" json:"go_away_error_code,omitempty"`
+	// True if the server acknowledged a PING frame that was sent after the
+	// stream completed. This indicates that the server did not abandon the
+	// connection.
+	ConnectionAlive bool `protobuf:"varint,4,opt,name=connection_alive,json=connectionAlive,proto3" json:"connection_alive,omitempty"`
+}
+
+func (x *HTTP2StreamOutcome) Reset() {
+	*x = HTTP2StreamOutcome{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2StreamOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2StreamOutcome) ProtoMessage() {}
+
+func (x *HTTP2StreamOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2StreamOutcome.ProtoReflect.Descriptor instead.
+func (*HTTP2StreamOutcome) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HTTP2StreamOutcome) GetEndStream() bool {
+	if x != nil {
+		return x.EndStream
+	}
+	return false
+}
+
+func (x *HTTP2StreamOutcome) GetRstStreamErrorCode() uint32 {
+	if x != nil && x.RstStreamErrorCode != nil {
+		return *x.RstStreamErrorCode
+	}
+	return 0
+}
+
+func (x *HTTP2StreamOutcome) GetGoAwayErrorCode() uint32 {
+	if x != nil && x.GoAwayErrorCode != nil {
+		return *x.GoAwayErrorCode
+	}
+	return 0
+}
+
+func (x *HTTP2StreamOutcome) GetConnectionAlive() bool {
+	if x != nil {
+		return x.ConnectionAlive
+	}
+	return false
+}
+
 // The client is not able to fulfill the ClientCompatRequest. This may be due
 // to a runtime error or an unexpected internal error such as the requested protocol
 // not being supported. This is completely independent of the actual RPC invocation.
@@ -537,7 +807,7 @@ type ClientErrorResult struct {
 func (x *ClientErrorResult) Reset() {
 	*x = ClientErrorResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[3]
+		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -550,7 +820,7 @@ func (x *ClientErrorResult) String() string {
 func (*ClientErrorResult) ProtoMessage() {}
 
 func (x *ClientErrorResult) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[3]
+	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +833,7 @@ func (x *ClientErrorResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientErrorResult.ProtoReflect.Descriptor instead.
 func (*ClientErrorResult) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{3}
+	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ClientErrorResult) GetMessage() string {
@@ -603,7 +873,7 @@ type WireDetails struct {
 func (x *WireDetails) Reset() {
 	*x = WireDetails{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[4]
+		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -616,7 +886,7 @@ func (x *WireDetails) String() string {
 func (*WireDetails) ProtoMessage() {}
 
 func (x *WireDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[4]
+	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +899,7 @@ func (x *WireDetails) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WireDetails.ProtoReflect.Descriptor instead.
 func (*WireDetails) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{4}
+	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *WireDetails) GetActualStatusCode() int32 {
@@ -676,13 +946,14 @@ type ClientCompatRequest_Cancel struct {
 	//	*ClientCompatRequest_Cancel_BeforeCloseSend
 	//	*ClientCompatRequest_Cancel_AfterCloseSendMs
 	//	*ClientCompatRequest_Cancel_AfterNumResponses
+	//	*ClientCompatRequest_Cancel_AfterNumRequests_
 	CancelTiming isClientCompatRequest_Cancel_CancelTiming `protobuf_oneof:"cancel_timing"`
 }
 
 func (x *ClientCompatRequest_Cancel) Reset() {
 	*x = ClientCompatRequest_Cancel{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5]
+		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -695,7 +966,7 @@ func (x *ClientCompatRequest_Cancel) String() string {
 func (*ClientCompatRequest_Cancel) ProtoMessage() {}
 
 func (x *ClientCompatRequest_Cancel) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5]
+	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,6 +1010,13 @@ func (x *ClientCompatRequest_Cancel) GetAfterNumResponses() uint32 {
 	return 0
 }
 
+func (x *ClientCompatRequest_Cancel) GetAfterNumRequests() *ClientCompatRequest_Cancel_AfterNumRequests {
+	if x, ok := x.GetCancelTiming().(*ClientCompatRequest_Cancel_AfterNumRequests_); ok {
+		return x.AfterNumRequests
+	}
+	return nil
+}
+
 type isClientCompatRequest_Cancel_CancelTiming interface {
 	isClientCompatRequest_Cancel_CancelTiming()
 }
@@ -782,12 +1060,175 @@ type ClientCompatRequest_Cancel_AfterNumResponses struct {
 	AfterNumResponses uint32 `protobuf:"varint,3,opt,name=after_num_responses,json=afterNumResponses,proto3,oneof"`
 }
 
+type ClientCompatRequest_Cancel_AfterNumRequests_ struct {
+	// When present, the client should cancel in the middle of
+	// the request stream, while it is still sending. It should
+	// not send any request messages after canceling, and it
+	// should report the ones it did not send in the result's
+	// num_unsent_requests field.
+	//
+	// This applies only to client and bidi stream RPCs.
+	AfterNumRequests *ClientCompatRequest_Cancel_AfterNumRequests `protobuf:"bytes,4,opt,name=after_num_requests,json=afterNumRequests,proto3,oneof"`
+}
+
 func (*ClientCompatRequest_Cancel_BeforeCloseSend) isClientCompatRequest_Cancel_CancelTiming() {}
 
 func (*ClientCompatRequest_Cancel_AfterCloseSendMs) isClientCompatRequest_Cancel_CancelTiming() {}
 
 func (*ClientCompatRequest_Cancel_AfterNumResponses) isClientCompatRequest_Cancel_CancelTiming() {}
 
+func (*ClientCompatRequest_Cancel_AfterNumRequests_) isClientCompatRequest_Cancel_CancelTiming() {}
+
+type ClientCompatRequest_RetryPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of attempts, including the original attempt.
+	// This will be greater than one.
+	MaxAttempts uint32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	// The backoff delay before the first retry is chosen at random,
+	// between zero and this value.
+	InitialBackoffMs uint32 `protobuf:"varint,2,opt,name=initial_backoff_ms,json=initialBackoffMs,proto3" json:"initial_backoff_ms,omitempty"`
+	// The maximum limit for the backoff delay.
+	MaxBackoffMs uint32 `protobuf:"varint,3,opt,name=max_backoff_ms,json=maxBackoffMs,proto3" json:"max_backoff_ms,omitempty"`
+	// After each retry, the upper bound for the backoff delay is
+	// multiplied by this value, up to max_backoff_ms.
+	BackoffMultiplier float32 `protobuf:"fixed32,4,opt,name=backoff_multiplier,json=backoffMultiplier,proto3" json:"backoff_multiplier,omitempty"`
+	// The status codes that indicate an attempt may be retried. An
+	// attempt that fails with any other code is not retried.
+	RetryableCodes []Code `protobuf:"varint,5,rep,packed,name=retryable_codes,json=retryableCodes,proto3,enum=connectrpc.conformance.v1.Code" json:"retryable_codes,omitempty"`
+}
+
+func (x *ClientCompatRequest_RetryPolicy) Reset() {
+	*x = ClientCompatRequest_RetryPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientCompatRequest_RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCompatRequest_RetryPolicy) ProtoMessage() {}
+
+func (x *ClientCompatRequest_RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCompatRequest_RetryPolicy.ProtoReflect.Descriptor instead.
+func (*ClientCompatRequest_RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ClientCompatRequest_RetryPolicy) GetMaxAttempts() uint32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest_RetryPolicy) GetInitialBackoffMs() uint32 {
+	if x != nil {
+		return x.InitialBackoffMs
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest_RetryPolicy) GetMaxBackoffMs() uint32 {
+	if x != nil {
+		return x.MaxBackoffMs
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest_RetryPolicy) GetBackoffMultiplier() float32 {
+	if x != nil {
+		return x.BackoffMultiplier
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest_RetryPolicy) GetRetryableCodes() []Code {
+	if x != nil {
+		return x.RetryableCodes
+	}
+	return nil
+}
+
+// Describes cancellation in the middle of a request stream.
+type ClientCompatRequest_Cancel_AfterNumRequests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The client should cancel right after sending this number
+	// of request messages. This will be greater than zero and
+	// less than the number of request messages.
+	NumRequests uint32 `protobuf:"varint,1,opt,name=num_requests,json=numRequests,proto3" json:"num_requests,omitempty"`
+	// When non-zero, the client should delay for this many
+	// milliseconds after sending the above number of request
+	// messages and then cancel.
+	DelayMs uint32 `protobuf:"varint,2,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+}
+
+func (x *ClientCompatRequest_Cancel_AfterNumRequests) Reset() {
+	*x = ClientCompatRequest_Cancel_AfterNumRequests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientCompatRequest_Cancel_AfterNumRequests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCompatRequest_Cancel_AfterNumRequests) ProtoMessage() {}
+
+func (x *ClientCompatRequest_Cancel_AfterNumRequests) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_client_compat_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCompatRequest_Cancel_AfterNumRequests.ProtoReflect.Descriptor instead.
+func (*ClientCompatRequest_Cancel_AfterNumRequests) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *ClientCompatRequest_Cancel_AfterNumRequests) GetNumRequests() uint32 {
+	if x != nil {
+		return x.NumRequests
+	}
+	return 0
+}
+
+func (x *ClientCompatRequest_Cancel_AfterNumRequests) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
 var File_connectrpc_conformance_v1_client_compat_proto protoreflect.FileDescriptor
 
 var file_connectrpc_conformance_v1_client_compat_proto_rawDesc = []byte{
@@ -806,7 +1247,7 @@ var file_connectrpc_conformance_v1_client_compat_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xa7, 0x0a, 0x0a, 0x13, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x22, 0xe3, 0x11, 0x0a, 0x13, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70,
 	0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x73,
 	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65,
 	0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x0c, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x76,
@@ -874,103 +1315,196 @@ var file_connectrpc_conformance_v1_client_compat_proto_rawDesc = []byte{
 	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
 	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x72, 0x61, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0xc2, 0x01, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x44, 0x0a, 0x11, 0x62,
-	0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00,
-	0x52, 0x0f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x6e,
-	0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65,
-	0x5f, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
-	0x52, 0x10, 0x61, 0x66, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65, 0x6e, 0x64,
-	0x4d, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x5f,
-	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48,
-	0x00, 0x52, 0x11, 0x61, 0x66, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x74,
-	0x69, 0x6d, 0x69, 0x6e, 0x67, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42, 0x0d, 0x0a, 0x0b,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x14,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x4d, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x12, 0x57, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x43, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x69, 0x6e, 0x66,
+	0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6c, 0x61,
+	0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x73, 0x12,
+	0x2c, 0x0a, 0x12, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x5f,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x5d, 0x0a,
+	0x0c, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
 	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x44, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2c, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0b, 0x72, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x30, 0x0a, 0x14,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x72, 0x70, 0x63, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x70, 0x63, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x38,
+	0x0a, 0x18, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x16, 0x66, 0x65, 0x74, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x1c, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x3c, 0x0a, 0x1b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6d,
+	0x61, 0x78, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x4d, 0x61, 0x78, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x1a, 0x8c, 0x03, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x44, 0x0a,
+	0x11, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x73, 0x65,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x48, 0x00, 0x52, 0x0f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53,
+	0x65, 0x6e, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x5f, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x00, 0x52, 0x10, 0x61, 0x66, 0x74, 0x65, 0x72, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x53, 0x65,
+	0x6e, 0x64, 0x4d, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x75,
+	0x6d, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x00, 0x52, 0x11, 0x61, 0x66, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x76, 0x0a, 0x12, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f,
+	0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x46, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x2e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4e,
+	0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x48, 0x00, 0x52, 0x10, 0x61, 0x66,
+	0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x1a, 0x50,
+	0x0a, 0x10, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73,
+	0x42, 0x0f, 0x0a, 0x0d, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x69, 0x6e,
+	0x67, 0x1a, 0xfd, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65,
+	0x6d, 0x70, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f,
+	0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66,
+	0x4d, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66,
+	0x66, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42,
+	0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x62, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x0f, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64,
+	0x65, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x6f, 0x64, 0x65,
+	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x42, 0x09, 0x0a,
+	0x07, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4d, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
 	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x22, 0xc7, 0x03, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x49, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f,
-	0x61, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x48, 0x00, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0xe4, 0x05, 0x0a,
+	0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x49, 0x0a, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x36,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72,
+	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x75, 0x6d, 0x5f, 0x75, 0x6e,
+	0x73, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x10, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x00, 0x52, 0x0e, 0x68, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f,
+	0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63,
+	0x6b, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x65, 0x64, 0x62, 0x61, 0x63,
+	0x6b, 0x12, 0x52, 0x0a, 0x0d, 0x68, 0x74, 0x74, 0x70, 0x32, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x0c, 0x68, 0x74, 0x74, 0x70, 0x32, 0x4f, 0x75,
+	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x5b, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
+	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x18, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x5f, 0x6d, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x15, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x4d, 0x73, 0x12, 0x22, 0x0a, 0x0a, 0x65,
+	0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x01, 0x52, 0x09, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73, 0x88, 0x01, 0x01, 0x42,
+	0x13, 0x0a, 0x11, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64,
+	0x5f, 0x6d, 0x73, 0x22, 0xf9, 0x01, 0x0a, 0x12, 0x48, 0x54, 0x54, 0x50, 0x32, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e,
+	0x64, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x65, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x36, 0x0a, 0x15, 0x72, 0x73, 0x74,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x12, 0x72, 0x73, 0x74, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x30, 0x0a, 0x12, 0x67, 0x6f, 0x5f, 0x61, 0x77, 0x61, 0x79, 0x5f, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52,
+	0x0f, 0x67, 0x6f, 0x41, 0x77, 0x61, 0x79, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x42, 0x18,
+	0x0a, 0x16, 0x5f, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x67, 0x6f, 0x5f,
+	0x61, 0x77, 0x61, 0x79, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22,
+	0x2d, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xae,
+	0x02, 0x0a, 0x0b, 0x57, 0x69, 0x72, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2c,
+	0x0a, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x61, 0x63, 0x74, 0x75,
+	0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x11,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x72, 0x61,
+	0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x61,
+	0x77, 0x12, 0x53, 0x0a, 0x14, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x68, 0x74, 0x74, 0x70,
+	0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x12, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72,
+	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x17, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c,
+	0x5f, 0x67, 0x72, 0x70, 0x63, 0x77, 0x65, 0x62, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x15, 0x61, 0x63, 0x74, 0x75, 0x61,
+	0x6c, 0x47, 0x72, 0x70, 0x63, 0x77, 0x65, 0x62, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73,
+	0x88, 0x01, 0x01, 0x42, 0x1a, 0x0a, 0x18, 0x5f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x67,
+	0x72, 0x70, 0x63, 0x77, 0x65, 0x62, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x42,
+	0x92, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
 	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6e, 0x75,
-	0x6d, 0x5f, 0x75, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6e, 0x75, 0x6d, 0x55, 0x6e, 0x73, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x10, 0x68, 0x74,
-	0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0e, 0x68, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x65,
-	0x64, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x65,
-	0x64, 0x62, 0x61, 0x63, 0x6b, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x2d, 0x0a, 0x11, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xae, 0x02, 0x0a, 0x0b, 0x57, 0x69,
-	0x72, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x63, 0x74,
-	0x75, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x72, 0x61, 0x77, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0f, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x61, 0x77, 0x12, 0x53, 0x0a, 0x14,
-	0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x74, 0x72, 0x61, 0x69,
-	0x6c, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x12, 0x61,
-	0x63, 0x74, 0x75, 0x61, 0x6c, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72,
-	0x73, 0x12, 0x3b, 0x0a, 0x17, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x67, 0x72, 0x70, 0x63,
-	0x77, 0x65, 0x62, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x48, 0x00, 0x52, 0x15, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x47, 0x72, 0x70, 0x63,
-	0x77, 0x65, 0x62, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01, 0x42, 0x1a,
-	0x0a, 0x18, 0x5f, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x77, 0x65,
-	0x62, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x42, 0x92, 0x02, 0x0a, 0x1d, 0x63,
-	0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x11, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
-	0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43,
-	0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a,
-	0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x31, 0x42, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f,
+	0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x76, 0x31,
+	0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c,
+	0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02,
+	0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -985,53 +1519,64 @@ func file_connectrpc_conformance_v1_client_compat_proto_rawDescGZIP() []byte {
 	return file_connectrpc_conformance_v1_client_compat_proto_rawDescData
 }
 
-var file_connectrpc_conformance_v1_client_compat_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_connectrpc_conformance_v1_client_compat_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_connectrpc_conformance_v1_client_compat_proto_goTypes = []interface{}{
-	(*ClientCompatRequest)(nil),        // 0: connectrpc.conformance.v1.ClientCompatRequest
-	(*ClientCompatResponse)(nil),       // 1: connectrpc.conformance.v1.ClientCompatResponse
-	(*ClientResponseResult)(nil),       // 2: connectrpc.conformance.v1.ClientResponseResult
-	(*ClientErrorResult)(nil),          // 3: connectrpc.conformance.v1.ClientErrorResult
-	(*WireDetails)(nil),                // 4: connectrpc.conformance.v1.WireDetails
-	(*ClientCompatRequest_Cancel)(nil), // 5: connectrpc.conformance.v1.ClientCompatRequest.Cancel
-	(HTTPVersion)(0),                   // 6: connectrpc.conformance.v1.HTTPVersion
-	(Protocol)(0),                      // 7: connectrpc.conformance.v1.Protocol
-	(Codec)(0),                         // 8: connectrpc.conformance.v1.Codec
-	(Compression)(0),                   // 9: connectrpc.conformance.v1.Compression
-	(*TLSCreds)(nil),                   // 10: connectrpc.conformance.v1.TLSCreds
-	(StreamType)(0),                    // 11: connectrpc.conformance.v1.StreamType
-	(*Header)(nil),                     // 12: connectrpc.conformance.v1.Header
-	(*anypb.Any)(nil),                  // 13: google.protobuf.Any
-	(*RawHTTPRequest)(nil),             // 14: connectrpc.conformance.v1.RawHTTPRequest
-	(*ConformancePayload)(nil),         // 15: connectrpc.conformance.v1.ConformancePayload
-	(*Error)(nil),                      // 16: connectrpc.conformance.v1.Error
-	(*structpb.Struct)(nil),            // 17: google.protobuf.Struct
-	(*emptypb.Empty)(nil),              // 18: google.protobuf.Empty
+	(*ClientCompatRequest)(nil),                         // 0: connectrpc.conformance.v1.ClientCompatRequest
+	(*ClientCompatResponse)(nil),                        // 1: connectrpc.conformance.v1.ClientCompatResponse
+	(*ClientResponseResult)(nil),                        // 2: connectrpc.conformance.v1.ClientResponseResult
+	(*HTTP2StreamOutcome)(nil),                          // 3: connectrpc.conformance.v1.HTTP2StreamOutcome
+	(*ClientErrorResult)(nil),                           // 4: connectrpc.conformance.v1.ClientErrorResult
+	(*WireDetails)(nil),                                 // 5: connectrpc.conformance.v1.WireDetails
+	(*ClientCompatRequest_Cancel)(nil),                  // 6: connectrpc.conformance.v1.ClientCompatRequest.Cancel
+	(*ClientCompatRequest_RetryPolicy)(nil),             // 7: connectrpc.conformance.v1.ClientCompatRequest.RetryPolicy
+	(*ClientCompatRequest_Cancel_AfterNumRequests)(nil), // 8: connectrpc.conformance.v1.ClientCompatRequest.Cancel.AfterNumRequests
+	(HTTPVersion)(0),                                    // 9: connectrpc.conformance.v1.HTTPVersion
+	(Protocol)(0),                                       // 10: connectrpc.conformance.v1.Protocol
+	(Codec)(0),                                          // 11: connectrpc.conformance.v1.Codec
+	(Compression)(0),                                    // 12: connectrpc.conformance.v1.Compression
+	(*TLSCreds)(nil),                                    // 13: connectrpc.conformance.v1.TLSCreds
+	(StreamType)(0),                                     // 14: connectrpc.conformance.v1.StreamType
+	(*Header)(nil),                                      // 15: connectrpc.conformance.v1.Header
+	(*anypb.Any)(nil),                                   // 16: google.protobuf.Any
+	(*RawHTTPRequest)(nil),                              // 17: connectrpc.conformance.v1.RawHTTPRequest
+	(*ConformancePayload)(nil),                          // 18: connectrpc.conformance.v1.ConformancePayload
+	(*Error)(nil),                                       // 19: connectrpc.conformance.v1.Error
+	(*ServerObservation)(nil),                           // 20: connectrpc.conformance.v1.ServerObservation
+	(*structpb.Struct)(nil),                             // 21: google.protobuf.Struct
+	(*emptypb.Empty)(nil),                               // 22: google.protobuf.Empty
+	(Code)(0),                                           // 23: connectrpc.conformance.v1.Code
 }
 var file_connectrpc_conformance_v1_client_compat_proto_depIdxs = []int32{
-	6,  // 0: connectrpc.conformance.v1.ClientCompatRequest.http_version:type_name -> connectrpc.conformance.v1.HTTPVersion
-	7,  // 1: connectrpc.conformance.v1.ClientCompatRequest.protocol:type_name -> connectrpc.conformance.v1.Protocol
-	8,  // 2: connectrpc.conformance.v1.ClientCompatRequest.codec:type_name -> connectrpc.conformance.v1.Codec
-	9,  // 3: connectrpc.conformance.v1.ClientCompatRequest.compression:type_name -> connectrpc.conformance.v1.Compression
-	10, // 4: connectrpc.conformance.v1.ClientCompatRequest.client_tls_creds:type_name -> connectrpc.conformance.v1.TLSCreds
-	11, // 5: connectrpc.conformance.v1.ClientCompatRequest.stream_type:type_name -> connectrpc.conformance.v1.StreamType
-	12, // 6: connectrpc.conformance.v1.ClientCompatRequest.request_headers:type_name -> connectrpc.conformance.v1.Header
-	13, // 7: connectrpc.conformance.v1.ClientCompatRequest.request_messages:type_name -> google.protobuf.Any
-	5,  // 8: connectrpc.conformance.v1.ClientCompatRequest.cancel:type_name -> connectrpc.conformance.v1.ClientCompatRequest.Cancel
-	14, // 9: connectrpc.conformance.v1.ClientCompatRequest.raw_request:type_name -> connectrpc.conformance.v1.RawHTTPRequest
-	2,  // 10: connectrpc.conformance.v1.ClientCompatResponse.response:type_name -> connectrpc.conformance.v1.ClientResponseResult
-	3,  // 11: connectrpc.conformance.v1.ClientCompatResponse.error:type_name -> connectrpc.conformance.v1.ClientErrorResult
-	12, // 12: connectrpc.conformance.v1.ClientResponseResult.response_headers:type_name -> connectrpc.conformance.v1.Header
-	15, // 13: connectrpc.conformance.v1.ClientResponseResult.payloads:type_name -> connectrpc.conformance.v1.ConformancePayload
-	16, // 14: connectrpc.conformance.v1.ClientResponseResult.error:type_name -> connectrpc.conformance.v1.Error
-	12, // 15: connectrpc.conformance.v1.ClientResponseResult.response_trailers:type_name -> connectrpc.conformance.v1.Header
-	17, // 16: connectrpc.conformance.v1.WireDetails.connect_error_raw:type_name -> google.protobuf.Struct
-	12, // 17: connectrpc.conformance.v1.WireDetails.actual_http_trailers:type_name -> connectrpc.conformance.v1.Header
-	18, // 18: connectrpc.conformance.v1.ClientCompatRequest.Cancel.before_close_send:type_name -> google.protobuf.Empty
-	19, // [19:19] is the sub-list for method output_type
-	19, // [19:19] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	9,  // 0: connectrpc.conformance.v1.ClientCompatRequest.http_version:type_name -> connectrpc.conformance.v1.HTTPVersion
+	10, // 1: connectrpc.conformance.v1.ClientCompatRequest.protocol:type_name -> connectrpc.conformance.v1.Protocol
+	11, // 2: connectrpc.conformance.v1.ClientCompatRequest.codec:type_name -> connectrpc.conformance.v1.Codec
+	12, // 3: connectrpc.conformance.v1.ClientCompatRequest.compression:type_name -> connectrpc.conformance.v1.Compression
+	13, // 4: connectrpc.conformance.v1.ClientCompatRequest.client_tls_creds:type_name -> connectrpc.conformance.v1.TLSCreds
+	14, // 5: connectrpc.conformance.v1.ClientCompatRequest.stream_type:type_name -> connectrpc.conformance.v1.StreamType
+	15, // 6: connectrpc.conformance.v1.ClientCompatRequest.request_headers:type_name -> connectrpc.conformance.v1.Header
+	16, // 7: connectrpc.conformance.v1.ClientCompatRequest.request_messages:type_name -> google.protobuf.Any
+	6,  // 8: connectrpc.conformance.v1.ClientCompatRequest.cancel:type_name -> connectrpc.conformance.v1.ClientCompatRequest.Cancel
+	17, // 9: connectrpc.conformance.v1.ClientCompatRequest.raw_request:type_name -> connectrpc.conformance.v1.RawHTTPRequest
+	12, // 10: connectrpc.conformance.v1.ClientCompatRequest.accept_compressions:type_name -> connectrpc.conformance.v1.Compression
+	7,  // 11: connectrpc.conformance.v1.ClientCompatRequest.retry_policy:type_name -> connectrpc.conformance.v1.ClientCompatRequest.RetryPolicy
+	2,  // 12: connectrpc.conformance.v1.ClientCompatResponse.response:type_name -> connectrpc.conformance.v1.ClientResponseResult
+	4,  // 13: connectrpc.conformance.v1.ClientCompatResponse.error:type_name -> connectrpc.conformance.v1.ClientErrorResult
+	15, // 14: connectrpc.conformance.v1.ClientResponseResult.response_headers:type_name -> connectrpc.conformance.v1.Header
+	18, // 15: connectrpc.conformance.v1.ClientResponseResult.payloads:type_name -> connectrpc.conformance.v1.ConformancePayload
+	19, // 16: connectrpc.conformance.v1.ClientResponseResult.error:type_name -> connectrpc.conformance.v1.Error
+	15, // 17: connectrpc.conformance.v1.ClientResponseResult.response_trailers:type_name -> connectrpc.conformance.v1.Header
+	3,  // 18: connectrpc.conformance.v1.ClientResponseResult.http2_outcome:type_name -> connectrpc.conformance.v1.HTTP2StreamOutcome
+	20, // 19: connectrpc.conformance.v1.ClientResponseResult.server_observation:type_name -> connectrpc.conformance.v1.ServerObservation
+	21, // 20: connectrpc.conformance.v1.WireDetails.connect_error_raw:type_name -> google.protobuf.Struct
+	15, // 21: connectrpc.conformance.v1.WireDetails.actual_http_trailers:type_name -> connectrpc.conformance.v1.Header
+	22, // 22: connectrpc.conformance.v1.ClientCompatRequest.Cancel.before_close_send:type_name -> google.protobuf.Empty
+	8,  // 23: connectrpc.conformance.v1.ClientCompatRequest.Cancel.after_num_requests:type_name -> connectrpc.conformance.v1.ClientCompatRequest.Cancel.AfterNumRequests
+	23, // 24: connectrpc.conformance.v1.ClientCompatRequest.RetryPolicy.retryable_codes:type_name -> connectrpc.conformance.v1.Code
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_connectrpc_conformance_v1_client_compat_proto_init() }
@@ -1079,7 +1624,7 @@ func file_connectrpc_conformance_v1_client_compat_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_client_compat_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientErrorResult); i {
+			switch v := v.(*HTTP2StreamOutcome); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1091,7 +1636,7 @@ func file_connectrpc_conformance_v1_client_compat_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_client_compat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WireDetails); i {
+			switch v := v.(*ClientErrorResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1103,6 +1648,18 @@ func file_connectrpc_conformance_v1_client_compat_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WireDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_client_compat_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientCompatRequest_Cancel); i {
 			case 0:
 				return &v.state
@@ -1114,6 +1671,30 @@ func file_connectrpc_conformance_v1_client_compat_proto_init() {
 				return nil
 			}
 		}
+		file_connectrpc_conformance_v1_client_compat_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientCompatRequest_RetryPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_client_compat_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientCompatRequest_Cancel_AfterNumRequests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[1].OneofWrappers = []interface{}{
@@ -1121,11 +1702,13 @@ func file_connectrpc_conformance_v1_client_compat_proto_init() {
 		(*ClientCompatResponse_Error)(nil),
 	}
 	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[2].OneofWrappers = []interface{}{}
-	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[4].OneofWrappers = []interface{}{}
-	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_client_compat_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*ClientCompatRequest_Cancel_BeforeCloseSend)(nil),
 		(*ClientCompatRequest_Cancel_AfterCloseSendMs)(nil),
 		(*ClientCompatRequest_Cancel_AfterNumResponses)(nil),
+		(*ClientCompatRequest_Cancel_AfterNumRequests_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1133,7 +1716,7 @@ func file_connectrpc_conformance_v1_client_compat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_connectrpc_conformance_v1_client_compat_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
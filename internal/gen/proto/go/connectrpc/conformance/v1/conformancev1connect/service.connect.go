@@ -65,6 +65,9 @@ const (
 	// ConformanceServiceIdempotentUnaryProcedure is the fully-qualified name of the
 	// ConformanceService's IdempotentUnary RPC.
 	ConformanceServiceIdempotentUnaryProcedure = "/connectrpc.conformance.v1.ConformanceService/IdempotentUnary"
+	// ConformanceServiceGetServerObservationProcedure is the fully-qualified name of the
+	// ConformanceService's GetServerObservation RPC.
+	ConformanceServiceGetServerObservationProcedure = "/connectrpc.conformance.v1.ConformanceService/GetServerObservation"
 )
 
 // ConformanceServiceClient is a client for the connectrpc.conformance.v1.ConformanceService
@@ -180,6 +183,26 @@ type ConformanceServiceClient interface {
 	// Implementations should use an HTTP GET when invoking this endpoint and
 	// leverage query parameters to send data.
 	IdempotentUnary(context.Context, *connect.Request[v1.IdempotentUnaryRequest]) (*connect.Response[v1.IdempotentUnaryResponse], error)
+	// A unary operation that returns what the server observed while handling
+	// an earlier RPC. The earlier RPC is identified by the value of its
+	// "x-test-case-name" request header, which is given in the request.
+	//
+	// Servers should record, for every RPC they handle whose request includes
+	// an "x-expect-server-observation" header, whether the handler's context
+	// was canceled before the handler returned, either because the client
+	// canceled the RPC or because its deadline elapsed. Other RPCs need not
+	// be observed. If there were multiple RPCs for the same test case, the
+	// most recent one is reported. Servers may forget an observation once it
+	// has been reported, or if it isn't requested soon after the RPC ends.
+	//
+	// If the server has not yet received an RPC for the given test case, or if
+	// that RPC is still in progress and its context has not been canceled, the
+	// server should wait until that changes before responding. If that does not
+	// happen before this RPC's own deadline, the RPC fails.
+	//
+	// Servers need only implement this method if their configuration
+	// indicates support for server observations.
+	GetServerObservation(context.Context, *connect.Request[v1.GetServerObservationRequest]) (*connect.Response[v1.GetServerObservationResponse], error)
 }
 
 // NewConformanceServiceClient constructs a client for the
@@ -231,17 +254,24 @@ func NewConformanceServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
+		getServerObservation: connect.NewClient[v1.GetServerObservationRequest, v1.GetServerObservationResponse](
+			httpClient,
+			baseURL+ConformanceServiceGetServerObservationProcedure,
+			connect.WithSchema(conformanceServiceMethods.ByName("GetServerObservation")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // conformanceServiceClient implements ConformanceServiceClient.
 type conformanceServiceClient struct {
-	unary           *connect.Client[v1.UnaryRequest, v1.UnaryResponse]
-	serverStream    *connect.Client[v1.ServerStreamRequest, v1.ServerStreamResponse]
-	clientStream    *connect.Client[v1.ClientStreamRequest, v1.ClientStreamResponse]
-	bidiStream      *connect.Client[v1.BidiStreamRequest, v1.BidiStreamResponse]
-	unimplemented   *connect.Client[v1.UnimplementedRequest, v1.UnimplementedResponse]
-	idempotentUnary *connect.Client[v1.IdempotentUnaryRequest, v1.IdempotentUnaryResponse]
+	unary                *connect.Client[v1.UnaryRequest, v1.UnaryResponse]
+	serverStream         *connect.Client[v1.ServerStreamRequest, v1.ServerStreamResponse]
+	clientStream         *connect.Client[v1.ClientStreamRequest, v1.ClientStreamResponse]
+	bidiStream           *connect.Client[v1.BidiStreamRequest, v1.BidiStreamResponse]
+	unimplemented        *connect.Client[v1.UnimplementedRequest, v1.UnimplementedResponse]
+	idempotentUnary      *connect.Client[v1.IdempotentUnaryRequest, v1.IdempotentUnaryResponse]
+	getServerObservation *connect.Client[v1.GetServerObservationRequest, v1.GetServerObservationResponse]
 }
 
 // Unary calls connectrpc.conformance.v1.ConformanceService.Unary.
@@ -274,6 +304,11 @@ func (c *conformanceServiceClient) IdempotentUnary(ctx context.Context, req *con
 	return c.idempotentUnary.CallUnary(ctx, req)
 }
 
+// GetServerObservation calls connectrpc.conformance.v1.ConformanceService.GetServerObservation.
+func (c *conformanceServiceClient) GetServerObservation(ctx context.Context, req *connect.Request[v1.GetServerObservationRequest]) (*connect.Response[v1.GetServerObservationResponse], error) {
+	return c.getServerObservation.CallUnary(ctx, req)
+}
+
 // ConformanceServiceHandler is an implementation of the
 // connectrpc.conformance.v1.ConformanceService service.
 type ConformanceServiceHandler interface {
@@ -387,6 +422,26 @@ type ConformanceServiceHandler interface {
 	// Implementations should use an HTTP GET when invoking this endpoint and
 	// leverage query parameters to send data.
 	IdempotentUnary(context.Context, *connect.Request[v1.IdempotentUnaryRequest]) (*connect.Response[v1.IdempotentUnaryResponse], error)
+	// A unary operation that returns what the server observed while handling
+	// an earlier RPC. The earlier RPC is identified by the value of its
+	// "x-test-case-name" request header, which is given in the request.
+	//
+	// Servers should record, for every RPC they handle whose request includes
+	// an "x-expect-server-observation" header, whether the handler's context
+	// was canceled before the handler returned, either because the client
+	// canceled the RPC or because its deadline elapsed. Other RPCs need not
+	// be observed. If there were multiple RPCs for the same test case, the
+	// most recent one is reported. Servers may forget an observation once it
+	// has been reported, or if it isn't requested soon after the RPC ends.
+	//
+	// If the server has not yet received an RPC for the given test case, or if
+	// that RPC is still in progress and its context has not been canceled, the
+	// server should wait until that changes before responding. If that does not
+	// happen before this RPC's own deadline, the RPC fails.
+	//
+	// Servers need only implement this method if their configuration
+	// indicates support for server observations.
+	GetServerObservation(context.Context, *connect.Request[v1.GetServerObservationRequest]) (*connect.Response[v1.GetServerObservationResponse], error)
 }
 
 // NewConformanceServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -433,6 +488,12 @@ func NewConformanceServiceHandler(svc ConformanceServiceHandler, opts ...connect
 		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
+	conformanceServiceGetServerObservationHandler := connect.NewUnaryHandler(
+		ConformanceServiceGetServerObservationProcedure,
+		svc.GetServerObservation,
+		connect.WithSchema(conformanceServiceMethods.ByName("GetServerObservation")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/connectrpc.conformance.v1.ConformanceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ConformanceServiceUnaryProcedure:
@@ -447,6 +508,8 @@ func NewConformanceServiceHandler(svc ConformanceServiceHandler, opts ...connect
 			conformanceServiceUnimplementedHandler.ServeHTTP(w, r)
 		case ConformanceServiceIdempotentUnaryProcedure:
 			conformanceServiceIdempotentUnaryHandler.ServeHTTP(w, r)
+		case ConformanceServiceGetServerObservationProcedure:
+			conformanceServiceGetServerObservationHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -479,3 +542,7 @@ func (UnimplementedConformanceServiceHandler) Unimplemented(context.Context, *co
 func (UnimplementedConformanceServiceHandler) IdempotentUnary(context.Context, *connect.Request[v1.IdempotentUnaryRequest]) (*connect.Response[v1.IdempotentUnaryResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.ConformanceService.IdempotentUnary is not implemented"))
 }
+
+func (UnimplementedConformanceServiceHandler) GetServerObservation(context.Context, *connect.Request[v1.GetServerObservationRequest]) (*connect.Response[v1.GetServerObservationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.ConformanceService.GetServerObservation is not implemented"))
+}
@@ -0,0 +1,270 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: connectrpc/conformance/v1/transcoding.proto
+
+package conformancev1connect
+
+import (
+	v1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TranscodingServiceName is the fully-qualified name of the TranscodingService service.
+	TranscodingServiceName = "connectrpc.conformance.v1.TranscodingService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TranscodingServiceGetResourceProcedure is the fully-qualified name of the TranscodingService's
+	// GetResource RPC.
+	TranscodingServiceGetResourceProcedure = "/connectrpc.conformance.v1.TranscodingService/GetResource"
+	// TranscodingServiceCreateResourceProcedure is the fully-qualified name of the TranscodingService's
+	// CreateResource RPC.
+	TranscodingServiceCreateResourceProcedure = "/connectrpc.conformance.v1.TranscodingService/CreateResource"
+	// TranscodingServiceUpdateResourceProcedure is the fully-qualified name of the TranscodingService's
+	// UpdateResource RPC.
+	TranscodingServiceUpdateResourceProcedure = "/connectrpc.conformance.v1.TranscodingService/UpdateResource"
+	// TranscodingServiceDeleteResourceProcedure is the fully-qualified name of the TranscodingService's
+	// DeleteResource RPC.
+	TranscodingServiceDeleteResourceProcedure = "/connectrpc.conformance.v1.TranscodingService/DeleteResource"
+	// TranscodingServiceSearchResourcesProcedure is the fully-qualified name of the
+	// TranscodingService's SearchResources RPC.
+	TranscodingServiceSearchResourcesProcedure = "/connectrpc.conformance.v1.TranscodingService/SearchResources"
+)
+
+// TranscodingServiceClient is a client for the connectrpc.conformance.v1.TranscodingService
+// service.
+type TranscodingServiceClient interface {
+	// Retrieves a resource. The name is bound from the URL path, using a path
+	// template with a single-segment wildcard, and all other fields are bound
+	// from query parameters.
+	GetResource(context.Context, *connect.Request[v1.GetResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Creates a resource. The parent is bound from the URL path, the resource
+	// is bound from the request body, and all other fields are bound from query
+	// parameters.
+	CreateResource(context.Context, *connect.Request[v1.CreateResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Updates a resource. A nested field is bound from the URL path and all
+	// other fields are bound from the request body.
+	UpdateResource(context.Context, *connect.Request[v1.UpdateResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Deletes a resource. The name is bound from the URL path, using a path
+	// template with a multi-segment wildcard, and all other fields are bound
+	// from query parameters.
+	DeleteResource(context.Context, *connect.Request[v1.DeleteResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Searches for resources. The path template includes a custom verb. The
+	// parent is bound from the URL path and all other fields are bound from
+	// the request body.
+	SearchResources(context.Context, *connect.Request[v1.SearchResourcesRequest]) (*connect.Response[v1.UnaryResponse], error)
+}
+
+// NewTranscodingServiceClient constructs a client for the
+// connectrpc.conformance.v1.TranscodingService service. By default, it uses the Connect protocol
+// with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To
+// use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb()
+// options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTranscodingServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TranscodingServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	transcodingServiceMethods := v1.File_connectrpc_conformance_v1_transcoding_proto.Services().ByName("TranscodingService").Methods()
+	return &transcodingServiceClient{
+		getResource: connect.NewClient[v1.GetResourceRequest, v1.UnaryResponse](
+			httpClient,
+			baseURL+TranscodingServiceGetResourceProcedure,
+			connect.WithSchema(transcodingServiceMethods.ByName("GetResource")),
+			connect.WithClientOptions(opts...),
+		),
+		createResource: connect.NewClient[v1.CreateResourceRequest, v1.UnaryResponse](
+			httpClient,
+			baseURL+TranscodingServiceCreateResourceProcedure,
+			connect.WithSchema(transcodingServiceMethods.ByName("CreateResource")),
+			connect.WithClientOptions(opts...),
+		),
+		updateResource: connect.NewClient[v1.UpdateResourceRequest, v1.UnaryResponse](
+			httpClient,
+			baseURL+TranscodingServiceUpdateResourceProcedure,
+			connect.WithSchema(transcodingServiceMethods.ByName("UpdateResource")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteResource: connect.NewClient[v1.DeleteResourceRequest, v1.UnaryResponse](
+			httpClient,
+			baseURL+TranscodingServiceDeleteResourceProcedure,
+			connect.WithSchema(transcodingServiceMethods.ByName("DeleteResource")),
+			connect.WithClientOptions(opts...),
+		),
+		searchResources: connect.NewClient[v1.SearchResourcesRequest, v1.UnaryResponse](
+			httpClient,
+			baseURL+TranscodingServiceSearchResourcesProcedure,
+			connect.WithSchema(transcodingServiceMethods.ByName("SearchResources")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// transcodingServiceClient implements TranscodingServiceClient.
+type transcodingServiceClient struct {
+	getResource     *connect.Client[v1.GetResourceRequest, v1.UnaryResponse]
+	createResource  *connect.Client[v1.CreateResourceRequest, v1.UnaryResponse]
+	updateResource  *connect.Client[v1.UpdateResourceRequest, v1.UnaryResponse]
+	deleteResource  *connect.Client[v1.DeleteResourceRequest, v1.UnaryResponse]
+	searchResources *connect.Client[v1.SearchResourcesRequest, v1.UnaryResponse]
+}
+
+// GetResource calls connectrpc.conformance.v1.TranscodingService.GetResource.
+func (c *transcodingServiceClient) GetResource(ctx context.Context, req *connect.Request[v1.GetResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return c.getResource.CallUnary(ctx, req)
+}
+
+// CreateResource calls connectrpc.conformance.v1.TranscodingService.CreateResource.
+func (c *transcodingServiceClient) CreateResource(ctx context.Context, req *connect.Request[v1.CreateResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return c.createResource.CallUnary(ctx, req)
+}
+
+// UpdateResource calls connectrpc.conformance.v1.TranscodingService.UpdateResource.
+func (c *transcodingServiceClient) UpdateResource(ctx context.Context, req *connect.Request[v1.UpdateResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return c.updateResource.CallUnary(ctx, req)
+}
+
+// DeleteResource calls connectrpc.conformance.v1.TranscodingService.DeleteResource.
+func (c *transcodingServiceClient) DeleteResource(ctx context.Context, req *connect.Request[v1.DeleteResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return c.deleteResource.CallUnary(ctx, req)
+}
+
+// SearchResources calls connectrpc.conformance.v1.TranscodingService.SearchResources.
+func (c *transcodingServiceClient) SearchResources(ctx context.Context, req *connect.Request[v1.SearchResourcesRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return c.searchResources.CallUnary(ctx, req)
+}
+
+// TranscodingServiceHandler is an implementation of the
+// connectrpc.conformance.v1.TranscodingService service.
+type TranscodingServiceHandler interface {
+	// Retrieves a resource. The name is bound from the URL path, using a path
+	// template with a single-segment wildcard, and all other fields are bound
+	// from query parameters.
+	GetResource(context.Context, *connect.Request[v1.GetResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Creates a resource. The parent is bound from the URL path, the resource
+	// is bound from the request body, and all other fields are bound from query
+	// parameters.
+	CreateResource(context.Context, *connect.Request[v1.CreateResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Updates a resource. A nested field is bound from the URL path and all
+	// other fields are bound from the request body.
+	UpdateResource(context.Context, *connect.Request[v1.UpdateResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Deletes a resource. The name is bound from the URL path, using a path
+	// template with a multi-segment wildcard, and all other fields are bound
+	// from query parameters.
+	DeleteResource(context.Context, *connect.Request[v1.DeleteResourceRequest]) (*connect.Response[v1.UnaryResponse], error)
+	// Searches for resources. The path template includes a custom verb. The
+	// parent is bound from the URL path and all other fields are bound from
+	// the request body.
+	SearchResources(context.Context, *connect.Request[v1.SearchResourcesRequest]) (*connect.Response[v1.UnaryResponse], error)
+}
+
+// NewTranscodingServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTranscodingServiceHandler(svc TranscodingServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	transcodingServiceMethods := v1.File_connectrpc_conformance_v1_transcoding_proto.Services().ByName("TranscodingService").Methods()
+	transcodingServiceGetResourceHandler := connect.NewUnaryHandler(
+		TranscodingServiceGetResourceProcedure,
+		svc.GetResource,
+		connect.WithSchema(transcodingServiceMethods.ByName("GetResource")),
+		connect.WithHandlerOptions(opts...),
+	)
+	transcodingServiceCreateResourceHandler := connect.NewUnaryHandler(
+		TranscodingServiceCreateResourceProcedure,
+		svc.CreateResource,
+		connect.WithSchema(transcodingServiceMethods.ByName("CreateResource")),
+		connect.WithHandlerOptions(opts...),
+	)
+	transcodingServiceUpdateResourceHandler := connect.NewUnaryHandler(
+		TranscodingServiceUpdateResourceProcedure,
+		svc.UpdateResource,
+		connect.WithSchema(transcodingServiceMethods.ByName("UpdateResource")),
+		connect.WithHandlerOptions(opts...),
+	)
+	transcodingServiceDeleteResourceHandler := connect.NewUnaryHandler(
+		TranscodingServiceDeleteResourceProcedure,
+		svc.DeleteResource,
+		connect.WithSchema(transcodingServiceMethods.ByName("DeleteResource")),
+		connect.WithHandlerOptions(opts...),
+	)
+	transcodingServiceSearchResourcesHandler := connect.NewUnaryHandler(
+		TranscodingServiceSearchResourcesProcedure,
+		svc.SearchResources,
+		connect.WithSchema(transcodingServiceMethods.ByName("SearchResources")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/connectrpc.conformance.v1.TranscodingService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TranscodingServiceGetResourceProcedure:
+			transcodingServiceGetResourceHandler.ServeHTTP(w, r)
+		case TranscodingServiceCreateResourceProcedure:
+			transcodingServiceCreateResourceHandler.ServeHTTP(w, r)
+		case TranscodingServiceUpdateResourceProcedure:
+			transcodingServiceUpdateResourceHandler.ServeHTTP(w, r)
+		case TranscodingServiceDeleteResourceProcedure:
+			transcodingServiceDeleteResourceHandler.ServeHTTP(w, r)
+		case TranscodingServiceSearchResourcesProcedure:
+			transcodingServiceSearchResourcesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTranscodingServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTranscodingServiceHandler struct{}
+
+func (UnimplementedTranscodingServiceHandler) GetResource(context.Context, *connect.Request[v1.GetResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.TranscodingService.GetResource is not implemented"))
+}
+
+func (UnimplementedTranscodingServiceHandler) CreateResource(context.Context, *connect.Request[v1.CreateResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.TranscodingService.CreateResource is not implemented"))
+}
+
+func (UnimplementedTranscodingServiceHandler) UpdateResource(context.Context, *connect.Request[v1.UpdateResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.TranscodingService.UpdateResource is not implemented"))
+}
+
+func (UnimplementedTranscodingServiceHandler) DeleteResource(context.Context, *connect.Request[v1.DeleteResourceRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.TranscodingService.DeleteResource is not implemented"))
+}
+
+func (UnimplementedTranscodingServiceHandler) SearchResources(context.Context, *connect.Request[v1.SearchResourcesRequest]) (*connect.Response[v1.UnaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("connectrpc.conformance.v1.TranscodingService.SearchResources is not implemented"))
+}
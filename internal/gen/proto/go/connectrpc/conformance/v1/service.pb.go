@@ -24,6 +24,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -59,6 +60,25 @@ type UnaryResponseDefinition struct {
 	//
 	// For test definitions, this field should be used instead of the above fields.
 	RawResponse *RawHTTPResponse `protobuf:"bytes,5,opt,name=raw_response,json=rawResponse,proto3" json:"raw_response,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field.
+	//
+	// If non-zero, the response data is padded with zeros until it is this
+	// many bytes. Since the padding is highly compressible, a compressed
+	// response will be much smaller on the wire than when it is decompressed.
+	// This is used to verify that clients enforce their message receive limit
+	// on the decompressed size of a message.
+	//
+	// The test runner also populates this field for test cases that use the
+	// expand_responses directive.
+	InflatedResponseSize uint32 `protobuf:"varint,7,opt,name=inflated_response_size,json=inflatedResponseSize,proto3" json:"inflated_response_size,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field.
+	//
+	// If present, the server gracefully drains the connection on which the
+	// request arrived. Since the RPC has only one response, the timing's
+	// after_num_responses must be zero, if set.
+	ConnectionDrain *ConnectionDrain `protobuf:"bytes,8,opt,name=connection_drain,json=connectionDrain,proto3" json:"connection_drain,omitempty"`
 }
 
 func (x *UnaryResponseDefinition) Reset() {
@@ -142,6 +162,20 @@ func (x *UnaryResponseDefinition) GetRawResponse() *RawHTTPResponse {
 	return nil
 }
 
+func (x *UnaryResponseDefinition) GetInflatedResponseSize() uint32 {
+	if x != nil {
+		return x.InflatedResponseSize
+	}
+	return 0
+}
+
+func (x *UnaryResponseDefinition) GetConnectionDrain() *ConnectionDrain {
+	if x != nil {
+		return x.ConnectionDrain
+	}
+	return nil
+}
+
 type isUnaryResponseDefinition_Response interface {
 	isUnaryResponseDefinition_Response()
 }
@@ -189,6 +223,26 @@ type StreamResponseDefinition struct {
 	//
 	// For test definitions, this field should be used instead of the above fields.
 	RawResponse *RawHTTPResponse `protobuf:"bytes,6,opt,name=raw_response,json=rawResponse,proto3" json:"raw_response,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field.
+	//
+	// When non-empty, this should have no more entries than there are values
+	// in response_data. The first value is applied to the first response, and
+	// so on. For each entry that is non-zero, the response data is padded with
+	// zeros until it is that many bytes. Since the padding is highly compressible,
+	// a compressed response will be much smaller on the wire than when it is
+	// decompressed. This is used to verify that clients enforce their message
+	// receive limit on the decompressed size of a message.
+	//
+	// The test runner also populates this field for test cases that use the
+	// expand_responses directive.
+	InflatedResponseSizes []uint32 `protobuf:"varint,7,rep,packed,name=inflated_response_sizes,json=inflatedResponseSizes,proto3" json:"inflated_response_sizes,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field.
+	//
+	// If present, the server gracefully drains the connection on which the
+	// request arrived.
+	ConnectionDrain *ConnectionDrain `protobuf:"bytes,8,opt,name=connection_drain,json=connectionDrain,proto3" json:"connection_drain,omitempty"`
 }
 
 func (x *StreamResponseDefinition) Reset() {
@@ -265,6 +319,116 @@ func (x *StreamResponseDefinition) GetRawResponse() *RawHTTPResponse {
 	return nil
 }
 
+func (x *StreamResponseDefinition) GetInflatedResponseSizes() []uint32 {
+	if x != nil {
+		return x.InflatedResponseSizes
+	}
+	return nil
+}
+
+func (x *StreamResponseDefinition) GetConnectionDrain() *ConnectionDrain {
+	if x != nil {
+		return x.ConnectionDrain
+	}
+	return nil
+}
+
+// Describes when the reference server should gracefully drain a connection.
+// This is used to verify that clients allow in-flight RPCs to complete, but
+// issue new RPCs on a fresh connection, when a server drains a connection.
+//
+// For HTTP/2, the server sends a GOAWAY frame with a NO_ERROR code and the
+// maximum stream ID, which allows any RPCs already started to complete but
+// tells the client not to start any more on the connection. For HTTP/3, the
+// server closes the connection once it is idle, after the RPC completes,
+// regardless of the timing below. This is ignored for HTTP 1.1.
+type ConnectionDrain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Timing:
+	//
+	//	*ConnectionDrain_AfterNumResponses
+	//	*ConnectionDrain_AfterCompletion
+	Timing isConnectionDrain_Timing `protobuf_oneof:"timing"`
+}
+
+func (x *ConnectionDrain) Reset() {
+	*x = ConnectionDrain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionDrain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionDrain) ProtoMessage() {}
+
+func (x *ConnectionDrain) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionDrain.ProtoReflect.Descriptor instead.
+func (*ConnectionDrain) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *ConnectionDrain) GetTiming() isConnectionDrain_Timing {
+	if m != nil {
+		return m.Timing
+	}
+	return nil
+}
+
+func (x *ConnectionDrain) GetAfterNumResponses() uint32 {
+	if x, ok := x.GetTiming().(*ConnectionDrain_AfterNumResponses); ok {
+		return x.AfterNumResponses
+	}
+	return 0
+}
+
+func (x *ConnectionDrain) GetAfterCompletion() *emptypb.Empty {
+	if x, ok := x.GetTiming().(*ConnectionDrain_AfterCompletion); ok {
+		return x.AfterCompletion
+	}
+	return nil
+}
+
+type isConnectionDrain_Timing interface {
+	isConnectionDrain_Timing()
+}
+
+type ConnectionDrain_AfterNumResponses struct {
+	// The connection is drained after this many response messages are
+	// sent, while the RPC is still in progress. If zero, the connection
+	// is drained after the request is received, before any responses
+	// are sent.
+	AfterNumResponses uint32 `protobuf:"varint,1,opt,name=after_num_responses,json=afterNumResponses,proto3,oneof"`
+}
+
+type ConnectionDrain_AfterCompletion struct {
+	// The connection is drained after the RPC completes, so it is the
+	// next RPC that observes the drain.
+	AfterCompletion *emptypb.Empty `protobuf:"bytes,2,opt,name=after_completion,json=afterCompletion,proto3,oneof"`
+}
+
+func (*ConnectionDrain_AfterNumResponses) isConnectionDrain_Timing() {}
+
+func (*ConnectionDrain_AfterCompletion) isConnectionDrain_Timing() {}
+
 type UnaryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -274,12 +438,22 @@ type UnaryRequest struct {
 	ResponseDefinition *UnaryResponseDefinition `protobuf:"bytes,1,opt,name=response_definition,json=responseDefinition,proto3" json:"response_definition,omitempty"`
 	// Additional data. Only used to pad the request size to test large request messages.
 	RequestData []byte `protobuf:"bytes,2,opt,name=request_data,json=requestData,proto3" json:"request_data,omitempty"`
+	// Response definitions for each attempt of the RPC, which are used to test
+	// clients that retry RPCs. When non-empty, response_definition
+	// should not be set. The first entry defines the response to the first
+	// attempt, the second entry the response to the second attempt, and so on.
+	// If the client makes more attempts than there are entries, the last entry
+	// is used for all subsequent attempts.
+	//
+	// Servers under test need not support this field; it is only used with
+	// the reference server, which counts the attempts for each test case.
+	AttemptResponseDefinitions []*UnaryResponseDefinition `protobuf:"bytes,3,rep,name=attempt_response_definitions,json=attemptResponseDefinitions,proto3" json:"attempt_response_definitions,omitempty"`
 }
 
 func (x *UnaryRequest) Reset() {
 	*x = UnaryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -292,7 +466,7 @@ func (x *UnaryRequest) String() string {
 func (*UnaryRequest) ProtoMessage() {}
 
 func (x *UnaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -305,7 +479,7 @@ func (x *UnaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnaryRequest.ProtoReflect.Descriptor instead.
 func (*UnaryRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{2}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UnaryRequest) GetResponseDefinition() *UnaryResponseDefinition {
@@ -322,6 +496,13 @@ func (x *UnaryRequest) GetRequestData() []byte {
 	return nil
 }
 
+func (x *UnaryRequest) GetAttemptResponseDefinitions() []*UnaryResponseDefinition {
+	if x != nil {
+		return x.AttemptResponseDefinitions
+	}
+	return nil
+}
+
 type UnaryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -334,7 +515,7 @@ type UnaryResponse struct {
 func (x *UnaryResponse) Reset() {
 	*x = UnaryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -347,7 +528,7 @@ func (x *UnaryResponse) String() string {
 func (*UnaryResponse) ProtoMessage() {}
 
 func (x *UnaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +541,7 @@ func (x *UnaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnaryResponse.ProtoReflect.Descriptor instead.
 func (*UnaryResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{3}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UnaryResponse) GetPayload() *ConformancePayload {
@@ -384,7 +565,7 @@ type IdempotentUnaryRequest struct {
 func (x *IdempotentUnaryRequest) Reset() {
 	*x = IdempotentUnaryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -397,7 +578,7 @@ func (x *IdempotentUnaryRequest) String() string {
 func (*IdempotentUnaryRequest) ProtoMessage() {}
 
 func (x *IdempotentUnaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +591,7 @@ func (x *IdempotentUnaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdempotentUnaryRequest.ProtoReflect.Descriptor instead.
 func (*IdempotentUnaryRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{4}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *IdempotentUnaryRequest) GetResponseDefinition() *UnaryResponseDefinition {
@@ -439,7 +620,7 @@ type IdempotentUnaryResponse struct {
 func (x *IdempotentUnaryResponse) Reset() {
 	*x = IdempotentUnaryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -452,7 +633,7 @@ func (x *IdempotentUnaryResponse) String() string {
 func (*IdempotentUnaryResponse) ProtoMessage() {}
 
 func (x *IdempotentUnaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +646,7 @@ func (x *IdempotentUnaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdempotentUnaryResponse.ProtoReflect.Descriptor instead.
 func (*IdempotentUnaryResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{5}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *IdempotentUnaryResponse) GetPayload() *ConformancePayload {
@@ -489,7 +670,7 @@ type ServerStreamRequest struct {
 func (x *ServerStreamRequest) Reset() {
 	*x = ServerStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -502,7 +683,7 @@ func (x *ServerStreamRequest) String() string {
 func (*ServerStreamRequest) ProtoMessage() {}
 
 func (x *ServerStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +696,7 @@ func (x *ServerStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStreamRequest.ProtoReflect.Descriptor instead.
 func (*ServerStreamRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{6}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ServerStreamRequest) GetResponseDefinition() *StreamResponseDefinition {
@@ -544,7 +725,7 @@ type ServerStreamResponse struct {
 func (x *ServerStreamResponse) Reset() {
 	*x = ServerStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +738,7 @@ func (x *ServerStreamResponse) String() string {
 func (*ServerStreamResponse) ProtoMessage() {}
 
 func (x *ServerStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +751,7 @@ func (x *ServerStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStreamResponse.ProtoReflect.Descriptor instead.
 func (*ServerStreamResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{7}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ServerStreamResponse) GetPayload() *ConformancePayload {
@@ -597,7 +778,7 @@ type ClientStreamRequest struct {
 func (x *ClientStreamRequest) Reset() {
 	*x = ClientStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -610,7 +791,7 @@ func (x *ClientStreamRequest) String() string {
 func (*ClientStreamRequest) ProtoMessage() {}
 
 func (x *ClientStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,7 +804,7 @@ func (x *ClientStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientStreamRequest.ProtoReflect.Descriptor instead.
 func (*ClientStreamRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{8}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ClientStreamRequest) GetResponseDefinition() *UnaryResponseDefinition {
@@ -652,7 +833,7 @@ type ClientStreamResponse struct {
 func (x *ClientStreamResponse) Reset() {
 	*x = ClientStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -665,7 +846,7 @@ func (x *ClientStreamResponse) String() string {
 func (*ClientStreamResponse) ProtoMessage() {}
 
 func (x *ClientStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,7 +859,7 @@ func (x *ClientStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientStreamResponse.ProtoReflect.Descriptor instead.
 func (*ClientStreamResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{9}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ClientStreamResponse) GetPayload() *ConformancePayload {
@@ -718,7 +899,7 @@ type BidiStreamRequest struct {
 func (x *BidiStreamRequest) Reset() {
 	*x = BidiStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -731,7 +912,7 @@ func (x *BidiStreamRequest) String() string {
 func (*BidiStreamRequest) ProtoMessage() {}
 
 func (x *BidiStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,7 +925,7 @@ func (x *BidiStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BidiStreamRequest.ProtoReflect.Descriptor instead.
 func (*BidiStreamRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{10}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BidiStreamRequest) GetResponseDefinition() *StreamResponseDefinition {
@@ -780,7 +961,7 @@ type BidiStreamResponse struct {
 func (x *BidiStreamResponse) Reset() {
 	*x = BidiStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -793,7 +974,7 @@ func (x *BidiStreamResponse) String() string {
 func (*BidiStreamResponse) ProtoMessage() {}
 
 func (x *BidiStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,7 +987,7 @@ func (x *BidiStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BidiStreamResponse.ProtoReflect.Descriptor instead.
 func (*BidiStreamResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{11}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BidiStreamResponse) GetPayload() *ConformancePayload {
@@ -825,7 +1006,7 @@ type UnimplementedRequest struct {
 func (x *UnimplementedRequest) Reset() {
 	*x = UnimplementedRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -838,7 +1019,7 @@ func (x *UnimplementedRequest) String() string {
 func (*UnimplementedRequest) ProtoMessage() {}
 
 func (x *UnimplementedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +1032,7 @@ func (x *UnimplementedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnimplementedRequest.ProtoReflect.Descriptor instead.
 func (*UnimplementedRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{12}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{13}
 }
 
 type UnimplementedResponse struct {
@@ -863,7 +1044,7 @@ type UnimplementedResponse struct {
 func (x *UnimplementedResponse) Reset() {
 	*x = UnimplementedResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -876,7 +1057,7 @@ func (x *UnimplementedResponse) String() string {
 func (*UnimplementedResponse) ProtoMessage() {}
 
 func (x *UnimplementedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,38 +1070,35 @@ func (x *UnimplementedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnimplementedResponse.ProtoReflect.Descriptor instead.
 func (*UnimplementedResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{13}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14}
 }
 
-type ConformancePayload struct {
+type GetServerObservationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Any response data specified in the response definition to the server should be
-	// echoed back here.
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	// Echoes back information about the request stream observed so far.
-	RequestInfo *ConformancePayload_RequestInfo `protobuf:"bytes,2,opt,name=request_info,json=requestInfo,proto3" json:"request_info,omitempty"`
+	// The name of the test case whose RPC should be reported.
+	TestName string `protobuf:"bytes,1,opt,name=test_name,json=testName,proto3" json:"test_name,omitempty"`
 }
 
-func (x *ConformancePayload) Reset() {
-	*x = ConformancePayload{}
+func (x *GetServerObservationRequest) Reset() {
+	*x = GetServerObservationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConformancePayload) String() string {
+func (x *GetServerObservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConformancePayload) ProtoMessage() {}
+func (*GetServerObservationRequest) ProtoMessage() {}
 
-func (x *ConformancePayload) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
+func (x *GetServerObservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -931,61 +1109,44 @@ func (x *ConformancePayload) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConformancePayload.ProtoReflect.Descriptor instead.
-func (*ConformancePayload) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *ConformancePayload) GetData() []byte {
-	if x != nil {
-		return x.Data
-	}
-	return nil
+// Deprecated: Use GetServerObservationRequest.ProtoReflect.Descriptor instead.
+func (*GetServerObservationRequest) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ConformancePayload) GetRequestInfo() *ConformancePayload_RequestInfo {
+func (x *GetServerObservationRequest) GetTestName() string {
 	if x != nil {
-		return x.RequestInfo
+		return x.TestName
 	}
-	return nil
+	return ""
 }
 
-// An error definition used for specifying a desired error response
-type Error struct {
+type GetServerObservationResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The error code.
-	// For a list of Connect error codes see: https://connectrpc.com/docs/protocol#error-codes
-	Code Code `protobuf:"varint,1,opt,name=code,proto3,enum=connectrpc.conformance.v1.Code" json:"code,omitempty"`
-	// If this value is absent in a test case response definition, the contents of the
-	// actual error message will not be checked. This is useful for certain kinds of
-	// error conditions where the exact message to be used is not specified, only the
-	// code.
-	Message *string `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
-	// Errors in Connect and gRPC protocols can have arbitrary messages
-	// attached to them, which are known as error details.
-	Details []*anypb.Any `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+	// What the server observed while handling the RPC.
+	Observation *ServerObservation `protobuf:"bytes,1,opt,name=observation,proto3" json:"observation,omitempty"`
 }
 
-func (x *Error) Reset() {
-	*x = Error{}
+func (x *GetServerObservationResponse) Reset() {
+	*x = GetServerObservationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Error) String() string {
+func (x *GetServerObservationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Error) ProtoMessage() {}
+func (*GetServerObservationResponse) ProtoMessage() {}
 
-func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
+func (x *GetServerObservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,63 +1157,53 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Error.ProtoReflect.Descriptor instead.
-func (*Error) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *Error) GetCode() Code {
-	if x != nil {
-		return x.Code
-	}
-	return Code_CODE_UNSPECIFIED
-}
-
-func (x *Error) GetMessage() string {
-	if x != nil && x.Message != nil {
-		return *x.Message
-	}
-	return ""
+// Deprecated: Use GetServerObservationResponse.ProtoReflect.Descriptor instead.
+func (*GetServerObservationResponse) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Error) GetDetails() []*anypb.Any {
+func (x *GetServerObservationResponse) GetObservation() *ServerObservation {
 	if x != nil {
-		return x.Details
+		return x.Observation
 	}
 	return nil
 }
 
-// A tuple of name and values (ASCII) for a header or trailer entry.
-type Header struct {
+// Describes what a server observed while handling an RPC.
+type ServerObservation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Header/trailer name (key).
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Header/trailer value. This is repeated to explicitly support headers and
-	// trailers where a key is repeated. In such a case, these values must be in
-	// the same order as which values appeared in the header or trailer block.
-	Value []string `protobuf:"bytes,2,rep,name=value,proto3" json:"value,omitempty"`
+	// True if the handler's context was canceled, or its deadline elapsed,
+	// before the handler returned.
+	Canceled bool `protobuf:"varint,1,opt,name=canceled,proto3" json:"canceled,omitempty"`
+	// If canceled is true, the number of milliseconds between the handler
+	// starting and its context being canceled.
+	CanceledAfterMs uint32 `protobuf:"varint,2,opt,name=canceled_after_ms,json=canceledAfterMs,proto3" json:"canceled_after_ms,omitempty"`
+	// If canceled is true, the code that corresponds to the context's error:
+	// CANCELED if the client canceled the RPC or DEADLINE_EXCEEDED if its
+	// deadline elapsed.
+	Code Code `protobuf:"varint,3,opt,name=code,proto3,enum=connectrpc.conformance.v1.Code" json:"code,omitempty"`
 }
 
-func (x *Header) Reset() {
-	*x = Header{}
+func (x *ServerObservation) Reset() {
+	*x = ServerObservation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Header) String() string {
+func (x *ServerObservation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Header) ProtoMessage() {}
+func (*ServerObservation) ProtoMessage() {}
 
-func (x *Header) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
+func (x *ServerObservation) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1063,12 +1214,209 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Header.ProtoReflect.Descriptor instead.
-func (*Header) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ServerObservation.ProtoReflect.Descriptor instead.
+func (*ServerObservation) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *Header) GetName() string {
+func (x *ServerObservation) GetCanceled() bool {
+	if x != nil {
+		return x.Canceled
+	}
+	return false
+}
+
+func (x *ServerObservation) GetCanceledAfterMs() uint32 {
+	if x != nil {
+		return x.CanceledAfterMs
+	}
+	return 0
+}
+
+func (x *ServerObservation) GetCode() Code {
+	if x != nil {
+		return x.Code
+	}
+	return Code_CODE_UNSPECIFIED
+}
+
+type ConformancePayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Any response data specified in the response definition to the server should be
+	// echoed back here.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Echoes back information about the request stream observed so far.
+	RequestInfo *ConformancePayload_RequestInfo `protobuf:"bytes,2,opt,name=request_info,json=requestInfo,proto3" json:"request_info,omitempty"`
+}
+
+func (x *ConformancePayload) Reset() {
+	*x = ConformancePayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConformancePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConformancePayload) ProtoMessage() {}
+
+func (x *ConformancePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConformancePayload.ProtoReflect.Descriptor instead.
+func (*ConformancePayload) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConformancePayload) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ConformancePayload) GetRequestInfo() *ConformancePayload_RequestInfo {
+	if x != nil {
+		return x.RequestInfo
+	}
+	return nil
+}
+
+// An error definition used for specifying a desired error response
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The error code.
+	// For a list of Connect error codes see: https://connectrpc.com/docs/protocol#error-codes
+	Code Code `protobuf:"varint,1,opt,name=code,proto3,enum=connectrpc.conformance.v1.Code" json:"code,omitempty"`
+	// If this value is absent in a test case response definition, the contents of the
+	// actual error message will not be checked. This is useful for certain kinds of
+	// error conditions where the exact message to be used is not specified, only the
+	// code.
+	Message *string `protobuf:"bytes,2,opt,name=message,proto3,oneof" json:"message,omitempty"`
+	// Errors in Connect and gRPC protocols can have arbitrary messages
+	// attached to them, which are known as error details.
+	Details []*anypb.Any `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Error) GetCode() Code {
+	if x != nil {
+		return x.Code
+	}
+	return Code_CODE_UNSPECIFIED
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil && x.Message != nil {
+		return *x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// A tuple of name and values (ASCII) for a header or trailer entry.
+type Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Header/trailer name (key).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Header/trailer value. This is repeated to explicitly support headers and
+	// trailers where a key is repeated. In such a case, these values must be in
+	// the same order as which values appeared in the header or trailer block.
+	Value []string `protobuf:"bytes,2,rep,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Header) Reset() {
+	*x = Header{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Header) ProtoMessage() {}
+
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Header) GetName() string {
 	if x != nil {
 		return x.Name
 	}
@@ -1107,12 +1455,19 @@ type RawHTTPRequest struct {
 	//	*RawHTTPRequest_Unary
 	//	*RawHTTPRequest_Stream
 	Body isRawHTTPRequest_Body `protobuf_oneof:"body"`
+	// If present, the request is instead sent as the given sequence of
+	// HTTP/2 frames, and the body above is ignored. This can only be used
+	// with HTTP/2. The request is sent on a new connection, which is not
+	// used for any other requests. The verb, URI, query params, and headers
+	// above form the request's header block: they are added to the start of
+	// the first frame in the script that is a HEADERS frame and not trailers.
+	FrameScript *HTTP2FrameScript `protobuf:"bytes,8,opt,name=frame_script,json=frameScript,proto3" json:"frame_script,omitempty"`
 }
 
 func (x *RawHTTPRequest) Reset() {
 	*x = RawHTTPRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1125,7 +1480,7 @@ func (x *RawHTTPRequest) String() string {
 func (*RawHTTPRequest) ProtoMessage() {}
 
 func (x *RawHTTPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,7 +1493,7 @@ func (x *RawHTTPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawHTTPRequest.ProtoReflect.Descriptor instead.
 func (*RawHTTPRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RawHTTPRequest) GetVerb() string {
@@ -1197,6 +1552,13 @@ func (x *RawHTTPRequest) GetStream() *StreamContents {
 	return nil
 }
 
+func (x *RawHTTPRequest) GetFrameScript() *HTTP2FrameScript {
+	if x != nil {
+		return x.FrameScript
+	}
+	return nil
+}
+
 type isRawHTTPRequest_Body interface {
 	isRawHTTPRequest_Body()
 }
@@ -1233,12 +1595,21 @@ type MessageContents struct {
 	// If specified and not identity, the above data will be
 	// compressed using the given algorithm.
 	Compression Compression `protobuf:"varint,4,opt,name=compression,proto3,enum=connectrpc.conformance.v1.Compression" json:"compression,omitempty"`
+	// If larger than the size of the above data, the data is padded with
+	// zeros until it is this many bytes. The padding is generated as it is
+	// compressed, so it never needs to be held in memory. This can be used
+	// to craft a "decompression bomb": a message whose compressed size is
+	// near a peer's receive limit, but which inflates to far more than the
+	// peer could buffer in a reasonable amount of time. The padded data is
+	// generally not a valid message, so the peer is expected to reject it
+	// based on its size alone.
+	InflatedSize uint32 `protobuf:"varint,5,opt,name=inflated_size,json=inflatedSize,proto3" json:"inflated_size,omitempty"`
 }
 
 func (x *MessageContents) Reset() {
 	*x = MessageContents{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1251,7 +1622,7 @@ func (x *MessageContents) String() string {
 func (*MessageContents) ProtoMessage() {}
 
 func (x *MessageContents) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1264,7 +1635,7 @@ func (x *MessageContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageContents.ProtoReflect.Descriptor instead.
 func (*MessageContents) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{18}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (m *MessageContents) GetData() isMessageContents_Data {
@@ -1302,6 +1673,13 @@ func (x *MessageContents) GetCompression() Compression {
 	return Compression_COMPRESSION_UNSPECIFIED
 }
 
+func (x *MessageContents) GetInflatedSize() uint32 {
+	if x != nil {
+		return x.InflatedSize
+	}
+	return 0
+}
+
 type isMessageContents_Data interface {
 	isMessageContents_Data()
 }
@@ -1342,7 +1720,7 @@ type StreamContents struct {
 func (x *StreamContents) Reset() {
 	*x = StreamContents{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1355,7 +1733,7 @@ func (x *StreamContents) String() string {
 func (*StreamContents) ProtoMessage() {}
 
 func (x *StreamContents) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1368,7 +1746,7 @@ func (x *StreamContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamContents.ProtoReflect.Descriptor instead.
 func (*StreamContents) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{19}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *StreamContents) GetItems() []*StreamContents_StreamItem {
@@ -1397,12 +1775,16 @@ type RawHTTPResponse struct {
 	Body isRawHTTPResponse_Body `protobuf_oneof:"body"`
 	// Trailers to be set on the response.
 	Trailers []*Header `protobuf:"bytes,5,rep,name=trailers,proto3" json:"trailers,omitempty"`
+	// If present, the response is instead sent as the given sequence of
+	// HTTP/2 frames, and all of the above fields are ignored. This can
+	// only be used with HTTP/2.
+	FrameScript *HTTP2FrameScript `protobuf:"bytes,6,opt,name=frame_script,json=frameScript,proto3" json:"frame_script,omitempty"`
 }
 
 func (x *RawHTTPResponse) Reset() {
 	*x = RawHTTPResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1415,7 +1797,7 @@ func (x *RawHTTPResponse) String() string {
 func (*RawHTTPResponse) ProtoMessage() {}
 
 func (x *RawHTTPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,7 +1810,7 @@ func (x *RawHTTPResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawHTTPResponse.ProtoReflect.Descriptor instead.
 func (*RawHTTPResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{20}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *RawHTTPResponse) GetStatusCode() uint32 {
@@ -1473,6 +1855,13 @@ func (x *RawHTTPResponse) GetTrailers() []*Header {
 	return nil
 }
 
+func (x *RawHTTPResponse) GetFrameScript() *HTTP2FrameScript {
+	if x != nil {
+		return x.FrameScript
+	}
+	return nil
+}
+
 type isRawHTTPResponse_Body interface {
 	isRawHTTPResponse_Body()
 }
@@ -1492,50 +1881,276 @@ func (*RawHTTPResponse_Unary) isRawHTTPResponse_Body() {}
 
 func (*RawHTTPResponse_Stream) isRawHTTPResponse_Body() {}
 
-type ConformancePayload_RequestInfo struct {
+// HTTP2FrameScript describes a sequence of HTTP/2 frames that are written
+// directly to a connection. This can be used to craft requests and responses
+// that exhibit misbehavior specific to HTTP/2, which cannot be described by
+// the other fields of a RawHTTPRequest or RawHTTPResponse. Header blocks in
+// the script are encoded without using the HPACK dynamic table, so that they
+// do not affect other streams on the connection.
+//
+// In a RawHTTPResponse, the reference server writes the frames to the
+// connection on which an RPC arrived, instead of sending a normal response.
+// Once the script starts, the server sends no frames of its own for the
+// RPC's stream. In particular, it sends no WINDOW_UPDATE frames for it, so
+// a client that continues to send request data may exhaust its flow control
+// window. After the last frame is written, if the script did not end the
+// stream, it is left open with no further frames sent for it.
+//
+// In a RawHTTPRequest, the reference client writes the frames to a new
+// connection, after the connection preface and an empty SETTINGS frame. The
+// RPC's stream has ID 1. The client acknowledges SETTINGS and PING frames
+// from the server and sends WINDOW_UPDATE frames for all data it receives.
+// After the last frame is written, the client waits up to two seconds for
+// the server to finish the stream, unless the script already reset it. The
+// observed outcome is reported in ClientResponseResult.http2_outcome.
+type HTTP2FrameScript struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The server echos back the request headers it observed here.
-	RequestHeaders []*Header `protobuf:"bytes,1,rep,name=request_headers,json=requestHeaders,proto3" json:"request_headers,omitempty"`
-	// The timeout observed that was included in the request. Other timeouts use a
-	// type of uint32, but we want to be lenient here to allow whatever value the RPC
-	// server observes, even if it's outside the range of uint32.
-	TimeoutMs *int64 `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3,oneof" json:"timeout_ms,omitempty"`
-	// The server should echo back all requests received.
-	// For unary and server-streaming requests, this should always contain a single request
-	// For client-streaming and half-duplex bidi-streaming, this should contain
-	// all client requests in the order received and be present in each response.
-	// For full-duplex bidirectional-streaming, this should contain all requests in the order
-	// they were received since the last sent response.
-	Requests []*anypb.Any `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"`
-	// If present, the request used the Connect protocol and a GET method. This
-	// captures other relevant information about the request. If a server implementation
-	// is unable to populate this (due to the server framework not exposing all of these
-	// details to application code), it may be an empty message. This implies that the
-	// server framework, at a minimum, at least expose to application code whether the
-	// request used GET vs. POST.
-	ConnectGetInfo *ConformancePayload_ConnectGetInfo `protobuf:"bytes,4,opt,name=connect_get_info,json=connectGetInfo,proto3" json:"connect_get_info,omitempty"`
+	Frames []*HTTP2Frame `protobuf:"bytes,1,rep,name=frames,proto3" json:"frames,omitempty"`
 }
 
-func (x *ConformancePayload_RequestInfo) Reset() {
-	*x = ConformancePayload_RequestInfo{}
+func (x *HTTP2FrameScript) Reset() {
+	*x = HTTP2FrameScript{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConformancePayload_RequestInfo) String() string {
+func (x *HTTP2FrameScript) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConformancePayload_RequestInfo) ProtoMessage() {}
+func (*HTTP2FrameScript) ProtoMessage() {}
+
+func (x *HTTP2FrameScript) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2FrameScript.ProtoReflect.Descriptor instead.
+func (*HTTP2FrameScript) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HTTP2FrameScript) GetFrames() []*HTTP2Frame {
+	if x != nil {
+		return x.Frames
+	}
+	return nil
+}
+
+// HTTP2Frame describes a single frame in an HTTP2FrameScript. Unless
+// otherwise noted, frames are written for the RPC's stream.
+//
+// When used in a RawHTTPRequest, these describe frames sent by the client,
+// instead of by the server.
+type HTTP2Frame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Frame:
+	//
+	//	*HTTP2Frame_Headers
+	//	*HTTP2Frame_Data
+	//	*HTTP2Frame_RstStream
+	//	*HTTP2Frame_GoAway
+	//	*HTTP2Frame_WindowUpdate
+	//	*HTTP2Frame_Raw
+	Frame isHTTP2Frame_Frame `protobuf_oneof:"frame"`
+	// Wait this many milliseconds before writing the frame.
+	DelayMs uint32 `protobuf:"varint,7,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+}
+
+func (x *HTTP2Frame) Reset() {
+	*x = HTTP2Frame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2Frame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Frame) ProtoMessage() {}
+
+func (x *HTTP2Frame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2Frame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (m *HTTP2Frame) GetFrame() isHTTP2Frame_Frame {
+	if m != nil {
+		return m.Frame
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetHeaders() *HTTP2Frame_HeadersFrame {
+	if x, ok := x.GetFrame().(*HTTP2Frame_Headers); ok {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetData() *HTTP2Frame_DataFrame {
+	if x, ok := x.GetFrame().(*HTTP2Frame_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetRstStream() *HTTP2Frame_RstStreamFrame {
+	if x, ok := x.GetFrame().(*HTTP2Frame_RstStream); ok {
+		return x.RstStream
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetGoAway() *HTTP2Frame_GoAwayFrame {
+	if x, ok := x.GetFrame().(*HTTP2Frame_GoAway); ok {
+		return x.GoAway
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetWindowUpdate() *HTTP2Frame_WindowUpdateFrame {
+	if x, ok := x.GetFrame().(*HTTP2Frame_WindowUpdate); ok {
+		return x.WindowUpdate
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetRaw() *HTTP2Frame_RawFrame {
+	if x, ok := x.GetFrame().(*HTTP2Frame_Raw); ok {
+		return x.Raw
+	}
+	return nil
+}
+
+func (x *HTTP2Frame) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+type isHTTP2Frame_Frame interface {
+	isHTTP2Frame_Frame()
+}
+
+type HTTP2Frame_Headers struct {
+	// A HEADERS frame, possibly followed by CONTINUATION frames.
+	Headers *HTTP2Frame_HeadersFrame `protobuf:"bytes,1,opt,name=headers,proto3,oneof"`
+}
+
+type HTTP2Frame_Data struct {
+	// One or more DATA frames.
+	Data *HTTP2Frame_DataFrame `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+type HTTP2Frame_RstStream struct {
+	// A RST_STREAM frame.
+	RstStream *HTTP2Frame_RstStreamFrame `protobuf:"bytes,3,opt,name=rst_stream,json=rstStream,proto3,oneof"`
+}
+
+type HTTP2Frame_GoAway struct {
+	// A GOAWAY frame, which applies to the whole connection.
+	GoAway *HTTP2Frame_GoAwayFrame `protobuf:"bytes,4,opt,name=go_away,json=goAway,proto3,oneof"`
+}
+
+type HTTP2Frame_WindowUpdate struct {
+	// A WINDOW_UPDATE frame.
+	WindowUpdate *HTTP2Frame_WindowUpdateFrame `protobuf:"bytes,5,opt,name=window_update,json=windowUpdate,proto3,oneof"`
+}
+
+type HTTP2Frame_Raw struct {
+	// An arbitrary frame, written as is.
+	Raw *HTTP2Frame_RawFrame `protobuf:"bytes,6,opt,name=raw,proto3,oneof"`
+}
+
+func (*HTTP2Frame_Headers) isHTTP2Frame_Frame() {}
+
+func (*HTTP2Frame_Data) isHTTP2Frame_Frame() {}
+
+func (*HTTP2Frame_RstStream) isHTTP2Frame_Frame() {}
+
+func (*HTTP2Frame_GoAway) isHTTP2Frame_Frame() {}
+
+func (*HTTP2Frame_WindowUpdate) isHTTP2Frame_Frame() {}
+
+func (*HTTP2Frame_Raw) isHTTP2Frame_Frame() {}
+
+type ConformancePayload_RequestInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The server echos back the request headers it observed here.
+	RequestHeaders []*Header `protobuf:"bytes,1,rep,name=request_headers,json=requestHeaders,proto3" json:"request_headers,omitempty"`
+	// The timeout observed that was included in the request. Other timeouts use a
+	// type of uint32, but we want to be lenient here to allow whatever value the RPC
+	// server observes, even if it's outside the range of uint32.
+	TimeoutMs *int64 `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3,oneof" json:"timeout_ms,omitempty"`
+	// The server should echo back all requests received.
+	// For unary and server-streaming requests, this should always contain a single request
+	// For client-streaming and half-duplex bidi-streaming, this should contain
+	// all client requests in the order received and be present in each response.
+	// For full-duplex bidirectional-streaming, this should contain all requests in the order
+	// they were received since the last sent response.
+	Requests []*anypb.Any `protobuf:"bytes,3,rep,name=requests,proto3" json:"requests,omitempty"`
+	// If present, the request used the Connect protocol and a GET method. This
+	// captures other relevant information about the request. If a server implementation
+	// is unable to populate this (due to the server framework not exposing all of these
+	// details to application code), it may be an empty message. This implies that the
+	// server framework, at a minimum, at least expose to application code whether the
+	// request used GET vs. POST.
+	ConnectGetInfo *ConformancePayload_ConnectGetInfo `protobuf:"bytes,4,opt,name=connect_get_info,json=connectGetInfo,proto3" json:"connect_get_info,omitempty"`
+}
+
+func (x *ConformancePayload_RequestInfo) Reset() {
+	*x = ConformancePayload_RequestInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConformancePayload_RequestInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConformancePayload_RequestInfo) ProtoMessage() {}
 
 func (x *ConformancePayload_RequestInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1548,7 +2163,7 @@ func (x *ConformancePayload_RequestInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConformancePayload_RequestInfo.ProtoReflect.Descriptor instead.
 func (*ConformancePayload_RequestInfo) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14, 0}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{18, 0}
 }
 
 func (x *ConformancePayload_RequestInfo) GetRequestHeaders() []*Header {
@@ -1591,7 +2206,7 @@ type ConformancePayload_ConnectGetInfo struct {
 func (x *ConformancePayload_ConnectGetInfo) Reset() {
 	*x = ConformancePayload_ConnectGetInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1604,7 +2219,7 @@ func (x *ConformancePayload_ConnectGetInfo) String() string {
 func (*ConformancePayload_ConnectGetInfo) ProtoMessage() {}
 
 func (x *ConformancePayload_ConnectGetInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1617,7 +2232,7 @@ func (x *ConformancePayload_ConnectGetInfo) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ConformancePayload_ConnectGetInfo.ProtoReflect.Descriptor instead.
 func (*ConformancePayload_ConnectGetInfo) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14, 1}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{18, 1}
 }
 
 func (x *ConformancePayload_ConnectGetInfo) GetQueryParams() []*Header {
@@ -1644,7 +2259,7 @@ type RawHTTPRequest_EncodedQueryParam struct {
 func (x *RawHTTPRequest_EncodedQueryParam) Reset() {
 	*x = RawHTTPRequest_EncodedQueryParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1657,7 +2272,7 @@ func (x *RawHTTPRequest_EncodedQueryParam) String() string {
 func (*RawHTTPRequest_EncodedQueryParam) ProtoMessage() {}
 
 func (x *RawHTTPRequest_EncodedQueryParam) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1670,7 +2285,7 @@ func (x *RawHTTPRequest_EncodedQueryParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawHTTPRequest_EncodedQueryParam.ProtoReflect.Descriptor instead.
 func (*RawHTTPRequest_EncodedQueryParam) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17, 0}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{21, 0}
 }
 
 func (x *RawHTTPRequest_EncodedQueryParam) GetName() string {
@@ -1702,25 +2317,508 @@ type StreamContents_StreamItem struct {
 	Flags   uint32           `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`         // must be in the range 0 to 255.
 	Length  *uint32          `protobuf:"varint,2,opt,name=length,proto3,oneof" json:"length,omitempty"` // if absent use actual length of payload
 	Payload *MessageContents `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// If non-zero, the writer waits this many milliseconds before writing
+	// this item. This can be used to model a slow or stalled peer. If the
+	// preceding item's length is larger than its payload, the peer will
+	// have only received part of a message when the delay starts.
+	//
+	// This is not supported in HTTP/2 frame scripts, which instead can
+	// delay the frames that contain the items.
+	DelayMs uint32 `protobuf:"varint,4,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+	// If true, anything already written is flushed to the peer before the
+	// delay. Otherwise, it may be buffered until this item is written. This
+	// is ignored if delay_ms is zero.
+	FlushBeforeDelay bool `protobuf:"varint,5,opt,name=flush_before_delay,json=flushBeforeDelay,proto3" json:"flush_before_delay,omitempty"`
+}
+
+func (x *StreamContents_StreamItem) Reset() {
+	*x = StreamContents_StreamItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamContents_StreamItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamContents_StreamItem) ProtoMessage() {}
+
+func (x *StreamContents_StreamItem) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamContents_StreamItem.ProtoReflect.Descriptor instead.
+func (*StreamContents_StreamItem) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{23, 0}
+}
+
+func (x *StreamContents_StreamItem) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *StreamContents_StreamItem) GetLength() uint32 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+func (x *StreamContents_StreamItem) GetPayload() *MessageContents {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *StreamContents_StreamItem) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+func (x *StreamContents_StreamItem) GetFlushBeforeDelay() bool {
+	if x != nil {
+		return x.FlushBeforeDelay
+	}
+	return false
+}
+
+type HTTP2Frame_HeadersFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The value of the ":status" pseudo-header. If zero, 200 is used.
+	// This is ignored if trailers is true or if the frame is part of
+	// a request.
+	StatusCode uint32 `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// The headers to send.
+	Headers []*Header `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	// If true, this is a trailers block, which has no ":status"
+	// pseudo-header. Trailers normally also end the stream, but
+	// that is controlled separately via end_stream.
+	Trailers bool `protobuf:"varint,3,opt,name=trailers,proto3" json:"trailers,omitempty"`
+	// If true, the END_STREAM flag is set.
+	EndStream bool `protobuf:"varint,4,opt,name=end_stream,json=endStream,proto3" json:"end_stream,omitempty"`
+	// If non-zero, the header block is split into fragments of at most this
+	// many bytes. The first is sent in the HEADERS frame and the rest are
+	// sent in CONTINUATION frames. If zero, the header block is split only
+	// if it is larger than 16,384 bytes (the minimum maximum frame size).
+	MaxFragmentSize uint32 `protobuf:"varint,5,opt,name=max_fragment_size,json=maxFragmentSize,proto3" json:"max_fragment_size,omitempty"`
+	// If non-zero, an "x-filler" header is added whose value is this many
+	// bytes long. This is useful for creating very large header blocks.
+	FillerSize uint32 `protobuf:"varint,6,opt,name=filler_size,json=fillerSize,proto3" json:"filler_size,omitempty"`
+}
+
+func (x *HTTP2Frame_HeadersFrame) Reset() {
+	*x = HTTP2Frame_HeadersFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2Frame_HeadersFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Frame_HeadersFrame) ProtoMessage() {}
+
+func (x *HTTP2Frame_HeadersFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2Frame_HeadersFrame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame_HeadersFrame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26, 0}
+}
+
+func (x *HTTP2Frame_HeadersFrame) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *HTTP2Frame_HeadersFrame) GetHeaders() []*Header {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HTTP2Frame_HeadersFrame) GetTrailers() bool {
+	if x != nil {
+		return x.Trailers
+	}
+	return false
+}
+
+func (x *HTTP2Frame_HeadersFrame) GetEndStream() bool {
+	if x != nil {
+		return x.EndStream
+	}
+	return false
+}
+
+func (x *HTTP2Frame_HeadersFrame) GetMaxFragmentSize() uint32 {
+	if x != nil {
+		return x.MaxFragmentSize
+	}
+	return 0
+}
+
+func (x *HTTP2Frame_HeadersFrame) GetFillerSize() uint32 {
+	if x != nil {
+		return x.FillerSize
+	}
+	return 0
+}
+
+type HTTP2Frame_DataFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Contents:
+	//
+	//	*HTTP2Frame_DataFrame_Unary
+	//	*HTTP2Frame_DataFrame_Stream
+	Contents isHTTP2Frame_DataFrame_Contents `protobuf_oneof:"contents"`
+	// If true, the END_STREAM flag is set on the last frame.
+	EndStream bool `protobuf:"varint,3,opt,name=end_stream,json=endStream,proto3" json:"end_stream,omitempty"`
+	// If non-zero, each frame is padded with this many bytes.
+	// Must be in the range 0 to 255.
+	PadLength uint32 `protobuf:"varint,4,opt,name=pad_length,json=padLength,proto3" json:"pad_length,omitempty"`
+	// If non-zero, the data is split into frames of at most this many bytes.
+	// If zero, the data is split only if it is larger than 16,384 bytes.
+	MaxFrameSize uint32 `protobuf:"varint,5,opt,name=max_frame_size,json=maxFrameSize,proto3" json:"max_frame_size,omitempty"`
+}
+
+func (x *HTTP2Frame_DataFrame) Reset() {
+	*x = HTTP2Frame_DataFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2Frame_DataFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Frame_DataFrame) ProtoMessage() {}
+
+func (x *HTTP2Frame_DataFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2Frame_DataFrame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame_DataFrame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26, 1}
+}
+
+func (m *HTTP2Frame_DataFrame) GetContents() isHTTP2Frame_DataFrame_Contents {
+	if m != nil {
+		return m.Contents
+	}
+	return nil
+}
+
+func (x *HTTP2Frame_DataFrame) GetUnary() *MessageContents {
+	if x, ok := x.GetContents().(*HTTP2Frame_DataFrame_Unary); ok {
+		return x.Unary
+	}
+	return nil
+}
+
+func (x *HTTP2Frame_DataFrame) GetStream() *StreamContents {
+	if x, ok := x.GetContents().(*HTTP2Frame_DataFrame_Stream); ok {
+		return x.Stream
+	}
+	return nil
+}
+
+func (x *HTTP2Frame_DataFrame) GetEndStream() bool {
+	if x != nil {
+		return x.EndStream
+	}
+	return false
+}
+
+func (x *HTTP2Frame_DataFrame) GetPadLength() uint32 {
+	if x != nil {
+		return x.PadLength
+	}
+	return 0
+}
+
+func (x *HTTP2Frame_DataFrame) GetMaxFrameSize() uint32 {
+	if x != nil {
+		return x.MaxFrameSize
+	}
+	return 0
+}
+
+type isHTTP2Frame_DataFrame_Contents interface {
+	isHTTP2Frame_DataFrame_Contents()
+}
+
+type HTTP2Frame_DataFrame_Unary struct {
+	// The data is a single message.
+	Unary *MessageContents `protobuf:"bytes,1,opt,name=unary,proto3,oneof"`
+}
+
+type HTTP2Frame_DataFrame_Stream struct {
+	// The data is a stream, encoded using a five-byte
+	// prefix before each item in the stream.
+	Stream *StreamContents `protobuf:"bytes,2,opt,name=stream,proto3,oneof"`
+}
+
+func (*HTTP2Frame_DataFrame_Unary) isHTTP2Frame_DataFrame_Contents() {}
+
+func (*HTTP2Frame_DataFrame_Stream) isHTTP2Frame_DataFrame_Contents() {}
+
+type HTTP2Frame_RstStreamFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The HTTP/2 error code, such as 8 for CANCEL.
+	ErrorCode uint32 `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+}
+
+func (x *HTTP2Frame_RstStreamFrame) Reset() {
+	*x = HTTP2Frame_RstStreamFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2Frame_RstStreamFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Frame_RstStreamFrame) ProtoMessage() {}
+
+func (x *HTTP2Frame_RstStreamFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2Frame_RstStreamFrame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame_RstStreamFrame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26, 2}
+}
+
+func (x *HTTP2Frame_RstStreamFrame) GetErrorCode() uint32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+type HTTP2Frame_GoAwayFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The last stream ID that the server will process. If absent, the
+	// ID of the RPC's stream is used. A lower value, such as zero, can
+	// be used to indicate that the RPC's stream was not processed.
+	LastStreamId *uint32 `protobuf:"varint,1,opt,name=last_stream_id,json=lastStreamId,proto3,oneof" json:"last_stream_id,omitempty"`
+	// The HTTP/2 error code, such as 0 for NO_ERROR.
+	ErrorCode uint32 `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// Optional debug data.
+	DebugData []byte `protobuf:"bytes,3,opt,name=debug_data,json=debugData,proto3" json:"debug_data,omitempty"`
+}
+
+func (x *HTTP2Frame_GoAwayFrame) Reset() {
+	*x = HTTP2Frame_GoAwayFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2Frame_GoAwayFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Frame_GoAwayFrame) ProtoMessage() {}
+
+func (x *HTTP2Frame_GoAwayFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2Frame_GoAwayFrame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame_GoAwayFrame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26, 3}
+}
+
+func (x *HTTP2Frame_GoAwayFrame) GetLastStreamId() uint32 {
+	if x != nil && x.LastStreamId != nil {
+		return *x.LastStreamId
+	}
+	return 0
+}
+
+func (x *HTTP2Frame_GoAwayFrame) GetErrorCode() uint32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *HTTP2Frame_GoAwayFrame) GetDebugData() []byte {
+	if x != nil {
+		return x.DebugData
+	}
+	return nil
+}
+
+type HTTP2Frame_WindowUpdateFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// If true, the frame is written for the connection (stream zero)
+	// instead of for the RPC's stream.
+	Connection bool `protobuf:"varint,1,opt,name=connection,proto3" json:"connection,omitempty"`
+	// The window size increment. Zero is allowed, though it is
+	// a protocol error.
+	Increment uint32 `protobuf:"varint,2,opt,name=increment,proto3" json:"increment,omitempty"`
+}
+
+func (x *HTTP2Frame_WindowUpdateFrame) Reset() {
+	*x = HTTP2Frame_WindowUpdateFrame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTTP2Frame_WindowUpdateFrame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP2Frame_WindowUpdateFrame) ProtoMessage() {}
+
+func (x *HTTP2Frame_WindowUpdateFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP2Frame_WindowUpdateFrame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame_WindowUpdateFrame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26, 4}
+}
+
+func (x *HTTP2Frame_WindowUpdateFrame) GetConnection() bool {
+	if x != nil {
+		return x.Connection
+	}
+	return false
+}
+
+func (x *HTTP2Frame_WindowUpdateFrame) GetIncrement() uint32 {
+	if x != nil {
+		return x.Increment
+	}
+	return 0
+}
+
+type HTTP2Frame_RawFrame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The frame type.
+	Type uint32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	// The frame flags.
+	Flags uint32 `protobuf:"varint,2,opt,name=flags,proto3" json:"flags,omitempty"`
+	// The stream ID. If absent, the ID of the RPC's stream is used.
+	StreamId *uint32 `protobuf:"varint,3,opt,name=stream_id,json=streamId,proto3,oneof" json:"stream_id,omitempty"`
+	// The frame payload.
+	Payload []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
 }
 
-func (x *StreamContents_StreamItem) Reset() {
-	*x = StreamContents_StreamItem{}
+func (x *HTTP2Frame_RawFrame) Reset() {
+	*x = HTTP2Frame_RawFrame{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StreamContents_StreamItem) String() string {
+func (x *HTTP2Frame_RawFrame) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamContents_StreamItem) ProtoMessage() {}
+func (*HTTP2Frame_RawFrame) ProtoMessage() {}
 
-func (x *StreamContents_StreamItem) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
+func (x *HTTP2Frame_RawFrame) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1731,26 +2829,33 @@ func (x *StreamContents_StreamItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamContents_StreamItem.ProtoReflect.Descriptor instead.
-func (*StreamContents_StreamItem) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{19, 0}
+// Deprecated: Use HTTP2Frame_RawFrame.ProtoReflect.Descriptor instead.
+func (*HTTP2Frame_RawFrame) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{26, 5}
 }
 
-func (x *StreamContents_StreamItem) GetFlags() uint32 {
+func (x *HTTP2Frame_RawFrame) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *HTTP2Frame_RawFrame) GetFlags() uint32 {
 	if x != nil {
 		return x.Flags
 	}
 	return 0
 }
 
-func (x *StreamContents_StreamItem) GetLength() uint32 {
-	if x != nil && x.Length != nil {
-		return *x.Length
+func (x *HTTP2Frame_RawFrame) GetStreamId() uint32 {
+	if x != nil && x.StreamId != nil {
+		return *x.StreamId
 	}
 	return 0
 }
 
-func (x *StreamContents_StreamItem) GetPayload() *MessageContents {
+func (x *HTTP2Frame_RawFrame) GetPayload() []byte {
 	if x != nil {
 		return x.Payload
 	}
@@ -1768,336 +2873,512 @@ var file_connectrpc_conformance_v1_service_proto_rawDesc = []byte{
 	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f,
 	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9f, 0x03, 0x0a, 0x17, 0x55, 0x6e, 0x61, 0x72,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x12, 0x25, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74,
-	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65,
-	0x72, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64,
-	0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x4d,
-	0x0a, 0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
-	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x52, 0x0b, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x90, 0x03, 0x0a, 0x18, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65,
-	0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xac, 0x04, 0x0a, 0x17, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x25,
+	0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
 	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4e, 0x0a,
-	0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65,
-	0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x4d, 0x0a,
-	0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
-	0x0b, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x96, 0x01, 0x0a,
-	0x0c, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a,
-	0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e,
+	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69,
+	0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
 	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12,
-	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x58, 0x0a, 0x0d, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22,
-	0xa0, 0x01, 0x0a, 0x16, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e,
-	0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x13, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61,
-	0x74, 0x61, 0x22, 0x62, 0x0a, 0x17, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74,
-	0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61,
+	0x79, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x4d, 0x0a, 0x0c, 0x72,
+	0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61,
+	0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0b, 0x72,
+	0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x69, 0x6e,
+	0x66, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x69, 0x6e, 0x66, 0x6c,
+	0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x55, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64,
+	0x72, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x9f, 0x04, 0x0a, 0x18, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12,
+	0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
 	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x64,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54,
+	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x4d, 0x0a, 0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54,
+	0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0b, 0x72, 0x61, 0x77, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x15, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x65,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x73, 0x12, 0x55,
+	0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x72, 0x61,
+	0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44,
+	0x72, 0x61, 0x69, 0x6e, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x44, 0x72, 0x61, 0x69, 0x6e, 0x22, 0x92, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x30, 0x0a, 0x13, 0x61, 0x66, 0x74,
+	0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x11, 0x61, 0x66, 0x74, 0x65, 0x72, 0x4e,
+	0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x10, 0x61,
+	0x66, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52,
+	0x0f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x08, 0x0a, 0x06, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x22, 0x8c, 0x02, 0x0a, 0x0c, 0x55,
+	0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x13, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x12, 0x74, 0x0a, 0x1c, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1a, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x58, 0x0a, 0x0d, 0x55, 0x6e, 0x61,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0xa0, 0x01, 0x0a, 0x16, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65,
+	0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63,
 	0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x63, 0x6f,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f,
 	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x63, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x62, 0x0a, 0x17, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f,
+	0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x13, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x64, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x33, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x14, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x9d, 0x01, 0x0a,
+	0x13, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x14,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xbd, 0x01,
+	0x0a, 0x11, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x33, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x75, 0x6c,
+	0x6c, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x66, 0x75, 0x6c, 0x6c, 0x44, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5d, 0x0a,
+	0x12, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x16, 0x0a, 0x14,
+	0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3a, 0x0a,
+	0x1b, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x6e, 0x0a, 0x1c, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x90, 0x01, 0x0a, 0x11, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x63,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x65, 0x64,
+	0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x33, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x87, 0x04, 0x0a,
+	0x12, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0xa6, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x22, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x4d, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x08, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x66, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x3c, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
 	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x11, 0x42, 0x69,
-	0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x64, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x75,
-	0x70, 0x6c, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x75, 0x6c, 0x6c,
-	0x44, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5d, 0x0a, 0x12, 0x42, 0x69, 0x64,
-	0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x55, 0x6e, 0x69, 0x6d,
-	0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x17, 0x0a, 0x15, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x87, 0x04, 0x0a, 0x12, 0x43, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e,
-	0x66, 0x6f, 0x1a, 0xa6, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0e,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x22,
-	0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x88,
-	0x01, 0x01, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x12, 0x66, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f,
-	0x67, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x42,
+	0x0d, 0x0a, 0x0b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x1a, 0x56,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x44, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x33, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f,
 	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x0d, 0x0a, 0x0b,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x1a, 0x56, 0x0a, 0x0e, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x44, 0x0a,
-	0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a,
-	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01,
-	0x01, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x32, 0x0a,
-	0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0xd1, 0x04, 0x0a, 0x0e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x72, 0x62, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x61, 0x77, 0x5f, 0x71,
-	0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x72, 0x61, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x12, 0x6d, 0x0a, 0x14, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f,
-	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e,
-	0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52,
-	0x12, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00,
-	0x52, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x8e, 0x01, 0x0a,
-	0x11, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52,
+	0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x32, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0xa1, 0x05, 0x0a, 0x0e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x72, 0x62, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x3b, 0x0a,
+	0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x61,
+	0x77, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x72, 0x61, 0x77, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x6d, 0x0a, 0x14, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x52, 0x12, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
 	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
 	0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65,
-	0x36, 0x34, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0c, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x06, 0x0a,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xd2, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x3d, 0x0a, 0x0e, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x62, 0x69, 0x6e, 0x61, 0x72,
-	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x73, 0x48, 0x00, 0x52, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x4e, 0x0a, 0x0c, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x53, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x52, 0x0b, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x1a,
+	0x8e, 0x01, 0x0a, 0x11, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62,
+	0x61, 0x73, 0x65, 0x36, 0x34, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65,
+	0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xf7, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x06,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x3d, 0x0a, 0x0e,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x66, 0x6c, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x69, 0x6e,
+	0x66, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xb8, 0x02, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4a, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x1a, 0xd9, 0x01, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x74, 0x65, 0x6d,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1b, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x88, 0x01, 0x01, 0x12, 0x44, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x6c,
+	0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x65, 0x6c,
+	0x61, 0x79, 0x4d, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x5f, 0x62, 0x65,
+	0x66, 0x6f, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x44, 0x65, 0x6c,
+	0x61, 0x79, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x8f, 0x03,
+	0x0a, 0x0f, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x42, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x05, 0x75, 0x6e,
+	0x61, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00,
+	0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3d, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x69,
+	0x6c, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x08, 0x74,
+	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x4e, 0x0a, 0x0c, 0x66, 0x72, 0x61, 0x6d, 0x65,
+	0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xef, 0x01, 0x0a, 0x0e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4a, 0x0a,
-	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x74,
-	0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x90, 0x01, 0x0a, 0x0a, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1b,
-	0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
-	0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12, 0x44, 0x0a, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xbf, 0x02, 0x0a,
-	0x0f, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64,
-	0x65, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46,
+	0x72, 0x61, 0x6d, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x52, 0x0b, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22,
+	0x51, 0x0a, 0x10, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x53, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x12, 0x3d, 0x0a, 0x06, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x52, 0x06, 0x66, 0x72, 0x61, 0x6d,
+	0x65, 0x73, 0x22, 0x9e, 0x0b, 0x0a, 0x0a, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x12, 0x4e, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
 	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x42,
-	0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x05, 0x75, 0x6e, 0x61,
-	0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52,
-	0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3d, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c,
-	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x08, 0x74, 0x72,
-	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x32, 0xb8,
-	0x05, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5a, 0x0a, 0x05, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x27,
-	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x71, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x30, 0x01, 0x12, 0x71, 0x0a, 0x0c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
-	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x45, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50,
+	0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x46, 0x72, 0x61, 0x6d, 0x65,
+	0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x55, 0x0a, 0x0a, 0x72, 0x73, 0x74, 0x5f,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x2e, 0x52, 0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x72, 0x61,
+	0x6d, 0x65, 0x48, 0x00, 0x52, 0x09, 0x72, 0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x4c, 0x0a, 0x07, 0x67, 0x6f, 0x5f, 0x61, 0x77, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x31, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54,
+	0x50, 0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x47, 0x6f, 0x41, 0x77, 0x61, 0x79, 0x46, 0x72,
+	0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x06, 0x67, 0x6f, 0x41, 0x77, 0x61, 0x79, 0x12, 0x5e, 0x0a,
+	0x0d, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
 	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x6d, 0x0a, 0x0a, 0x42, 0x69, 0x64, 0x69, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2c, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x2e, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52,
+	0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x42, 0x0a,
+	0x03, 0x72, 0x61, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x2e, 0x52, 0x61, 0x77, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x03, 0x72, 0x61,
+	0x77, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x1a, 0xf4, 0x01, 0x0a,
+	0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3b,
+	0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74,
+	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x74,
+	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x72,
+	0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x72, 0x53,
+	0x69, 0x7a, 0x65, 0x1a, 0x84, 0x02, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x46, 0x72, 0x61, 0x6d,
+	0x65, 0x12, 0x42, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x05,
+	0x75, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
 	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73,
+	0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6e,
+	0x64, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x65, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x64,
+	0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70,
+	0x61, 0x64, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x0a,
+	0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x2f, 0x0a, 0x0e, 0x52, 0x73,
+	0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x89, 0x01, 0x0a, 0x0b,
+	0x47, 0x6f, 0x41, 0x77, 0x61, 0x79, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x0e, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x44, 0x61, 0x74, 0x61, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x1a, 0x51, 0x0a, 0x11, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x7e, 0x0a, 0x08, 0x52, 0x61,
+	0x77, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x20, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x08, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x0c, 0x0a, 0x0a,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x66, 0x72,
+	0x61, 0x6d, 0x65, 0x32, 0xc2, 0x06, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5a, 0x0a, 0x05, 0x55, 0x6e,
+	0x61, 0x72, 0x79, 0x12, 0x27, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
 	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x72, 0x0a, 0x0d, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x71, 0x0a, 0x0c, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x6d, 0x0a, 0x0a,
+	0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2c, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
 	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7d, 0x0a, 0x0f, 0x49, 0x64,
-	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x31, 0x2e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x72, 0x0a, 0x0d, 0x55,
+	0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x2e, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f,
-	0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65,
-	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x42, 0x8d, 0x02, 0x0a, 0x1d, 0x63, 0x6f,
-	0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x6e, 0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c,
-	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x7d, 0x0a, 0x0f, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61,
+	0x72, 0x79, 0x12, 0x31, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x12, 0x87,
+	0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x37, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x8d, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c,
+	0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c,
+	0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47,
+	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2112,94 +3393,127 @@ func file_connectrpc_conformance_v1_service_proto_rawDescGZIP() []byte {
 	return file_connectrpc_conformance_v1_service_proto_rawDescData
 }
 
-var file_connectrpc_conformance_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_connectrpc_conformance_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_connectrpc_conformance_v1_service_proto_goTypes = []interface{}{
 	(*UnaryResponseDefinition)(nil),           // 0: connectrpc.conformance.v1.UnaryResponseDefinition
 	(*StreamResponseDefinition)(nil),          // 1: connectrpc.conformance.v1.StreamResponseDefinition
-	(*UnaryRequest)(nil),                      // 2: connectrpc.conformance.v1.UnaryRequest
-	(*UnaryResponse)(nil),                     // 3: connectrpc.conformance.v1.UnaryResponse
-	(*IdempotentUnaryRequest)(nil),            // 4: connectrpc.conformance.v1.IdempotentUnaryRequest
-	(*IdempotentUnaryResponse)(nil),           // 5: connectrpc.conformance.v1.IdempotentUnaryResponse
-	(*ServerStreamRequest)(nil),               // 6: connectrpc.conformance.v1.ServerStreamRequest
-	(*ServerStreamResponse)(nil),              // 7: connectrpc.conformance.v1.ServerStreamResponse
-	(*ClientStreamRequest)(nil),               // 8: connectrpc.conformance.v1.ClientStreamRequest
-	(*ClientStreamResponse)(nil),              // 9: connectrpc.conformance.v1.ClientStreamResponse
-	(*BidiStreamRequest)(nil),                 // 10: connectrpc.conformance.v1.BidiStreamRequest
-	(*BidiStreamResponse)(nil),                // 11: connectrpc.conformance.v1.BidiStreamResponse
-	(*UnimplementedRequest)(nil),              // 12: connectrpc.conformance.v1.UnimplementedRequest
-	(*UnimplementedResponse)(nil),             // 13: connectrpc.conformance.v1.UnimplementedResponse
-	(*ConformancePayload)(nil),                // 14: connectrpc.conformance.v1.ConformancePayload
-	(*Error)(nil),                             // 15: connectrpc.conformance.v1.Error
-	(*Header)(nil),                            // 16: connectrpc.conformance.v1.Header
-	(*RawHTTPRequest)(nil),                    // 17: connectrpc.conformance.v1.RawHTTPRequest
-	(*MessageContents)(nil),                   // 18: connectrpc.conformance.v1.MessageContents
-	(*StreamContents)(nil),                    // 19: connectrpc.conformance.v1.StreamContents
-	(*RawHTTPResponse)(nil),                   // 20: connectrpc.conformance.v1.RawHTTPResponse
-	(*ConformancePayload_RequestInfo)(nil),    // 21: connectrpc.conformance.v1.ConformancePayload.RequestInfo
-	(*ConformancePayload_ConnectGetInfo)(nil), // 22: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
-	(*RawHTTPRequest_EncodedQueryParam)(nil),  // 23: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
-	(*StreamContents_StreamItem)(nil),         // 24: connectrpc.conformance.v1.StreamContents.StreamItem
-	(Code)(0),                                 // 25: connectrpc.conformance.v1.Code
-	(*anypb.Any)(nil),                         // 26: google.protobuf.Any
-	(Compression)(0),                          // 27: connectrpc.conformance.v1.Compression
+	(*ConnectionDrain)(nil),                   // 2: connectrpc.conformance.v1.ConnectionDrain
+	(*UnaryRequest)(nil),                      // 3: connectrpc.conformance.v1.UnaryRequest
+	(*UnaryResponse)(nil),                     // 4: connectrpc.conformance.v1.UnaryResponse
+	(*IdempotentUnaryRequest)(nil),            // 5: connectrpc.conformance.v1.IdempotentUnaryRequest
+	(*IdempotentUnaryResponse)(nil),           // 6: connectrpc.conformance.v1.IdempotentUnaryResponse
+	(*ServerStreamRequest)(nil),               // 7: connectrpc.conformance.v1.ServerStreamRequest
+	(*ServerStreamResponse)(nil),              // 8: connectrpc.conformance.v1.ServerStreamResponse
+	(*ClientStreamRequest)(nil),               // 9: connectrpc.conformance.v1.ClientStreamRequest
+	(*ClientStreamResponse)(nil),              // 10: connectrpc.conformance.v1.ClientStreamResponse
+	(*BidiStreamRequest)(nil),                 // 11: connectrpc.conformance.v1.BidiStreamRequest
+	(*BidiStreamResponse)(nil),                // 12: connectrpc.conformance.v1.BidiStreamResponse
+	(*UnimplementedRequest)(nil),              // 13: connectrpc.conformance.v1.UnimplementedRequest
+	(*UnimplementedResponse)(nil),             // 14: connectrpc.conformance.v1.UnimplementedResponse
+	(*GetServerObservationRequest)(nil),       // 15: connectrpc.conformance.v1.GetServerObservationRequest
+	(*GetServerObservationResponse)(nil),      // 16: connectrpc.conformance.v1.GetServerObservationResponse
+	(*ServerObservation)(nil),                 // 17: connectrpc.conformance.v1.ServerObservation
+	(*ConformancePayload)(nil),                // 18: connectrpc.conformance.v1.ConformancePayload
+	(*Error)(nil),                             // 19: connectrpc.conformance.v1.Error
+	(*Header)(nil),                            // 20: connectrpc.conformance.v1.Header
+	(*RawHTTPRequest)(nil),                    // 21: connectrpc.conformance.v1.RawHTTPRequest
+	(*MessageContents)(nil),                   // 22: connectrpc.conformance.v1.MessageContents
+	(*StreamContents)(nil),                    // 23: connectrpc.conformance.v1.StreamContents
+	(*RawHTTPResponse)(nil),                   // 24: connectrpc.conformance.v1.RawHTTPResponse
+	(*HTTP2FrameScript)(nil),                  // 25: connectrpc.conformance.v1.HTTP2FrameScript
+	(*HTTP2Frame)(nil),                        // 26: connectrpc.conformance.v1.HTTP2Frame
+	(*ConformancePayload_RequestInfo)(nil),    // 27: connectrpc.conformance.v1.ConformancePayload.RequestInfo
+	(*ConformancePayload_ConnectGetInfo)(nil), // 28: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
+	(*RawHTTPRequest_EncodedQueryParam)(nil),  // 29: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
+	(*StreamContents_StreamItem)(nil),         // 30: connectrpc.conformance.v1.StreamContents.StreamItem
+	(*HTTP2Frame_HeadersFrame)(nil),           // 31: connectrpc.conformance.v1.HTTP2Frame.HeadersFrame
+	(*HTTP2Frame_DataFrame)(nil),              // 32: connectrpc.conformance.v1.HTTP2Frame.DataFrame
+	(*HTTP2Frame_RstStreamFrame)(nil),         // 33: connectrpc.conformance.v1.HTTP2Frame.RstStreamFrame
+	(*HTTP2Frame_GoAwayFrame)(nil),            // 34: connectrpc.conformance.v1.HTTP2Frame.GoAwayFrame
+	(*HTTP2Frame_WindowUpdateFrame)(nil),      // 35: connectrpc.conformance.v1.HTTP2Frame.WindowUpdateFrame
+	(*HTTP2Frame_RawFrame)(nil),               // 36: connectrpc.conformance.v1.HTTP2Frame.RawFrame
+	(*emptypb.Empty)(nil),                     // 37: google.protobuf.Empty
+	(Code)(0),                                 // 38: connectrpc.conformance.v1.Code
+	(*anypb.Any)(nil),                         // 39: google.protobuf.Any
+	(Compression)(0),                          // 40: connectrpc.conformance.v1.Compression
 }
 var file_connectrpc_conformance_v1_service_proto_depIdxs = []int32{
-	16, // 0: connectrpc.conformance.v1.UnaryResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
-	15, // 1: connectrpc.conformance.v1.UnaryResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
-	16, // 2: connectrpc.conformance.v1.UnaryResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
-	20, // 3: connectrpc.conformance.v1.UnaryResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
-	16, // 4: connectrpc.conformance.v1.StreamResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
-	15, // 5: connectrpc.conformance.v1.StreamResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
-	16, // 6: connectrpc.conformance.v1.StreamResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
-	20, // 7: connectrpc.conformance.v1.StreamResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
-	0,  // 8: connectrpc.conformance.v1.UnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
-	14, // 9: connectrpc.conformance.v1.UnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	0,  // 10: connectrpc.conformance.v1.IdempotentUnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
-	14, // 11: connectrpc.conformance.v1.IdempotentUnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	1,  // 12: connectrpc.conformance.v1.ServerStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
-	14, // 13: connectrpc.conformance.v1.ServerStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	0,  // 14: connectrpc.conformance.v1.ClientStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
-	14, // 15: connectrpc.conformance.v1.ClientStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	1,  // 16: connectrpc.conformance.v1.BidiStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
-	14, // 17: connectrpc.conformance.v1.BidiStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	21, // 18: connectrpc.conformance.v1.ConformancePayload.request_info:type_name -> connectrpc.conformance.v1.ConformancePayload.RequestInfo
-	25, // 19: connectrpc.conformance.v1.Error.code:type_name -> connectrpc.conformance.v1.Code
-	26, // 20: connectrpc.conformance.v1.Error.details:type_name -> google.protobuf.Any
-	16, // 21: connectrpc.conformance.v1.RawHTTPRequest.headers:type_name -> connectrpc.conformance.v1.Header
-	16, // 22: connectrpc.conformance.v1.RawHTTPRequest.raw_query_params:type_name -> connectrpc.conformance.v1.Header
-	23, // 23: connectrpc.conformance.v1.RawHTTPRequest.encoded_query_params:type_name -> connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
-	18, // 24: connectrpc.conformance.v1.RawHTTPRequest.unary:type_name -> connectrpc.conformance.v1.MessageContents
-	19, // 25: connectrpc.conformance.v1.RawHTTPRequest.stream:type_name -> connectrpc.conformance.v1.StreamContents
-	26, // 26: connectrpc.conformance.v1.MessageContents.binary_message:type_name -> google.protobuf.Any
-	27, // 27: connectrpc.conformance.v1.MessageContents.compression:type_name -> connectrpc.conformance.v1.Compression
-	24, // 28: connectrpc.conformance.v1.StreamContents.items:type_name -> connectrpc.conformance.v1.StreamContents.StreamItem
-	16, // 29: connectrpc.conformance.v1.RawHTTPResponse.headers:type_name -> connectrpc.conformance.v1.Header
-	18, // 30: connectrpc.conformance.v1.RawHTTPResponse.unary:type_name -> connectrpc.conformance.v1.MessageContents
-	19, // 31: connectrpc.conformance.v1.RawHTTPResponse.stream:type_name -> connectrpc.conformance.v1.StreamContents
-	16, // 32: connectrpc.conformance.v1.RawHTTPResponse.trailers:type_name -> connectrpc.conformance.v1.Header
-	16, // 33: connectrpc.conformance.v1.ConformancePayload.RequestInfo.request_headers:type_name -> connectrpc.conformance.v1.Header
-	26, // 34: connectrpc.conformance.v1.ConformancePayload.RequestInfo.requests:type_name -> google.protobuf.Any
-	22, // 35: connectrpc.conformance.v1.ConformancePayload.RequestInfo.connect_get_info:type_name -> connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
-	16, // 36: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo.query_params:type_name -> connectrpc.conformance.v1.Header
-	18, // 37: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam.value:type_name -> connectrpc.conformance.v1.MessageContents
-	18, // 38: connectrpc.conformance.v1.StreamContents.StreamItem.payload:type_name -> connectrpc.conformance.v1.MessageContents
-	2,  // 39: connectrpc.conformance.v1.ConformanceService.Unary:input_type -> connectrpc.conformance.v1.UnaryRequest
-	6,  // 40: connectrpc.conformance.v1.ConformanceService.ServerStream:input_type -> connectrpc.conformance.v1.ServerStreamRequest
-	8,  // 41: connectrpc.conformance.v1.ConformanceService.ClientStream:input_type -> connectrpc.conformance.v1.ClientStreamRequest
-	10, // 42: connectrpc.conformance.v1.ConformanceService.BidiStream:input_type -> connectrpc.conformance.v1.BidiStreamRequest
-	12, // 43: connectrpc.conformance.v1.ConformanceService.Unimplemented:input_type -> connectrpc.conformance.v1.UnimplementedRequest
-	4,  // 44: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:input_type -> connectrpc.conformance.v1.IdempotentUnaryRequest
-	3,  // 45: connectrpc.conformance.v1.ConformanceService.Unary:output_type -> connectrpc.conformance.v1.UnaryResponse
-	7,  // 46: connectrpc.conformance.v1.ConformanceService.ServerStream:output_type -> connectrpc.conformance.v1.ServerStreamResponse
-	9,  // 47: connectrpc.conformance.v1.ConformanceService.ClientStream:output_type -> connectrpc.conformance.v1.ClientStreamResponse
-	11, // 48: connectrpc.conformance.v1.ConformanceService.BidiStream:output_type -> connectrpc.conformance.v1.BidiStreamResponse
-	13, // 49: connectrpc.conformance.v1.ConformanceService.Unimplemented:output_type -> connectrpc.conformance.v1.UnimplementedResponse
-	5,  // 50: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:output_type -> connectrpc.conformance.v1.IdempotentUnaryResponse
-	45, // [45:51] is the sub-list for method output_type
-	39, // [39:45] is the sub-list for method input_type
-	39, // [39:39] is the sub-list for extension type_name
-	39, // [39:39] is the sub-list for extension extendee
-	0,  // [0:39] is the sub-list for field type_name
+	20, // 0: connectrpc.conformance.v1.UnaryResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
+	19, // 1: connectrpc.conformance.v1.UnaryResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
+	20, // 2: connectrpc.conformance.v1.UnaryResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
+	24, // 3: connectrpc.conformance.v1.UnaryResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
+	2,  // 4: connectrpc.conformance.v1.UnaryResponseDefinition.connection_drain:type_name -> connectrpc.conformance.v1.ConnectionDrain
+	20, // 5: connectrpc.conformance.v1.StreamResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
+	19, // 6: connectrpc.conformance.v1.StreamResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
+	20, // 7: connectrpc.conformance.v1.StreamResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
+	24, // 8: connectrpc.conformance.v1.StreamResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
+	2,  // 9: connectrpc.conformance.v1.StreamResponseDefinition.connection_drain:type_name -> connectrpc.conformance.v1.ConnectionDrain
+	37, // 10: connectrpc.conformance.v1.ConnectionDrain.after_completion:type_name -> google.protobuf.Empty
+	0,  // 11: connectrpc.conformance.v1.UnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	0,  // 12: connectrpc.conformance.v1.UnaryRequest.attempt_response_definitions:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	18, // 13: connectrpc.conformance.v1.UnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	0,  // 14: connectrpc.conformance.v1.IdempotentUnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	18, // 15: connectrpc.conformance.v1.IdempotentUnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	1,  // 16: connectrpc.conformance.v1.ServerStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
+	18, // 17: connectrpc.conformance.v1.ServerStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	0,  // 18: connectrpc.conformance.v1.ClientStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	18, // 19: connectrpc.conformance.v1.ClientStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	1,  // 20: connectrpc.conformance.v1.BidiStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
+	18, // 21: connectrpc.conformance.v1.BidiStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	17, // 22: connectrpc.conformance.v1.GetServerObservationResponse.observation:type_name -> connectrpc.conformance.v1.ServerObservation
+	38, // 23: connectrpc.conformance.v1.ServerObservation.code:type_name -> connectrpc.conformance.v1.Code
+	27, // 24: connectrpc.conformance.v1.ConformancePayload.request_info:type_name -> connectrpc.conformance.v1.ConformancePayload.RequestInfo
+	38, // 25: connectrpc.conformance.v1.Error.code:type_name -> connectrpc.conformance.v1.Code
+	39, // 26: connectrpc.conformance.v1.Error.details:type_name -> google.protobuf.Any
+	20, // 27: connectrpc.conformance.v1.RawHTTPRequest.headers:type_name -> connectrpc.conformance.v1.Header
+	20, // 28: connectrpc.conformance.v1.RawHTTPRequest.raw_query_params:type_name -> connectrpc.conformance.v1.Header
+	29, // 29: connectrpc.conformance.v1.RawHTTPRequest.encoded_query_params:type_name -> connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
+	22, // 30: connectrpc.conformance.v1.RawHTTPRequest.unary:type_name -> connectrpc.conformance.v1.MessageContents
+	23, // 31: connectrpc.conformance.v1.RawHTTPRequest.stream:type_name -> connectrpc.conformance.v1.StreamContents
+	25, // 32: connectrpc.conformance.v1.RawHTTPRequest.frame_script:type_name -> connectrpc.conformance.v1.HTTP2FrameScript
+	39, // 33: connectrpc.conformance.v1.MessageContents.binary_message:type_name -> google.protobuf.Any
+	40, // 34: connectrpc.conformance.v1.MessageContents.compression:type_name -> connectrpc.conformance.v1.Compression
+	30, // 35: connectrpc.conformance.v1.StreamContents.items:type_name -> connectrpc.conformance.v1.StreamContents.StreamItem
+	20, // 36: connectrpc.conformance.v1.RawHTTPResponse.headers:type_name -> connectrpc.conformance.v1.Header
+	22, // 37: connectrpc.conformance.v1.RawHTTPResponse.unary:type_name -> connectrpc.conformance.v1.MessageContents
+	23, // 38: connectrpc.conformance.v1.RawHTTPResponse.stream:type_name -> connectrpc.conformance.v1.StreamContents
+	20, // 39: connectrpc.conformance.v1.RawHTTPResponse.trailers:type_name -> connectrpc.conformance.v1.Header
+	25, // 40: connectrpc.conformance.v1.RawHTTPResponse.frame_script:type_name -> connectrpc.conformance.v1.HTTP2FrameScript
+	26, // 41: connectrpc.conformance.v1.HTTP2FrameScript.frames:type_name -> connectrpc.conformance.v1.HTTP2Frame
+	31, // 42: connectrpc.conformance.v1.HTTP2Frame.headers:type_name -> connectrpc.conformance.v1.HTTP2Frame.HeadersFrame
+	32, // 43: connectrpc.conformance.v1.HTTP2Frame.data:type_name -> connectrpc.conformance.v1.HTTP2Frame.DataFrame
+	33, // 44: connectrpc.conformance.v1.HTTP2Frame.rst_stream:type_name -> connectrpc.conformance.v1.HTTP2Frame.RstStreamFrame
+	34, // 45: connectrpc.conformance.v1.HTTP2Frame.go_away:type_name -> connectrpc.conformance.v1.HTTP2Frame.GoAwayFrame
+	35, // 46: connectrpc.conformance.v1.HTTP2Frame.window_update:type_name -> connectrpc.conformance.v1.HTTP2Frame.WindowUpdateFrame
+	36, // 47: connectrpc.conformance.v1.HTTP2Frame.raw:type_name -> connectrpc.conformance.v1.HTTP2Frame.RawFrame
+	20, // 48: connectrpc.conformance.v1.ConformancePayload.RequestInfo.request_headers:type_name -> connectrpc.conformance.v1.Header
+	39, // 49: connectrpc.conformance.v1.ConformancePayload.RequestInfo.requests:type_name -> google.protobuf.Any
+	28, // 50: connectrpc.conformance.v1.ConformancePayload.RequestInfo.connect_get_info:type_name -> connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
+	20, // 51: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo.query_params:type_name -> connectrpc.conformance.v1.Header
+	22, // 52: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam.value:type_name -> connectrpc.conformance.v1.MessageContents
+	22, // 53: connectrpc.conformance.v1.StreamContents.StreamItem.payload:type_name -> connectrpc.conformance.v1.MessageContents
+	20, // 54: connectrpc.conformance.v1.HTTP2Frame.HeadersFrame.headers:type_name -> connectrpc.conformance.v1.Header
+	22, // 55: connectrpc.conformance.v1.HTTP2Frame.DataFrame.unary:type_name -> connectrpc.conformance.v1.MessageContents
+	23, // 56: connectrpc.conformance.v1.HTTP2Frame.DataFrame.stream:type_name -> connectrpc.conformance.v1.StreamContents
+	3,  // 57: connectrpc.conformance.v1.ConformanceService.Unary:input_type -> connectrpc.conformance.v1.UnaryRequest
+	7,  // 58: connectrpc.conformance.v1.ConformanceService.ServerStream:input_type -> connectrpc.conformance.v1.ServerStreamRequest
+	9,  // 59: connectrpc.conformance.v1.ConformanceService.ClientStream:input_type -> connectrpc.conformance.v1.ClientStreamRequest
+	11, // 60: connectrpc.conformance.v1.ConformanceService.BidiStream:input_type -> connectrpc.conformance.v1.BidiStreamRequest
+	13, // 61: connectrpc.conformance.v1.ConformanceService.Unimplemented:input_type -> connectrpc.conformance.v1.UnimplementedRequest
+	5,  // 62: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:input_type -> connectrpc.conformance.v1.IdempotentUnaryRequest
+	15, // 63: connectrpc.conformance.v1.ConformanceService.GetServerObservation:input_type -> connectrpc.conformance.v1.GetServerObservationRequest
+	4,  // 64: connectrpc.conformance.v1.ConformanceService.Unary:output_type -> connectrpc.conformance.v1.UnaryResponse
+	8,  // 65: connectrpc.conformance.v1.ConformanceService.ServerStream:output_type -> connectrpc.conformance.v1.ServerStreamResponse
+	10, // 66: connectrpc.conformance.v1.ConformanceService.ClientStream:output_type -> connectrpc.conformance.v1.ClientStreamResponse
+	12, // 67: connectrpc.conformance.v1.ConformanceService.BidiStream:output_type -> connectrpc.conformance.v1.BidiStreamResponse
+	14, // 68: connectrpc.conformance.v1.ConformanceService.Unimplemented:output_type -> connectrpc.conformance.v1.UnimplementedResponse
+	6,  // 69: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:output_type -> connectrpc.conformance.v1.IdempotentUnaryResponse
+	16, // 70: connectrpc.conformance.v1.ConformanceService.GetServerObservation:output_type -> connectrpc.conformance.v1.GetServerObservationResponse
+	64, // [64:71] is the sub-list for method output_type
+	57, // [57:64] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_connectrpc_conformance_v1_service_proto_init() }
@@ -2234,7 +3548,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnaryRequest); i {
+			switch v := v.(*ConnectionDrain); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2246,7 +3560,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnaryResponse); i {
+			switch v := v.(*UnaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2258,7 +3572,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IdempotentUnaryRequest); i {
+			switch v := v.(*UnaryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2270,7 +3584,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IdempotentUnaryResponse); i {
+			switch v := v.(*IdempotentUnaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2282,7 +3596,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerStreamRequest); i {
+			switch v := v.(*IdempotentUnaryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2294,7 +3608,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerStreamResponse); i {
+			switch v := v.(*ServerStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2306,7 +3620,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientStreamRequest); i {
+			switch v := v.(*ServerStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2318,7 +3632,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientStreamResponse); i {
+			switch v := v.(*ClientStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2330,7 +3644,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BidiStreamRequest); i {
+			switch v := v.(*ClientStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2342,7 +3656,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BidiStreamResponse); i {
+			switch v := v.(*BidiStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2354,7 +3668,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnimplementedRequest); i {
+			switch v := v.(*BidiStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2366,7 +3680,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnimplementedResponse); i {
+			switch v := v.(*UnimplementedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2378,7 +3692,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConformancePayload); i {
+			switch v := v.(*UnimplementedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2390,7 +3704,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Error); i {
+			switch v := v.(*GetServerObservationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2402,7 +3716,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Header); i {
+			switch v := v.(*GetServerObservationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2414,7 +3728,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawHTTPRequest); i {
+			switch v := v.(*ServerObservation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2426,7 +3740,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageContents); i {
+			switch v := v.(*ConformancePayload); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2438,7 +3752,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamContents); i {
+			switch v := v.(*Error); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2450,7 +3764,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawHTTPResponse); i {
+			switch v := v.(*Header); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2462,7 +3776,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConformancePayload_RequestInfo); i {
+			switch v := v.(*RawHTTPRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2474,7 +3788,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConformancePayload_ConnectGetInfo); i {
+			switch v := v.(*MessageContents); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2486,7 +3800,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawHTTPRequest_EncodedQueryParam); i {
+			switch v := v.(*StreamContents); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2498,6 +3812,78 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawHTTPResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2FrameScript); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConformancePayload_RequestInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConformancePayload_ConnectGetInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawHTTPRequest_EncodedQueryParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamContents_StreamItem); i {
 			case 0:
 				return &v.state
@@ -2509,34 +3895,124 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 				return nil
 			}
 		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame_HeadersFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame_DataFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame_RstStreamFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame_GoAwayFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame_WindowUpdateFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HTTP2Frame_RawFrame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_connectrpc_conformance_v1_service_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*UnaryResponseDefinition_ResponseData)(nil),
 		(*UnaryResponseDefinition_Error)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[15].OneofWrappers = []interface{}{}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_service_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*ConnectionDrain_AfterNumResponses)(nil),
+		(*ConnectionDrain_AfterCompletion)(nil),
+	}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[19].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[21].OneofWrappers = []interface{}{
 		(*RawHTTPRequest_Unary)(nil),
 		(*RawHTTPRequest_Stream)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[18].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_service_proto_msgTypes[22].OneofWrappers = []interface{}{
 		(*MessageContents_Binary)(nil),
 		(*MessageContents_Text)(nil),
 		(*MessageContents_BinaryMessage)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_service_proto_msgTypes[24].OneofWrappers = []interface{}{
 		(*RawHTTPResponse_Unary)(nil),
 		(*RawHTTPResponse_Stream)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[21].OneofWrappers = []interface{}{}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[24].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[26].OneofWrappers = []interface{}{
+		(*HTTP2Frame_Headers)(nil),
+		(*HTTP2Frame_Data)(nil),
+		(*HTTP2Frame_RstStream)(nil),
+		(*HTTP2Frame_GoAway)(nil),
+		(*HTTP2Frame_WindowUpdate)(nil),
+		(*HTTP2Frame_Raw)(nil),
+	}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[27].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[30].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[32].OneofWrappers = []interface{}{
+		(*HTTP2Frame_DataFrame_Unary)(nil),
+		(*HTTP2Frame_DataFrame_Stream)(nil),
+	}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[34].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[36].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_connectrpc_conformance_v1_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -33,12 +33,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ConformanceService_Unary_FullMethodName           = "/connectrpc.conformance.v1.ConformanceService/Unary"
-	ConformanceService_ServerStream_FullMethodName    = "/connectrpc.conformance.v1.ConformanceService/ServerStream"
-	ConformanceService_ClientStream_FullMethodName    = "/connectrpc.conformance.v1.ConformanceService/ClientStream"
-	ConformanceService_BidiStream_FullMethodName      = "/connectrpc.conformance.v1.ConformanceService/BidiStream"
-	ConformanceService_Unimplemented_FullMethodName   = "/connectrpc.conformance.v1.ConformanceService/Unimplemented"
-	ConformanceService_IdempotentUnary_FullMethodName = "/connectrpc.conformance.v1.ConformanceService/IdempotentUnary"
+	ConformanceService_Unary_FullMethodName                = "/connectrpc.conformance.v1.ConformanceService/Unary"
+	ConformanceService_ServerStream_FullMethodName         = "/connectrpc.conformance.v1.ConformanceService/ServerStream"
+	ConformanceService_ClientStream_FullMethodName         = "/connectrpc.conformance.v1.ConformanceService/ClientStream"
+	ConformanceService_BidiStream_FullMethodName           = "/connectrpc.conformance.v1.ConformanceService/BidiStream"
+	ConformanceService_Unimplemented_FullMethodName        = "/connectrpc.conformance.v1.ConformanceService/Unimplemented"
+	ConformanceService_IdempotentUnary_FullMethodName      = "/connectrpc.conformance.v1.ConformanceService/IdempotentUnary"
+	ConformanceService_GetServerObservation_FullMethodName = "/connectrpc.conformance.v1.ConformanceService/GetServerObservation"
 )
 
 // ConformanceServiceClient is the client API for ConformanceService service.
@@ -155,6 +156,26 @@ type ConformanceServiceClient interface {
 	// Implementations should use an HTTP GET when invoking this endpoint and
 	// leverage query parameters to send data.
 	IdempotentUnary(ctx context.Context, in *IdempotentUnaryRequest, opts ...grpc.CallOption) (*IdempotentUnaryResponse, error)
+	// A unary operation that returns what the server observed while handling
+	// an earlier RPC. The earlier RPC is identified by the value of its
+	// "x-test-case-name" request header, which is given in the request.
+	//
+	// Servers should record, for every RPC they handle whose request includes
+	// an "x-expect-server-observation" header, whether the handler's context
+	// was canceled before the handler returned, either because the client
+	// canceled the RPC or because its deadline elapsed. Other RPCs need not
+	// be observed. If there were multiple RPCs for the same test case, the
+	// most recent one is reported. Servers may forget an observation once it
+	// has been reported, or if it isn't requested soon after the RPC ends.
+	//
+	// If the server has not yet received an RPC for the given test case, or if
+	// that RPC is still in progress and its context has not been canceled, the
+	// server should wait until that changes before responding. If that does not
+	// happen before this RPC's own deadline, the RPC fails.
+	//
+	// Servers need only implement this method if their configuration
+	// indicates support for server observations.
+	GetServerObservation(ctx context.Context, in *GetServerObservationRequest, opts ...grpc.CallOption) (*GetServerObservationResponse, error)
 }
 
 type conformanceServiceClient struct {
@@ -289,6 +310,15 @@ func (c *conformanceServiceClient) IdempotentUnary(ctx context.Context, in *Idem
 	return out, nil
 }
 
+func (c *conformanceServiceClient) GetServerObservation(ctx context.Context, in *GetServerObservationRequest, opts ...grpc.CallOption) (*GetServerObservationResponse, error) {
+	out := new(GetServerObservationResponse)
+	err := c.cc.Invoke(ctx, ConformanceService_GetServerObservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConformanceServiceServer is the server API for ConformanceService service.
 // All implementations must embed UnimplementedConformanceServiceServer
 // for forward compatibility
@@ -403,6 +433,26 @@ type ConformanceServiceServer interface {
 	// Implementations should use an HTTP GET when invoking this endpoint and
 	// leverage query parameters to send data.
 	IdempotentUnary(context.Context, *IdempotentUnaryRequest) (*IdempotentUnaryResponse, error)
+	// A unary operation that returns what the server observed while handling
+	// an earlier RPC. The earlier RPC is identified by the value of its
+	// "x-test-case-name" request header, which is given in the request.
+	//
+	// Servers should record, for every RPC they handle whose request includes
+	// an "x-expect-server-observation" header, whether the handler's context
+	// was canceled before the handler returned, either because the client
+	// canceled the RPC or because its deadline elapsed. Other RPCs need not
+	// be observed. If there were multiple RPCs for the same test case, the
+	// most recent one is reported. Servers may forget an observation once it
+	// has been reported, or if it isn't requested soon after the RPC ends.
+	//
+	// If the server has not yet received an RPC for the given test case, or if
+	// that RPC is still in progress and its context has not been canceled, the
+	// server should wait until that changes before responding. If that does not
+	// happen before this RPC's own deadline, the RPC fails.
+	//
+	// Servers need only implement this method if their configuration
+	// indicates support for server observations.
+	GetServerObservation(context.Context, *GetServerObservationRequest) (*GetServerObservationResponse, error)
 	mustEmbedUnimplementedConformanceServiceServer()
 }
 
@@ -428,6 +478,9 @@ func (UnimplementedConformanceServiceServer) Unimplemented(context.Context, *Uni
 func (UnimplementedConformanceServiceServer) IdempotentUnary(context.Context, *IdempotentUnaryRequest) (*IdempotentUnaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IdempotentUnary not implemented")
 }
+func (UnimplementedConformanceServiceServer) GetServerObservation(context.Context, *GetServerObservationRequest) (*GetServerObservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerObservation not implemented")
+}
 func (UnimplementedConformanceServiceServer) mustEmbedUnimplementedConformanceServiceServer() {}
 
 // UnsafeConformanceServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -568,6 +621,24 @@ func _ConformanceService_IdempotentUnary_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConformanceService_GetServerObservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServerObservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConformanceServiceServer).GetServerObservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConformanceService_GetServerObservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConformanceServiceServer).GetServerObservation(ctx, req.(*GetServerObservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConformanceService_ServiceDesc is the grpc.ServiceDesc for ConformanceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -587,6 +658,10 @@ var ConformanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IdempotentUnary",
 			Handler:    _ConformanceService_IdempotentUnary_Handler,
 		},
+		{
+			MethodName: "GetServerObservation",
+			Handler:    _ConformanceService_GetServerObservation_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -147,6 +147,58 @@ func (TestSuite_ConnectVersionMode) EnumDescriptor() ([]byte, []int) {
 	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{0, 1}
 }
 
+type TestCase_ExpandedMetadata_Kind int32
+
+const (
+	TestCase_ExpandedMetadata_KIND_UNSPECIFIED       TestCase_ExpandedMetadata_Kind = 0
+	TestCase_ExpandedMetadata_KIND_REQUEST_HEADERS   TestCase_ExpandedMetadata_Kind = 1
+	TestCase_ExpandedMetadata_KIND_RESPONSE_HEADERS  TestCase_ExpandedMetadata_Kind = 2
+	TestCase_ExpandedMetadata_KIND_RESPONSE_TRAILERS TestCase_ExpandedMetadata_Kind = 3
+)
+
+// Enum value maps for TestCase_ExpandedMetadata_Kind.
+var (
+	TestCase_ExpandedMetadata_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_REQUEST_HEADERS",
+		2: "KIND_RESPONSE_HEADERS",
+		3: "KIND_RESPONSE_TRAILERS",
+	}
+	TestCase_ExpandedMetadata_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED":       0,
+		"KIND_REQUEST_HEADERS":   1,
+		"KIND_RESPONSE_HEADERS":  2,
+		"KIND_RESPONSE_TRAILERS": 3,
+	}
+)
+
+func (x TestCase_ExpandedMetadata_Kind) Enum() *TestCase_ExpandedMetadata_Kind {
+	p := new(TestCase_ExpandedMetadata_Kind)
+	*p = x
+	return p
+}
+
+func (x TestCase_ExpandedMetadata_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TestCase_ExpandedMetadata_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_connectrpc_conformance_v1_suite_proto_enumTypes[2].Descriptor()
+}
+
+func (TestCase_ExpandedMetadata_Kind) Type() protoreflect.EnumType {
+	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[2]
+}
+
+func (x TestCase_ExpandedMetadata_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TestCase_ExpandedMetadata_Kind.Descriptor instead.
+func (TestCase_ExpandedMetadata_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{1, 1, 0}
+}
+
 // TestSuite represents a set of conformance test cases. This is also the schema
 // used for the structure of a YAML test file. Each YAML file represents a test
 // suite, which can contain numerous cases. Each test suite has various properties
@@ -195,6 +247,26 @@ type TestSuite struct {
 	// size of received messages. When true, mode should be set to indicate
 	// whether it is the client or the server that must support the limit.
 	ReliesOnMessageReceiveLimit bool `protobuf:"varint,12,opt,name=relies_on_message_receive_limit,json=reliesOnMessageReceiveLimit,proto3" json:"relies_on_message_receive_limit,omitempty"`
+	// If true, the cases in this suite rely on the compression used for
+	// responses differing from the compression used for requests. The
+	// cases are run for every pair of distinct compression algorithms
+	// (from relevant_compressions, if non-empty).
+	ReliesOnAsymmetricCompression bool `protobuf:"varint,13,opt,name=relies_on_asymmetric_compression,json=reliesOnAsymmetricCompression,proto3" json:"relies_on_asymmetric_compression,omitempty"`
+	// If true, the cases in this suite rely on support for limiting the
+	// size of sent messages. This is only allowed when mode is
+	// TEST_MODE_CLIENT.
+	ReliesOnMessageSendLimit bool `protobuf:"varint,14,opt,name=relies_on_message_send_limit,json=reliesOnMessageSendLimit,proto3" json:"relies_on_message_send_limit,omitempty"`
+	// If true, the cases in this suite rely on support for retrying RPCs.
+	// This is only allowed when mode is TEST_MODE_CLIENT.
+	ReliesOnRetries bool `protobuf:"varint,15,opt,name=relies_on_retries,json=reliesOnRetries,proto3" json:"relies_on_retries,omitempty"`
+	// If true, the cases in this suite rely on the server implementing the
+	// GetServerObservation method. This is only allowed when mode is
+	// TEST_MODE_SERVER.
+	ReliesOnServerObservations bool `protobuf:"varint,16,opt,name=relies_on_server_observations,json=reliesOnServerObservations,proto3" json:"relies_on_server_observations,omitempty"`
+	// If true, the cases in this suite rely on support for limiting the
+	// size of headers and trailers. When true, mode should be set to indicate
+	// whether it is the client or the server that must support the limit.
+	ReliesOnHeaderSizeLimit bool `protobuf:"varint,17,opt,name=relies_on_header_size_limit,json=reliesOnHeaderSizeLimit,proto3" json:"relies_on_header_size_limit,omitempty"`
 }
 
 func (x *TestSuite) Reset() {
@@ -313,6 +385,41 @@ func (x *TestSuite) GetReliesOnMessageReceiveLimit() bool {
 	return false
 }
 
+func (x *TestSuite) GetReliesOnAsymmetricCompression() bool {
+	if x != nil {
+		return x.ReliesOnAsymmetricCompression
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnMessageSendLimit() bool {
+	if x != nil {
+		return x.ReliesOnMessageSendLimit
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnRetries() bool {
+	if x != nil {
+		return x.ReliesOnRetries
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnServerObservations() bool {
+	if x != nil {
+		return x.ReliesOnServerObservations
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnHeaderSizeLimit() bool {
+	if x != nil {
+		return x.ReliesOnHeaderSizeLimit
+	}
+	return false
+}
+
 type TestCase struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -336,7 +443,9 @@ type TestCase struct {
 	// so on. For each entry, if the size is present, it is used to expand
 	// the data field in the request (which is actually part of the response
 	// definition). The specified size is added to the current limit on
-	// message size that the server will accept. That sum is the size of the
+	// message size that the server will accept. (If the suite's mode is
+	// TEST_MODE_CLIENT, it is instead added to the limit on message size
+	// that the client will send.) That sum is the size of the
 	// the serialized message that will be sent, and the data field will be
 	// padded as needed to reach that size.
 	ExpandRequests []*TestCase_ExpandedSize `protobuf:"bytes,2,rep,name=expand_requests,json=expandRequests,proto3" json:"expand_requests,omitempty"`
@@ -364,8 +473,80 @@ type TestCase struct {
 	// actual error code returned may be flexible. In that case, this field provides
 	// other acceptable error codes, in addition to the one indicated in the
 	// expected_response. As long as the actual error's code matches any of these, the
-	// error is considered conformant, and the test case can pass.
+	// error is considered conformant, and the test case can pass. If the actual
+	// error's code is one of these, instead of the one in expected_response,
+	// then the expected HTTP status code is not checked, since a different
+	// error usually comes with a different status.
 	OtherAllowedErrorCodes []Code `protobuf:"varint,4,rep,packed,name=other_allowed_error_codes,json=otherAllowedErrorCodes,proto3,enum=connectrpc.conformance.v1.Code" json:"other_allowed_error_codes,omitempty"`
+	// When expected_response includes an HTTP/2 stream outcome, in some cases,
+	// the actual outcome may be flexible. In that case, this field provides other
+	// acceptable outcomes, in addition to the one indicated in the expected_response.
+	// As long as the actual outcome matches any of these, the test case can pass.
+	OtherAllowedHttp2Outcomes []*HTTP2StreamOutcome `protobuf:"bytes,5,rep,name=other_allowed_http2_outcomes,json=otherAllowedHttp2Outcomes,proto3" json:"other_allowed_http2_outcomes,omitempty"`
+	// This is the response-side equivalent of expand_requests, for testing the
+	// limit on message size that the client will accept. It may only be used
+	// in test suites whose mode is TEST_MODE_CLIENT. When non-empty, the first
+	// request message must include a response definition with response data.
+	// For unary and client-stream RPCs, this may have at most one entry. For
+	// other RPCs, this should have no more entries than there are values in
+	// the response definition's response_data. The first value is applied to
+	// the first response message, and so on.
+	//
+	// For each entry, if the size is present, the specified size is added to the
+	// current limit on message size that the client will accept. The response
+	// data is then padded (by the reference server) so that the serialized
+	// response message is that size. Note that the size does not account for the
+	// request info that the server echoes back in the payload, so a response
+	// message that includes request info will be larger than this size by that
+	// much. Test cases that expect a response to be accepted should leave enough
+	// room for that, unless the response has no request info, like a response in
+	// a server stream that is not the first.
+	//
+	// If an expected response is not specified explicitly, one is generated that
+	// includes the padded response data. If any entry has a size that is greater
+	// than zero, the generated expected response instead includes a
+	// RESOURCE_EXHAUSTED error after any responses that precede the first such
+	// entry.
+	ExpandResponses []*TestCase_ExpandedSize `protobuf:"bytes,6,rep,name=expand_responses,json=expandResponses,proto3" json:"expand_responses,omitempty"`
+	// If non-zero, the test runner asks the server to shut down gracefully
+	// this many milliseconds after the test case is sent to the client. The
+	// RPC should still be in progress at that time, and it is expected to
+	// complete normally. A server is asked to shut down by sending it a
+	// SIGTERM signal. So cases with this field are run against a separate
+	// server process, shared only with other cases that have the same value.
+	// It may only be used in test suites whose mode is TEST_MODE_SERVER.
+	GracefulShutdownAfterMs uint32 `protobuf:"varint,7,opt,name=graceful_shutdown_after_ms,json=gracefulShutdownAfterMs,proto3" json:"graceful_shutdown_after_ms,omitempty"`
+	// If true, the client is expected to surface each message in the response
+	// stream as soon as it is received, instead of buffering the stream. The
+	// first request message must include a response definition with at least
+	// two values in response_data and a non-zero response_delay_ms, so that the
+	// server sends the messages that far apart. The payload_receive_times_ms
+	// reported by the client must reflect that spacing. It may only be used for
+	// server-stream and bidi-stream RPCs in test suites whose mode is
+	// TEST_MODE_CLIENT.
+	//
+	// If an expected response is not specified explicitly, or it does not
+	// include payload_receive_times_ms, the expected receive times are computed
+	// from the response delay.
+	ExpectIncrementalResponses bool `protobuf:"varint,8,opt,name=expect_incremental_responses,json=expectIncrementalResponses,proto3" json:"expect_incremental_responses,omitempty"`
+	// To support very large metadata without having to spell it out in YAML
+	// test cases, this value can be specified. Each entry adds header (or
+	// trailer) fields to the request headers or to the response definition in
+	// the first request message. The fields added are named "x-padding-1",
+	// "x-padding-2", and so on. Their total size is relative to the current
+	// limit on header size. Test cases whose size is greater than zero may
+	// only be used in test suites that rely on the header size limit.
+	//
+	// If an expected response is not specified explicitly, one is generated.
+	// If the added fields are within the limit, the generated response is the
+	// same as if they had been defined explicitly. If an entry has a size that
+	// is greater than zero, the generated response instead includes an error:
+	// a client should reject the response with a RESOURCE_EXHAUSTED or INTERNAL
+	// error, and a server should reject the request with an HTTP 431 status
+	// (which the client reports as UNKNOWN). With HTTP/2 and HTTP/3, the server
+	// may instead reset the stream, which the client may report as
+	// RESOURCE_EXHAUSTED or INTERNAL.
+	ExpandMetadata []*TestCase_ExpandedMetadata `protobuf:"bytes,9,rep,name=expand_metadata,json=expandMetadata,proto3" json:"expand_metadata,omitempty"`
 }
 
 func (x *TestCase) Reset() {
@@ -428,6 +609,41 @@ func (x *TestCase) GetOtherAllowedErrorCodes() []Code {
 	return nil
 }
 
+func (x *TestCase) GetOtherAllowedHttp2Outcomes() []*HTTP2StreamOutcome {
+	if x != nil {
+		return x.OtherAllowedHttp2Outcomes
+	}
+	return nil
+}
+
+func (x *TestCase) GetExpandResponses() []*TestCase_ExpandedSize {
+	if x != nil {
+		return x.ExpandResponses
+	}
+	return nil
+}
+
+func (x *TestCase) GetGracefulShutdownAfterMs() uint32 {
+	if x != nil {
+		return x.GracefulShutdownAfterMs
+	}
+	return 0
+}
+
+func (x *TestCase) GetExpectIncrementalResponses() bool {
+	if x != nil {
+		return x.ExpectIncrementalResponses
+	}
+	return false
+}
+
+func (x *TestCase) GetExpandMetadata() []*TestCase_ExpandedMetadata {
+	if x != nil {
+		return x.ExpandMetadata
+	}
+	return nil
+}
+
 type TestCase_ExpandedSize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -479,6 +695,90 @@ func (x *TestCase_ExpandedSize) GetSizeRelativeToLimit() int32 {
 	return 0
 }
 
+type TestCase_ExpandedMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Where the fields are added. Request headers may only be used in test
+	// suites whose mode is TEST_MODE_SERVER. Response headers and trailers
+	// may only be used in test suites whose mode is TEST_MODE_CLIENT.
+	Kind TestCase_ExpandedMetadata_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=connectrpc.conformance.v1.TestCase_ExpandedMetadata_Kind" json:"kind,omitempty"`
+	// The total size, in bytes, relative to the limit. The size of each
+	// field is computed the same way as for HTTP/2's
+	// SETTINGS_MAX_HEADER_LIST_SIZE: the length of the name, plus the length
+	// of the value, plus 32. This is only the size of the added fields: it
+	// does not include any other headers or trailers. So test cases that
+	// expect the metadata to be accepted should leave room for those.
+	SizeRelativeToLimit int32 `protobuf:"varint,2,opt,name=size_relative_to_limit,json=sizeRelativeToLimit,proto3" json:"size_relative_to_limit,omitempty"`
+	// The number of fields to add. The total size is divided evenly among
+	// them. If zero, a single field is added.
+	NumFields uint32 `protobuf:"varint,3,opt,name=num_fields,json=numFields,proto3" json:"num_fields,omitempty"`
+	// If true, the fields are binary: their names end in "-bin" and their
+	// values are base64-encoded, like in the Header message.
+	Binary bool `protobuf:"varint,4,opt,name=binary,proto3" json:"binary,omitempty"`
+}
+
+func (x *TestCase_ExpandedMetadata) Reset() {
+	*x = TestCase_ExpandedMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestCase_ExpandedMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCase_ExpandedMetadata) ProtoMessage() {}
+
+func (x *TestCase_ExpandedMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCase_ExpandedMetadata.ProtoReflect.Descriptor instead.
+func (*TestCase_ExpandedMetadata) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *TestCase_ExpandedMetadata) GetKind() TestCase_ExpandedMetadata_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return TestCase_ExpandedMetadata_KIND_UNSPECIFIED
+}
+
+func (x *TestCase_ExpandedMetadata) GetSizeRelativeToLimit() int32 {
+	if x != nil {
+		return x.SizeRelativeToLimit
+	}
+	return 0
+}
+
+func (x *TestCase_ExpandedMetadata) GetNumFields() uint32 {
+	if x != nil {
+		return x.NumFields
+	}
+	return 0
+}
+
+func (x *TestCase_ExpandedMetadata) GetBinary() bool {
+	if x != nil {
+		return x.Binary
+	}
+	return false
+}
+
 var File_connectrpc_conformance_v1_suite_proto protoreflect.FileDescriptor
 
 var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
@@ -491,7 +791,7 @@ var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
 	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x26, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x08, 0x0a, 0x09, 0x54, 0x65,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc, 0x0a, 0x0a, 0x09, 0x54, 0x65,
 	0x73, 0x74, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x04, 0x6d,
 	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
@@ -544,49 +844,115 @@ var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
 	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x1b, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x4d, 0x65, 0x73,
 	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x22, 0x51, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x15,
-	0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x45, 0x53, 0x54, 0x5f,
-	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a,
-	0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45,
-	0x52, 0x10, 0x02, 0x22, 0x7d, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4e,
-	0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44,
-	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
-	0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x10,
-	0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52,
-	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x47, 0x4e, 0x4f, 0x52, 0x45,
-	0x10, 0x02, 0x22, 0xce, 0x03, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x12,
-	0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x0f, 0x65, 0x78, 0x70,
-	0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64,
-	0x53, 0x69, 0x7a, 0x65, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x5a, 0x0a, 0x19, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x61, 0x6c, 0x6c, 0x6f,
-	0x77, 0x65, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x12, 0x47, 0x0a, 0x20, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x61, 0x73,
+	0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1d, 0x72, 0x65, 0x6c, 0x69,
+	0x65, 0x73, 0x4f, 0x6e, 0x41, 0x73, 0x79, 0x6d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x43, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x1c, 0x72, 0x65, 0x6c,
+	0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x18, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x53, 0x65, 0x6e, 0x64, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x6c,
+	0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x52, 0x65,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x1d, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x5f,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x72, 0x65,
+	0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x1b, 0x72, 0x65, 0x6c, 0x69,
+	0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x72,
+	0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x53, 0x69, 0x7a,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x51, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a,
+	0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e,
+	0x54, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x10, 0x02, 0x22, 0x7d, 0x0a, 0x12, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
+	0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54,
+	0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45,
+	0x51, 0x55, 0x49, 0x52, 0x45, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x49, 0x47, 0x4e, 0x4f, 0x52, 0x45, 0x10, 0x02, 0x22, 0xb8, 0x09, 0x0a, 0x08, 0x54, 0x65, 0x73,
+	0x74, 0x43, 0x61, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x59, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78,
+	0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x61,
+	0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
 	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x16, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x41, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x63,
-	0x0a, 0x0c, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x38,
-	0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f,
-	0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
-	0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x42, 0x8b, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x19, 0x6f, 0x74, 0x68, 0x65,
+	0x72, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x16, 0x6f, 0x74,
+	0x68, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43,
+	0x6f, 0x64, 0x65, 0x73, 0x12, 0x6e, 0x0a, 0x1c, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x32, 0x5f, 0x6f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x32, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x52, 0x19, 0x6f, 0x74, 0x68, 0x65, 0x72,
+	0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x48, 0x74, 0x74, 0x70, 0x32, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x73, 0x12, 0x5b, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x5f, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43,
+	0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65,
+	0x52, 0x0f, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x73, 0x12, 0x3b, 0x0a, 0x1a, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x5f, 0x73, 0x68,
+	0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x17, 0x67, 0x72, 0x61, 0x63, 0x65, 0x66, 0x75, 0x6c, 0x53,
+	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x66, 0x74, 0x65, 0x72, 0x4d, 0x73, 0x12, 0x40,
+	0x0a, 0x1c, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73,
+	0x12, 0x5d, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x5f, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45,
+	0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x0e, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a,
+	0x63, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x38, 0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54,
+	0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x1a, 0xbc, 0x02, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65,
+	0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4d, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x39, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70,
+	0x61, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x4b, 0x69,
+	0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65,
+	0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6e, 0x75, 0x6d, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x6e, 0x75, 0x6d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x22, 0x6d, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x10,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x53, 0x54, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x53, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15,
+	0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x48, 0x45,
+	0x41, 0x44, 0x45, 0x52, 0x53, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x49, 0x4c, 0x45, 0x52,
+	0x53, 0x10, 0x03, 0x42, 0x8b, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
 	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
 	0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x53, 0x75, 0x69, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x74,
 	0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
@@ -618,39 +984,46 @@ func file_connectrpc_conformance_v1_suite_proto_rawDescGZIP() []byte {
 	return file_connectrpc_conformance_v1_suite_proto_rawDescData
 }
 
-var file_connectrpc_conformance_v1_suite_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_connectrpc_conformance_v1_suite_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_connectrpc_conformance_v1_suite_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_connectrpc_conformance_v1_suite_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_connectrpc_conformance_v1_suite_proto_goTypes = []interface{}{
-	(TestSuite_TestMode)(0),           // 0: connectrpc.conformance.v1.TestSuite.TestMode
-	(TestSuite_ConnectVersionMode)(0), // 1: connectrpc.conformance.v1.TestSuite.ConnectVersionMode
-	(*TestSuite)(nil),                 // 2: connectrpc.conformance.v1.TestSuite
-	(*TestCase)(nil),                  // 3: connectrpc.conformance.v1.TestCase
-	(*TestCase_ExpandedSize)(nil),     // 4: connectrpc.conformance.v1.TestCase.ExpandedSize
-	(Protocol)(0),                     // 5: connectrpc.conformance.v1.Protocol
-	(HTTPVersion)(0),                  // 6: connectrpc.conformance.v1.HTTPVersion
-	(Codec)(0),                        // 7: connectrpc.conformance.v1.Codec
-	(Compression)(0),                  // 8: connectrpc.conformance.v1.Compression
-	(*ClientCompatRequest)(nil),       // 9: connectrpc.conformance.v1.ClientCompatRequest
-	(*ClientResponseResult)(nil),      // 10: connectrpc.conformance.v1.ClientResponseResult
-	(Code)(0),                         // 11: connectrpc.conformance.v1.Code
+	(TestSuite_TestMode)(0),             // 0: connectrpc.conformance.v1.TestSuite.TestMode
+	(TestSuite_ConnectVersionMode)(0),   // 1: connectrpc.conformance.v1.TestSuite.ConnectVersionMode
+	(TestCase_ExpandedMetadata_Kind)(0), // 2: connectrpc.conformance.v1.TestCase.ExpandedMetadata.Kind
+	(*TestSuite)(nil),                   // 3: connectrpc.conformance.v1.TestSuite
+	(*TestCase)(nil),                    // 4: connectrpc.conformance.v1.TestCase
+	(*TestCase_ExpandedSize)(nil),       // 5: connectrpc.conformance.v1.TestCase.ExpandedSize
+	(*TestCase_ExpandedMetadata)(nil),   // 6: connectrpc.conformance.v1.TestCase.ExpandedMetadata
+	(Protocol)(0),                       // 7: connectrpc.conformance.v1.Protocol
+	(HTTPVersion)(0),                    // 8: connectrpc.conformance.v1.HTTPVersion
+	(Codec)(0),                          // 9: connectrpc.conformance.v1.Codec
+	(Compression)(0),                    // 10: connectrpc.conformance.v1.Compression
+	(*ClientCompatRequest)(nil),         // 11: connectrpc.conformance.v1.ClientCompatRequest
+	(*ClientResponseResult)(nil),        // 12: connectrpc.conformance.v1.ClientResponseResult
+	(Code)(0),                           // 13: connectrpc.conformance.v1.Code
+	(*HTTP2StreamOutcome)(nil),          // 14: connectrpc.conformance.v1.HTTP2StreamOutcome
 }
 var file_connectrpc_conformance_v1_suite_proto_depIdxs = []int32{
 	0,  // 0: connectrpc.conformance.v1.TestSuite.mode:type_name -> connectrpc.conformance.v1.TestSuite.TestMode
-	3,  // 1: connectrpc.conformance.v1.TestSuite.test_cases:type_name -> connectrpc.conformance.v1.TestCase
-	5,  // 2: connectrpc.conformance.v1.TestSuite.relevant_protocols:type_name -> connectrpc.conformance.v1.Protocol
-	6,  // 3: connectrpc.conformance.v1.TestSuite.relevant_http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
-	7,  // 4: connectrpc.conformance.v1.TestSuite.relevant_codecs:type_name -> connectrpc.conformance.v1.Codec
-	8,  // 5: connectrpc.conformance.v1.TestSuite.relevant_compressions:type_name -> connectrpc.conformance.v1.Compression
+	4,  // 1: connectrpc.conformance.v1.TestSuite.test_cases:type_name -> connectrpc.conformance.v1.TestCase
+	7,  // 2: connectrpc.conformance.v1.TestSuite.relevant_protocols:type_name -> connectrpc.conformance.v1.Protocol
+	8,  // 3: connectrpc.conformance.v1.TestSuite.relevant_http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
+	9,  // 4: connectrpc.conformance.v1.TestSuite.relevant_codecs:type_name -> connectrpc.conformance.v1.Codec
+	10, // 5: connectrpc.conformance.v1.TestSuite.relevant_compressions:type_name -> connectrpc.conformance.v1.Compression
 	1,  // 6: connectrpc.conformance.v1.TestSuite.connect_version_mode:type_name -> connectrpc.conformance.v1.TestSuite.ConnectVersionMode
-	9,  // 7: connectrpc.conformance.v1.TestCase.request:type_name -> connectrpc.conformance.v1.ClientCompatRequest
-	4,  // 8: connectrpc.conformance.v1.TestCase.expand_requests:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
-	10, // 9: connectrpc.conformance.v1.TestCase.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
-	11, // 10: connectrpc.conformance.v1.TestCase.other_allowed_error_codes:type_name -> connectrpc.conformance.v1.Code
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	11, // 7: connectrpc.conformance.v1.TestCase.request:type_name -> connectrpc.conformance.v1.ClientCompatRequest
+	5,  // 8: connectrpc.conformance.v1.TestCase.expand_requests:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
+	12, // 9: connectrpc.conformance.v1.TestCase.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
+	13, // 10: connectrpc.conformance.v1.TestCase.other_allowed_error_codes:type_name -> connectrpc.conformance.v1.Code
+	14, // 11: connectrpc.conformance.v1.TestCase.other_allowed_http2_outcomes:type_name -> connectrpc.conformance.v1.HTTP2StreamOutcome
+	5,  // 12: connectrpc.conformance.v1.TestCase.expand_responses:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
+	6,  // 13: connectrpc.conformance.v1.TestCase.expand_metadata:type_name -> connectrpc.conformance.v1.TestCase.ExpandedMetadata
+	2,  // 14: connectrpc.conformance.v1.TestCase.ExpandedMetadata.kind:type_name -> connectrpc.conformance.v1.TestCase.ExpandedMetadata.Kind
+	15, // [15:15] is the sub-list for method output_type
+	15, // [15:15] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_connectrpc_conformance_v1_suite_proto_init() }
@@ -697,6 +1070,18 @@ func file_connectrpc_conformance_v1_suite_proto_init() {
 				return nil
 			}
 		}
+		file_connectrpc_conformance_v1_suite_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TestCase_ExpandedMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_connectrpc_conformance_v1_suite_proto_msgTypes[2].OneofWrappers = []interface{}{}
 	type x struct{}
@@ -704,8 +1089,8 @@ func file_connectrpc_conformance_v1_suite_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_connectrpc_conformance_v1_suite_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,299 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: connectrpc/conformance/v1/transcoding.proto
+
+package conformancev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TranscodingService_GetResource_FullMethodName     = "/connectrpc.conformance.v1.TranscodingService/GetResource"
+	TranscodingService_CreateResource_FullMethodName  = "/connectrpc.conformance.v1.TranscodingService/CreateResource"
+	TranscodingService_UpdateResource_FullMethodName  = "/connectrpc.conformance.v1.TranscodingService/UpdateResource"
+	TranscodingService_DeleteResource_FullMethodName  = "/connectrpc.conformance.v1.TranscodingService/DeleteResource"
+	TranscodingService_SearchResources_FullMethodName = "/connectrpc.conformance.v1.TranscodingService/SearchResources"
+)
+
+// TranscodingServiceClient is the client API for TranscodingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TranscodingServiceClient interface {
+	// Retrieves a resource. The name is bound from the URL path, using a path
+	// template with a single-segment wildcard, and all other fields are bound
+	// from query parameters.
+	GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error)
+	// Creates a resource. The parent is bound from the URL path, the resource
+	// is bound from the request body, and all other fields are bound from query
+	// parameters.
+	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error)
+	// Updates a resource. A nested field is bound from the URL path and all
+	// other fields are bound from the request body.
+	UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error)
+	// Deletes a resource. The name is bound from the URL path, using a path
+	// template with a multi-segment wildcard, and all other fields are bound
+	// from query parameters.
+	DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error)
+	// Searches for resources. The path template includes a custom verb. The
+	// parent is bound from the URL path and all other fields are bound from
+	// the request body.
+	SearchResources(ctx context.Context, in *SearchResourcesRequest, opts ...grpc.CallOption) (*UnaryResponse, error)
+}
+
+type transcodingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTranscodingServiceClient(cc grpc.ClientConnInterface) TranscodingServiceClient {
+	return &transcodingServiceClient{cc}
+}
+
+func (c *transcodingServiceClient) GetResource(ctx context.Context, in *GetResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error) {
+	out := new(UnaryResponse)
+	err := c.cc.Invoke(ctx, TranscodingService_GetResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcodingServiceClient) CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error) {
+	out := new(UnaryResponse)
+	err := c.cc.Invoke(ctx, TranscodingService_CreateResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcodingServiceClient) UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error) {
+	out := new(UnaryResponse)
+	err := c.cc.Invoke(ctx, TranscodingService_UpdateResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcodingServiceClient) DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*UnaryResponse, error) {
+	out := new(UnaryResponse)
+	err := c.cc.Invoke(ctx, TranscodingService_DeleteResource_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transcodingServiceClient) SearchResources(ctx context.Context, in *SearchResourcesRequest, opts ...grpc.CallOption) (*UnaryResponse, error) {
+	out := new(UnaryResponse)
+	err := c.cc.Invoke(ctx, TranscodingService_SearchResources_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TranscodingServiceServer is the server API for TranscodingService service.
+// All implementations must embed UnimplementedTranscodingServiceServer
+// for forward compatibility
+type TranscodingServiceServer interface {
+	// Retrieves a resource. The name is bound from the URL path, using a path
+	// template with a single-segment wildcard, and all other fields are bound
+	// from query parameters.
+	GetResource(context.Context, *GetResourceRequest) (*UnaryResponse, error)
+	// Creates a resource. The parent is bound from the URL path, the resource
+	// is bound from the request body, and all other fields are bound from query
+	// parameters.
+	CreateResource(context.Context, *CreateResourceRequest) (*UnaryResponse, error)
+	// Updates a resource. A nested field is bound from the URL path and all
+	// other fields are bound from the request body.
+	UpdateResource(context.Context, *UpdateResourceRequest) (*UnaryResponse, error)
+	// Deletes a resource. The name is bound from the URL path, using a path
+	// template with a multi-segment wildcard, and all other fields are bound
+	// from query parameters.
+	DeleteResource(context.Context, *DeleteResourceRequest) (*UnaryResponse, error)
+	// Searches for resources. The path template includes a custom verb. The
+	// parent is bound from the URL path and all other fields are bound from
+	// the request body.
+	SearchResources(context.Context, *SearchResourcesRequest) (*UnaryResponse, error)
+	mustEmbedUnimplementedTranscodingServiceServer()
+}
+
+// UnimplementedTranscodingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTranscodingServiceServer struct {
+}
+
+func (UnimplementedTranscodingServiceServer) GetResource(context.Context, *GetResourceRequest) (*UnaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResource not implemented")
+}
+func (UnimplementedTranscodingServiceServer) CreateResource(context.Context, *CreateResourceRequest) (*UnaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateResource not implemented")
+}
+func (UnimplementedTranscodingServiceServer) UpdateResource(context.Context, *UpdateResourceRequest) (*UnaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateResource not implemented")
+}
+func (UnimplementedTranscodingServiceServer) DeleteResource(context.Context, *DeleteResourceRequest) (*UnaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteResource not implemented")
+}
+func (UnimplementedTranscodingServiceServer) SearchResources(context.Context, *SearchResourcesRequest) (*UnaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchResources not implemented")
+}
+func (UnimplementedTranscodingServiceServer) mustEmbedUnimplementedTranscodingServiceServer() {}
+
+// UnsafeTranscodingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TranscodingServiceServer will
+// result in compilation errors.
+type UnsafeTranscodingServiceServer interface {
+	mustEmbedUnimplementedTranscodingServiceServer()
+}
+
+func RegisterTranscodingServiceServer(s grpc.ServiceRegistrar, srv TranscodingServiceServer) {
+	s.RegisterService(&TranscodingService_ServiceDesc, srv)
+}
+
+func _TranscodingService_GetResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscodingServiceServer).GetResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscodingService_GetResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscodingServiceServer).GetResource(ctx, req.(*GetResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscodingService_CreateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscodingServiceServer).CreateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscodingService_CreateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscodingServiceServer).CreateResource(ctx, req.(*CreateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscodingService_UpdateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscodingServiceServer).UpdateResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscodingService_UpdateResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscodingServiceServer).UpdateResource(ctx, req.(*UpdateResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscodingService_DeleteResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscodingServiceServer).DeleteResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscodingService_DeleteResource_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscodingServiceServer).DeleteResource(ctx, req.(*DeleteResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TranscodingService_SearchResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TranscodingServiceServer).SearchResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TranscodingService_SearchResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TranscodingServiceServer).SearchResources(ctx, req.(*SearchResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TranscodingService_ServiceDesc is the grpc.ServiceDesc for TranscodingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TranscodingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "connectrpc.conformance.v1.TranscodingService",
+	HandlerType: (*TranscodingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetResource",
+			Handler:    _TranscodingService_GetResource_Handler,
+		},
+		{
+			MethodName: "CreateResource",
+			Handler:    _TranscodingService_CreateResource_Handler,
+		},
+		{
+			MethodName: "UpdateResource",
+			Handler:    _TranscodingService_UpdateResource_Handler,
+		},
+		{
+			MethodName: "DeleteResource",
+			Handler:    _TranscodingService_DeleteResource_Handler,
+		},
+		{
+			MethodName: "SearchResources",
+			Handler:    _TranscodingService_SearchResources_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "connectrpc/conformance/v1/transcoding.proto",
+}
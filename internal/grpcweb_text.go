@@ -0,0 +1,209 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// GRPCWebContentType is the content-type for the binary variant
+	// of the gRPC-Web protocol.
+	GRPCWebContentType = "application/grpc-web"
+	// GRPCWebTextContentType is the content-type for the text variant
+	// of the gRPC-Web protocol, where the body is base64-encoded.
+	GRPCWebTextContentType = "application/grpc-web-text"
+)
+
+// IsGRPCWebContentType returns true if the given content-type indicates
+// the binary variant of the gRPC-Web protocol.
+func IsGRPCWebContentType(contentType string) bool {
+	contentType = baseContentType(contentType)
+	return contentType == GRPCWebContentType || strings.HasPrefix(contentType, GRPCWebContentType+"+")
+}
+
+// IsGRPCWebTextContentType returns true if the given content-type indicates
+// the text variant of the gRPC-Web protocol.
+func IsGRPCWebTextContentType(contentType string) bool {
+	contentType = baseContentType(contentType)
+	return contentType == GRPCWebTextContentType || strings.HasPrefix(contentType, GRPCWebTextContentType+"+")
+}
+
+// GRPCWebToTextContentType converts the given gRPC-Web content-type to the
+// corresponding text variant. The codec suffix, if any, is preserved.
+func GRPCWebToTextContentType(contentType string) string {
+	return GRPCWebTextContentType + contentType[len(GRPCWebContentType):]
+}
+
+// GRPCWebFromTextContentType converts the given gRPC-Web text content-type
+// to the corresponding binary variant. The codec suffix, if any, is preserved.
+func GRPCWebFromTextContentType(contentType string) string {
+	return GRPCWebContentType + contentType[len(GRPCWebTextContentType):]
+}
+
+func baseContentType(contentType string) string {
+	if pos := strings.IndexByte(contentType, ';'); pos >= 0 {
+		contentType = contentType[:pos]
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
+
+// GRPCWebTextDecoder incrementally decodes the base64-encoded body of a
+// gRPC-Web text request or response. The body may be split into chunks
+// at arbitrary boundaries. Since senders may encode each chunk separately,
+// padding may appear in the middle of the body, so the data is decoded
+// four characters (one base64 quantum) at a time.
+type GRPCWebTextDecoder struct {
+	pending []byte
+}
+
+// Decode decodes the given data, returning the decoded bytes. Any trailing
+// bytes that do not yet form a complete quantum are buffered and decoded
+// with data from subsequent calls.
+func (d *GRPCWebTextDecoder) Decode(data []byte) ([]byte, error) {
+	var result []byte
+	var quantum [4]byte
+	var decoded [3]byte
+	for len(data) > 0 || len(d.pending) >= 4 {
+		for len(d.pending) < 4 && len(data) > 0 {
+			d.pending = append(d.pending, data[0])
+			data = data[1:]
+		}
+		if len(d.pending) < 4 {
+			break
+		}
+		copy(quantum[:], d.pending)
+		d.pending = d.pending[:copy(d.pending, d.pending[4:])]
+		n, err := base64.StdEncoding.Decode(decoded[:], quantum[:])
+		if err != nil {
+			return result, fmt.Errorf("invalid base64 in gRPC-Web text body: %w", err)
+		}
+		result = append(result, decoded[:n]...)
+	}
+	return result, nil
+}
+
+// Finish checks that there is no incomplete quantum buffered. It should
+// be called once the end of the body has been reached.
+func (d *GRPCWebTextDecoder) Finish() error {
+	if len(d.pending) > 0 {
+		return errors.New("gRPC-Web text body ends with incomplete base64 data")
+	}
+	return nil
+}
+
+// NewGRPCWebTextDecodingReader returns a reader that decodes the given
+// base64-encoded gRPC-Web text body.
+func NewGRPCWebTextDecodingReader(body io.ReadCloser) io.ReadCloser {
+	return &grpcWebTextDecodingReader{body: body}
+}
+
+type grpcWebTextDecodingReader struct {
+	body    io.ReadCloser
+	decoder GRPCWebTextDecoder
+	decoded []byte
+	buf     []byte
+	err     error
+}
+
+func (r *grpcWebTextDecodingReader) Read(data []byte) (int, error) {
+	for len(r.decoded) == 0 && r.err == nil {
+		if r.buf == nil {
+			r.buf = make([]byte, 32*1024)
+		}
+		n, err := r.body.Read(r.buf)
+		decoded, decodeErr := r.decoder.Decode(r.buf[:n])
+		r.decoded = decoded
+		switch {
+		case decodeErr != nil:
+			r.err = decodeErr
+		case errors.Is(err, io.EOF):
+			r.err = r.decoder.Finish()
+			if r.err == nil {
+				r.err = io.EOF
+			}
+		case err != nil:
+			r.err = err
+		}
+	}
+	if len(r.decoded) > 0 {
+		n := copy(data, r.decoded)
+		r.decoded = r.decoded[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+func (r *grpcWebTextDecodingReader) Close() error {
+	return r.body.Close()
+}
+
+// NewGRPCWebTextEncodingReader returns a reader that base64-encodes the
+// given body, for sending as a gRPC-Web text request body. The body is
+// encoded as one continuous base64 stream, with padding only at the end,
+// since not all servers accept padding in the middle of a request body.
+// So up to two bytes of each chunk read from the given body may be held
+// back until more data is read or the end of the body is reached.
+func NewGRPCWebTextEncodingReader(body io.ReadCloser) io.ReadCloser {
+	return &grpcWebTextEncodingReader{body: body}
+}
+
+type grpcWebTextEncodingReader struct {
+	body    io.ReadCloser
+	pending []byte // less than three bytes, not yet encoded
+	encoded []byte
+	buf     []byte
+	err     error
+}
+
+func (r *grpcWebTextEncodingReader) Read(data []byte) (int, error) {
+	for len(r.encoded) == 0 && r.err == nil {
+		if r.buf == nil {
+			r.buf = make([]byte, 32*1024)
+		}
+		n, err := r.body.Read(r.buf)
+		r.pending = append(r.pending, r.buf[:n]...)
+		if err != nil {
+			// Encode everything, with padding, at the end of the body.
+			r.encoded = base64.StdEncoding.AppendEncode(r.encoded[:0], r.pending)
+			r.pending = nil
+			r.err = err
+			break
+		}
+		complete := len(r.pending) / 3 * 3
+		r.encoded = base64.StdEncoding.AppendEncode(r.encoded[:0], r.pending[:complete])
+		r.pending = r.pending[:copy(r.pending, r.pending[complete:])]
+	}
+	if len(r.encoded) > 0 {
+		n := copy(data, r.encoded)
+		r.encoded = r.encoded[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+func (r *grpcWebTextEncodingReader) Close() error {
+	return r.body.Close()
+}
+
+// GRPCWebTextEncode base64-encodes the given data, for sending as part of
+// a gRPC-Web text body.
+func GRPCWebTextEncode(data []byte) []byte {
+	return base64.StdEncoding.AppendEncode(nil, data)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCWebTextContentTypes(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsGRPCWebContentType("application/grpc-web"))
+	assert.True(t, IsGRPCWebContentType("application/grpc-web+proto; charset=utf-8"))
+	assert.False(t, IsGRPCWebContentType("application/grpc-web-text"))
+	assert.True(t, IsGRPCWebTextContentType("application/grpc-web-text"))
+	assert.True(t, IsGRPCWebTextContentType("Application/gRPC-Web-Text+json"))
+	assert.False(t, IsGRPCWebTextContentType("application/grpc-web+proto"))
+	assert.Equal(t, "application/grpc-web-text+proto", GRPCWebToTextContentType("application/grpc-web+proto"))
+	assert.Equal(t, "application/grpc-web", GRPCWebFromTextContentType("application/grpc-web-text"))
+}
+
+func TestGRPCWebTextDecoder(t *testing.T) {
+	t.Parallel()
+	// Padding in the middle, as when each message is encoded separately.
+	encoded := base64.StdEncoding.EncodeToString([]byte("a")) +
+		base64.StdEncoding.EncodeToString([]byte("bc")) +
+		base64.StdEncoding.EncodeToString([]byte("def"))
+	for chunkSize := 1; chunkSize <= len(encoded); chunkSize++ {
+		var decoder GRPCWebTextDecoder
+		var decoded []byte
+		for start := 0; start < len(encoded); start += chunkSize {
+			chunk, err := decoder.Decode([]byte(encoded[start:min(start+chunkSize, len(encoded))]))
+			require.NoError(t, err)
+			decoded = append(decoded, chunk...)
+		}
+		require.NoError(t, decoder.Finish())
+		assert.Equal(t, "abcdef", string(decoded), "chunk size %d", chunkSize)
+	}
+
+	var decoder GRPCWebTextDecoder
+	_, err := decoder.Decode([]byte("YWJj"))
+	require.NoError(t, err)
+	_, err = decoder.Decode([]byte("YW"))
+	require.NoError(t, err)
+	require.Error(t, decoder.Finish())
+	_, err = decoder.Decode([]byte("!!"))
+	require.ErrorContains(t, err, "invalid base64")
+}
+
+func TestGRPCWebTextReaders(t *testing.T) {
+	t.Parallel()
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	// One byte at a time, so that bytes are held back between reads.
+	encodingReader := NewGRPCWebTextEncodingReader(io.NopCloser(iotest.OneByteReader(bytes.NewReader(data))))
+	encoded, err := io.ReadAll(encodingReader)
+	require.NoError(t, err)
+	// The body is one continuous base64 stream.
+	assert.Equal(t, base64.StdEncoding.EncodeToString(data), string(encoded))
+
+	decodingReader := NewGRPCWebTextDecodingReader(io.NopCloser(iotest.HalfReader(bytes.NewReader(encoded))))
+	decoded, err := io.ReadAll(decodingReader)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	decodingReader = NewGRPCWebTextDecodingReader(io.NopCloser(strings.NewReader("YWJjZA")))
+	_, err = io.ReadAll(decodingReader)
+	require.ErrorContains(t, err, "incomplete base64")
+}
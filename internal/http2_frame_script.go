@@ -0,0 +1,195 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	// This is the smallest maximum frame size that a peer can advertise,
+	// so frames no larger than this can always be sent.
+	http2MinMaxFrameSize = 16384
+	http2FillerHeader    = "x-filler"
+)
+
+// ValidateHTTP2FrameScript checks that all frames in the given script
+// can be encoded.
+func ValidateHTTP2FrameScript(script *conformancev1.HTTP2FrameScript) error {
+	for i, frame := range script.Frames {
+		if _, _, err := EncodeHTTP2Frame(frame, 1, nil); err != nil {
+			return fmt.Errorf("frame script: frame #%d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// EncodeHTTP2Frame returns the encoded bytes for the given frame, which may
+// actually be multiple frames. It also returns the number of bytes that count
+// against the peer's flow control window. The given stream ID is used for
+// frames that do not indicate otherwise.
+//
+// If the frame is a HEADERS frame, the given leading fields are encoded
+// at the start of the header block. This is where pseudo-headers go.
+// Header blocks do not use the HPACK dynamic table.
+func EncodeHTTP2Frame(frame *conformancev1.HTTP2Frame, streamID uint32, leadingFields []hpack.HeaderField) ([]byte, uint32, error) {
+	var buf bytes.Buffer
+	framer := http2.NewFramer(&buf, nil)
+	// Scripts are allowed to misbehave.
+	framer.AllowIllegalWrites = true
+	var flow uint32
+	var err error
+	switch frameKind := frame.Frame.(type) {
+	case *conformancev1.HTTP2Frame_Headers:
+		err = encodeHTTP2Headers(framer, frameKind.Headers, streamID, leadingFields)
+	case *conformancev1.HTTP2Frame_Data:
+		flow, err = encodeHTTP2Data(framer, frameKind.Data, streamID)
+	case *conformancev1.HTTP2Frame_RstStream:
+		err = framer.WriteRSTStream(streamID, http2.ErrCode(frameKind.RstStream.ErrorCode))
+	case *conformancev1.HTTP2Frame_GoAway:
+		lastStreamID := streamID
+		if frameKind.GoAway.LastStreamId != nil {
+			lastStreamID = frameKind.GoAway.GetLastStreamId()
+		}
+		err = framer.WriteGoAway(lastStreamID, http2.ErrCode(frameKind.GoAway.ErrorCode), frameKind.GoAway.DebugData)
+	case *conformancev1.HTTP2Frame_WindowUpdate:
+		windowStreamID := streamID
+		if frameKind.WindowUpdate.Connection {
+			windowStreamID = 0
+		}
+		err = framer.WriteWindowUpdate(windowStreamID, frameKind.WindowUpdate.Increment)
+	case *conformancev1.HTTP2Frame_Raw:
+		raw := frameKind.Raw
+		if raw.Type > 255 {
+			return nil, 0, fmt.Errorf("type is out of range: %d, should be [0,255]", raw.Type)
+		}
+		if raw.Flags > 255 {
+			return nil, 0, fmt.Errorf("flags is out of range: %d, should be [0,255]", raw.Flags)
+		}
+		rawStreamID := streamID
+		if raw.StreamId != nil {
+			rawStreamID = raw.GetStreamId()
+		}
+		err = framer.WriteRawFrame(http2.FrameType(raw.Type), http2.Flags(raw.Flags), rawStreamID, raw.Payload)
+	case nil:
+		return nil, 0, errors.New("no frame specified")
+	default:
+		return nil, 0, fmt.Errorf("unsupported frame type: %T", frameKind)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), flow, nil
+}
+
+func encodeHTTP2Headers(framer *http2.Framer, headers *conformancev1.HTTP2Frame_HeadersFrame, streamID uint32, leadingFields []hpack.HeaderField) error {
+	var block bytes.Buffer
+	encoder := hpack.NewEncoder(&block)
+	// Fields are marked sensitive so that they are never added to the
+	// dynamic table. Otherwise, the peer's table would get out of sync
+	// with the encoder used for frames that are not scripted.
+	writeField := func(name, value string) error {
+		return encoder.WriteField(hpack.HeaderField{Name: name, Value: value, Sensitive: true})
+	}
+	for _, field := range leadingFields {
+		if err := writeField(field.Name, field.Value); err != nil {
+			return err
+		}
+	}
+	for _, hdr := range headers.Headers {
+		for _, val := range hdr.Value {
+			if err := writeField(hdr.Name, val); err != nil {
+				return err
+			}
+		}
+	}
+	if headers.FillerSize > 0 {
+		if err := writeField(http2FillerHeader, strings.Repeat("x", int(headers.FillerSize))); err != nil {
+			return err
+		}
+	}
+	maxFragmentSize := int(headers.MaxFragmentSize)
+	if maxFragmentSize == 0 {
+		maxFragmentSize = http2MinMaxFrameSize
+	}
+	remaining := block.Bytes()
+	fragment := remaining[:min(len(remaining), maxFragmentSize)]
+	remaining = remaining[len(fragment):]
+	err := framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      streamID,
+		BlockFragment: fragment,
+		EndStream:     headers.EndStream,
+		EndHeaders:    len(remaining) == 0,
+	})
+	for err == nil && len(remaining) > 0 {
+		fragment = remaining[:min(len(remaining), maxFragmentSize)]
+		remaining = remaining[len(fragment):]
+		err = framer.WriteContinuation(streamID, len(remaining) == 0, fragment)
+	}
+	return err
+}
+
+func encodeHTTP2Data(framer *http2.Framer, data *conformancev1.HTTP2Frame_DataFrame, streamID uint32) (uint32, error) {
+	if data.PadLength > 255 {
+		return 0, fmt.Errorf("pad length is out of range: %d, should be [0,255]", data.PadLength)
+	}
+	var contents bytes.Buffer
+	var err error
+	switch dataContents := data.Contents.(type) {
+	case *conformancev1.HTTP2Frame_DataFrame_Unary:
+		err = WriteRawMessageContents(dataContents.Unary, &contents)
+	case *conformancev1.HTTP2Frame_DataFrame_Stream:
+		for i, item := range dataContents.Stream.Items {
+			if item.DelayMs > 0 {
+				return 0, fmt.Errorf("message #%d: delay is not supported in a frame script; delay the frame instead", i+1)
+			}
+		}
+		err = WriteRawStreamContents(context.Background(), dataContents.Stream, &contents)
+	}
+	if err != nil {
+		return 0, err
+	}
+	maxFrameSize := int(data.MaxFrameSize)
+	if maxFrameSize == 0 {
+		maxFrameSize = http2MinMaxFrameSize
+	}
+	var pad []byte
+	if data.PadLength > 0 {
+		pad = make([]byte, data.PadLength)
+	}
+	var flow uint32
+	remaining := contents.Bytes()
+	// Always write at least one frame, even if there is no data.
+	for first := true; first || len(remaining) > 0; first = false {
+		chunk := remaining[:min(len(remaining), maxFrameSize)]
+		remaining = remaining[len(chunk):]
+		if err := framer.WriteDataPadded(streamID, data.EndStream && len(remaining) == 0, chunk, pad); err != nil {
+			return 0, err
+		}
+		flow += uint32(len(chunk))
+		if pad != nil {
+			flow += uint32(len(pad)) + 1 // pad length byte also counts
+		}
+	}
+	return flow, nil
+}
@@ -0,0 +1,229 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncodeHTTP2Frame(t *testing.T) {
+	t.Parallel()
+
+	headers := &conformancev1.HTTP2Frame{
+		Frame: &conformancev1.HTTP2Frame_Headers{
+			Headers: &conformancev1.HTTP2Frame_HeadersFrame{
+				Headers: []*conformancev1.Header{
+					{Name: "content-type", Value: []string{"application/grpc"}},
+					{Name: "x-custom-header", Value: []string{"foo", "bar"}},
+				},
+				MaxFragmentSize: 10,
+			},
+		},
+	}
+	data, flow, err := EncodeHTTP2Frame(headers, 7, []hpack.HeaderField{{Name: ":status", Value: "200"}})
+	require.NoError(t, err)
+	assert.Zero(t, flow)
+	frames := readFrames(t, data)
+	require.Greater(t, len(frames), 2)
+	var block []byte
+	for i, frame := range frames {
+		assert.Equal(t, uint32(7), frame.streamID)
+		if i == 0 {
+			assert.Equal(t, http2.FrameHeaders, frame.frameType)
+			assert.False(t, frame.flags.Has(http2.FlagHeadersEndStream))
+		} else {
+			assert.Equal(t, http2.FrameContinuation, frame.frameType)
+		}
+		assert.LessOrEqual(t, len(frame.payload), 10)
+		// Both HEADERS and CONTINUATION use the same flag value.
+		assert.Equal(t, i == len(frames)-1, frame.flags.Has(http2.FlagHeadersEndHeaders))
+		block = append(block, frame.payload...)
+	}
+	fields, err := hpack.NewDecoder(4096, nil).DecodeFull(block)
+	require.NoError(t, err)
+	var names, values []string
+	for _, field := range fields {
+		assert.True(t, field.Sensitive)
+		names = append(names, field.Name)
+		values = append(values, field.Value)
+	}
+	assert.Equal(t, []string{":status", "content-type", "x-custom-header", "x-custom-header"}, names)
+	assert.Equal(t, []string{"200", "application/grpc", "foo", "bar"}, values)
+
+	filler := &conformancev1.HTTP2Frame{
+		Frame: &conformancev1.HTTP2Frame_Headers{
+			Headers: &conformancev1.HTTP2Frame_HeadersFrame{FillerSize: 20000},
+		},
+	}
+	data, _, err = EncodeHTTP2Frame(filler, 7, nil)
+	require.NoError(t, err)
+	frames = readFrames(t, data)
+	// The header block is larger than the default fragment size.
+	require.Len(t, frames, 2)
+	assert.Equal(t, http2.FrameHeaders, frames[0].frameType)
+	assert.Equal(t, http2.FrameContinuation, frames[1].frameType)
+	fields, err = hpack.NewDecoder(4096, nil).DecodeFull(append(frames[0].payload, frames[1].payload...))
+	require.NoError(t, err)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "x-filler", fields[0].Name)
+	assert.Len(t, fields[0].Value, 20000)
+
+	dataFrame := &conformancev1.HTTP2Frame{
+		Frame: &conformancev1.HTTP2Frame_Data{
+			Data: &conformancev1.HTTP2Frame_DataFrame{
+				Contents: &conformancev1.HTTP2Frame_DataFrame_Unary{
+					Unary: &conformancev1.MessageContents{
+						Data: &conformancev1.MessageContents_Binary{Binary: []byte("0123456789")},
+					},
+				},
+				EndStream:    true,
+				PadLength:    5,
+				MaxFrameSize: 4,
+			},
+		},
+	}
+	data, flow, err = EncodeHTTP2Frame(dataFrame, 7, nil)
+	require.NoError(t, err)
+	// Three frames, each with six bytes for the pad length and padding.
+	assert.Equal(t, uint32(10+3*6), flow)
+	frames = readFrames(t, data)
+	require.Len(t, frames, 3)
+	var contents []byte
+	for i, frame := range frames {
+		assert.Equal(t, http2.FrameData, frame.frameType)
+		assert.True(t, frame.flags.Has(http2.FlagDataPadded))
+		assert.Equal(t, i == len(frames)-1, frame.flags.Has(http2.FlagDataEndStream))
+		require.Equal(t, byte(5), frame.payload[0])
+		contents = append(contents, frame.payload[1:len(frame.payload)-5]...)
+	}
+	assert.Equal(t, []byte("0123456789"), contents)
+
+	goAway := &conformancev1.HTTP2Frame{
+		Frame: &conformancev1.HTTP2Frame_GoAway{
+			GoAway: &conformancev1.HTTP2Frame_GoAwayFrame{
+				LastStreamId: proto.Uint32(0),
+				ErrorCode:    uint32(http2.ErrCodeEnhanceYourCalm),
+			},
+		},
+	}
+	data, _, err = EncodeHTTP2Frame(goAway, 7, nil)
+	require.NoError(t, err)
+	goAwayFrame, ok := readFrame(t, data).(*http2.GoAwayFrame)
+	require.True(t, ok)
+	assert.Equal(t, uint32(0), goAwayFrame.LastStreamID)
+	assert.Equal(t, http2.ErrCodeEnhanceYourCalm, goAwayFrame.ErrCode)
+}
+
+func TestValidateHTTP2FrameScript(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		frame       *conformancev1.HTTP2Frame
+		expectedErr string
+	}{
+		{
+			name:        "no frame",
+			frame:       &conformancev1.HTTP2Frame{},
+			expectedErr: "frame script: frame #1: no frame specified",
+		},
+		{
+			name: "pad length too large",
+			frame: &conformancev1.HTTP2Frame{
+				Frame: &conformancev1.HTTP2Frame_Data{
+					Data: &conformancev1.HTTP2Frame_DataFrame{PadLength: 256},
+				},
+			},
+			expectedErr: "frame script: frame #1: pad length is out of range: 256, should be [0,255]",
+		},
+		{
+			name: "raw type too large",
+			frame: &conformancev1.HTTP2Frame{
+				Frame: &conformancev1.HTTP2Frame_Raw{
+					Raw: &conformancev1.HTTP2Frame_RawFrame{Type: 1000},
+				},
+			},
+			expectedErr: "frame script: frame #1: type is out of range: 1000, should be [0,255]",
+		},
+		{
+			name: "raw",
+			frame: &conformancev1.HTTP2Frame{
+				Frame: &conformancev1.HTTP2Frame_Raw{
+					Raw: &conformancev1.HTTP2Frame_RawFrame{Type: 0xff, Flags: 0x1, Payload: []byte("abc")},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateHTTP2FrameScript(&conformancev1.HTTP2FrameScript{
+				Frames: []*conformancev1.HTTP2Frame{testCase.frame},
+			})
+			if testCase.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+type rawFrame struct {
+	frameType http2.FrameType
+	flags     http2.Flags
+	streamID  uint32
+	payload   []byte
+}
+
+func readFrames(t *testing.T, data []byte) []rawFrame {
+	t.Helper()
+	reader := bytes.NewReader(data)
+	var frames []rawFrame
+	for reader.Len() > 0 {
+		header, err := http2.ReadFrameHeader(reader)
+		require.NoError(t, err)
+		payload := make([]byte, header.Length)
+		_, err = io.ReadFull(reader, payload)
+		require.NoError(t, err)
+		frames = append(frames, rawFrame{
+			frameType: header.Type,
+			flags:     header.Flags,
+			streamID:  header.StreamID,
+			payload:   payload,
+		})
+	}
+	return frames
+}
+
+func readFrame(t *testing.T, data []byte) http2.Frame {
+	t.Helper()
+	framer := http2.NewFramer(nil, bytes.NewReader(data))
+	frame, err := framer.ReadFrame()
+	require.NoError(t, err)
+	_, err = framer.ReadFrame()
+	require.ErrorIs(t, err, io.EOF)
+	return frame
+}
@@ -0,0 +1,29 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+// Inflate pads the given data with zeros until it is the given size. The
+// data is returned unchanged if it is already at least that size. Since the
+// padding is highly compressible, this is used to produce messages whose
+// decompressed size exceeds a peer's message size limit even though their
+// compressed size does not.
+func Inflate(data []byte, size uint32) []byte {
+	if len(data) >= int(size) {
+		return data
+	}
+	padded := make([]byte, size)
+	copy(padded, data)
+	return padded
+}
@@ -16,9 +16,13 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"net/http"
+	"sync"
+	"time"
 
 	"connectrpc.com/conformance/internal/compression"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
@@ -41,12 +45,70 @@ func WriteRawMessageContents(contents *conformancev1.MessageContents, writer io.
 		return fmt.Errorf("invalid message contents data type: %T", data)
 	}
 
-	compressor, err := compression.GetCompressor(contents.Compression)
+	padding := max(int64(contents.InflatedSize)-int64(len(msgBytes)), 0)
+	if padding > 0 && contents.Compression != conformancev1.Compression_COMPRESSION_UNSPECIFIED &&
+		contents.Compression != conformancev1.Compression_COMPRESSION_IDENTITY {
+		return writeInflatedMessageContents(contents.Compression, msgBytes, contents.InflatedSize, writer)
+	}
+	return writeCompressed(contents.Compression, msgBytes, padding, writer)
+}
+
+// inflatedKey identifies the compressed form of inflated message contents.
+type inflatedKey struct {
+	compression conformancev1.Compression
+	data        string
+	size        uint32
+}
+
+// inflatedContents caches the compressed form of inflated message contents,
+// since a large payload can take a while to compress and the same one is
+// usually sent by many test cases, often concurrently. Uncompressed contents
+// are not cached, since they are as large as the inflated size.
+//
+//nolint:gochecknoglobals
+var inflatedContents sync.Map // map[inflatedKey]*inflatedEntry
+
+type inflatedEntry struct {
+	once sync.Once
+	data []byte
+	err  error
+}
+
+func writeInflatedMessageContents(comp conformancev1.Compression, msgBytes []byte, size uint32, writer io.Writer) error {
+	key := inflatedKey{compression: comp, data: string(msgBytes), size: size}
+	val, _ := inflatedContents.LoadOrStore(key, &inflatedEntry{})
+	entry := val.(*inflatedEntry) //nolint:errcheck,forcetypeassert
+	entry.once.Do(func() {
+		var buf bytes.Buffer
+		entry.err = writeCompressed(comp, msgBytes, int64(size)-int64(len(msgBytes)), &buf)
+		entry.data = buf.Bytes()
+	})
+	if entry.err != nil {
+		return entry.err
+	}
+	_, err := writer.Write(entry.data)
+	return err
+}
+
+// writeCompressed writes the given data, followed by the given number of
+// zeros, to the given writer using the given compression algorithm. The
+// zeros are written to the compressor in chunks, so that even a very large
+// amount of padding never needs to be held in memory.
+func writeCompressed(comp conformancev1.Compression, data []byte, padding int64, writer io.Writer) error {
+	compressor, err := compression.GetCompressor(comp)
 	if err != nil {
 		return err
 	}
-	compressor.Reset(writer)
-	_, err = compressor.Write(msgBytes)
+	// Hide any Close method, so the compressor can't close the writer,
+	// which may have more written to it after this message.
+	compressor.Reset(struct{ io.Writer }{writer})
+	_, err = compressor.Write(data)
+	if err == nil && padding > 0 {
+		zeros := make([]byte, min(padding, 1024*1024))
+		for ; padding > 0 && err == nil; padding -= int64(len(zeros)) {
+			_, err = compressor.Write(zeros[:min(padding, int64(len(zeros)))])
+		}
+	}
 	if err == nil {
 		err = compressor.Close()
 	}
@@ -54,12 +116,17 @@ func WriteRawMessageContents(contents *conformancev1.MessageContents, writer io.
 }
 
 // WriteRawStreamContents writes the given stream contents to the given writer.
-func WriteRawStreamContents(contents *conformancev1.StreamContents, writer io.Writer) error {
+// If any items have a delay, this waits accordingly before writing them, and
+// returns early if the given context is done.
+func WriteRawStreamContents(ctx context.Context, contents *conformancev1.StreamContents, writer io.Writer) error {
 	for i, item := range contents.Items {
 		var prefix [5]byte
 		if item.Flags > 255 {
 			return fmt.Errorf("message #%d: flags is out of range: %d, should be [0,255]", i+1, item.Flags)
 		}
+		if err := delayStreamItem(ctx, item, writer); err != nil {
+			return fmt.Errorf("message #%d: %w", i+1, err)
+		}
 		prefix[0] = byte(item.Flags)
 		if item.Length != nil {
 			binary.BigEndian.PutUint32(prefix[1:], item.GetLength())
@@ -88,3 +155,20 @@ func WriteRawStreamContents(contents *conformancev1.StreamContents, writer io.Wr
 	}
 	return nil
 }
+
+func delayStreamItem(ctx context.Context, item *conformancev1.StreamContents_StreamItem, writer io.Writer) error {
+	if item.DelayMs == 0 {
+		return nil
+	}
+	if flusher, ok := writer.(http.Flusher); ok && item.FlushBeforeDelay {
+		flusher.Flush()
+	}
+	timer := time.NewTimer(time.Duration(item.DelayMs) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -16,8 +16,11 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"connectrpc.com/conformance/internal/compression"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
@@ -59,6 +62,16 @@ func TestWriteRawMessageContents(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "inflated",
+			data: &conformancev1.MessageContents{
+				Data: &conformancev1.MessageContents_Binary{
+					Binary: []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+				},
+				// More than one chunk of padding, and not a multiple of it.
+				InflatedSize: 3*1024*1024 + 17,
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -78,6 +91,13 @@ func TestWriteRawMessageContents(t *testing.T) {
 					require.NoError(t, err)
 
 					checkMessageContents(t, data, buf.Bytes())
+
+					// Writing the same contents again must produce the
+					// same bytes, even if they were cached.
+					var again bytes.Buffer
+					err = WriteRawMessageContents(data, &again)
+					require.NoError(t, err)
+					assert.Equal(t, buf.Bytes(), again.Bytes())
 				})
 			}
 		})
@@ -154,7 +174,7 @@ func TestWriteRawStreamContents(t *testing.T) {
 			t.Parallel()
 
 			var buf bytes.Buffer
-			err := WriteRawStreamContents(&conformancev1.StreamContents{Items: testCase.data}, &buf)
+			err := WriteRawStreamContents(context.Background(), &conformancev1.StreamContents{Items: testCase.data}, &buf)
 			require.NoError(t, err)
 
 			checkStreamContents(t, testCase.data, buf.Bytes())
@@ -162,6 +182,55 @@ func TestWriteRawStreamContents(t *testing.T) {
 	}
 }
 
+func TestWriteRawMessageContents_DoesNotClose(t *testing.T) {
+	t.Parallel()
+	writer := &closeRecorder{}
+	err := WriteRawMessageContents(&conformancev1.MessageContents{
+		Data: &conformancev1.MessageContents_Text{Text: "abc"},
+	}, writer)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", writer.String())
+	assert.False(t, writer.closed)
+}
+
+func TestWriteRawStreamContents_Delay(t *testing.T) {
+	t.Parallel()
+
+	contents := &conformancev1.StreamContents{
+		Items: []*conformancev1.StreamContents_StreamItem{
+			{
+				// Declared length is longer than the payload, so the
+				// reader sees a partial message during the delay.
+				Length: proto.Uint32(10),
+				Payload: &conformancev1.MessageContents{
+					Data: &conformancev1.MessageContents_Text{Text: "abc"},
+				},
+			},
+			{
+				Payload: &conformancev1.MessageContents{
+					Data: &conformancev1.MessageContents_Text{Text: "defg"},
+				},
+				DelayMs:          100,
+				FlushBeforeDelay: true,
+			},
+		},
+	}
+	recorder := httptest.NewRecorder()
+	start := time.Now()
+	err := WriteRawStreamContents(context.Background(), contents, recorder)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.True(t, recorder.Flushed)
+	assert.Equal(t, []byte("\x00\x00\x00\x00\x0aabc\x00\x00\x00\x00\x04defg"), recorder.Body.Bytes())
+
+	// The delay is interrupted if the context is canceled.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	contents.Items[1].DelayMs = 60_000
+	err = WriteRawStreamContents(ctx, contents, httptest.NewRecorder())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
 func messageValue(t *testing.T) *anypb.Any {
 	t.Helper()
 	val, err := structpb.NewValue(map[string]any{
@@ -192,11 +261,11 @@ func checkMessageContents(t *testing.T, msg *conformancev1.MessageContents, data
 	require.NoError(t, err)
 	switch msgData := msg.Data.(type) {
 	case *conformancev1.MessageContents_Binary:
-		assert.Equal(t, msgData.Binary, decompressed.Bytes())
+		assert.Equal(t, Inflate(msgData.Binary, msg.InflatedSize), decompressed.Bytes())
 	case *conformancev1.MessageContents_Text:
-		assert.Equal(t, msgData.Text, decompressed.String())
+		assert.Equal(t, string(Inflate([]byte(msgData.Text), msg.InflatedSize)), decompressed.String())
 	case *conformancev1.MessageContents_BinaryMessage:
-		assert.Equal(t, msgData.BinaryMessage.Value, decompressed.Bytes())
+		assert.Equal(t, Inflate(msgData.BinaryMessage.Value, msg.InflatedSize), decompressed.Bytes())
 	case nil:
 		assert.Zero(t, decompressed.Len())
 	default:
@@ -221,3 +290,13 @@ func checkStreamContents(t *testing.T, items []*conformancev1.StreamContents_Str
 	}
 	assert.Empty(t, data)
 }
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
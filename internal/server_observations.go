@@ -0,0 +1,172 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+const (
+	// cancelGracePeriod is how long to wait, after a handler fails, for its
+	// context to be canceled. When a client cancels an RPC, the handler may
+	// see an error reading the request before the context is canceled.
+	cancelGracePeriod = 100 * time.Millisecond
+	// defaultObservationRetention is how long an observation is kept, after
+	// the handler returns, if it is never fetched.
+	defaultObservationRetention = time.Minute
+)
+
+// ServerObservations records, for each test case, whether the context of
+// the server handler for its RPC was canceled before the handler returned.
+// This is what servers report from the GetServerObservation method. Only
+// RPCs whose requests include the ExpectServerObservationHeader need to be
+// observed.
+type ServerObservations struct {
+	retention time.Duration
+
+	mu   sync.Mutex
+	rpcs map[string]*observedRPC
+}
+
+type observedRPC struct {
+	started bool
+	start   time.Time
+	// Closed once the handler's context is canceled or the handler returns,
+	// after which observation is set.
+	done        chan struct{}
+	observation *conformancev1.ServerObservation
+}
+
+// NewServerObservations returns a new, empty set of observations.
+func NewServerObservations() *ServerObservations {
+	return &ServerObservations{
+		retention: defaultObservationRetention,
+		rpcs:      map[string]*observedRPC{},
+	}
+}
+
+// Handle calls the given handler for an RPC for the given test case and
+// records whether the given context, which is the handler's context, is
+// canceled before the handler returns. If testCaseName is empty, the RPC is
+// not observed and the handler is just called. So callers pass an empty
+// name for RPCs whose requests do not include the
+// ExpectServerObservationHeader.
+func (o *ServerObservations) Handle(ctx context.Context, testCaseName string, handler func() error) error {
+	if testCaseName == "" {
+		return handler()
+	}
+	finish := o.Observe(ctx, testCaseName)
+	err := handler()
+	finish(err)
+	return err
+}
+
+// Observe records the start of an RPC for the given test case, whose handler
+// uses the given context. The returned function must be called when the
+// handler returns, with the error that it returned. If the observation is
+// not fetched via Get within a minute after that, it is forgotten.
+func (o *ServerObservations) Observe(ctx context.Context, testCaseName string) (finish func(error)) {
+	rpc := o.start(testCaseName)
+	stop := context.AfterFunc(ctx, func() {
+		rpc.observation = &conformancev1.ServerObservation{
+			Canceled:        true,
+			CanceledAfterMs: uint32(time.Since(rpc.start).Milliseconds()),
+			Code:            contextErrorCode(ctx.Err()),
+		}
+		close(rpc.done)
+	})
+	return func(err error) {
+		if err != nil {
+			timer := time.NewTimer(cancelGracePeriod)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+			case <-timer.C:
+			}
+		}
+		if stop() {
+			// The handler returned before its context was canceled.
+			rpc.observation = &conformancev1.ServerObservation{}
+			close(rpc.done)
+		}
+		time.AfterFunc(o.retention, func() {
+			o.forget(testCaseName, rpc, false)
+		})
+	}
+}
+
+// Get returns what was observed for the most recent RPC for the given test
+// case. If there has been no such RPC, or if it is still in progress and its
+// context has not been canceled, this waits until that changes or until the
+// given context is done.
+//
+// Once an observation has been returned, it is forgotten, so a later call
+// for the same test case waits for another RPC.
+func (o *ServerObservations) Get(ctx context.Context, testCaseName string) (*conformancev1.ServerObservation, error) {
+	o.mu.Lock()
+	rpc := o.rpcs[testCaseName]
+	if rpc == nil {
+		rpc = &observedRPC{done: make(chan struct{})}
+		o.rpcs[testCaseName] = rpc
+	}
+	o.mu.Unlock()
+	select {
+	case <-rpc.done:
+		o.forget(testCaseName, rpc, false)
+		return rpc.observation, nil
+	case <-ctx.Done():
+		o.forget(testCaseName, rpc, true)
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes the given RPC, unless a more recent RPC for the same test
+// case has replaced it. If onlyIfUnstarted is true, the RPC is only removed
+// if it is a placeholder created by Get for an RPC that never started.
+func (o *ServerObservations) forget(testCaseName string, rpc *observedRPC, onlyIfUnstarted bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.rpcs[testCaseName] != rpc || (onlyIfUnstarted && rpc.started) {
+		return
+	}
+	delete(o.rpcs, testCaseName)
+}
+
+func (o *ServerObservations) start(testCaseName string) *observedRPC {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	rpc := o.rpcs[testCaseName]
+	if rpc == nil || rpc.started {
+		// If there was an earlier RPC for the same test case, the most
+		// recent one replaces it.
+		rpc = &observedRPC{done: make(chan struct{})}
+		o.rpcs[testCaseName] = rpc
+	}
+	rpc.started = true
+	rpc.start = time.Now()
+	return rpc
+}
+
+func contextErrorCode(err error) conformancev1.Code {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return conformancev1.Code_CODE_DEADLINE_EXCEEDED
+	}
+	return conformancev1.Code_CODE_CANCELED
+}
@@ -0,0 +1,141 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerObservations(t *testing.T) {
+	t.Parallel()
+	t.Run("completed", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		finish := observations.Observe(context.Background(), "abc")
+		finish(nil)
+		observation, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.False(t, observation.GetCanceled())
+	})
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		ctx, cancel := context.WithCancel(context.Background())
+		finish := observations.Observe(ctx, "abc")
+		cancel()
+		observation, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.True(t, observation.GetCanceled())
+		assert.Equal(t, conformancev1.Code_CODE_CANCELED, observation.GetCode())
+		finish(context.Canceled)
+	})
+	t.Run("deadline-exceeded", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		finish := observations.Observe(ctx, "abc")
+		defer finish(nil)
+		observation, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.True(t, observation.GetCanceled())
+		assert.Equal(t, conformancev1.Code_CODE_DEADLINE_EXCEEDED, observation.GetCode())
+		assert.GreaterOrEqual(t, observation.GetCanceledAfterMs(), uint32(50))
+	})
+	t.Run("canceled-after-failure", func(t *testing.T) {
+		t.Parallel()
+		// The handler can fail before its context is canceled, such as when
+		// it sees the request stream reset. That should still be reported
+		// as canceled.
+		observations := NewServerObservations()
+		ctx, cancel := context.WithCancel(context.Background())
+		finish := observations.Observe(ctx, "abc")
+		time.AfterFunc(10*time.Millisecond, cancel)
+		finish(errors.New("stream reset"))
+		observation, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.True(t, observation.GetCanceled())
+	})
+	t.Run("waits-for-rpc", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		time.AfterFunc(10*time.Millisecond, func() {
+			observations.Observe(context.Background(), "abc")(nil)
+		})
+		observation, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.False(t, observation.GetCanceled())
+	})
+	t.Run("forgotten-after-get", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		observations.Observe(context.Background(), "abc")(nil)
+		_, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.Empty(t, observations.rpcs)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = observations.Get(ctx, "abc")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, observations.rpcs)
+	})
+	t.Run("forgotten-if-not-fetched", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		observations.retention = 10 * time.Millisecond
+		observations.Observe(context.Background(), "abc")(nil)
+		assert.Eventually(t, func() bool {
+			observations.mu.Lock()
+			defer observations.mu.Unlock()
+			return len(observations.rpcs) == 0
+		}, time.Second, 5*time.Millisecond)
+	})
+	t.Run("handle", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		handlerErr := errors.New("handler failed")
+		err := observations.Handle(context.Background(), "abc", func() error {
+			return handlerErr
+		})
+		require.ErrorIs(t, err, handlerErr)
+		observation, err := observations.Get(context.Background(), "abc")
+		require.NoError(t, err)
+		assert.False(t, observation.GetCanceled())
+		// RPCs without a test case name are not observed.
+		var called bool
+		err = observations.Handle(context.Background(), "", func() error {
+			called = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, called)
+		assert.Empty(t, observations.rpcs)
+	})
+	t.Run("no-rpc", func(t *testing.T) {
+		t.Parallel()
+		observations := NewServerObservations()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := observations.Get(ctx, "abc")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
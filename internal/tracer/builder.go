@@ -83,18 +83,34 @@ func newBuilder(req *http.Request, client bool, collector Collector) (*builder,
 		}
 	}
 	testName := req.Header.Get(testCaseNameHeader)
+	start := time.Now()
 	return &builder{
 		collector: collector,
-		start:     time.Now(),
+		start:     start,
 		client:    client,
 		trace: Trace{
 			TestName: testName,
 			Request:  req,
 			Events:   []Event{&RequestStart{Request: req, getHeaders: getHeaders}},
+			Start:    start,
 		},
 	}, ctx
 }
 
+// useRequestHeaders sets the headers for the RequestStart event to
+// the given headers.
+func (b *builder) useRequestHeaders(headers http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, event := range b.trace.Events {
+		if start, ok := event.(*RequestStart); ok {
+			start.getHeaders = func() http.Header {
+				return headers
+			}
+		}
+	}
+}
+
 // add adds the given event to the trace being built.
 func (b *builder) add(event Event) {
 	var finish bool
@@ -168,6 +184,23 @@ func (b *builder) add(event Event) {
 	}
 }
 
+// addChunk records that a chunk of body data was written or read.
+func (b *builder) addChunk(isRequest bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.trace.TestName == "" {
+		return
+	}
+	if isRequest {
+		b.trace.requestChunks++
+	} else {
+		b.trace.responseChunks++
+	}
+}
+
 func (b *builder) getAndClearLocked() Trace {
 	trace := b.trace
 	b.trace = Trace{} // reset; subsequent calls to add or build ignored
@@ -0,0 +1,479 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+const (
+	pcapngSectionHeaderBlock  = 0x0A0D0D0A
+	pcapngInterfaceDescBlock  = 0x00000001
+	pcapngEnhancedPacketBlock = 0x00000006
+	pcapngByteOrderMagic      = 0x1A2B3C4D
+	pcapngLinkTypeRaw         = 101 // raw IP packets, no link-layer header
+	ipv4HeaderLen             = 20
+	tcpHeaderLen              = 20
+	maxSegmentLen             = 65535 - ipv4HeaderLen - tcpHeaderLen
+	tcpFlagSYN                = 0x02
+	tcpFlagPSH                = 0x08
+	tcpFlagACK                = 0x10
+	clientInitialSeq          = 1000
+	serverInitialSeq          = 5000
+	defaultCapturedClientPort = 49152
+	defaultCapturedServerPort = 443
+	// maxSharedControlFrames is the number of most recent frames for stream
+	// zero (other than SETTINGS and GOAWAY) that are shared by all streams.
+	// These frames, like PING and WINDOW_UPDATE, are not needed to decode
+	// any other frame, so older ones are discarded.
+	maxSharedControlFrames = 64
+	// maxStreamID is the largest HTTP/2 stream ID.
+	maxStreamID = 1<<31 - 1
+)
+
+var errNoPacketCapture = errors.New("no packet capture available")
+
+//nolint:gochecknoglobals
+var (
+	defaultCapturedClientAddr = net.IPv4(10, 0, 0, 1).To4()
+	defaultCapturedServerAddr = net.IPv4(10, 0, 0, 2).To4()
+)
+
+// packetCapturer is implemented by collectors that want the raw bytes
+// that flow through connections returned by TracingHTTP2Conn.
+type packetCapturer interface {
+	capturingPackets() bool
+}
+
+// http2Capture records the raw bytes exchanged on a single HTTP/2 connection.
+//
+// Frames that affect the whole connection are recorded here and shared by
+// all operations on the connection. This includes the client preface, the
+// SETTINGS and GOAWAY frames, and the most recent of the other frames for
+// stream zero. All other frames are recorded in the streamCapture for the
+// stream that they belong to, so they are only retained as long as the
+// trace for that stream is retained.
+//
+// Since HPACK compression is stateful, decoding the header blocks for one
+// stream requires the header blocks for the streams before it. Instead of
+// retaining all of those, the state of the HPACK dynamic table is tracked,
+// and a snapshot is recorded along with each header block for a traced
+// stream. When a capture is written, each snapshot is restored with a
+// synthesized header block.
+type http2Capture struct {
+	start                  time.Time
+	clientAddr, serverAddr *net.TCPAddr
+
+	mu     sync.Mutex
+	seq    uint64
+	shared []capturedFrame
+	// The HPACK dynamic tables for the header blocks sent by
+	// the client and by the server.
+	clientTable, serverTable *hpackTable
+}
+
+// streamCapture records the raw frames for a single stream. It is
+// attached to the trace for the stream.
+type streamCapture struct {
+	conn   *http2Capture
+	frames []capturedFrame
+	// The counts returned by applied.
+	clientApplied, serverApplied uint64
+}
+
+type capturedFrame struct {
+	seq        uint64
+	at         time.Time
+	fromClient bool
+	data       []byte
+	// True for frames on stream zero that are not needed
+	// to decode any other frame.
+	control bool
+	// For the first frame of a header block, the state of the HPACK
+	// dynamic table to restore before the frame, if any.
+	hpackState *hpackSnapshot
+}
+
+func newHTTP2Capture(conn net.Conn, isServer bool) *http2Capture {
+	clientAddr, _ := conn.RemoteAddr().(*net.TCPAddr)
+	serverAddr, _ := conn.LocalAddr().(*net.TCPAddr)
+	if !isServer {
+		clientAddr, serverAddr = serverAddr, clientAddr
+	}
+	return &http2Capture{
+		start:       time.Now(),
+		clientAddr:  capturedAddr(clientAddr, defaultCapturedClientAddr, defaultCapturedClientPort),
+		serverAddr:  capturedAddr(serverAddr, defaultCapturedServerAddr, defaultCapturedServerPort),
+		clientTable: newHPACKTable(),
+		serverTable: newHPACKTable(),
+	}
+}
+
+// capturedAddr returns the address to use in synthesized packets. Only
+// IPv4 headers are synthesized, so other addresses are replaced with the
+// given default (though the port is preserved, if known).
+func capturedAddr(addr *net.TCPAddr, defaultIP net.IP, defaultPort int) *net.TCPAddr {
+	result := &net.TCPAddr{IP: defaultIP, Port: defaultPort}
+	if addr == nil {
+		return result
+	}
+	if ip := addr.IP.To4(); ip != nil && !ip.Equal(net.IPv4zero) {
+		result.IP = ip
+	}
+	if addr.Port != 0 {
+		result.Port = addr.Port
+	}
+	return result
+}
+
+// record records the given raw bytes, which are shared by all operations
+// on the connection.
+func (c *http2Capture) record(data []byte, fromClient bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.shared = append(c.shared, c.newFrameLocked(data, fromClient))
+}
+
+// recordFrame records the given raw frame bytes, associating them with the
+// given stream if they belong to one.
+func (c *http2Capture) recordFrame(frame http2.Frame, data []byte, fromClient bool, stream *streamCapture) {
+	header := frame.Header()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case header.Type == http2.FrameHeaders, header.Type == http2.FrameContinuation, header.Type == http2.FramePushPromise:
+		// Header blocks are always tracked, even for streams that are not
+		// being traced, since they update the HPACK state.
+		c.recordHeaderBlockFrameLocked(header, data, fromClient, stream)
+	case header.StreamID != 0:
+		if stream != nil {
+			stream.frames = append(stream.frames, c.newFrameLocked(data, fromClient))
+		}
+	case header.Type == http2.FrameSettings, header.Type == http2.FrameGoAway:
+		c.shared = append(c.shared, c.newFrameLocked(data, fromClient))
+	default:
+		captured := c.newFrameLocked(data, fromClient)
+		captured.control = true
+		c.shared = append(c.shared, captured)
+		c.pruneLocked()
+	}
+}
+
+// applied returns the number of header blocks that had been applied to the
+// HPACK table for the given direction after the stream's most recent header
+// block in that direction. If no other header blocks have been applied
+// since, the HPACK state in the stream's capture is already correct.
+func (s *streamCapture) applied(fromClient bool) *uint64 {
+	if fromClient {
+		return &s.clientApplied
+	}
+	return &s.serverApplied
+}
+
+func (c *http2Capture) newFrameLocked(data []byte, fromClient bool) capturedFrame {
+	c.seq++
+	return capturedFrame{
+		seq:        c.seq,
+		at:         time.Now(),
+		fromClient: fromClient,
+		data:       append([]byte(nil), data...), // defensive copy; buffers are reused
+	}
+}
+
+// recordHeaderBlockFrameLocked records a frame that carries (part of) a
+// header block and applies complete header blocks to the HPACK state.
+func (c *http2Capture) recordHeaderBlockFrameLocked(header http2.FrameHeader, data []byte, fromClient bool, stream *streamCapture) {
+	table := c.serverTable
+	if fromClient {
+		table = c.clientTable
+	}
+	if header.Type != http2.FrameContinuation || table.pending == nil {
+		table.pending = &headerBlock{stream: stream}
+	}
+	block := table.pending
+	block.fragment = append(block.fragment, headerBlockFragment(header, data)...)
+	if block.stream != nil {
+		captured := c.newFrameLocked(data, fromClient)
+		if header.Type != http2.FrameContinuation && table.applied != *block.stream.applied(fromClient) {
+			// Header blocks for other streams have changed the HPACK state
+			// since this stream's last header block.
+			captured.hpackState = table.snapshot()
+		}
+		block.stream.frames = append(block.stream.frames, captured)
+	}
+	// The END_HEADERS flag is the same for HEADERS, PUSH_PROMISE, and
+	// CONTINUATION frames.
+	if header.Flags.Has(http2.FlagHeadersEndHeaders) {
+		table.pending = nil
+		table.apply(block.fragment)
+		if block.stream != nil {
+			*block.stream.applied(fromClient) = table.applied
+		}
+	}
+}
+
+// pruneLocked discards all but the most recent shared control frames.
+func (c *http2Capture) pruneLocked() {
+	var controlFrames int
+	for _, frame := range c.shared {
+		if frame.control {
+			controlFrames++
+		}
+	}
+	if controlFrames <= maxSharedControlFrames {
+		return
+	}
+	kept := c.shared[:0]
+	for _, frame := range c.shared {
+		if frame.control && controlFrames > maxSharedControlFrames {
+			controlFrames--
+			continue
+		}
+		kept = append(kept, frame)
+	}
+	clear(c.shared[len(kept):])
+	c.shared = kept
+}
+
+// headerBlockFragment returns the portion of the given raw frame that is
+// part of a header block, omitting the frame header, padding, and other
+// fields that precede the fragment.
+func headerBlockFragment(header http2.FrameHeader, data []byte) []byte {
+	if len(data) < frameHeaderLen {
+		return nil
+	}
+	payload := data[frameHeaderLen:]
+	if header.Type == http2.FrameContinuation {
+		return payload
+	}
+	// The PADDED flag is the same for HEADERS and PUSH_PROMISE frames.
+	if header.Flags.Has(http2.FlagHeadersPadded) {
+		if len(payload) == 0 || int(payload[0]) >= len(payload) {
+			return nil
+		}
+		payload = payload[1 : len(payload)-int(payload[0])]
+	}
+	var skip int
+	switch {
+	case header.Type == http2.FramePushPromise:
+		skip = 4 // promised stream ID
+	case header.Flags.Has(http2.FlagHeadersPriority):
+		skip = 5 // stream dependency and weight
+	}
+	if len(payload) < skip {
+		return nil
+	}
+	return payload[skip:]
+}
+
+// writePcapng writes the frames for the given stream, along with the frames
+// shared by all streams on the connection, as a pcapng file. The frames are
+// wrapped in synthesized TCP/IP headers, including a synthesized handshake.
+func (s *streamCapture) writePcapng(w io.Writer) error {
+	s.conn.mu.Lock()
+	frames := make([]capturedFrame, 0, len(s.conn.shared)+len(s.frames))
+	frames = append(frames, s.conn.shared...)
+	frames = append(frames, s.frames...)
+	s.conn.mu.Unlock()
+	sort.Slice(frames, func(i, j int) bool {
+		return frames[i].seq < frames[j].seq
+	})
+
+	pw := &pcapngWriter{w: w}
+	pw.writeSectionHeader()
+	pw.writeInterfaceDescription()
+	conv := tcpConversation{
+		writer:     pw,
+		client:     s.conn.clientAddr,
+		server:     s.conn.serverAddr,
+		clientNext: clientInitialSeq,
+		serverNext: serverInitialSeq,
+	}
+	conv.handshake(s.conn.start)
+	// Synthesized header blocks that restore HPACK state are sent on
+	// otherwise unused streams, counting down from the largest stream ID.
+	primingStreamID := uint32(maxStreamID)
+	for _, frame := range frames {
+		if frame.hpackState != nil {
+			fromClient, fromServer := frame.hpackState.primingFrames(frame.fromClient, primingStreamID)
+			primingStreamID -= 2
+			conv.send(frame.at, true, fromClient)
+			conv.send(frame.at, false, fromServer)
+		}
+		conv.send(frame.at, frame.fromClient, frame.data)
+	}
+	return pw.err
+}
+
+// tcpConversation synthesizes the TCP segments for a single connection.
+type tcpConversation struct {
+	writer                 *pcapngWriter
+	client, server         *net.TCPAddr
+	clientNext, serverNext uint32
+	ipID                   uint16
+}
+
+func (c *tcpConversation) handshake(at time.Time) {
+	c.segment(at, true, tcpFlagSYN, nil)
+	c.clientNext++
+	c.segment(at, false, tcpFlagSYN|tcpFlagACK, nil)
+	c.serverNext++
+	c.segment(at, true, tcpFlagACK, nil)
+}
+
+func (c *tcpConversation) send(at time.Time, fromClient bool, data []byte) {
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > maxSegmentLen {
+			chunk = chunk[:maxSegmentLen]
+		}
+		data = data[len(chunk):]
+		c.segment(at, fromClient, tcpFlagPSH|tcpFlagACK, chunk)
+		if fromClient {
+			c.clientNext += uint32(len(chunk))
+		} else {
+			c.serverNext += uint32(len(chunk))
+		}
+	}
+}
+
+func (c *tcpConversation) segment(at time.Time, fromClient bool, flags byte, payload []byte) {
+	src, dst := c.client, c.server
+	seq, ack := c.clientNext, c.serverNext
+	if !fromClient {
+		src, dst = dst, src
+		seq, ack = ack, seq
+	}
+	if flags&tcpFlagACK == 0 {
+		ack = 0
+	}
+	c.ipID++
+	packet := make([]byte, ipv4HeaderLen+tcpHeaderLen+len(payload))
+
+	ipHeader := packet[:ipv4HeaderLen]
+	ipHeader[0] = 0x45 // version 4, header length of 5 words
+	binary.BigEndian.PutUint16(ipHeader[2:], uint16(len(packet)))
+	binary.BigEndian.PutUint16(ipHeader[4:], c.ipID)
+	binary.BigEndian.PutUint16(ipHeader[6:], 0x4000) // don't fragment
+	ipHeader[8] = 64                                 // TTL
+	ipHeader[9] = 6                                  // TCP
+	copy(ipHeader[12:16], src.IP.To4())
+	copy(ipHeader[16:20], dst.IP.To4())
+	binary.BigEndian.PutUint16(ipHeader[10:], checksum(0, ipHeader))
+
+	tcpSegment := packet[ipv4HeaderLen:]
+	binary.BigEndian.PutUint16(tcpSegment[0:], uint16(src.Port))
+	binary.BigEndian.PutUint16(tcpSegment[2:], uint16(dst.Port))
+	binary.BigEndian.PutUint32(tcpSegment[4:], seq)
+	binary.BigEndian.PutUint32(tcpSegment[8:], ack)
+	tcpSegment[12] = (tcpHeaderLen / 4) << 4
+	tcpSegment[13] = flags
+	binary.BigEndian.PutUint16(tcpSegment[14:], 65535) // window
+	copy(tcpSegment[tcpHeaderLen:], payload)
+	// The TCP checksum includes a pseudo-header with the addresses,
+	// protocol, and segment length.
+	var pseudoHeader [12]byte
+	copy(pseudoHeader[0:4], src.IP.To4())
+	copy(pseudoHeader[4:8], dst.IP.To4())
+	pseudoHeader[9] = 6
+	binary.BigEndian.PutUint16(pseudoHeader[10:], uint16(len(tcpSegment)))
+	binary.BigEndian.PutUint16(tcpSegment[16:], checksum(sum(0, pseudoHeader[:]), tcpSegment))
+
+	c.writer.writeEnhancedPacket(at, packet)
+}
+
+func sum(initial uint32, data []byte) uint32 {
+	total := initial
+	for i := 0; i+1 < len(data); i += 2 {
+		total += uint32(binary.BigEndian.Uint16(data[i:]))
+	}
+	if len(data)%2 == 1 {
+		total += uint32(data[len(data)-1]) << 8
+	}
+	return total
+}
+
+func checksum(initial uint32, data []byte) uint16 {
+	total := sum(initial, data)
+	for total > 0xffff {
+		total = (total >> 16) + (total & 0xffff)
+	}
+	return ^uint16(total)
+}
+
+// pcapngWriter writes blocks in the pcapng format. All blocks are written
+// in little-endian byte order. The first error encountered is recorded,
+// and subsequent writes are ignored.
+type pcapngWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *pcapngWriter) writeSectionHeader() {
+	var body [16]byte
+	binary.LittleEndian.PutUint32(body[0:], pcapngByteOrderMagic)
+	binary.LittleEndian.PutUint16(body[4:], 1) // major version
+	binary.LittleEndian.PutUint16(body[6:], 0) // minor version
+	// section length is unspecified
+	binary.LittleEndian.PutUint64(body[8:], 0xFFFFFFFFFFFFFFFF)
+	p.writeBlock(pcapngSectionHeaderBlock, body[:])
+}
+
+func (p *pcapngWriter) writeInterfaceDescription() {
+	var body [8]byte
+	binary.LittleEndian.PutUint16(body[0:], pcapngLinkTypeRaw)
+	// bytes 2-3 are reserved; bytes 4-7 are snap length, where zero means no limit
+	// no options, so default timestamp resolution (microseconds) is used
+	p.writeBlock(pcapngInterfaceDescBlock, body[:])
+}
+
+func (p *pcapngWriter) writeEnhancedPacket(at time.Time, packet []byte) {
+	paddedLen := (len(packet) + 3) &^ 3
+	body := make([]byte, 20+paddedLen)
+	// bytes 0-3 are the interface ID, which is always zero
+	micros := uint64(at.UnixMicro())
+	binary.LittleEndian.PutUint32(body[4:], uint32(micros>>32))
+	binary.LittleEndian.PutUint32(body[8:], uint32(micros))
+	binary.LittleEndian.PutUint32(body[12:], uint32(len(packet))) // captured length
+	binary.LittleEndian.PutUint32(body[16:], uint32(len(packet))) // original length
+	copy(body[20:], packet)
+	p.writeBlock(pcapngEnhancedPacketBlock, body)
+}
+
+func (p *pcapngWriter) writeBlock(blockType uint32, body []byte) {
+	if p.err != nil {
+		return
+	}
+	totalLen := uint32(12 + len(body))
+	var header [8]byte
+	binary.LittleEndian.PutUint32(header[0:], blockType)
+	binary.LittleEndian.PutUint32(header[4:], totalLen)
+	var trailer [4]byte
+	binary.LittleEndian.PutUint32(trailer[0:], totalLen)
+	for _, data := range [][]byte{header[:], body, trailer[:]} {
+		if _, err := p.w.Write(data); err != nil {
+			p.err = err
+			return
+		}
+	}
+}
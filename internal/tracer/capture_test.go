@@ -0,0 +1,278 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+func TestPacketCapture(t *testing.T) {
+	t.Parallel()
+	clientTracer := &Tracer{CapturePackets: true}
+	serverTracer := &Tracer{CapturePackets: true}
+	client, url := startCaptureServer(t, clientTracer, serverTracer)
+	// Both requests use the same connection.
+	for _, testName := range []string{"first", "second"} {
+		doCaptureRequest(t, client, url, testName, clientTracer, serverTracer)
+	}
+
+	for _, tracer := range []*Tracer{clientTracer, serverTracer} {
+		trace := awaitTrace(t, tracer, "first")
+		require.True(t, trace.HasPacketCapture())
+		var buf bytes.Buffer
+		require.NoError(t, trace.WritePacketCapture(&buf))
+		fromClient, fromServer := readCapture(t, buf.Bytes())
+
+		require.True(t, bytes.HasPrefix(fromClient, []byte(clientPreface)))
+		clientFrames := readFrames(t, fromClient[len(clientPreface):])
+		serverFrames := readFrames(t, fromServer)
+		// Only the frames for the first operation are present. Since it
+		// was the first on the connection, no HPACK state is restored.
+		assert.Equal(t, []string{"first"}, clientFrames.testNames)
+		assert.Equal(t, "body of first", clientFrames.data.String())
+		assert.Len(t, serverFrames.statuses, 1)
+		assert.Equal(t, "BODY OF FIRST", serverFrames.data.String())
+		assert.Zero(t, clientFrames.restoredBlocks)
+		assert.Zero(t, serverFrames.restoredBlocks)
+
+		trace = awaitTrace(t, tracer, "second")
+		buf.Reset()
+		require.NoError(t, trace.WritePacketCapture(&buf))
+		fromClient, fromServer = readCapture(t, buf.Bytes())
+		clientFrames = readFrames(t, fromClient[len(clientPreface):])
+		serverFrames = readFrames(t, fromServer)
+		assert.Equal(t, []string{"second"}, clientFrames.testNames)
+		assert.Equal(t, "body of second", clientFrames.data.String())
+		assert.Len(t, serverFrames.statuses, 1)
+		assert.Equal(t, "BODY OF SECOND", serverFrames.data.String())
+		// The state left by the first operation's header blocks is restored
+		// before the second operation's header blocks.
+		assert.Equal(t, 1, clientFrames.restoredBlocks)
+		assert.Equal(t, 1, serverFrames.restoredBlocks)
+	}
+
+	trace := &Trace{}
+	assert.False(t, trace.HasPacketCapture())
+	assert.Error(t, trace.WritePacketCapture(io.Discard))
+}
+
+func TestPacketCapture_LongLivedConnection(t *testing.T) {
+	t.Parallel()
+	clientTracer := &Tracer{CapturePackets: true}
+	serverTracer := &Tracer{CapturePackets: true}
+	client, url := startCaptureServer(t, clientTracer, serverTracer)
+	// Each request has a distinct test name, which is added to the HPACK
+	// dynamic table, so the earliest entries are eventually evicted.
+	const numRequests = 300
+	for i := range numRequests {
+		doCaptureRequest(t, client, url, fmt.Sprintf("request-%03d", i), clientTracer, serverTracer)
+	}
+
+	for _, tracer := range []*Tracer{clientTracer, serverTracer} {
+		first := awaitTrace(t, tracer, "request-000")
+		last := awaitTrace(t, tracer, fmt.Sprintf("request-%03d", numRequests-1))
+		conn := first.capture.conn
+		conn.mu.Lock()
+		numShared := len(conn.shared)
+		conn.mu.Unlock()
+		// Header blocks for the requests are not shared.
+		assert.LessOrEqual(t, numShared, maxSharedControlFrames+8)
+
+		// Captures for both early and late streams can still be decoded.
+		for _, trace := range []*Trace{first, last} {
+			var buf bytes.Buffer
+			require.NoError(t, trace.WritePacketCapture(&buf))
+			fromClient, fromServer := readCapture(t, buf.Bytes())
+			require.True(t, bytes.HasPrefix(fromClient, []byte(clientPreface)))
+			clientFrames := readFrames(t, fromClient[len(clientPreface):])
+			serverFrames := readFrames(t, fromServer)
+			assert.Contains(t, clientFrames.testNames, trace.TestName)
+			assert.Equal(t, "body of "+trace.TestName, clientFrames.data.String())
+			assert.NotEmpty(t, serverFrames.statuses)
+			assert.Equal(t, strings.ToUpper("body of "+trace.TestName), serverFrames.data.String())
+			if trace == last {
+				assert.Positive(t, clientFrames.restoredBlocks)
+			}
+		}
+	}
+}
+
+// startCaptureServer starts an HTTP/2 server that captures packets with
+// the given server tracer. It returns a client that captures packets with
+// the given client tracer and the server's base URL.
+func startCaptureServer(t *testing.T, clientTracer, serverTracer *Tracer) (*http.Client, string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			respWriter.Header().Set("Content-Type", "application/grpc")
+			_, _ = respWriter.Write(bytes.ToUpper(body))
+		}),
+		ReadHeaderTimeout: 5 * time.Second,
+		Protocols:         &protocols,
+	}
+	go func() {
+		_ = server.Serve(TracingHTTP2Listener(listener, serverTracer))
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	client := &http.Client{
+		Transport: &http.Transport{
+			Protocols: &protocols,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				return TracingHTTP2Conn(conn, false, clientTracer), nil
+			},
+		},
+	}
+	return client, "http://" + listener.Addr().String()
+}
+
+func doCaptureRequest(t *testing.T, client *http.Client, url, testName string, clientTracer, serverTracer *Tracer) {
+	t.Helper()
+	clientTracer.Init(testName)
+	serverTracer.Init(testName)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		url+"/com.foo.Service/Bar", strings.NewReader("body of "+testName))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set(testCaseNameHeader, testName)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func awaitTrace(t *testing.T, tracer *Tracer, testName string) *Trace {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), TraceTimeout)
+	defer cancel()
+	trace, err := tracer.Await(ctx, testName)
+	require.NoError(t, err)
+	return trace
+}
+
+// readCapture parses the given pcapng data, verifies the checksums of the
+// synthesized headers, and returns the TCP payloads sent in each direction.
+func readCapture(t *testing.T, data []byte) (fromClient, fromServer []byte) {
+	t.Helper()
+	var clientPort uint16
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), 12)
+		blockType := binary.LittleEndian.Uint32(data)
+		blockLen := binary.LittleEndian.Uint32(data[4:])
+		require.Zero(t, blockLen%4)
+		require.LessOrEqual(t, int(blockLen), len(data))
+		require.Equal(t, blockLen, binary.LittleEndian.Uint32(data[blockLen-4:]))
+		body := data[8 : blockLen-4]
+		data = data[blockLen:]
+		if blockType != pcapngEnhancedPacketBlock {
+			continue
+		}
+		packetLen := binary.LittleEndian.Uint32(body[12:])
+		packet := body[20 : 20+packetLen]
+		require.Equal(t, uint16(0), checksum(0, packet[:ipv4HeaderLen]), "invalid IP checksum")
+		segment := packet[ipv4HeaderLen:]
+		var pseudoHeader [12]byte
+		copy(pseudoHeader[:8], packet[12:20])
+		pseudoHeader[9] = 6
+		binary.BigEndian.PutUint16(pseudoHeader[10:], uint16(len(segment)))
+		require.Equal(t, uint16(0), checksum(sum(0, pseudoHeader[:]), segment), "invalid TCP checksum")
+		srcPort := binary.BigEndian.Uint16(segment)
+		if segment[13] == tcpFlagSYN {
+			clientPort = srcPort
+		}
+		if srcPort == clientPort {
+			fromClient = append(fromClient, segment[tcpHeaderLen:]...)
+		} else {
+			fromServer = append(fromServer, segment[tcpHeaderLen:]...)
+		}
+	}
+	require.NotZero(t, clientPort, "no SYN packet found")
+	return fromClient, fromServer
+}
+
+type capturedFrames struct {
+	testNames []string
+	statuses  []string
+	data      bytes.Buffer
+	// The number of synthesized header blocks that restore HPACK state.
+	restoredBlocks int
+}
+
+func readFrames(t *testing.T, data []byte) *capturedFrames {
+	t.Helper()
+	var result capturedFrames
+	framer := http2.NewFramer(io.Discard, bytes.NewReader(data))
+	// Header blocks are decoded here, instead of by the framer, since the
+	// synthesized ones are not valid HTTP requests or responses. They are
+	// decoded with a table of the initial size, like a real peer would.
+	decoder := hpack.NewDecoder(initialHeaderTableSize, nil)
+	decoder.SetAllowedMaxDynamicTableSize(math.MaxUint32)
+	for {
+		frame, err := framer.ReadFrame()
+		if errors.Is(err, io.EOF) {
+			return &result
+		}
+		require.NoError(t, err)
+		switch frame := frame.(type) {
+		case *http2.HeadersFrame:
+			require.True(t, frame.HeadersEnded())
+			fields, err := decoder.DecodeFull(frame.HeaderBlockFragment())
+			require.NoError(t, err)
+			if frame.StreamID > maxStreamID/2 {
+				if frame.StreamEnded() {
+					result.restoredBlocks++
+				}
+				continue
+			}
+			for _, field := range fields {
+				switch field.Name {
+				case strings.ToLower(testCaseNameHeader):
+					result.testNames = append(result.testNames, field.Value)
+				case ":status":
+					result.statuses = append(result.statuses, field.Value)
+				}
+			}
+		case *http2.DataFrame:
+			result.data.Write(frame.Data())
+		}
+	}
+}
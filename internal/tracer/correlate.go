@@ -0,0 +1,310 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+)
+
+const (
+	clientSide = "client"
+	serverSide = "server"
+)
+
+// These headers are managed by the HTTP stack on either side of the connection,
+// so differences in them are not interesting when comparing what was sent with
+// what was received.
+//
+//nolint:gochecknoglobals
+var uncorrelatedHeaders = map[string]struct{}{
+	"Connection":        {},
+	"Content-Length":    {},
+	"Date":              {},
+	"Host":              {},
+	"Keep-Alive":        {},
+	"Proxy-Connection":  {},
+	"Trailer":           {},
+	"Transfer-Encoding": {},
+	"Upgrade":           {},
+}
+
+// PrintCorrelated prints two traces for the same HTTP operation, one recorded
+// by the client and the other recorded by the server, as a single timeline.
+// Each line is labeled with the side that recorded it. After the timeline, any
+// differences between what one side sent and the other side received are
+// printed. Such differences reveal changes made in transit, like by a proxy or
+// by middleware that sits between the two.
+func PrintCorrelated(clientTrace, serverTrace *Trace, printer internal.Printer) {
+	type sideEvent struct {
+		side   string
+		offset time.Duration
+		event  Event
+	}
+	start := clientTrace.Start
+	if serverTrace.Start.Before(start) {
+		start = serverTrace.Start
+	}
+	events := make([]sideEvent, 0, len(clientTrace.Events)+len(serverTrace.Events))
+	for _, trace := range []struct {
+		side  string
+		trace *Trace
+	}{{clientSide, clientTrace}, {serverSide, serverTrace}} {
+		shift := trace.trace.Start.Sub(start)
+		for _, event := range trace.trace.Events {
+			events = append(events, sideEvent{
+				side:   trace.side,
+				offset: event.getEventOffset() + shift,
+				event:  event,
+			})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].offset < events[j].offset
+	})
+	for _, event := range events {
+		shifted := withEventOffset(event.event, event.offset)
+		shifted.print(&sidePrinter{side: event.side, printer: printer})
+	}
+	clientTrace.printTrailers(&sidePrinter{side: clientSide, printer: printer})
+	serverTrace.printTrailers(&sidePrinter{side: serverSide, printer: printer})
+
+	diffs := correlate(clientTrace, serverTrace)
+	if len(diffs) == 0 {
+		return
+	}
+	printer.Printf("---- Differences ----")
+	for _, diff := range diffs {
+		printer.Printf("%s", diff)
+	}
+}
+
+// correlate compares the given client and server traces and returns
+// a description of each difference found.
+func correlate(clientTrace, serverTrace *Trace) []string {
+	var diffs []string
+	if clientStart, serverStart := requestStart(clientTrace), requestStart(serverTrace); clientStart != nil && serverStart != nil {
+		diffs = append(diffs, diffHeaders("request header", clientStart.getHeaders(), serverStart.getHeaders())...)
+	}
+	clientReqData, clientReqDone := requestData(clientTrace)
+	serverReqData, serverReqDone := requestData(serverTrace)
+	if clientReqDone && serverReqDone {
+		diffs = append(diffs, diffMessages("request", clientReqData, serverReqData)...)
+	}
+	if clientTrace.Response == nil || serverTrace.Response == nil {
+		return diffs
+	}
+	if clientTrace.Response.StatusCode != serverTrace.Response.StatusCode {
+		diffs = append(diffs, fmt.Sprintf("response status changed in transit: sent %d, received %d",
+			serverTrace.Response.StatusCode, clientTrace.Response.StatusCode))
+	}
+	diffs = append(diffs, diffHeaders("response header", serverTrace.Response.Header, clientTrace.Response.Header)...)
+	serverRespData, serverRespDone := responseData(serverTrace)
+	clientRespData, clientRespDone := responseData(clientTrace)
+	if serverRespDone && clientRespDone {
+		diffs = append(diffs, diffMessages("response", serverRespData, clientRespData)...)
+		diffs = append(diffs, diffHeaders("response trailer", serverTrace.Response.Trailer, clientTrace.Response.Trailer)...)
+	}
+	return diffs
+}
+
+type messageData struct {
+	env    *Envelope
+	length uint64
+	data   []byte
+}
+
+func requestStart(trace *Trace) *RequestStart {
+	for _, event := range trace.Events {
+		if start, ok := event.(*RequestStart); ok {
+			return start
+		}
+	}
+	return nil
+}
+
+// requestData returns the request messages in the given trace. The
+// returned bool is true if the end of the request body was observed
+// without error.
+func requestData(trace *Trace) ([]messageData, bool) {
+	var msgs []messageData
+	for _, event := range trace.Events {
+		switch event := event.(type) {
+		case *RequestBodyData:
+			msgs = append(msgs, messageData{env: event.Envelope, length: event.Len, data: event.Data})
+		case *RequestBodyEnd:
+			return msgs, event.Err == nil
+		}
+	}
+	return msgs, false
+}
+
+// responseData returns the response messages in the given trace. The
+// returned bool is true if the end of the response body was observed
+// without error.
+func responseData(trace *Trace) ([]messageData, bool) {
+	var msgs []messageData
+	for _, event := range trace.Events {
+		switch event := event.(type) {
+		case *ResponseBodyData:
+			msgs = append(msgs, messageData{env: event.Envelope, length: event.Len, data: event.Data})
+		case *ResponseBodyEnd:
+			return msgs, event.Err == nil
+		}
+	}
+	return msgs, false
+}
+
+func diffHeaders(what string, sent, received http.Header) []string {
+	keys := make([]string, 0, len(sent)+len(received))
+	for key := range sent {
+		keys = append(keys, key)
+	}
+	for key := range received {
+		if _, ok := sent[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var diffs []string
+	for _, key := range keys {
+		if _, ignore := uncorrelatedHeaders[key]; ignore {
+			continue
+		}
+		sentVals, receivedVals := sent[key], received[key]
+		switch {
+		case len(sentVals) == 0 && len(receivedVals) == 0:
+			// trailer keys may be present but with no values
+		case len(receivedVals) == 0:
+			diffs = append(diffs, fmt.Sprintf("%s %q stripped in transit: sent %q", what, key, sentVals))
+		case len(sentVals) == 0:
+			diffs = append(diffs, fmt.Sprintf("%s %q added in transit: received %q", what, key, receivedVals))
+		case !slices.Equal(sentVals, receivedVals):
+			diffs = append(diffs, fmt.Sprintf("%s %q changed in transit: sent %q, received %q", what, key, sentVals, receivedVals))
+		}
+	}
+	return diffs
+}
+
+func diffMessages(what string, sent, received []messageData) []string {
+	if len(sent) != len(received) {
+		sentBytes, receivedBytes := totalLen(sent), totalLen(received)
+		if sentBytes == receivedBytes {
+			return []string{fmt.Sprintf("%s body re-chunked in transit: sent %d message(s), received %d message(s), with %d bytes total",
+				what, len(sent), len(received), sentBytes)}
+		}
+		return []string{fmt.Sprintf("%s body changed in transit: sent %d message(s) with %d bytes total, received %d message(s) with %d bytes total",
+			what, len(sent), sentBytes, len(received), receivedBytes)}
+	}
+	var diffs []string
+	for i := range sent {
+		sentMsg, receivedMsg := sent[i], received[i]
+		switch {
+		case !envelopesEqual(sentMsg.env, receivedMsg.env):
+			diffs = append(diffs, fmt.Sprintf("%s message #%d prefix changed in transit: sent %s, received %s",
+				what, i+1, describeEnvelope(sentMsg.env), describeEnvelope(receivedMsg.env)))
+		case sentMsg.length != receivedMsg.length:
+			diffs = append(diffs, fmt.Sprintf("%s message #%d length changed in transit: sent %d bytes, received %d bytes",
+				what, i+1, sentMsg.length, receivedMsg.length))
+		case !bytes.Equal(sentMsg.data, receivedMsg.data):
+			diffs = append(diffs, fmt.Sprintf("%s message #%d data changed in transit", what, i+1))
+		}
+	}
+	return diffs
+}
+
+func totalLen(msgs []messageData) uint64 {
+	var total uint64
+	for _, msg := range msgs {
+		if msg.env != nil {
+			total += prefixLen
+		}
+		total += msg.length
+	}
+	return total
+}
+
+func envelopesEqual(a, b *Envelope) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func describeEnvelope(env *Envelope) string {
+	if env == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("flags=%d, len=%d", env.Flags, env.Len)
+}
+
+// withEventOffset returns a copy of the given event with the given offset.
+func withEventOffset(event Event, offset time.Duration) Event {
+	var clone Event
+	switch event := event.(type) {
+	case *RequestStart:
+		eventCopy := *event
+		clone = &eventCopy
+	case *RequestBodyData:
+		eventCopy := *event
+		clone = &eventCopy
+	case *RequestBodyEnd:
+		eventCopy := *event
+		clone = &eventCopy
+	case *ResponseStart:
+		eventCopy := *event
+		clone = &eventCopy
+	case *ResponseError:
+		eventCopy := *event
+		clone = &eventCopy
+	case *ResponseBodyData:
+		eventCopy := *event
+		clone = &eventCopy
+	case *ResponseBodyEndStream:
+		eventCopy := *event
+		clone = &eventCopy
+	case *ResponseBodyEnd:
+		eventCopy := *event
+		clone = &eventCopy
+	case *RequestCanceled:
+		eventCopy := *event
+		clone = &eventCopy
+	default:
+		return event
+	}
+	clone.setEventOffset(offset)
+	return clone
+}
+
+// sidePrinter labels each printed line with the side of the
+// connection that recorded it.
+type sidePrinter struct {
+	side    string
+	printer internal.Printer
+}
+
+func (p *sidePrinter) Printf(msg string, args ...any) {
+	p.printer.Printf(p.side+" "+msg, args...)
+}
+
+func (p *sidePrinter) PrefixPrintf(prefix, msg string, args ...any) {
+	p.printer.PrefixPrintf(p.side+" "+prefix, msg, args...)
+}
@@ -0,0 +1,152 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorrelate(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	req := &http.Request{
+		Method:     http.MethodPost,
+		URL:        &url.URL{Path: "/com.foo.Service/Bar"},
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+	}
+	makeTrace := func(offset time.Duration, reqHeaders http.Header, resp *http.Response, events ...Event) *Trace {
+		reqStart := &RequestStart{
+			Request:    req,
+			getHeaders: func() http.Header { return reqHeaders },
+		}
+		return &Trace{
+			Request:  req,
+			Response: resp,
+			Events:   append([]Event{reqStart}, events...),
+			Start:    start.Add(offset),
+		}
+	}
+	withOffset := func(event Event, offset time.Duration) Event {
+		event.setEventOffset(offset)
+		return event
+	}
+
+	clientTrace := makeTrace(
+		0,
+		headers("Content-Type", "application/grpc", "X-Stripped", "abc", "X-Changed", "1", "Content-Length", "100"),
+		&http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			ProtoMajor: 2,
+			Header:     headers("Content-Type", "application/grpc", "X-Added", "def"),
+			Trailer:    headers("Grpc-Status", "0"),
+		},
+		withOffset(&RequestBodyData{Envelope: &Envelope{Len: 3}, Len: 3, Data: []byte("abc")}, time.Millisecond),
+		withOffset(&RequestBodyEnd{}, 2*time.Millisecond),
+		withOffset(&ResponseStart{}, 10*time.Millisecond),
+		withOffset(&ResponseBodyData{Envelope: &Envelope{Len: 3}, Len: 3, Data: []byte("xyz")}, 11*time.Millisecond),
+		withOffset(&ResponseBodyEnd{}, 12*time.Millisecond),
+	)
+	serverTrace := makeTrace(
+		time.Millisecond,
+		headers("Content-Type", "application/grpc", "X-Changed", "2", "Date", "today"),
+		&http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			ProtoMajor: 2,
+			Header:     headers("Content-Type", "application/grpc"),
+			Trailer:    headers("Grpc-Status", "0", "Grpc-Message", "foo"),
+		},
+		withOffset(&RequestBodyData{Envelope: &Envelope{Len: 1}, Len: 1, Data: []byte("a")}, time.Millisecond),
+		withOffset(&RequestBodyData{Envelope: &Envelope{Len: 1}, Len: 1, Data: []byte("b")}, time.Millisecond),
+		withOffset(&RequestBodyData{Envelope: &Envelope{Len: 1}, Len: 1, Data: []byte("c")}, time.Millisecond),
+		withOffset(&RequestBodyEnd{}, 2*time.Millisecond),
+		withOffset(&ResponseStart{}, 8*time.Millisecond),
+		withOffset(&ResponseBodyData{Envelope: &Envelope{Len: 3}, Len: 3, Data: []byte("xyw")}, 8*time.Millisecond),
+		withOffset(&ResponseBodyEnd{}, 9*time.Millisecond),
+	)
+	for _, event := range clientTrace.Events {
+		if respStart, ok := event.(*ResponseStart); ok {
+			respStart.Response = clientTrace.Response
+		}
+	}
+	for _, event := range serverTrace.Events {
+		if respStart, ok := event.(*ResponseStart); ok {
+			respStart.Response = serverTrace.Response
+		}
+	}
+
+	assert.Equal(t, []string{
+		`request header "X-Changed" changed in transit: sent ["1"], received ["2"]`,
+		`request header "X-Stripped" stripped in transit: sent ["abc"]`,
+		"request body changed in transit: sent 1 message(s) with 8 bytes total, received 3 message(s) with 18 bytes total",
+		`response header "X-Added" added in transit: received ["def"]`,
+		"response message #1 data changed in transit",
+		`response trailer "Grpc-Message" stripped in transit: sent ["foo"]`,
+	}, correlate(clientTrace, serverTrace))
+
+	var printer internal.SimplePrinter
+	PrintCorrelated(clientTrace, serverTrace, &printer)
+	diffsIndex := -1
+	var sides []string
+	for i, line := range printer.Messages {
+		if line == "---- Differences ----\n" {
+			diffsIndex = i
+			break
+		}
+		sides = append(sides, line[:len(clientSide)])
+	}
+	require.GreaterOrEqual(t, diffsIndex, 0)
+	assert.Len(t, printer.Messages[diffsIndex+1:], 6)
+	// Events are interleaved by their time, relative to the start of the client trace.
+	require.NotEmpty(t, sides)
+	assert.Equal(t, clientSide, sides[0])
+	var sawServer bool
+	var switches int
+	for i := 1; i < len(sides); i++ {
+		if sides[i] == serverSide {
+			sawServer = true
+		}
+		if sides[i] != sides[i-1] {
+			switches++
+		}
+	}
+	assert.True(t, sawServer)
+	assert.Greater(t, switches, 2)
+	// The server's response starts at 9ms (1ms after client start + 8ms), before the
+	// client receives it at 10ms.
+	var serverRespIndex, clientRespIndex int
+	for i, line := range printer.Messages {
+		if strings.Contains(line, "200 OK") {
+			if strings.HasPrefix(line, serverSide) {
+				serverRespIndex = i
+				assert.Contains(t, line, "9.000ms")
+			} else {
+				clientRespIndex = i
+				assert.Contains(t, line, "10.000ms")
+			}
+		}
+	}
+	assert.Less(t, serverRespIndex, clientRespIndex)
+}
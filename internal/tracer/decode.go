@@ -0,0 +1,354 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// maxDecodedLineLen is the maximum length of a single line of decoded
+// output. Longer lines are truncated so that large payloads (which are
+// common in the test suites that exercise size limits) do not flood the
+// trace output.
+const maxDecodedLineLen = 256
+
+// maxDecompressedLen is the maximum number of bytes that will be
+// decompressed in order to decode a message. Messages that inflate to
+// more than this are not decoded, so that a "decompression bomb" in a
+// test case does not exhaust the tracer's memory.
+const maxDecompressedLen = 16 * 1024 * 1024
+
+// messageDecoder can decode the message data in the body of an HTTP
+// operation into a human-readable form.
+type messageDecoder struct {
+	// The type of message in the body. This is nil if the
+	// body is a unary Connect error instead of a message.
+	msgType protoreflect.MessageType
+	// If true, messages are encoded using JSON; otherwise
+	// they use the binary protobuf format.
+	isJSON bool
+	// If true, the body is a stream of enveloped messages.
+	isStream bool
+	// The compression encoding that applies to compressed
+	// messages (or to the entire body for unary protocols).
+	encoding string
+}
+
+// newMessageDecoder returns a decoder for the body of a request or response,
+// based on the given URI path and headers. For responses, statusCode must be
+// the HTTP status code of the response. This returns nil if the body is not
+// something that can be decoded, such as when the method is not known or the
+// codec is not supported.
+func newMessageDecoder(path string, headers http.Header, isRequest bool, statusCode int) *messageDecoder {
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+	if pos := strings.IndexByte(contentType, ';'); pos >= 0 {
+		contentType = strings.TrimSpace(contentType[:pos])
+	}
+	var decoder messageDecoder
+	var codec string
+	switch {
+	case strings.HasPrefix(contentType, "application/connect+"):
+		decoder.isStream = true
+		codec = strings.TrimPrefix(contentType, "application/connect+")
+		decoder.encoding = headers.Get("Connect-Content-Encoding")
+	case contentType == "application/grpc-web-text", strings.HasPrefix(contentType, "application/grpc-web-text+"):
+		// The body is base64-encoded, but it is decoded before it is traced.
+		decoder.isStream = true
+		codec = strings.TrimPrefix(strings.TrimPrefix(contentType, "application/grpc-web-text"), "+")
+		decoder.encoding = headers.Get("Grpc-Encoding")
+	case contentType == "application/grpc-web", strings.HasPrefix(contentType, "application/grpc-web+"):
+		decoder.isStream = true
+		codec = strings.TrimPrefix(strings.TrimPrefix(contentType, "application/grpc-web"), "+")
+		decoder.encoding = headers.Get("Grpc-Encoding")
+	case contentType == "application/grpc", strings.HasPrefix(contentType, "application/grpc+"):
+		decoder.isStream = true
+		codec = strings.TrimPrefix(strings.TrimPrefix(contentType, "application/grpc"), "+")
+		decoder.encoding = headers.Get("Grpc-Encoding")
+	case strings.HasPrefix(contentType, "application/"):
+		codec = strings.TrimPrefix(contentType, "application/")
+		decoder.encoding = headers.Get("Content-Encoding")
+	default:
+		return nil
+	}
+	if decoder.isStream && headers.Get("Content-Encoding") != "" {
+		// full body is encoded, so the stream is not parsed
+		return nil
+	}
+	switch codec {
+	case "", "proto":
+	case "json":
+		decoder.isJSON = true
+	default:
+		return nil
+	}
+	if !decoder.isStream && !isRequest && statusCode != http.StatusOK {
+		// Body is a Connect unary error, not a message.
+		if !decoder.isJSON {
+			return nil
+		}
+		return &decoder
+	}
+	decoder.msgType = messageTypeForPath(path, isRequest)
+	if decoder.msgType == nil {
+		return nil
+	}
+	return &decoder
+}
+
+// messageTypeForPath returns the request or response type for the RPC
+// method identified by the given URI path. It returns nil if the method
+// cannot be resolved.
+func messageTypeForPath(path string, isRequest bool) protoreflect.MessageType {
+	path = strings.TrimPrefix(path, "/")
+	pos := strings.LastIndexByte(path, '/')
+	if pos < 0 {
+		return nil
+	}
+	// The service name may include a path prefix.
+	serviceName := path[:pos]
+	if slash := strings.LastIndexByte(serviceName, '/'); slash >= 0 {
+		serviceName = serviceName[slash+1:]
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil
+	}
+	svc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	method := svc.Methods().ByName(protoreflect.Name(path[pos+1:]))
+	if method == nil {
+		return nil
+	}
+	msgName := method.Output().FullName()
+	if isRequest {
+		msgName = method.Input().FullName()
+	}
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(msgName)
+	if err != nil {
+		return nil
+	}
+	return msgType
+}
+
+// decode decompresses (if necessary) and unmarshals the given message data.
+func (d *messageDecoder) decode(data []byte, compressed bool) (proto.Message, error) {
+	data, err := d.decompress(data, compressed)
+	if err != nil {
+		return nil, err
+	}
+	msg := d.msgType.New().Interface()
+	if d.isJSON {
+		err = protojson.Unmarshal(data, msg)
+	} else {
+		err = proto.Unmarshal(data, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (d *messageDecoder) decompress(data []byte, compressed bool) ([]byte, error) {
+	if d.isStream && !compressed {
+		return data, nil
+	}
+	if !d.isStream && d.encoding == "" {
+		return data, nil
+	}
+	decomp := GetDecompressor(d.encoding)
+	if _, isBroken := decomp.(brokenDecompressor); isBroken {
+		return nil, fmt.Errorf("unsupported compression %q", d.encoding)
+	}
+	if err := decomp.Reset(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("could not decompress: %w", err)
+	}
+	var uncompressed bytes.Buffer
+	n, err := uncompressed.ReadFrom(io.LimitReader(decomp, maxDecompressedLen+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress: %w", err)
+	}
+	if n > maxDecompressedLen {
+		return nil, fmt.Errorf("decompressed size is larger than %d bytes", maxDecompressedLen)
+	}
+	return uncompressed.Bytes(), nil
+}
+
+// lines returns the human-readable lines that describe the given message data.
+// It returns nil if the data does not represent a complete message.
+func (d *messageDecoder) lines(env *Envelope, length uint64, data []byte) []string {
+	if d == nil {
+		return nil
+	}
+	var compressed bool
+	if d.isStream {
+		if env == nil || uint64(env.Len) != length {
+			// incomplete message
+			return nil
+		}
+		if env.Flags&0x82 != 0 {
+			// end-stream message, which is printed via ResponseBodyEndStream
+			return nil
+		}
+		compressed = env.Flags&0x01 != 0
+	}
+	if d.msgType == nil {
+		// unary Connect error
+		data, err := d.decompress(data, compressed)
+		if err != nil {
+			return []string{fmt.Sprintf("error: %v", err)}
+		}
+		return append([]string{"error JSON:"}, indentJSON(data)...)
+	}
+	msg, err := d.decode(data, compressed)
+	if err != nil {
+		return []string{fmt.Sprintf("could not decode %s: %v", d.msgType.Descriptor().FullName(), err)}
+	}
+	return append([]string{string(d.msgType.Descriptor().FullName()) + ":"}, marshalMessage(msg)...)
+}
+
+// decodeEndStream returns the human-readable lines that describe the given
+// end-stream message content. For the Connect protocol, this is a JSON
+// object. For the gRPC-Web protocol, it is a block of trailers.
+func decodeEndStream(content string) []string {
+	if strings.HasPrefix(strings.TrimSpace(content), "{") {
+		return decodeConnectEndStream([]byte(content))
+	}
+	return decodeGRPCWebTrailers(content)
+}
+
+func decodeConnectEndStream(content []byte) []string {
+	lines := indentJSON(content)
+	var endStream struct {
+		Error *struct {
+			Details []struct {
+				Type  string `json:"type"`
+				Value string `json:"value"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(content, &endStream); err != nil || endStream.Error == nil {
+		return lines
+	}
+	for i, detail := range endStream.Error.Details {
+		data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(detail.Value, "="))
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("error.details[%d]: could not decode value: %v", i, err))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("error.details[%d]: %s:", i, detail.Type))
+		lines = append(lines, indentLines(decodeMessage(detail.Type, data))...)
+	}
+	return lines
+}
+
+func decodeGRPCWebTrailers(content string) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.Trim(line, "\r")
+		lines = append(lines, line)
+		name, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		val = strings.Trim(val, " \t")
+		switch strings.ToLower(name) {
+		case "grpc-message":
+			if decoded, err := url.PathUnescape(val); err == nil && decoded != val {
+				lines = append(lines, fmt.Sprintf("  (decoded) %q", decoded))
+			}
+		case "grpc-status-details-bin":
+			data, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(val, "="))
+			if err != nil {
+				lines = append(lines, fmt.Sprintf("  (could not decode: %v)", err))
+				continue
+			}
+			lines = append(lines, "  (decoded)")
+			lines = append(lines, indentLines(decodeStatus(data))...)
+		}
+	}
+	return lines
+}
+
+func decodeStatus(data []byte) []string {
+	var stat status.Status
+	if err := proto.Unmarshal(data, &stat); err != nil {
+		return []string{fmt.Sprintf("could not decode %s: %v", stat.ProtoReflect().Descriptor().FullName(), err)}
+	}
+	return marshalMessage(&stat)
+}
+
+func decodeMessage(typeName string, data []byte) []string {
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		return []string{fmt.Sprintf("could not resolve message type: %v", err)}
+	}
+	msg := msgType.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return []string{fmt.Sprintf("could not decode: %v", err)}
+	}
+	return marshalMessage(msg)
+}
+
+func marshalMessage(msg proto.Message) []string {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return []string{fmt.Sprintf("could not format: %v", err)}
+	}
+	// We re-indent the output since protojson output is deliberately unstable.
+	return indentJSON(data)
+}
+
+func indentJSON(data []byte) []string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		// Not valid JSON, so just show it as is.
+		return splitLines(string(data))
+	}
+	return splitLines(buf.String())
+}
+
+func indentLines(lines []string) []string {
+	for i := range lines {
+		lines[i] = "    " + lines[i]
+	}
+	return lines
+}
+
+func splitLines(s string) []string {
+	lines := strings.Split(strings.TrimRight(s, "\r\n"), "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if len(line) > maxDecodedLineLen {
+			line = fmt.Sprintf("%s... (%d more bytes)", line[:maxDecodedLineLen], len(line)-maxDecodedLineLen)
+		}
+		lines[i] = line
+	}
+	return lines
+}
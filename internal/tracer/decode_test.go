@@ -0,0 +1,357 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/compression"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewMessageDecoder(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		path        string
+		headers     http.Header
+		isRequest   bool
+		statusCode  int
+		expectNil   bool
+		expectType  string
+		expectJSON  bool
+		expectStrm  bool
+		expectEncod string
+	}{
+		{
+			name:       "connect-unary-request",
+			path:       conformancev1connect.ConformanceServiceUnaryProcedure,
+			headers:    headers("Content-Type", "application/proto", "Content-Encoding", "gzip"),
+			isRequest:  true,
+			expectType: "connectrpc.conformance.v1.UnaryRequest",
+			// for unary, message compression is indicated via Content-Encoding
+			expectEncod: "gzip",
+		},
+		{
+			name:       "connect-unary-response",
+			path:       conformancev1connect.ConformanceServiceUnaryProcedure,
+			headers:    headers("Content-Type", "application/json"),
+			statusCode: http.StatusOK,
+			expectType: "connectrpc.conformance.v1.UnaryResponse",
+			expectJSON: true,
+		},
+		{
+			name:       "connect-unary-error",
+			path:       conformancev1connect.ConformanceServiceUnaryProcedure,
+			headers:    headers("Content-Type", "application/json"),
+			statusCode: http.StatusNotFound,
+			expectJSON: true,
+		},
+		{
+			name:       "connect-unary-error-not-json",
+			path:       conformancev1connect.ConformanceServiceUnaryProcedure,
+			headers:    headers("Content-Type", "text/plain"),
+			statusCode: http.StatusNotFound,
+			expectNil:  true,
+		},
+		{
+			name:        "connect-stream",
+			path:        conformancev1connect.ConformanceServiceServerStreamProcedure,
+			headers:     headers("Content-Type", "application/connect+json", "Connect-Content-Encoding", "br"),
+			statusCode:  http.StatusOK,
+			expectType:  "connectrpc.conformance.v1.ServerStreamResponse",
+			expectJSON:  true,
+			expectStrm:  true,
+			expectEncod: "br",
+		},
+		{
+			name:       "grpc-default-codec",
+			path:       conformancev1connect.ConformanceServiceBidiStreamProcedure,
+			headers:    headers("Content-Type", "application/grpc"),
+			isRequest:  true,
+			expectType: "connectrpc.conformance.v1.BidiStreamRequest",
+			expectStrm: true,
+		},
+		{
+			name:       "grpc-web-with-params",
+			path:       "/some/prefix" + conformancev1connect.ConformanceServiceClientStreamProcedure,
+			headers:    headers("Content-Type", "application/grpc-web+proto; charset=utf-8", "Grpc-Encoding", "snappy"),
+			isRequest:  true,
+			expectType: "connectrpc.conformance.v1.ClientStreamRequest",
+			expectStrm: true,
+			// for streams, compression is indicated via protocol-specific header
+			expectEncod: "snappy",
+		},
+		{
+			name:        "grpc-web-text",
+			path:        conformancev1connect.ConformanceServiceServerStreamProcedure,
+			headers:     headers("Content-Type", "application/grpc-web-text+json", "Grpc-Encoding", "gzip"),
+			statusCode:  http.StatusOK,
+			expectType:  "connectrpc.conformance.v1.ServerStreamResponse",
+			expectJSON:  true,
+			expectStrm:  true,
+			expectEncod: "gzip",
+		},
+		{
+			name:      "stream-with-content-encoding",
+			path:      conformancev1connect.ConformanceServiceClientStreamProcedure,
+			headers:   headers("Content-Type", "application/grpc-web+proto", "Content-Encoding", "gzip"),
+			isRequest: true,
+			expectNil: true,
+		},
+		{
+			name:      "unknown-codec",
+			path:      conformancev1connect.ConformanceServiceUnaryProcedure,
+			headers:   headers("Content-Type", "application/grpc+text"),
+			isRequest: true,
+			expectNil: true,
+		},
+		{
+			name:      "unknown-method",
+			path:      "/connectrpc.conformance.v1.ConformanceService/Foo",
+			headers:   headers("Content-Type", "application/proto"),
+			isRequest: true,
+			expectNil: true,
+		},
+		{
+			name:      "unknown-service",
+			path:      "/com.foo.Service/Bar",
+			headers:   headers("Content-Type", "application/proto"),
+			isRequest: true,
+			expectNil: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			decoder := newMessageDecoder(testCase.path, testCase.headers, testCase.isRequest, testCase.statusCode)
+			if testCase.expectNil {
+				require.Nil(t, decoder)
+				return
+			}
+			require.NotNil(t, decoder)
+			if testCase.expectType == "" {
+				require.Nil(t, decoder.msgType)
+			} else {
+				require.NotNil(t, decoder.msgType)
+				assert.Equal(t, testCase.expectType, string(decoder.msgType.Descriptor().FullName()))
+			}
+			assert.Equal(t, testCase.expectJSON, decoder.isJSON)
+			assert.Equal(t, testCase.expectStrm, decoder.isStream)
+			assert.Equal(t, testCase.expectEncod, decoder.encoding)
+		})
+	}
+}
+
+func TestPrintDecodedMessage(t *testing.T) {
+	t.Parallel()
+	msg := &conformancev1.UnaryRequest{
+		ResponseDefinition: &conformancev1.UnaryResponseDefinition{
+			ResponseHeaders: []*conformancev1.Header{{Name: "foo", Value: []string{"bar"}}},
+		},
+		RequestData: []byte("abc"),
+	}
+	protoData, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	jsonData, err := protojson.Marshal(msg)
+	require.NoError(t, err)
+	comp := compression.NewSnappyCompressor()
+	var compressed bytes.Buffer
+	comp.Reset(&compressed)
+	_, err = comp.Write(protoData)
+	require.NoError(t, err)
+	require.NoError(t, comp.Close())
+
+	expectedLines := []string{
+		" request>             message #1: decoded: connectrpc.conformance.v1.UnaryRequest:",
+		" request>               {",
+		` request>                 "responseDefinition": {`,
+		` request>                   "responseHeaders": [`,
+		" request>                     {",
+		` request>                       "name": "foo",`,
+		` request>                       "value": [`,
+		` request>                         "bar"`,
+		" request>                       ]",
+		" request>                     }",
+		" request>                   ]",
+		" request>                 },",
+		` request>                 "requestData": "YWJj"`,
+		" request>               }",
+	}
+
+	testCases := []struct {
+		name    string
+		headers http.Header
+		event   *RequestBodyData
+	}{
+		{
+			name:    "unary-proto",
+			headers: headers("Content-Type", "application/proto"),
+			event:   &RequestBodyData{Len: uint64(len(protoData)), Data: protoData},
+		},
+		{
+			name:    "unary-json",
+			headers: headers("Content-Type", "application/json"),
+			event:   &RequestBodyData{Len: uint64(len(jsonData)), Data: jsonData},
+		},
+		{
+			name:    "unary-compressed",
+			headers: headers("Content-Type", "application/proto", "Content-Encoding", "snappy"),
+			event:   &RequestBodyData{Len: uint64(compressed.Len()), Data: compressed.Bytes()},
+		},
+		{
+			name:    "stream-compressed",
+			headers: headers("Content-Type", "application/connect+proto", "Connect-Content-Encoding", "snappy"),
+			event: &RequestBodyData{
+				Envelope: &Envelope{Flags: 1, Len: uint32(compressed.Len())},
+				Len:      uint64(compressed.Len()),
+				Data:     compressed.Bytes(),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			event := testCase.event
+			event.decoder = newMessageDecoder(conformancev1connect.ConformanceServiceUnaryProcedure, testCase.headers, true, 0)
+			require.NotNil(t, event.decoder)
+			var printer internal.SimplePrinter
+			event.print(&printer)
+			require.GreaterOrEqual(t, len(printer.Messages), len(expectedLines))
+			actualLines := printer.Messages[len(printer.Messages)-len(expectedLines):]
+			for i := range actualLines {
+				actualLines[i] = strings.TrimSuffix(actualLines[i], "\n")
+			}
+			assert.Equal(t, expectedLines, actualLines)
+		})
+	}
+
+	t.Run("incomplete", func(t *testing.T) {
+		t.Parallel()
+		decoder := newMessageDecoder(conformancev1connect.ConformanceServiceUnaryProcedure, headers("Content-Type", "application/grpc"), true, 0)
+		event := &RequestBodyData{
+			Envelope: &Envelope{Len: uint32(len(protoData))},
+			Len:      2,
+			Data:     protoData[:2],
+			decoder:  decoder,
+		}
+		var printer internal.SimplePrinter
+		event.print(&printer)
+		for _, line := range printer.Messages {
+			assert.NotContains(t, line, "decoded")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		decoder := newMessageDecoder(conformancev1connect.ConformanceServiceUnaryProcedure, headers("Content-Type", "application/json"), true, 0)
+		event := &RequestBodyData{
+			Len:     3,
+			Data:    []byte("abc"),
+			decoder: decoder,
+		}
+		var printer internal.SimplePrinter
+		event.print(&printer)
+		require.NotEmpty(t, printer.Messages)
+		assert.Contains(t, printer.Messages[len(printer.Messages)-1], "could not decode connectrpc.conformance.v1.UnaryRequest")
+	})
+
+	t.Run("inflated", func(t *testing.T) {
+		t.Parallel()
+		comp := compression.NewSnappyCompressor()
+		var inflated bytes.Buffer
+		comp.Reset(&inflated)
+		_, err := comp.Write(make([]byte, maxDecompressedLen+1))
+		require.NoError(t, err)
+		require.NoError(t, comp.Close())
+		decoder := newMessageDecoder(conformancev1connect.ConformanceServiceUnaryProcedure, headers("Content-Type", "application/proto", "Content-Encoding", "snappy"), true, 0)
+		event := &RequestBodyData{
+			Len:     uint64(inflated.Len()),
+			Data:    inflated.Bytes(),
+			decoder: decoder,
+		}
+		var printer internal.SimplePrinter
+		event.print(&printer)
+		require.NotEmpty(t, printer.Messages)
+		assert.Contains(t, printer.Messages[len(printer.Messages)-1], "decompressed size is larger than")
+	})
+}
+
+func TestDecodeEndStream(t *testing.T) {
+	t.Parallel()
+	detail := &conformancev1.ConformancePayload{Data: []byte("abc")}
+	detailData, err := proto.Marshal(detail)
+	require.NoError(t, err)
+	detailValue := base64.RawStdEncoding.EncodeToString(detailData)
+
+	t.Run("connect", func(t *testing.T) {
+		t.Parallel()
+		lines := decodeEndStream(`{"error":{"code":"not_found","details":[{"type":"connectrpc.conformance.v1.ConformancePayload","value":"` + detailValue + `"}]},"metadata":{"foo":["bar"]}}`)
+		assert.Equal(t, []string{
+			"{",
+			`  "error": {`,
+			`    "code": "not_found",`,
+			`    "details": [`,
+			"      {",
+			`        "type": "connectrpc.conformance.v1.ConformancePayload",`,
+			`        "value": "` + detailValue + `"`,
+			"      }",
+			"    ]",
+			"  },",
+			`  "metadata": {`,
+			`    "foo": [`,
+			`      "bar"`,
+			"    ]",
+			"  }",
+			"}",
+			"error.details[0]: connectrpc.conformance.v1.ConformancePayload:",
+			"    {",
+			`      "data": "YWJj"`,
+			"    }",
+		}, lines)
+	})
+
+	t.Run("grpc-web", func(t *testing.T) {
+		t.Parallel()
+		stat := &status.Status{Code: 5, Message: "not found"}
+		statData, err := proto.Marshal(stat)
+		require.NoError(t, err)
+		lines := decodeEndStream("grpc-status: 5\r\ngrpc-message: not%20found\r\ngrpc-status-details-bin: " +
+			base64.RawStdEncoding.EncodeToString(statData) + "\r\n")
+		assert.Equal(t, []string{
+			"grpc-status: 5",
+			"grpc-message: not%20found",
+			`  (decoded) "not found"`,
+			"grpc-status-details-bin: " + base64.RawStdEncoding.EncodeToString(statData),
+			"  (decoded)",
+			"    {",
+			`      "code": 5,`,
+			`      "message": "not found"`,
+			"    }",
+			"",
+		}, lines)
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import "encoding/binary"
+
+// EnvelopeScanner splits a stream of enveloped messages, which may be read
+// in chunks of any size, into the parts of each message. The zero value is
+// ready to use, at the start of a stream.
+type EnvelopeScanner struct {
+	prefix []byte
+	// The envelope of the current message, once its prefix has been read.
+	env *Envelope
+	// The number of bytes of the current message's contents not yet read.
+	remaining uint32
+}
+
+// EnvelopeChunk is a part of a stream of enveloped messages, as returned
+// by EnvelopeScanner.Next. A chunk never spans more than one message.
+type EnvelopeChunk struct {
+	// True if the chunk starts with the first byte of a message's prefix.
+	Start bool
+	// The envelope of the message, if its prefix is complete. This is nil
+	// if the chunk is only part of a prefix.
+	Envelope *Envelope
+	// The part of the message's contents, after the prefix, in the chunk.
+	Contents []byte
+	// True if the chunk ends with the last byte of the message.
+	End bool
+}
+
+// Next consumes data from the start of the given data, up to the end of the
+// current message, and returns how many bytes were consumed and what they
+// are. Callers should call Next repeatedly with the rest of the data until
+// it has all been consumed.
+func (s *EnvelopeScanner) Next(data []byte) (int, EnvelopeChunk) {
+	var chunk EnvelopeChunk
+	var consumed int
+	if s.env == nil {
+		chunk.Start = len(s.prefix) == 0
+		need := prefixLen - len(s.prefix)
+		if len(data) < need {
+			s.prefix = append(s.prefix, data...)
+			return len(data), chunk
+		}
+		s.prefix = append(s.prefix, data[:need]...)
+		s.env = &Envelope{
+			Flags: s.prefix[0],
+			Len:   binary.BigEndian.Uint32(s.prefix[1:]),
+		}
+		s.remaining = s.env.Len
+		s.prefix = s.prefix[:0]
+		consumed, data = need, data[need:]
+	}
+	chunk.Envelope = s.env
+	n := min(len(data), int(s.remaining))
+	chunk.Contents = data[:n]
+	consumed += n
+	s.remaining -= uint32(n) //nolint:gosec // n is at most s.remaining
+	if s.remaining == 0 {
+		chunk.End = true
+		s.env = nil
+	}
+	return consumed, chunk
+}
+
+// PartialPrefix returns the number of bytes read of an incomplete prefix.
+// This is zero if a prefix is not being read, such as when a message's
+// contents are being read.
+func (s *EnvelopeScanner) PartialPrefix() int {
+	if s.env != nil {
+		return 0
+	}
+	return len(s.prefix)
+}
+
+// Reset returns the scanner to the start of a stream.
+func (s *EnvelopeScanner) Reset() {
+	s.prefix = s.prefix[:0]
+	s.env = nil
+	s.remaining = 0
+}
@@ -0,0 +1,55 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelopeScanner(t *testing.T) {
+	t.Parallel()
+	// Two messages, with three and zero bytes of contents, followed by
+	// part of the prefix of a third message. They are split across reads
+	// at arbitrary points, including in the middle of a prefix.
+	reads := [][]byte{
+		{2, 0, 0},
+		{0, 3, 'a'},
+		{'b', 'c', 0, 0, 0, 0, 0},
+		{1, 0},
+	}
+	var scanner EnvelopeScanner
+	var chunks []EnvelopeChunk
+	for _, data := range reads {
+		for len(data) > 0 {
+			n, chunk := scanner.Next(data)
+			chunks = append(chunks, chunk)
+			data = data[n:]
+		}
+	}
+	first := &Envelope{Flags: 2, Len: 3}
+	second := &Envelope{Flags: 0, Len: 0}
+	assert.Equal(t, []EnvelopeChunk{
+		{Start: true},
+		{Envelope: first, Contents: []byte{'a'}},
+		{Envelope: first, Contents: []byte{'b', 'c'}, End: true},
+		{Start: true, Envelope: second, Contents: []byte{}, End: true},
+		{Start: true},
+	}, chunks)
+	assert.Equal(t, 2, scanner.PartialPrefix())
+	scanner.Reset()
+	assert.Zero(t, scanner.PartialPrefix())
+}
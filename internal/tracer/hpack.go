@@ -0,0 +1,285 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"slices"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	// initialHeaderTableSize is the initial size of the HPACK dynamic table,
+	// before any dynamic table size updates (RFC 7541, Section 4.2).
+	initialHeaderTableSize = 4096
+	// maxPrimingFragmentLen is the largest header block fragment put in a
+	// synthesized frame. It is the smallest allowed SETTINGS_MAX_FRAME_SIZE.
+	maxPrimingFragmentLen = 16384
+)
+
+var errMalformedHeaderBlock = errors.New("malformed header block")
+
+// hpackTable tracks the entries in the HPACK dynamic table for the header
+// blocks sent in one direction of a connection.
+type hpackTable struct {
+	decoder *hpack.Decoder
+	maxSize uint32
+	size    uint32
+	// The entries in the table, oldest first.
+	entries []hpack.HeaderField
+	// The number of header blocks applied to the table.
+	applied uint64
+	// True if a header block could not be decoded, in which case the
+	// contents of the table are no longer known.
+	broken bool
+	// A header block that is continued in CONTINUATION frames.
+	pending *headerBlock
+}
+
+// headerBlock is a header block that has not yet been applied
+// to an hpackTable.
+type headerBlock struct {
+	fragment []byte
+	// The stream that the block belongs to, if it is being traced.
+	stream *streamCapture
+}
+
+// hpackSnapshot is the state of an HPACK dynamic table.
+type hpackSnapshot struct {
+	maxSize uint32
+	// The entries in the table, oldest first.
+	entries []hpack.HeaderField
+}
+
+func newHPACKTable() *hpackTable {
+	decoder := hpack.NewDecoder(initialHeaderTableSize, nil)
+	// Any size is accepted, since this only observes the connection.
+	decoder.SetAllowedMaxDynamicTableSize(math.MaxUint32)
+	return &hpackTable{decoder: decoder, maxSize: initialHeaderTableSize}
+}
+
+// snapshot returns the current state of the table, or nil if it
+// is not known.
+func (t *hpackTable) snapshot() *hpackSnapshot {
+	if t.broken {
+		return nil
+	}
+	return &hpackSnapshot{maxSize: t.maxSize, entries: slices.Clone(t.entries)}
+}
+
+// apply updates the table with the representations in the given header
+// block fragment, which must be a complete header block.
+func (t *hpackTable) apply(fragment []byte) {
+	t.applied++
+	if t.broken {
+		return
+	}
+	fields, err := t.decoder.DecodeFull(fragment)
+	if err != nil {
+		t.broken = true
+		return
+	}
+	reps, err := parseHPACKRepresentations(fragment)
+	if err != nil {
+		t.broken = true
+		return
+	}
+	for _, rep := range reps {
+		if rep.sizeUpdate {
+			t.maxSize = rep.maxSize
+		} else {
+			if len(fields) == 0 {
+				t.broken = true
+				return
+			}
+			if rep.inserted {
+				t.entries = append(t.entries, fields[0])
+				t.size += fields[0].Size()
+			}
+			fields = fields[1:]
+		}
+		for t.size > t.maxSize && len(t.entries) > 0 {
+			t.size -= t.entries[0].Size()
+			t.entries = t.entries[1:]
+		}
+	}
+}
+
+// encode returns a header block that, when decoded, leaves the
+// decoder's dynamic table in this state: it clears the table, by resizing
+// it to zero, restores its size, and then inserts each entry.
+func (s *hpackSnapshot) encode() []byte {
+	block := appendHPACKInt(nil, 0x20, 5, 0)
+	block = appendHPACKInt(block, 0x20, 5, uint64(s.maxSize))
+	for _, field := range s.entries {
+		// literal with incremental indexing, with a literal name
+		block = append(block, 0x40)
+		block = appendHPACKString(block, field.Name)
+		block = appendHPACKString(block, field.Value)
+	}
+	return block
+}
+
+// primingFrames returns raw frames that carry this snapshot's header block
+// in the given direction, on the given stream. Since only a client can open
+// a stream, a block sent by the server is preceded by an empty block from
+// the client.
+func (s *hpackSnapshot) primingFrames(fromClient bool, streamID uint32) (fromClientFrames, fromServerFrames []byte) {
+	var clientBuf, serverBuf bytes.Buffer
+	framer := http2.NewFramer(&clientBuf, nil)
+	if !fromClient {
+		_ = framer.WriteHeaders(http2.HeadersFrameParam{StreamID: streamID, EndHeaders: true})
+		framer = http2.NewFramer(&serverBuf, nil)
+	}
+	block := s.encode()
+	fragment := block[:min(len(block), maxPrimingFragmentLen)]
+	block = block[len(fragment):]
+	_ = framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      streamID,
+		BlockFragment: fragment,
+		EndStream:     true,
+		EndHeaders:    len(block) == 0,
+	})
+	for len(block) > 0 {
+		fragment = block[:min(len(block), maxPrimingFragmentLen)]
+		block = block[len(fragment):]
+		_ = framer.WriteContinuation(streamID, len(block) == 0, fragment)
+	}
+	return clientBuf.Bytes(), serverBuf.Bytes()
+}
+
+// hpackRepresentation describes a single representation in a header block.
+type hpackRepresentation struct {
+	// True if the representation is a literal header field that is
+	// inserted into the dynamic table.
+	inserted bool
+	// True if the representation is a dynamic table size update,
+	// in which case maxSize is the new size.
+	sizeUpdate bool
+	maxSize    uint32
+}
+
+// parseHPACKRepresentations parses the kinds of representations in the
+// given header block (RFC 7541, Section 6), without decoding them.
+func parseHPACKRepresentations(block []byte) ([]hpackRepresentation, error) {
+	var reps []hpackRepresentation
+	for len(block) > 0 {
+		var rep hpackRepresentation
+		var prefixBits uint
+		var hasStrings bool
+		switch first := block[0]; {
+		case first&0x80 != 0: // indexed header field
+			prefixBits = 7
+		case first&0xc0 == 0x40: // literal with incremental indexing
+			prefixBits = 6
+			hasStrings = true
+			rep.inserted = true
+		case first&0xe0 == 0x20: // dynamic table size update
+			prefixBits = 5
+			rep.sizeUpdate = true
+		default: // literal without indexing or never indexed
+			prefixBits = 4
+			hasStrings = true
+		}
+		val, rest, err := readHPACKInt(prefixBits, block)
+		if err != nil {
+			return nil, err
+		}
+		block = rest
+		if rep.sizeUpdate {
+			if val > math.MaxUint32 {
+				return nil, errMalformedHeaderBlock
+			}
+			rep.maxSize = uint32(val)
+		}
+		if hasStrings {
+			if val == 0 {
+				// The name is a literal, not an index.
+				if block, err = skipHPACKString(block); err != nil {
+					return nil, err
+				}
+			}
+			if block, err = skipHPACKString(block); err != nil {
+				return nil, err
+			}
+		}
+		reps = append(reps, rep)
+	}
+	return reps, nil
+}
+
+// readHPACKInt reads an integer with an N-bit prefix (RFC 7541, Section 5.1)
+// from the start of the given data, returning the rest of the data.
+func readHPACKInt(prefixBits uint, data []byte) (uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, errMalformedHeaderBlock
+	}
+	mask := uint64(1)<<prefixBits - 1
+	val := uint64(data[0]) & mask
+	data = data[1:]
+	if val < mask {
+		return val, data, nil
+	}
+	for shift := uint(0); len(data) > 0 && shift < 63; shift += 7 {
+		b := data[0]
+		data = data[1:]
+		val += uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return val, data, nil
+		}
+	}
+	return 0, nil, errMalformedHeaderBlock
+}
+
+// appendHPACKInt appends an integer with an N-bit prefix (RFC 7541,
+// Section 5.1), where the bits above the prefix in the first byte are
+// given by first.
+func appendHPACKInt(data []byte, first byte, prefixBits uint, val uint64) []byte {
+	mask := uint64(1)<<prefixBits - 1
+	if val < mask {
+		return append(data, first|byte(val))
+	}
+	data = append(data, first|byte(mask))
+	val -= mask
+	for val >= 0x80 {
+		data = append(data, byte(val&0x7f)|0x80)
+		val >>= 7
+	}
+	return append(data, byte(val))
+}
+
+// skipHPACKString skips the string literal (RFC 7541, Section 5.2) at the
+// start of the given data, returning the rest of the data.
+func skipHPACKString(data []byte) ([]byte, error) {
+	length, data, err := readHPACKInt(7, data)
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(len(data)) {
+		return nil, errMalformedHeaderBlock
+	}
+	return data[length:], nil
+}
+
+// appendHPACKString appends the given string as a string literal (RFC 7541,
+// Section 5.2), without Huffman encoding.
+func appendHPACKString(data []byte, str string) []byte {
+	data = appendHPACKInt(data, 0, 7, uint64(len(str)))
+	return append(data, str...)
+}
@@ -0,0 +1,189 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2/hpack"
+)
+
+func TestHPACKTable(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name   string
+		blocks [][]hpack.HeaderField
+		// If non-zero, the encoder's table is resized before the last block.
+		resize uint32
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "indexed",
+			blocks: [][]hpack.HeaderField{
+				{{Name: ":method", Value: "POST"}, {Name: "content-type", Value: "application/grpc"}},
+				{{Name: ":method", Value: "POST"}, {Name: "content-type", Value: "application/grpc"}},
+			},
+		},
+		{
+			name: "never indexed",
+			blocks: [][]hpack.HeaderField{
+				{{Name: "authorization", Value: "secret", Sensitive: true}, {Name: "x-foo", Value: "bar"}},
+			},
+		},
+		{
+			name:   "evicted",
+			blocks: manyHeaderBlocks(100),
+		},
+		{
+			name:   "resized",
+			blocks: manyHeaderBlocks(10),
+			resize: 256,
+		},
+		{
+			name:   "long values",
+			blocks: [][]hpack.HeaderField{{{Name: "x-long", Value: strings.Repeat("x", 300)}}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			encoder := hpack.NewEncoder(&buf)
+			encode := func(fields []hpack.HeaderField) []byte {
+				buf.Reset()
+				for _, field := range fields {
+					require.NoError(t, encoder.WriteField(field))
+				}
+				return bytes.Clone(buf.Bytes())
+			}
+			table := newHPACKTable()
+			for i, fields := range testCase.blocks {
+				if testCase.resize != 0 && i == len(testCase.blocks)-1 {
+					encoder.SetMaxDynamicTableSizeLimit(testCase.resize)
+				}
+				table.apply(encode(fields))
+			}
+			require.False(t, table.broken)
+			assert.Equal(t, uint64(len(testCase.blocks)), table.applied)
+			if testCase.resize != 0 {
+				assert.Equal(t, testCase.resize, table.maxSize)
+			}
+
+			// A decoder that is given the snapshot's header block can decode
+			// what the encoder produces next, even if it refers to entries
+			// in the dynamic table.
+			decoder := hpack.NewDecoder(initialHeaderTableSize, nil)
+			decoder.SetAllowedMaxDynamicTableSize(math.MaxUint32)
+			restored, err := decoder.DecodeFull(table.snapshot().encode())
+			require.NoError(t, err)
+			assert.Equal(t, table.entries, restored)
+			var next []hpack.HeaderField
+			for _, fields := range testCase.blocks {
+				next = append(next, fields...)
+			}
+			decoded, err := decoder.DecodeFull(encode(next))
+			require.NoError(t, err)
+			assert.Equal(t, next, decoded)
+		})
+	}
+}
+
+func TestHPACKTable_Broken(t *testing.T) {
+	t.Parallel()
+	table := newHPACKTable()
+	// an index into an empty dynamic table
+	table.apply([]byte{0xbe})
+	assert.True(t, table.broken)
+	assert.Nil(t, table.snapshot())
+	table.apply(nil)
+	assert.Equal(t, uint64(2), table.applied)
+}
+
+func TestParseHPACKRepresentations(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		block    []byte
+		expected []hpackRepresentation
+		invalid  bool
+	}{
+		{
+			name:     "indexed",
+			block:    []byte{0x82},
+			expected: []hpackRepresentation{{}},
+		},
+		{
+			name:     "incremental indexing with literal name",
+			block:    []byte{0x40, 0x01, 'a', 0x01, 'b'},
+			expected: []hpackRepresentation{{inserted: true}},
+		},
+		{
+			name:     "incremental indexing with indexed name",
+			block:    []byte{0x44, 0x01, '/'},
+			expected: []hpackRepresentation{{inserted: true}},
+		},
+		{
+			name:     "without indexing and never indexed",
+			block:    []byte{0x00, 0x01, 'a', 0x01, 'b', 0x14, 0x01, '/'},
+			expected: []hpackRepresentation{{}, {}},
+		},
+		{
+			name:     "size updates",
+			block:    []byte{0x20, 0x3f, 0xe1, 0x1f},
+			expected: []hpackRepresentation{{sizeUpdate: true}, {sizeUpdate: true, maxSize: 4096}},
+		},
+		{
+			name:    "truncated integer",
+			block:   []byte{0x3f, 0xe1},
+			invalid: true,
+		},
+		{
+			name:    "truncated string",
+			block:   []byte{0x40, 0x05, 'a'},
+			invalid: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			reps, err := parseHPACKRepresentations(testCase.block)
+			if testCase.invalid {
+				assert.ErrorIs(t, err, errMalformedHeaderBlock)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, reps)
+		})
+	}
+}
+
+func manyHeaderBlocks(count int) [][]hpack.HeaderField {
+	blocks := make([][]hpack.HeaderField, count)
+	for i := range blocks {
+		blocks[i] = []hpack.HeaderField{
+			{Name: ":path", Value: "/foo.Service/Bar"},
+			{Name: "x-test-case-name", Value: fmt.Sprintf("test case %d", i)},
+		}
+	}
+	return blocks
+}
@@ -52,6 +52,10 @@ const (
 // If isServer is true, this is a server connection, so requests are read
 // and responses are written. Otherwise, this is a client connection, and
 // requests are written and responses are read.
+//
+// If the given collector is a *Tracer whose CapturePackets field is true,
+// the raw frames are also recorded. They can be exported from the
+// resulting traces via Trace.WritePacketCapture.
 func TracingHTTP2Conn(conn net.Conn, isServer bool, collector Collector) net.Conn {
 	tracer := &tracingHTTP2Conn{
 		Conn:        conn,
@@ -64,6 +68,9 @@ func TracingHTTP2Conn(conn net.Conn, isServer bool, collector Collector) net.Con
 	tracer.readTracer.decoder = hpack.NewDecoder(math.MaxUint32, nil)
 	tracer.writeTracer.c = tracer
 	tracer.writeTracer.decoder = hpack.NewDecoder(math.MaxUint32, nil)
+	if capturer, ok := collector.(packetCapturer); ok && capturer.capturingPackets() {
+		tracer.capture = newHTTP2Capture(conn, isServer)
+	}
 	prefaceBytes := make([]byte, 0, len(clientPreface))
 	if isServer {
 		tracer.readTracer.prefaceBytes = prefaceBytes
@@ -89,6 +96,7 @@ type tracingHTTP2Conn struct {
 	net.Conn
 	isServer  bool
 	collector *http2RetryCollector
+	capture   *http2Capture // nil if not capturing packets
 
 	mu          sync.Mutex
 	streams     map[uint32]*http2Stream
@@ -141,12 +149,18 @@ func (c *tracingHTTP2Conn) Close() error {
 	return err
 }
 
-func (c *tracingHTTP2Conn) handleFrame(frame http2.Frame, isRequest bool) {
+func (c *tracingHTTP2Conn) handleFrame(frame http2.Frame, rawFrame []byte, isRequest bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if _, isHeaders := frame.(*http2.MetaHeadersFrame); !isHeaders {
+		c.recordFrameLocked(frame, rawFrame, isRequest, c.streams[frame.Header().StreamID])
+	}
 	switch frame := frame.(type) {
 	case *http2.MetaHeadersFrame:
+		// Request headers create the stream, so they are
+		// recorded once the stream is known.
 		stream, isNew := c.getStreamLocked(frame, isRequest)
+		c.recordFrameLocked(frame, rawFrame, isRequest, stream)
 		if stream == nil {
 			return
 		}
@@ -194,11 +208,24 @@ func (c *tracingHTTP2Conn) handleFrame(frame http2.Frame, isRequest bool) {
 	}
 }
 
+func (c *tracingHTTP2Conn) recordFrameLocked(frame http2.Frame, rawFrame []byte, isRequest bool, stream *http2Stream) {
+	if c.capture == nil {
+		return
+	}
+	var streamCap *streamCapture
+	if stream != nil {
+		streamCap = stream.capture
+	}
+	c.capture.recordFrame(frame, rawFrame, isRequest, streamCap)
+}
+
 func (c *tracingHTTP2Conn) receiveResponseLocked(stream *http2Stream, frame *http2.MetaHeadersFrame) {
 	stream.gotResponse = true
 	resp := makeResponse(frame) //nolint:bodyclose // there is no body to close on this response
 	stream.builder.add(&ResponseStart{Response: resp})
 	stream.responseTracer.isStreamProtocol, stream.responseTracer.decompressor = propertiesFromHeaders(resp.Header)
+	stream.responseTracer.base64 = base64DecoderFromHeaders(resp.Header)
+	stream.responseTracer.decoder = newMessageDecoder(stream.path, resp.Header, false, resp.StatusCode)
 	stream.responseTracer.builder = stream.builder
 }
 
@@ -220,10 +247,26 @@ func (c *tracingHTTP2Conn) getStreamLocked(frame *http2.MetaHeadersFrame, create
 func (c *tracingHTTP2Conn) newStreamLocked(frame *http2.MetaHeadersFrame) *http2Stream {
 	req := makeRequest(frame)
 	builder, _ := newBuilder(req, !c.isServer, c.collector)
+	// The request headers were decoded from the frame, so they already
+	// reflect what is on the wire, even for client-side traces.
+	builder.useRequestHeaders(req.Header)
 	isStream, decompressor := propertiesFromHeaders(req.Header)
+	decoder := newMessageDecoder(req.URL.Path, req.Header, true, 0)
 	stream := &http2Stream{
-		builder:       builder,
-		requestTracer: dataTracer{isRequest: true, isStreamProtocol: isStream, decompressor: decompressor, builder: builder},
+		builder: builder,
+		path:    req.URL.Path,
+		requestTracer: dataTracer{
+			isRequest:        true,
+			isStreamProtocol: isStream,
+			base64:           base64DecoderFromHeaders(req.Header),
+			decompressor:     decompressor,
+			decoder:          decoder,
+			builder:          builder,
+		},
+	}
+	if c.capture != nil && builder.trace.TestName != "" {
+		stream.capture = &streamCapture{conn: c.capture}
+		builder.trace.capture = stream.capture
 	}
 	c.collector.newAttempt(builder.trace.TestName)
 	if c.streams == nil {
@@ -289,6 +332,8 @@ func (c *tracingHTTP2Conn) cancelAll(err error) {
 
 type http2Stream struct {
 	builder        *builder
+	path           string
+	capture        *streamCapture // nil if not capturing packets
 	requestTracer  dataTracer
 	gotResponse    bool
 	responseTracer dataTracer
@@ -328,6 +373,9 @@ func (h *http2FrameTracer) trace(data []byte) {
 				h.broken = true
 				return
 			}
+			if h.c.capture != nil {
+				h.c.capture.record(h.prefaceBytes, true)
+			}
 			if len(data) == 0 {
 				return
 			}
@@ -396,6 +444,7 @@ func (h *http2FrameTracer) emitFrame() bool {
 	defer func() {
 		h.frame.Reset()
 	}()
+	rawFrame := h.frame.Bytes()
 	framer := http2.NewFramer(io.Discard, &h.frame)
 	framer.ReadMetaHeaders = h.decoder
 	frame, err := framer.ReadFrame()
@@ -403,7 +452,7 @@ func (h *http2FrameTracer) emitFrame() bool {
 		h.broken = true
 		return false
 	}
-	h.c.handleFrame(frame, h.isRequest)
+	h.c.handleFrame(frame, rawFrame, h.isRequest)
 	return true
 }
 
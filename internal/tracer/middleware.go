@@ -35,7 +35,12 @@ func TracingRoundTripper(transport http.RoundTripper, collector Collector) http.
 			builder.add(&RequestCanceled{})
 		}()
 		req = req.Clone(ctx)
-		req.Body = newRequestReader(req.Header, req.Body, true, builder)
+		if req.Body == nil {
+			// Client requests may omit the body, but we need one to wrap.
+			req.Body = http.NoBody
+		}
+		reqDecoder := newMessageDecoder(req.URL.Path, req.Header, true, 0)
+		req.Body = newRequestReader(req.Header, reqDecoder, req.Body, true, builder)
 		resp, err := transport.RoundTrip(req)
 		if err != nil {
 			builder.add(&ResponseError{Err: err})
@@ -43,7 +48,8 @@ func TracingRoundTripper(transport http.RoundTripper, collector Collector) http.
 			return nil, err
 		}
 		builder.add(&ResponseStart{Response: resp})
-		resp.Body = newReader(resp.Header, resp.Body, false, builder, cancel)
+		respDecoder := newMessageDecoder(req.URL.Path, resp.Header, false, resp.StatusCode)
+		resp.Body = newReader(resp.Header, respDecoder, resp.Body, false, builder, cancel)
 		return resp, nil
 	})
 }
@@ -62,7 +68,8 @@ func TracingHandler(handler http.Handler, collector Collector) http.Handler {
 		}()
 		//nolint:contextcheck
 		req = req.Clone(ctx)
-		req.Body = newRequestReader(req.Header, req.Body, true, builder)
+		reqDecoder := newMessageDecoder(req.URL.Path, req.Header, true, 0)
+		req.Body = newRequestReader(req.Header, reqDecoder, req.Body, true, builder)
 		traceWriter := &tracingResponseWriter{
 			respWriter: respWriter,
 			req:        req,
@@ -129,7 +136,9 @@ func (t *tracingResponseWriter) WriteHeader(statusCode int) {
 	t.dataTracer = dataTracer{
 		isRequest:        false,
 		isStreamProtocol: isStreamProtocol,
+		base64:           base64DecoderFromHeaders(t.Header()),
 		decompressor:     decompressor,
+		decoder:          newMessageDecoder(t.req.URL.Path, t.Header(), false, statusCode),
 		builder:          t.builder,
 	}
 	contentLenStr := t.Header().Get("Content-Length")
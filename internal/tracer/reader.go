@@ -16,7 +16,6 @@ package tracer
 
 import (
 	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -25,6 +24,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"connectrpc.com/conformance/internal"
 	"connectrpc.com/connect"
 )
 
@@ -40,13 +40,13 @@ type tracingReader struct {
 	dataTracer dataTracer
 }
 
-func newRequestReader(headers http.Header, reader io.ReadCloser, isRequest bool, builder *builder) io.ReadCloser {
+func newRequestReader(headers http.Header, decoder *messageDecoder, reader io.ReadCloser, isRequest bool, builder *builder) io.ReadCloser {
 	// no action to take when request body is done
 	whenDone := func() {}
-	return newReader(headers, reader, isRequest, builder, whenDone)
+	return newReader(headers, decoder, reader, isRequest, builder, whenDone)
 }
 
-func newReader(headers http.Header, reader io.ReadCloser, isRequest bool, builder *builder, whenDone func()) io.ReadCloser {
+func newReader(headers http.Header, decoder *messageDecoder, reader io.ReadCloser, isRequest bool, builder *builder, whenDone func()) io.ReadCloser {
 	isStream, decompressor := propertiesFromHeaders(headers)
 	return &tracingReader{
 		reader:    reader,
@@ -56,7 +56,9 @@ func newReader(headers http.Header, reader io.ReadCloser, isRequest bool, builde
 		dataTracer: dataTracer{
 			isRequest:        isRequest,
 			isStreamProtocol: isStream,
+			base64:           base64DecoderFromHeaders(headers),
 			decompressor:     decompressor,
+			decoder:          decoder,
 			builder:          builder,
 		},
 	}
@@ -104,119 +106,80 @@ func (t *tracingReader) tryFinish(err error) {
 type dataTracer struct {
 	isRequest        bool
 	isStreamProtocol bool
-	decompressor     connect.Decompressor
-	builder          *builder
+	// If non-nil, the body is base64-encoded (gRPC-Web text)
+	// and is decoded before it is traced.
+	base64       *internal.GRPCWebTextDecoder
+	base64Failed bool
+	// The encoded bytes, for gRPC-Web text, that have not yet
+	// been attributed to a message, and where to add any that
+	// remain at the end of the body.
+	wire         []byte
+	lastWire     *[]byte
+	decompressor connect.Decompressor
+	decoder      *messageDecoder
+	builder      *builder
 
 	mu        sync.Mutex
-	prefix    []byte
-	env       *Envelope
-	expecting uint32
-	actual    uint64
-	endStream *bytes.Buffer
+	envelopes EnvelopeScanner
+	// The envelope of the current message, once its prefix has been read.
+	env    *Envelope
+	actual uint64
+	data   bytes.Buffer
 }
 
 func (d *dataTracer) trace(data []byte) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if !d.isStreamProtocol {
-		d.actual += uint64(len(data))
-		return
+	if len(data) > 0 {
+		d.builder.addChunk(d.isRequest)
 	}
-	for {
-		if len(data) == 0 {
+	if d.base64 != nil {
+		d.wire = append(d.wire, data...)
+		if d.base64Failed {
+			// Can't decode the rest of the body, so it is not traced.
 			return
 		}
-
-		if d.expecting == 0 {
-			// still reading envelope prefix
-			n, done := d.tracePrefixLocked(data)
-			if !done {
-				// need to read more data to finish prefix
-				return
-			}
-			data = data[n:]
-			continue
-		}
-
-		n, done := d.traceMessageLocked(data)
-		if !done {
-			// need to read more data to finish message
-			return
+		decoded, err := d.base64.Decode(data)
+		if err != nil {
+			d.base64Failed = true
 		}
-		data = data[n:]
-	}
-}
-
-func (d *dataTracer) tracePrefixLocked(data []byte) (int, bool) {
-	need := prefixLen - len(d.prefix)
-	if len(data) < need {
-		// envelope still not complete...
-		d.prefix = append(d.prefix, data...)
-		return need, false
+		data = decoded
 	}
 
-	d.prefix = append(d.prefix, data[:need]...)
-	d.env = &Envelope{
-		Len:   binary.BigEndian.Uint32(d.prefix[1:]),
-		Flags: d.prefix[0],
+	if !d.isStreamProtocol {
+		d.actual += uint64(len(data))
+		_, _ = d.data.Write(data)
+		return
 	}
-	d.expecting = d.env.Len
-	d.prefix = d.prefix[:0]
-	if d.expecting == 0 {
-		// If we're not expecting any more data for this message, go
-		// ahead and emit event.
-		if d.isRequest {
-			d.builder.add(&RequestBodyData{
-				Envelope: d.env,
-				Len:      0,
-			})
-		} else {
-			d.builder.add(&ResponseBodyData{
-				Envelope: d.env,
-				Len:      0,
-			})
+	for len(data) > 0 {
+		n, chunk := d.envelopes.Next(data)
+		data = data[n:]
+		d.env = chunk.Envelope
+		d.actual += uint64(len(chunk.Contents))
+		_, _ = d.data.Write(chunk.Contents)
+		if chunk.End {
+			d.finishMessageLocked()
 		}
-		d.env = nil
-	} else if !d.isRequest && (d.env.Flags&0x82) != 0 {
-		// This is a response end-stream message. Capture the contents.
-		d.endStream = bytes.NewBuffer(make([]byte, 0, d.env.Len))
 	}
-	return need, true
 }
 
-func (d *dataTracer) traceMessageLocked(data []byte) (int, bool) {
-	need := int(d.expecting - uint32(d.actual))
-	if len(data) < need {
-		// message still not complete...
-		d.actual += uint64(len(data))
-		if d.endStream != nil {
-			_, _ = d.endStream.Write(data)
-		}
-		return need, false
+func (d *dataTracer) finishMessageLocked() {
+	msgData := bytes.Clone(d.data.Bytes())
+	if msgData == nil {
+		msgData = []byte{}
 	}
-
-	if d.isRequest {
-		d.builder.add(&RequestBodyData{
-			Envelope: d.env,
-			Len:      uint64(d.expecting),
-		})
-	} else {
-		d.builder.add(&ResponseBodyData{
-			Envelope: d.env,
-			Len:      uint64(d.expecting),
-		})
-	}
-	if d.endStream != nil { //nolint:nestif
-		_, _ = d.endStream.Write(data[:need])
+	d.emitLocked(uint64(d.env.Len), msgData)
+	if !d.isRequest && (d.env.Flags&0x82) != 0 { //nolint:nestif
+		// This is a response end-stream message. Capture the contents.
 		var content string
 		if d.decompressor == nil {
-			content = d.endStream.String()
+			content = string(msgData)
 		} else {
 			var uncompressed bytes.Buffer
-			if err := d.decompressor.Reset(d.endStream); err == nil {
-				_, err := uncompressed.ReadFrom(d.decompressor)
-				if err == nil {
+			if err := d.decompressor.Reset(bytes.NewReader(msgData)); err == nil {
+				n, err := uncompressed.ReadFrom(io.LimitReader(d.decompressor, maxDecompressedLen+1))
+				if err == nil && n <= maxDecompressedLen {
 					content = uncompressed.String()
 				}
 			}
@@ -226,12 +189,10 @@ func (d *dataTracer) traceMessageLocked(data []byte) (int, bool) {
 				Content: content,
 			})
 		}
-		d.endStream = nil
 	}
 	d.env = nil
-	d.expecting = 0
 	d.actual = 0
-	return need, true
+	d.data.Reset()
 }
 
 func (d *dataTracer) emitUnfinished() {
@@ -239,31 +200,55 @@ func (d *dataTracer) emitUnfinished() {
 	defer d.mu.Unlock()
 
 	var unfinished uint64
-	if d.expecting == 0 && len(d.prefix) > 0 {
-		unfinished = uint64(len(d.prefix))
+	var data []byte
+	if n := d.envelopes.PartialPrefix(); n > 0 {
+		unfinished = uint64(n)
 	} else {
 		unfinished = d.actual
+		data = bytes.Clone(d.data.Bytes())
 	}
 
 	if unfinished > 0 {
-		if d.isRequest {
-			d.builder.add(&RequestBodyData{
-				Envelope: d.env,
-				Len:      unfinished,
-			})
-		} else {
-			d.builder.add(&ResponseBodyData{
-				Envelope: d.env,
-				Len:      unfinished,
-			})
-		}
+		d.emitLocked(unfinished, data)
 	}
+	if len(d.wire) > 0 && d.lastWire != nil {
+		*d.lastWire = append(*d.lastWire, d.wire...)
+	}
+	d.wire = nil
 
-	d.endStream = nil // we didn't finish reading end-stream message; discard what we got
+	d.envelopes.Reset()
 	d.env = nil
-	d.expecting = 0
 	d.actual = 0
-	d.prefix = d.prefix[:0]
+	d.data.Reset()
+}
+
+func (d *dataTracer) emitLocked(length uint64, data []byte) {
+	var wire []byte
+	if d.base64 != nil {
+		wire = append([]byte{}, d.wire...)
+		d.wire = d.wire[:0]
+	}
+	if d.isRequest {
+		event := &RequestBodyData{
+			Envelope: d.env,
+			Len:      length,
+			Data:     data,
+			wire:     wire,
+			decoder:  d.decoder,
+		}
+		d.lastWire = &event.wire
+		d.builder.add(event)
+	} else {
+		event := &ResponseBodyData{
+			Envelope: d.env,
+			Len:      length,
+			Data:     data,
+			wire:     wire,
+			decoder:  d.decoder,
+		}
+		d.lastWire = &event.wire
+		d.builder.add(event)
+	}
 }
 
 // brokenDecompressor is a no-op implementation that treats all compressed
@@ -282,6 +267,15 @@ func (brokenDecompressor) Reset(io.Reader) error {
 	return nil
 }
 
+// base64DecoderFromHeaders returns a decoder for the body if the given headers
+// indicate the body is base64-encoded. Otherwise, it returns nil.
+func base64DecoderFromHeaders(headers http.Header) *internal.GRPCWebTextDecoder {
+	if headers.Get("Content-Encoding") != "" || !internal.IsGRPCWebTextContentType(headers.Get("Content-Type")) {
+		return nil
+	}
+	return &internal.GRPCWebTextDecoder{}
+}
+
 func propertiesFromHeaders(headers http.Header) (isStream bool, decomp connect.Decompressor) {
 	contentType := strings.ToLower(headers.Get("Content-Type"))
 	if headers.Get("Content-Encoding") != "" {
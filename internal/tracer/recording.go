@@ -0,0 +1,218 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Recording is the exchange, as seen on the wire, for a single HTTP
+// operation. It can be serialized to JSON, to be saved and later
+// replayed. The body data is recorded as it was written or read, in
+// chunks that each correspond to a message (or to the entire body for
+// unary protocols), including any envelope prefixes. For gRPC-Web text,
+// the chunks are the base64-encoded bytes exactly as they appeared on
+// the wire, so they may not align precisely with messages.
+type Recording struct {
+	TestName string            `json:"testName,omitempty"`
+	Request  RecordedRequest   `json:"request"`
+	Response *RecordedResponse `json:"response,omitempty"`
+}
+
+// RecordedRequest is the request portion of a Recording.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	// The request URI, including query string (if any) but
+	// excluding the scheme and host.
+	URI      string         `json:"uri"`
+	Proto    string         `json:"proto"`
+	Headers  http.Header    `json:"headers,omitempty"`
+	Body     []RecordedData `json:"body,omitempty"`
+	Trailers http.Header    `json:"trailers,omitempty"`
+}
+
+// RecordedResponse is the response portion of a Recording.
+type RecordedResponse struct {
+	// The time the response headers were sent or received,
+	// relative to the start of the request.
+	Offset     time.Duration  `json:"offsetNanos"`
+	StatusCode int            `json:"statusCode"`
+	Headers    http.Header    `json:"headers,omitempty"`
+	Body       []RecordedData `json:"body,omitempty"`
+	Trailers   http.Header    `json:"trailers,omitempty"`
+}
+
+// RecordedData is a chunk of body data in a Recording.
+type RecordedData struct {
+	// The time the data was written or read, relative to
+	// the start of the request.
+	Offset time.Duration `json:"offsetNanos"`
+	Data   []byte        `json:"data"`
+}
+
+// Record returns a recording of the exchange in this trace.
+func (t *Trace) Record() *Recording {
+	rec := &Recording{
+		TestName: t.TestName,
+		Request: RecordedRequest{
+			Method:   t.Request.Method,
+			URI:      t.Request.URL.RequestURI(),
+			Proto:    t.Request.Proto,
+			Trailers: t.Request.Trailer,
+		},
+	}
+	for _, event := range t.Events {
+		switch event := event.(type) {
+		case *RequestStart:
+			rec.Request.Headers = event.getHeaders()
+		case *RequestBodyData:
+			rec.Request.Body = append(rec.Request.Body, RecordedData{
+				Offset: event.getEventOffset(),
+				Data:   recordedBytes(event.Envelope, event.Data, event.wire),
+			})
+		case *ResponseStart:
+			rec.Response = &RecordedResponse{
+				Offset:     event.getEventOffset(),
+				StatusCode: event.Response.StatusCode,
+				Headers:    event.Response.Header,
+			}
+		case *ResponseBodyData:
+			if rec.Response != nil {
+				rec.Response.Body = append(rec.Response.Body, RecordedData{
+					Offset: event.getEventOffset(),
+					Data:   recordedBytes(event.Envelope, event.Data, event.wire),
+				})
+			}
+		}
+	}
+	if rec.Response != nil && t.Response != nil {
+		rec.Response.Trailers = t.Response.Trailer
+	}
+	return rec
+}
+
+// recordedBytes returns the bytes on the wire for a chunk of body data.
+// If wire is non-nil, the body was encoded (gRPC-Web text), and wire
+// holds the encoded bytes. Otherwise, the bytes are the given data,
+// preceded by the given envelope prefix, if any.
+func recordedBytes(env *Envelope, data, wire []byte) []byte {
+	if wire != nil {
+		return wire
+	}
+	if env == nil {
+		return data
+	}
+	result := make([]byte, prefixLen+len(data))
+	result[0] = env.Flags
+	binary.BigEndian.PutUint32(result[1:], env.Len)
+	copy(result[prefixLen:], data)
+	return result
+}
+
+// Diff compares this recorded request to the given actual request and
+// returns a description of each difference found. The timing and
+// chunking of the body data is not compared, only its contents.
+func (r *RecordedRequest) Diff(actual *RecordedRequest) []string {
+	var diffs []string
+	if r.Method != actual.Method {
+		diffs = append(diffs, fmt.Sprintf("request method differs: recorded %s, got %s", r.Method, actual.Method))
+	}
+	if r.URI != actual.URI {
+		diffs = append(diffs, fmt.Sprintf("request URI differs: recorded %q, got %q", r.URI, actual.URI))
+	}
+	diffs = append(diffs, compareHeaders("request header", r.Headers, actual.Headers)...)
+	diffs = append(diffs, compareBodies("request", r.Body, actual.Body)...)
+	diffs = append(diffs, compareHeaders("request trailer", r.Trailers, actual.Trailers)...)
+	return diffs
+}
+
+// Diff compares this recorded response to the given actual response and
+// returns a description of each difference found. The timing and
+// chunking of the body data is not compared, only its contents.
+func (r *RecordedResponse) Diff(actual *RecordedResponse) []string {
+	switch {
+	case r == nil && actual == nil:
+		return nil
+	case r == nil:
+		return []string{"response was not recorded but one was received"}
+	case actual == nil:
+		return []string{"response was recorded but none was received"}
+	}
+	var diffs []string
+	if r.StatusCode != actual.StatusCode {
+		diffs = append(diffs, fmt.Sprintf("response status differs: recorded %d, got %d", r.StatusCode, actual.StatusCode))
+	}
+	diffs = append(diffs, compareHeaders("response header", r.Headers, actual.Headers)...)
+	diffs = append(diffs, compareBodies("response", r.Body, actual.Body)...)
+	diffs = append(diffs, compareHeaders("response trailer", r.Trailers, actual.Trailers)...)
+	return diffs
+}
+
+func compareHeaders(what string, recorded, actual http.Header) []string {
+	keys := make([]string, 0, len(recorded)+len(actual))
+	for key := range recorded {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := recorded[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var diffs []string
+	for _, key := range keys {
+		if _, ignore := uncorrelatedHeaders[key]; ignore {
+			continue
+		}
+		recordedVals, actualVals := recorded[key], actual[key]
+		switch {
+		case len(recordedVals) == 0 && len(actualVals) == 0:
+		case len(actualVals) == 0:
+			diffs = append(diffs, fmt.Sprintf("%s %q missing: recorded %q", what, key, recordedVals))
+		case len(recordedVals) == 0:
+			diffs = append(diffs, fmt.Sprintf("%s %q unexpected: got %q", what, key, actualVals))
+		case !slices.Equal(recordedVals, actualVals):
+			diffs = append(diffs, fmt.Sprintf("%s %q differs: recorded %q, got %q", what, key, recordedVals, actualVals))
+		}
+	}
+	return diffs
+}
+
+func compareBodies(what string, recorded, actual []RecordedData) []string {
+	recordedBody, actualBody := concatData(recorded), concatData(actual)
+	if bytes.Equal(recordedBody, actualBody) {
+		return nil
+	}
+	pos := 0
+	for pos < len(recordedBody) && pos < len(actualBody) && recordedBody[pos] == actualBody[pos] {
+		pos++
+	}
+	return []string{fmt.Sprintf("%s body differs: recorded %d bytes, got %d bytes; first difference at offset %d",
+		what, len(recordedBody), len(actualBody), pos)}
+}
+
+func concatData(chunks []RecordedData) []byte {
+	var buf bytes.Buffer
+	for _, chunk := range chunks {
+		buf.Write(chunk.Data)
+	}
+	return buf.Bytes()
+}
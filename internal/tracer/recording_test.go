@@ -0,0 +1,151 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceRecord(t *testing.T) {
+	t.Parallel()
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/com.foo.Service/Bar", RawQuery: "a=b"},
+		Proto:  "HTTP/2.0",
+	}
+	reqHeaders := headers("Content-Type", "application/grpc")
+	reqData := &RequestBodyData{Envelope: &Envelope{Len: 3}, Len: 3, Data: []byte("xyz")}
+	reqData.setEventOffset(time.Millisecond)
+	respStart := &ResponseStart{
+		Response: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     headers("Content-Type", "application/grpc"),
+			Trailer:    headers("Grpc-Status", "0"),
+		},
+	}
+	respStart.setEventOffset(3 * time.Millisecond)
+	respData := &ResponseBodyData{Envelope: &Envelope{Flags: 1, Len: 2}, Len: 2, Data: []byte("ab")}
+	respData.setEventOffset(4 * time.Millisecond)
+	trace := &Trace{
+		TestName: "foo/bar",
+		Request:  req,
+		Response: respStart.Response,
+		Events: []Event{
+			&RequestStart{Request: req, getHeaders: func() http.Header { return reqHeaders }},
+			reqData,
+			&RequestBodyEnd{},
+			respStart,
+			respData,
+			&ResponseBodyEnd{},
+		},
+	}
+
+	rec := trace.Record()
+	assert.Equal(t, &Recording{
+		TestName: "foo/bar",
+		Request: RecordedRequest{
+			Method:  http.MethodPost,
+			URI:     "/com.foo.Service/Bar?a=b",
+			Proto:   "HTTP/2.0",
+			Headers: reqHeaders,
+			Body: []RecordedData{
+				{Offset: time.Millisecond, Data: []byte{0, 0, 0, 0, 3, 'x', 'y', 'z'}},
+			},
+		},
+		Response: &RecordedResponse{
+			Offset:     3 * time.Millisecond,
+			StatusCode: http.StatusOK,
+			Headers:    headers("Content-Type", "application/grpc"),
+			Body: []RecordedData{
+				{Offset: 4 * time.Millisecond, Data: []byte{1, 0, 0, 0, 2, 'a', 'b'}},
+			},
+			Trailers: headers("Grpc-Status", "0"),
+		},
+	}, rec)
+
+	// Chunking and timing are not compared.
+	actualResp := &RecordedResponse{
+		StatusCode: http.StatusOK,
+		Headers:    headers("Content-Type", "application/grpc", "Date", "today"),
+		Body: []RecordedData{
+			{Data: []byte{1, 0, 0}},
+			{Data: []byte{0, 2, 'a', 'b'}},
+		},
+		Trailers: headers("Grpc-Status", "0"),
+	}
+	assert.Empty(t, rec.Response.Diff(actualResp))
+
+	actualResp.StatusCode = http.StatusBadGateway
+	actualResp.Headers.Set("X-Extra", "1")
+	actualResp.Body[1].Data = []byte{0, 2, 'a', 'c'}
+	actualResp.Trailers.Set("Grpc-Status", "14")
+	assert.Equal(t, []string{
+		`response status differs: recorded 200, got 502`,
+		`response header "X-Extra" unexpected: got ["1"]`,
+		`response body differs: recorded 7 bytes, got 7 bytes; first difference at offset 6`,
+		`response trailer "Grpc-Status" differs: recorded ["0"], got ["14"]`,
+	}, rec.Response.Diff(actualResp))
+	assert.Equal(t, []string{"response was recorded but none was received"}, rec.Response.Diff(nil))
+
+	actualReq := rec.Request
+	actualReq.URI = "/com.foo.Service/Bar"
+	actualReq.Headers = http.Header{}
+	assert.Equal(t, []string{
+		`request URI differs: recorded "/com.foo.Service/Bar?a=b", got "/com.foo.Service/Bar"`,
+		`request header "Content-Type" missing: recorded ["application/grpc"]`,
+	}, rec.Request.Diff(&actualReq))
+}
+
+func TestTraceRecord_GRPCWebText(t *testing.T) {
+	t.Parallel()
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/com.foo.Service/Bar"},
+		Proto:  "HTTP/1.1",
+		Header: headers(testCaseNameHeader, "foo/bar", "Content-Type", "application/grpc-web-text"),
+	}
+	builder, _ := newBuilder(req, false, &Tracer{})
+	tracer := dataTracer{
+		isRequest:        true,
+		isStreamProtocol: true,
+		base64:           base64DecoderFromHeaders(req.Header),
+		builder:          builder,
+	}
+	// Two messages, encoded as one continuous base64 stream, like the
+	// reference client sends them, and split at arbitrary points.
+	body := []byte{0, 0, 0, 0, 3, 'x', 'y', 'z', 0, 0, 0, 0, 1, 'a'}
+	encoded := base64.StdEncoding.EncodeToString(body)
+	for _, chunk := range []string{encoded[:3], encoded[3:11], encoded[11:]} {
+		tracer.trace([]byte(chunk))
+	}
+	tracer.emitUnfinished()
+
+	rec := builder.trace.Record()
+	require.Len(t, rec.Request.Body, 2)
+	assert.Equal(t, encoded, string(concatData(rec.Request.Body)))
+	assert.Empty(t, rec.Request.Diff(&RecordedRequest{
+		Method:  http.MethodPost,
+		URI:     "/com.foo.Service/Bar",
+		Headers: rec.Request.Headers,
+		Body:    []RecordedData{{Data: []byte(encoded)}},
+	}))
+}
@@ -0,0 +1,161 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Stats summarizes what was put on the wire for a single HTTP operation.
+type Stats struct {
+	Request  BodyStats `json:"request"`
+	Response BodyStats `json:"response"`
+	// The time from the start of the request until the response
+	// headers. For client-side traces, this is the time until the
+	// headers were received. For server-side traces, it is the time
+	// until they were sent. This is zero if there was no response.
+	TimeToFirstByte time.Duration `json:"timeToFirstByteNanos"`
+}
+
+// BodyStats summarizes one direction of an HTTP operation: either the
+// request or the response. For gRPC-Web text, sizes of body data are
+// computed after the body is decoded from base64.
+type BodyStats struct {
+	// The total size of header names and values. For responses,
+	// this does not include trailers.
+	HeaderBytes int `json:"headerBytes"`
+	// The total size of trailer names and values. This is always
+	// zero for requests. Note that trailers in the Connect streaming
+	// and gRPC-Web protocols are in the body, not in HTTP trailers.
+	TrailerBytes int `json:"trailerBytes,omitempty"`
+	// The total size of the body, including envelope prefixes.
+	BodyBytes uint64 `json:"bodyBytes"`
+	// The number of messages in the body. This does not include
+	// end-stream messages.
+	Messages int `json:"messages"`
+	// The number of messages that were compressed.
+	CompressedMessages int `json:"compressedMessages"`
+	// The total size of all messages, as sent on the wire. This
+	// excludes envelope prefixes and end-stream messages.
+	MessageBytes uint64 `json:"messageBytes"`
+	// The total size of all messages, after decompression. This is
+	// the same as MessageBytes if no messages were compressed.
+	UncompressedMessageBytes uint64 `json:"uncompressedMessageBytes"`
+	// The number of separate chunks of body data. For traces of
+	// HTTP/2 connections, this is the number of non-empty DATA
+	// frames. For other traces, it is the number of write or read
+	// operations, which usually, but not always, corresponds to
+	// the number of DATA frames (for HTTP/2) or chunks (for HTTP/1.1).
+	Chunks int `json:"chunks"`
+}
+
+// Stats computes statistics about what was put on the wire for this operation.
+func (t *Trace) Stats() Stats {
+	var stats Stats
+	var reqHeaders http.Header
+	if start := requestStart(t); start != nil {
+		reqHeaders = start.getHeaders()
+	}
+	stats.Request.HeaderBytes = headerBytes(reqHeaders)
+	stats.Request.Chunks = t.requestChunks
+	if t.Response != nil {
+		stats.Response.HeaderBytes = headerBytes(t.Response.Header)
+		stats.Response.TrailerBytes = headerBytes(t.Response.Trailer)
+	}
+	stats.Response.Chunks = t.responseChunks
+	reqEncoding := bodyEncoding(reqHeaders)
+	var respEncoding string
+	if t.Response != nil {
+		respEncoding = bodyEncoding(t.Response.Header)
+	}
+	for _, event := range t.Events {
+		switch event := event.(type) {
+		case *RequestBodyData:
+			stats.Request.addMessage(event.Envelope, event.Len, event.Data, reqEncoding)
+		case *ResponseStart:
+			stats.TimeToFirstByte = event.getEventOffset()
+		case *ResponseBodyData:
+			stats.Response.addMessage(event.Envelope, event.Len, event.Data, respEncoding)
+		}
+	}
+	return stats
+}
+
+func (s *BodyStats) addMessage(env *Envelope, length uint64, data []byte, encoding string) {
+	s.BodyBytes += length
+	var compressed bool
+	if env != nil {
+		s.BodyBytes += prefixLen
+		if env.Flags&0x82 != 0 {
+			// end-stream message
+			return
+		}
+		compressed = env.Flags&0x01 != 0
+	} else {
+		// not enveloped, so compressed if the whole body is encoded
+		compressed = encoding != ""
+	}
+	s.Messages++
+	s.MessageBytes += length
+	if !compressed {
+		s.UncompressedMessageBytes += length
+		return
+	}
+	s.CompressedMessages++
+	decomp := GetDecompressor(encoding)
+	var uncompressed bytes.Buffer
+	if err := decomp.Reset(bytes.NewReader(data)); err == nil {
+		if _, err := uncompressed.ReadFrom(decomp); err == nil && uncompressed.Len() > 0 {
+			s.UncompressedMessageBytes += uint64(uncompressed.Len())
+			return
+		}
+	}
+	// Could not decompress (possibly incomplete or unsupported
+	// encoding), so just use the compressed size.
+	s.UncompressedMessageBytes += length
+}
+
+// bodyEncoding returns the compression encoding that applies to messages
+// in a body with the given headers. It returns the empty string if messages
+// are not compressed.
+func bodyEncoding(headers http.Header) string {
+	var encoding string
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+	switch {
+	case strings.HasPrefix(contentType, "application/connect"):
+		encoding = headers.Get("Connect-Content-Encoding")
+	case strings.HasPrefix(contentType, "application/grpc"):
+		encoding = headers.Get("Grpc-Encoding")
+	default:
+		encoding = headers.Get("Content-Encoding")
+	}
+	if encoding == "identity" {
+		return ""
+	}
+	return encoding
+}
+
+func headerBytes(headers http.Header) int {
+	var total int
+	for name, vals := range headers {
+		for _, val := range vals {
+			total += len(name) + len(val)
+		}
+	}
+	return total
+}
@@ -0,0 +1,91 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceStats(t *testing.T) {
+	t.Parallel()
+	uncompressed := bytes.Repeat([]byte("abcdefgh"), 100)
+	comp := compression.NewSnappyCompressor()
+	var compressedBuffer bytes.Buffer
+	comp.Reset(&compressedBuffer)
+	_, err := comp.Write(uncompressed)
+	require.NoError(t, err)
+	require.NoError(t, comp.Close())
+	compressed := compressedBuffer.Bytes()
+
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/com.foo.Service/Bar"},
+	}
+	reqHeaders := headers("Content-Type", "application/connect+proto", "Connect-Content-Encoding", "snappy")
+	respStart := &ResponseStart{
+		Response: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     headers("Content-Type", "application/grpc", "Grpc-Encoding", "snappy"),
+			Trailer:    headers("Grpc-Status", "0"),
+		},
+	}
+	respStart.setEventOffset(3 * time.Millisecond)
+	trace := &Trace{
+		Request:  req,
+		Response: respStart.Response,
+		Events: []Event{
+			&RequestStart{Request: req, getHeaders: func() http.Header { return reqHeaders }},
+			&RequestBodyData{Envelope: &Envelope{Flags: 1, Len: uint32(len(compressed))}, Len: uint64(len(compressed)), Data: compressed},
+			&RequestBodyData{Envelope: &Envelope{Len: 3}, Len: 3, Data: []byte("xyz")},
+			&RequestBodyEnd{},
+			respStart,
+			&ResponseBodyData{Envelope: &Envelope{Len: 10}, Len: 10, Data: make([]byte, 10)},
+			&ResponseBodyData{Envelope: &Envelope{Flags: 2, Len: 2}, Len: 2, Data: []byte("{}")},
+			&ResponseBodyEnd{},
+		},
+		requestChunks:  4,
+		responseChunks: 1,
+	}
+
+	assert.Equal(t, Stats{
+		Request: BodyStats{
+			HeaderBytes:              len("Content-Type") + len("application/connect+proto") + len("Connect-Content-Encoding") + len("snappy"),
+			BodyBytes:                uint64(len(compressed)) + 3 + 2*prefixLen,
+			Messages:                 2,
+			CompressedMessages:       1,
+			MessageBytes:             uint64(len(compressed)) + 3,
+			UncompressedMessageBytes: uint64(len(uncompressed)) + 3,
+			Chunks:                   4,
+		},
+		Response: BodyStats{
+			HeaderBytes:              len("Content-Type") + len("application/grpc") + len("Grpc-Encoding") + len("snappy"),
+			TrailerBytes:             len("Grpc-Status") + len("0"),
+			BodyBytes:                10 + 2 + 2*prefixLen,
+			Messages:                 1,
+			MessageBytes:             10,
+			UncompressedMessageBytes: 10,
+			Chunks:                   1,
+		},
+		TimeToFirstByte: 3 * time.Millisecond,
+	}, trace.Stats())
+}
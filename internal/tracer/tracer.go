@@ -17,6 +17,7 @@ package tracer
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"sort"
 	"strings"
@@ -46,6 +47,11 @@ const (
 // the operation. (If Clear is never called, the Tracer will use more and more memory,
 // but limited by the amount to store all traces for every operation traced.)
 type Tracer struct {
+	// If true, the raw bytes that flow through connections returned by
+	// TracingHTTP2Conn and TracingHTTP2Listener are also recorded, so
+	// they can be exported via Trace.WritePacketCapture.
+	CapturePackets bool
+
 	mu     sync.Mutex
 	traces map[string]*traceResult
 }
@@ -125,6 +131,10 @@ func (t *Tracer) Await(ctx context.Context, testName string) (*Trace, error) {
 	}
 }
 
+func (t *Tracer) capturingPackets() bool {
+	return t != nil && t.CapturePackets
+}
+
 // Trace represents the sequence of activity for a single HTTP operation.
 type Trace struct {
 	TestName string
@@ -132,12 +142,45 @@ type Trace struct {
 	Response *http.Response
 	Err      error
 	Events   []Event
+	// The time the operation started. The offsets of all events
+	// are relative to this time.
+	Start time.Time
+
+	capture *streamCapture
+	// The number of chunks of body data written or read.
+	requestChunks, responseChunks int
 }
 
 func (t *Trace) Print(printer internal.Printer) {
 	for _, event := range t.Events {
 		event.print(printer)
 	}
+	t.printTrailers(printer)
+}
+
+// HasPacketCapture returns true if the raw bytes for this operation
+// were recorded. This is only the case for operations traced via
+// TracingHTTP2Conn or TracingHTTP2Listener with a *Tracer whose
+// CapturePackets field is true.
+func (t *Trace) HasPacketCapture() bool {
+	return t.capture != nil
+}
+
+// WritePacketCapture writes the raw bytes for this operation to the given
+// writer in pcapng format. The capture includes the HTTP/2 frames for this
+// operation as well as connection-level frames and the header blocks of
+// other operations on the same connection (without which the headers for
+// this operation could not be decoded). The frames are wrapped in
+// synthesized TCP/IP headers, so the result can be opened in a tool like
+// Wireshark. If TLS was used, the capture contains the clear-text bytes.
+func (t *Trace) WritePacketCapture(w io.Writer) error {
+	if t.capture == nil {
+		return errNoPacketCapture
+	}
+	return t.capture.writePcapng(w)
+}
+
+func (t *Trace) printTrailers(printer internal.Printer) {
 	if t.Response != nil && len(t.Response.Trailer) > 0 {
 		printer.Printf(responsePrefix)
 		printHeaders(responsePrefix, t.Response.ProtoMajor == 1, t.Response.Trailer, printer)
@@ -153,11 +196,15 @@ type Collector interface {
 	Complete(Trace)
 }
 
-var _ Collector = (*Tracer)(nil)
+var (
+	_ Collector      = (*Tracer)(nil)
+	_ packetCapturer = (*Tracer)(nil)
+)
 
 // Event is a single item in a sequence of activity for an HTTP operation.
 type Event interface {
 	setEventOffset(time.Duration)
+	getEventOffset() time.Duration
 	print(internal.Printer)
 }
 
@@ -214,16 +261,31 @@ type RequestBodyData struct {
 	// the full message could not be written/read.
 	Len uint64
 
+	// The data written/read, excluding any envelope
+	// prefix. For compressed messages, this is the
+	// compressed form. For an incomplete message,
+	// this is only the partial data.
+	Data []byte
+
 	// Sequentially numbered index. The first message
 	// in the stream should have an index of zero, and
 	// then one, etc.
 	MessageIndex int
 
+	// For gRPC-Web text, the base64-encoded bytes as they
+	// appeared on the wire, up to the end of this message.
+	// Since base64 quanta need not align with messages,
+	// this may include part of the next message.
+	wire []byte
+
+	decoder *messageDecoder
+
 	eventOffset
 }
 
 func (r *RequestBodyData) print(printer internal.Printer) {
 	printData(requestPrefix, r.offsetMillis(), r.MessageIndex, r.Envelope, r.Len, printer)
+	printDecoded(requestPrefix, r.MessageIndex, r.decoder.lines(r.Envelope, r.Len, r.Data), printer)
 }
 
 // RequestBodyEnd represents the end of the request body being reached.
@@ -296,16 +358,31 @@ type ResponseBodyData struct {
 	// the full message could not be written/read.
 	Len uint64
 
+	// The data written/read, excluding any envelope
+	// prefix. For compressed messages, this is the
+	// compressed form. For an incomplete message,
+	// this is only the partial data.
+	Data []byte
+
 	// Sequentially numbered index. The first message
 	// in the stream should have an index of zero, and
 	// then one, etc.
 	MessageIndex int
 
+	// For gRPC-Web text, the base64-encoded bytes as they
+	// appeared on the wire, up to the end of this message.
+	// Since base64 quanta need not align with messages,
+	// this may include part of the next message.
+	wire []byte
+
+	decoder *messageDecoder
+
 	eventOffset
 }
 
 func (r *ResponseBodyData) print(printer internal.Printer) {
 	printData(responsePrefix, r.offsetMillis(), r.MessageIndex, r.Envelope, r.Len, printer)
+	printDecoded(responsePrefix, r.MessageIndex, r.decoder.lines(r.Envelope, r.Len, r.Data), printer)
 }
 
 // ResponseBodyEndStream represents the an "end-stream" message in the
@@ -319,9 +396,7 @@ type ResponseBodyEndStream struct {
 }
 
 func (r *ResponseBodyEndStream) print(printer internal.Printer) {
-	lines := strings.Split(r.Content, "\n")
-	for _, line := range lines {
-		line = strings.Trim(line, "\r")
+	for _, line := range decodeEndStream(r.Content) {
 		printer.Printf("%s %11s   eos: %s", responsePrefix, "", line)
 	}
 }
@@ -371,6 +446,8 @@ func GetDecompressor(encoding string) connect.Decompressor {
 		comp = conformancev1.Compression_COMPRESSION_DEFLATE
 	case "snappy":
 		comp = conformancev1.Compression_COMPRESSION_SNAPPY
+	case "lz4":
+		comp = conformancev1.Compression_COMPRESSION_LZ4
 	default:
 		return brokenDecompressor{}
 	}
@@ -394,6 +471,10 @@ func (o *eventOffset) setEventOffset(offset time.Duration) {
 	o.Offset = offset
 }
 
+func (o *eventOffset) getEventOffset() time.Duration {
+	return o.Offset
+}
+
 func (o *eventOffset) offsetMillis() float64 {
 	return o.Offset.Seconds() * 1000
 }
@@ -442,3 +523,13 @@ func printData(prefix string, offsetMillis float64, index int, env *Envelope, le
 		printer.Printf("%s %9.3fms message #%d: data: %d bytes", prefix, offsetMillis, index+1, length)
 	}
 }
+
+func printDecoded(prefix string, index int, lines []string, printer internal.Printer) {
+	if len(lines) == 0 {
+		return
+	}
+	printer.Printf("%s %11s message #%d: decoded: %s", prefix, "", index+1, lines[0])
+	for _, line := range lines[1:] {
+		printer.Printf("%s %11s   %s", prefix, "", line)
+	}
+}
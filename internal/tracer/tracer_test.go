@@ -448,6 +448,9 @@ func TestTracer(t *testing.T) {
 					clientTrace, err := clientTracer.Await(ctx, t.Name())
 					require.NoError(t, err)
 					checkTrace(t, testCall.expectTrace, clientTrace)
+
+					// nothing in between client and server, so nothing should change in transit
+					require.Empty(t, correlate(clientTrace, serverTrace))
 				})
 			}
 		})
@@ -0,0 +1,138 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transcoding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const statusClientClosedRequest = 499
+
+// HTTPStatusFromCode returns the HTTP status code that corresponds to the given
+// error code, as described in google/rpc/code.proto.
+func HTTPStatusFromCode(code connect.Code) int {
+	switch code {
+	case connect.CodeCanceled:
+		return statusClientClosedRequest
+	case connect.CodeUnknown, connect.CodeInternal, connect.CodeDataLoss:
+		return http.StatusInternalServerError
+	case connect.CodeInvalidArgument, connect.CodeFailedPrecondition, connect.CodeOutOfRange:
+		return http.StatusBadRequest
+	case connect.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists, connect.CodeAborted:
+		return http.StatusConflict
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnimplemented:
+		return http.StatusNotImplemented
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CodeFromHTTPStatus returns the error code that corresponds to the given
+// HTTP status code. This is only used when an error response does not include
+// a google.rpc.Status message. Where multiple codes map to the same HTTP
+// status, the most general code is used.
+func CodeFromHTTPStatus(httpStatus int) connect.Code {
+	switch httpStatus {
+	case statusClientClosedRequest:
+		return connect.CodeCanceled
+	case http.StatusBadRequest:
+		return connect.CodeInvalidArgument
+	case http.StatusUnauthorized:
+		return connect.CodeUnauthenticated
+	case http.StatusForbidden:
+		return connect.CodePermissionDenied
+	case http.StatusNotFound:
+		return connect.CodeNotFound
+	case http.StatusConflict:
+		return connect.CodeAborted
+	case http.StatusTooManyRequests:
+		return connect.CodeResourceExhausted
+	case http.StatusNotImplemented:
+		return connect.CodeUnimplemented
+	case http.StatusServiceUnavailable:
+		return connect.CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return connect.CodeDeadlineExceeded
+	case http.StatusInternalServerError:
+		return connect.CodeInternal
+	default:
+		return connect.CodeUnknown
+	}
+}
+
+// MarshalError encodes the given error as a JSON google.rpc.Status message,
+// for use as the body of an error response.
+func MarshalError(connectErr *connect.Error) ([]byte, error) {
+	stat := &status.Status{
+		Code:    int32(connectErr.Code()),
+		Message: connectErr.Message(),
+	}
+	for _, detail := range connectErr.Details() {
+		stat.Details = append(stat.Details, &anypb.Any{
+			TypeUrl: "type.googleapis.com/" + detail.Type(),
+			Value:   detail.Bytes(),
+		})
+	}
+	data, err := protojson.Marshal(stat)
+	if err != nil && len(stat.Details) > 0 {
+		// Details may refer to unknown types, which can't be
+		// encoded to JSON. So we try again without them.
+		stat.Details = nil
+		data, err = protojson.Marshal(stat)
+	}
+	return data, err
+}
+
+// UnmarshalError decodes an error from the given HTTP status code and body of
+// an error response. The body should be a JSON google.rpc.Status message. If it
+// is not, the error code is derived from the HTTP status code.
+func UnmarshalError(httpStatus int, body []byte) *connect.Error {
+	var stat status.Status
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, &stat); err != nil || stat.GetCode() == 0 {
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			message = http.StatusText(httpStatus)
+		}
+		return connect.NewError(CodeFromHTTPStatus(httpStatus), errors.New(message))
+	}
+	connectErr := connect.NewWireError(connect.Code(stat.GetCode()), errors.New(stat.GetMessage()))
+	for _, detail := range stat.GetDetails() {
+		errDetail, err := connect.NewErrorDetail(detail)
+		if err != nil {
+			continue
+		}
+		connectErr.AddDetail(errDetail)
+	}
+	return connectErr
+}
@@ -0,0 +1,523 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transcoding implements the mapping between RPCs and REST-style
+// HTTP requests, as described by google.api.http annotations.
+package transcoding
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Rule describes how an RPC method is mapped to an HTTP request.
+type Rule struct {
+	// The HTTP method.
+	Method string
+	// The path template.
+	Template *Template
+	// The field that is bound from the request body. If "*",
+	// all fields not bound from the path are bound from the body.
+	// If empty, the request has no body.
+	Body string
+
+	method     protoreflect.MethodDescriptor
+	boundPaths map[string]struct{}
+}
+
+// RuleForMethod returns the rule for the given method, computed from its
+// google.api.http annotation. Additional bindings are ignored.
+func RuleForMethod(method protoreflect.MethodDescriptor) (*Rule, error) {
+	httpRule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if !ok || httpRule == nil {
+		return nil, fmt.Errorf("method %s has no google.api.http annotation", method.FullName())
+	}
+	var httpMethod, template string
+	switch pattern := httpRule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		httpMethod, template = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		httpMethod, template = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		httpMethod, template = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		httpMethod, template = http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		httpMethod, template = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Custom:
+		httpMethod, template = pattern.Custom.GetKind(), pattern.Custom.GetPath()
+	default:
+		return nil, fmt.Errorf("method %s has google.api.http annotation with no pattern", method.FullName())
+	}
+	if httpRule.GetResponseBody() != "" {
+		return nil, fmt.Errorf("method %s: response_body in google.api.http annotation is not supported", method.FullName())
+	}
+	parsed, err := ParseTemplate(template)
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", method.FullName(), err)
+	}
+	rule := &Rule{
+		Method:     httpMethod,
+		Template:   parsed,
+		Body:       httpRule.GetBody(),
+		method:     method,
+		boundPaths: map[string]struct{}{},
+	}
+	for _, fieldPath := range parsed.FieldPaths() {
+		if _, err := resolveFieldPath(method.Input(), fieldPath); err != nil {
+			return nil, fmt.Errorf("method %s: path template: %w", method.FullName(), err)
+		}
+		rule.boundPaths[strings.Join(fieldPath, ".")] = struct{}{}
+	}
+	if rule.Body != "" && rule.Body != "*" {
+		if method.Input().Fields().ByName(protoreflect.Name(rule.Body)) == nil {
+			return nil, fmt.Errorf("method %s: body field %q does not exist", method.FullName(), rule.Body)
+		}
+	}
+	return rule, nil
+}
+
+// RuleForProcedure returns the rule for the method with the given
+// fully-qualified service name and method name. The service must be
+// registered in the global registry.
+func RuleForProcedure(service, method string) (*Rule, error) {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("could not find service %s: %w", service, err)
+	}
+	svc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, not a service", service, desc)
+	}
+	methodDesc := svc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, fmt.Errorf("method name %s does not exist on service %s", method, service)
+	}
+	return RuleForMethod(methodDesc)
+}
+
+// MethodDescriptor returns the method to which this rule applies.
+func (r *Rule) MethodDescriptor() protoreflect.MethodDescriptor {
+	return r.method
+}
+
+// Match returns true if the given HTTP method and escaped URL path match this
+// rule. If so, it also returns the values of the variables in the path.
+func (r *Rule) Match(method, path string) (map[string]string, bool) {
+	if method != r.Method {
+		return nil, false
+	}
+	return r.Template.Match(path)
+}
+
+// EncodeRequest computes the URL path, query parameters, and body for the
+// given request message. If the rule indicates no body, or the field bound
+// from the body is not set, the returned body is nil.
+func (r *Rule) EncodeRequest(msg proto.Message) (path string, query url.Values, body []byte, err error) {
+	reflectMsg := msg.ProtoReflect()
+	path, err = r.Template.Expand(func(fieldPath []string) (string, error) {
+		return getPathValue(reflectMsg, fieldPath)
+	})
+	if err != nil {
+		return "", nil, nil, err
+	}
+	query = url.Values{}
+	switch r.Body {
+	case "*":
+		// Everything not bound from the path is in the body.
+		clone := proto.Clone(msg)
+		for boundPath := range r.boundPaths {
+			clearField(clone.ProtoReflect(), strings.Split(boundPath, "."))
+		}
+		body, err = protojson.Marshal(clone)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return path, query, body, nil
+	case "":
+		// No body, so nothing to do here.
+	default:
+		// If the body field is not set, no body is sent, so
+		// that the field is also unset when the server decodes it.
+		field := reflectMsg.Descriptor().Fields().ByName(protoreflect.Name(r.Body))
+		if reflectMsg.Has(field) {
+			body, err = marshalField(reflectMsg, field)
+			if err != nil {
+				return "", nil, nil, err
+			}
+		}
+	}
+	if err := r.encodeQuery(reflectMsg, nil, nil, query); err != nil {
+		return "", nil, nil, err
+	}
+	return path, query, body, nil
+}
+
+// encodeQuery adds query parameters for all populated fields in the given message
+// that are not bound from the path or the body. Query parameter names use the
+// fields' JSON names.
+func (r *Rule) encodeQuery(msg protoreflect.Message, prefix, jsonPrefix []string, query url.Values) error {
+	var err error
+	msg.Range(func(field protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		fieldPath := append(prefix[:len(prefix):len(prefix)], string(field.Name()))
+		jsonPath := append(jsonPrefix[:len(jsonPrefix):len(jsonPrefix)], field.JSONName())
+		dottedPath := strings.Join(fieldPath, ".")
+		if _, bound := r.boundPaths[dottedPath]; bound {
+			return true
+		}
+		if len(prefix) == 0 && string(field.Name()) == r.Body {
+			return true
+		}
+		paramName := strings.Join(jsonPath, ".")
+		switch {
+		case field.IsMap():
+			err = fmt.Errorf("map field %s cannot be bound from query parameters", dottedPath)
+		case field.IsList():
+			if field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+				err = fmt.Errorf("repeated message field %s cannot be bound from query parameters", dottedPath)
+				break
+			}
+			list := val.List()
+			for i := range list.Len() {
+				query.Add(paramName, formatScalar(field, list.Get(i)))
+			}
+		case field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind:
+			err = r.encodeQuery(val.Message(), fieldPath, jsonPath, query)
+		default:
+			query.Add(paramName, formatScalar(field, val))
+		}
+		return err == nil
+	})
+	return err
+}
+
+// DecodeRequest populates the given message from the given path variables
+// (as returned from Match), query parameters, and request body.
+func (r *Rule) DecodeRequest(pathVars map[string]string, query url.Values, body []byte, msg proto.Message) error {
+	reflectMsg := msg.ProtoReflect()
+	switch r.Body {
+	case "*":
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, msg); err != nil {
+				return fmt.Errorf("could not parse request body: %w", err)
+			}
+		}
+		if len(query) > 0 {
+			return errors.New("query parameters are not allowed since all fields are bound from the request body")
+		}
+	case "":
+		if len(body) > 0 {
+			return errors.New("request body is not allowed")
+		}
+	default:
+		if len(body) > 0 {
+			field := reflectMsg.Descriptor().Fields().ByName(protoreflect.Name(r.Body))
+			if err := unmarshalField(body, reflectMsg, field); err != nil {
+				return fmt.Errorf("could not parse request body: %w", err)
+			}
+		}
+	}
+	// Path variables take precedence, so they override any values in the body.
+	for dottedPath, value := range pathVars {
+		if err := setField(reflectMsg, strings.Split(dottedPath, "."), []string{value}); err != nil {
+			return fmt.Errorf("path variable %s: %w", dottedPath, err)
+		}
+	}
+	// Sort the parameters so any errors are deterministic.
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fieldPath, err := resolveQueryParam(reflectMsg.Descriptor(), name)
+		if err != nil {
+			return fmt.Errorf("query parameter %q: %w", name, err)
+		}
+		dottedPath := strings.Join(fieldPath, ".")
+		if _, bound := r.boundPaths[dottedPath]; bound {
+			return fmt.Errorf("query parameter %q: field %s is bound from the path", name, dottedPath)
+		}
+		if fieldPath[0] == r.Body {
+			return fmt.Errorf("query parameter %q: field %s is bound from the request body", name, dottedPath)
+		}
+		if err := setField(reflectMsg, fieldPath, query[name]); err != nil {
+			return fmt.Errorf("query parameter %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// resolveFieldPath resolves the given field path, returning the descriptor
+// of the last field in the path. All other fields in the path must be
+// non-repeated message fields.
+func resolveFieldPath(msg protoreflect.MessageDescriptor, fieldPath []string) (protoreflect.FieldDescriptor, error) {
+	var field protoreflect.FieldDescriptor
+	for i, name := range fieldPath {
+		if i > 0 {
+			if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+				return nil, fmt.Errorf("field %s is not a singular message field", strings.Join(fieldPath[:i], "."))
+			}
+			msg = field.Message()
+		}
+		field = msg.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("field %s does not exist", strings.Join(fieldPath[:i+1], "."))
+		}
+	}
+	return field, nil
+}
+
+// resolveQueryParam resolves the given query parameter name to a field path.
+// Each component of the name can be either the field name or its JSON name.
+func resolveQueryParam(msg protoreflect.MessageDescriptor, name string) ([]string, error) {
+	parts := strings.Split(name, ".")
+	fieldPath := make([]string, len(parts))
+	for i, part := range parts {
+		if msg == nil {
+			return nil, fmt.Errorf("field %s is not a singular message field", strings.Join(fieldPath[:i], "."))
+		}
+		field := msg.Fields().ByName(protoreflect.Name(part))
+		if field == nil {
+			field = msg.Fields().ByJSONName(part)
+		}
+		if field == nil {
+			return nil, fmt.Errorf("field %s does not exist", strings.Join(append(fieldPath[:i], part), "."))
+		}
+		fieldPath[i] = string(field.Name())
+		msg = nil
+		if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+			msg = field.Message()
+		}
+	}
+	return fieldPath, nil
+}
+
+func getPathValue(msg protoreflect.Message, fieldPath []string) (string, error) {
+	for i, name := range fieldPath {
+		field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return "", fmt.Errorf("field %s does not exist", strings.Join(fieldPath[:i+1], "."))
+		}
+		if i < len(fieldPath)-1 {
+			msg = msg.Get(field).Message()
+			continue
+		}
+		if field.IsList() || field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+			return "", fmt.Errorf("field %s cannot be bound from the path", strings.Join(fieldPath, "."))
+		}
+		return formatScalar(field, msg.Get(field)), nil
+	}
+	return "", errors.New("empty field path")
+}
+
+func clearField(msg protoreflect.Message, fieldPath []string) {
+	for i, name := range fieldPath {
+		field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil || !msg.Has(field) {
+			return
+		}
+		if i == len(fieldPath)-1 {
+			msg.Clear(field)
+			return
+		}
+		msg = msg.Mutable(field).Message()
+	}
+}
+
+func setField(msg protoreflect.Message, fieldPath []string, values []string) error {
+	for i, name := range fieldPath {
+		field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return fmt.Errorf("field %s does not exist", strings.Join(fieldPath[:i+1], "."))
+		}
+		if i < len(fieldPath)-1 {
+			if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+				return fmt.Errorf("field %s is not a singular message field", strings.Join(fieldPath[:i+1], "."))
+			}
+			msg = msg.Mutable(field).Message()
+			continue
+		}
+		switch {
+		case field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind:
+			return fmt.Errorf("field %s must be a scalar or repeated scalar field", strings.Join(fieldPath, "."))
+		case field.IsList():
+			list := msg.Mutable(field).List()
+			for _, value := range values {
+				val, err := parseScalar(field, value)
+				if err != nil {
+					return err
+				}
+				list.Append(val)
+			}
+		default:
+			if len(values) != 1 {
+				return fmt.Errorf("field %s is not repeated but %d values were provided", strings.Join(fieldPath, "."), len(values))
+			}
+			val, err := parseScalar(field, values[0])
+			if err != nil {
+				return err
+			}
+			msg.Set(field, val)
+		}
+	}
+	return nil
+}
+
+func formatScalar(field protoreflect.FieldDescriptor, val protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.BytesKind:
+		return base64.URLEncoding.EncodeToString(val.Bytes())
+	case protoreflect.EnumKind:
+		if enumVal := field.Enum().Values().ByNumber(val.Enum()); enumVal != nil {
+			return string(enumVal.Name())
+		}
+		return strconv.Itoa(int(val.Enum()))
+	default:
+		// For all other kinds, this is the same as the string form of the value.
+		return val.String()
+	}
+}
+
+func parseScalar(field protoreflect.FieldDescriptor, str string) (protoreflect.Value, error) {
+	var val protoreflect.Value
+	var err error
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		val = protoreflect.ValueOfString(str)
+	case protoreflect.BytesKind:
+		var data []byte
+		data, err = decodeBase64(str)
+		val = protoreflect.ValueOfBytes(data)
+	case protoreflect.BoolKind:
+		var b bool
+		b, err = strconv.ParseBool(str)
+		val = protoreflect.ValueOfBool(b)
+	case protoreflect.EnumKind:
+		if enumVal := field.Enum().Values().ByName(protoreflect.Name(str)); enumVal != nil {
+			val = protoreflect.ValueOfEnum(enumVal.Number())
+			break
+		}
+		var num int64
+		num, err = strconv.ParseInt(str, 10, 32)
+		val = protoreflect.ValueOfEnum(protoreflect.EnumNumber(num))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var num int64
+		num, err = strconv.ParseInt(str, 10, 32)
+		val = protoreflect.ValueOfInt32(int32(num))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var num int64
+		num, err = strconv.ParseInt(str, 10, 64)
+		val = protoreflect.ValueOfInt64(num)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		var num uint64
+		num, err = strconv.ParseUint(str, 10, 32)
+		val = protoreflect.ValueOfUint32(uint32(num))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		var num uint64
+		num, err = strconv.ParseUint(str, 10, 64)
+		val = protoreflect.ValueOfUint64(num)
+	case protoreflect.FloatKind:
+		var num float64
+		num, err = strconv.ParseFloat(str, 32)
+		val = protoreflect.ValueOfFloat32(float32(num))
+	case protoreflect.DoubleKind:
+		var num float64
+		num, err = strconv.ParseFloat(str, 64)
+		val = protoreflect.ValueOfFloat64(num)
+	default:
+		err = fmt.Errorf("field %s has unsupported kind %v", field.FullName(), field.Kind())
+	}
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("invalid value %q for field %s: %w", str, field.Name(), err)
+	}
+	return val, nil
+}
+
+// decodeBase64 decodes the given string, which may use either the standard
+// or URL-safe alphabet, with or without padding.
+func decodeBase64(str string) ([]byte, error) {
+	str = strings.TrimRight(str, "=")
+	if strings.ContainsAny(str, "+/") {
+		return base64.RawStdEncoding.DecodeString(str)
+	}
+	return base64.RawURLEncoding.DecodeString(str)
+}
+
+// marshalField returns the JSON encoding of the given field's value.
+func marshalField(msg protoreflect.Message, field protoreflect.FieldDescriptor) ([]byte, error) {
+	if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+		return protojson.Marshal(msg.Get(field).Message().Interface())
+	}
+	// For other kinds of fields, marshal a message with just this
+	// field and then extract the field's value.
+	wrapper := msg.New()
+	if msg.Has(field) {
+		wrapper.Set(field, msg.Get(field))
+	}
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(wrapper.Interface())
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields[field.JSONName()], nil
+}
+
+// unmarshalField parses the given JSON data into the given field.
+func unmarshalField(data []byte, msg protoreflect.Message, field protoreflect.FieldDescriptor) error {
+	if field.Kind() == protoreflect.MessageKind && !field.IsList() && !field.IsMap() {
+		fieldVal := msg.NewField(field)
+		if err := protojson.Unmarshal(data, fieldVal.Message().Interface()); err != nil {
+			return err
+		}
+		msg.Set(field, fieldVal)
+		return nil
+	}
+	// For other kinds of fields, parse a message with just this
+	// field and then copy the field's value.
+	name, err := json.Marshal(field.JSONName())
+	if err != nil {
+		return err
+	}
+	wrapperData := make([]byte, 0, len(data)+len(name)+3)
+	wrapperData = append(wrapperData, '{')
+	wrapperData = append(wrapperData, name...)
+	wrapperData = append(wrapperData, ':')
+	wrapperData = append(wrapperData, data...)
+	wrapperData = append(wrapperData, '}')
+	wrapper := msg.New()
+	if err := protojson.Unmarshal(wrapperData, wrapper.Interface()); err != nil {
+		return err
+	}
+	if wrapper.Has(field) {
+		msg.Set(field, wrapper.Get(field))
+	}
+	return nil
+}
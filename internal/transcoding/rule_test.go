@@ -0,0 +1,274 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package transcoding
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func TestRule_RoundTrip(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		method         string
+		msg            proto.Message
+		expectedMethod string
+		expectedPath   string
+		expectedQuery  url.Values
+		expectBody     bool
+	}{
+		{
+			name:   "get",
+			method: "GetResource",
+			msg: &conformancev1.GetResourceRequest{
+				Name:           "shelves/s 1/resources/r1",
+				Fields:         []string{"title", "a&b"},
+				IncludeDeleted: true,
+				ResponseDefinition: &conformancev1.UnaryResponseDefinition{
+					Response: &conformancev1.UnaryResponseDefinition_Error{
+						Error: &conformancev1.Error{
+							Code:    conformancev1.Code_CODE_NOT_FOUND,
+							Message: proto.String("not found"),
+						},
+					},
+				},
+			},
+			expectedMethod: http.MethodGet,
+			expectedPath:   "/v1/shelves/s%201/resources/r1",
+			expectedQuery: url.Values{
+				"fields":                           {"title", "a&b"},
+				"includeDeleted":                   {"true"},
+				"responseDefinition.error.code":    {"CODE_NOT_FOUND"},
+				"responseDefinition.error.message": {"not found"},
+			},
+		},
+		{
+			name:   "body field",
+			method: "CreateResource",
+			msg: &conformancev1.CreateResourceRequest{
+				Parent:     "shelves/s1",
+				ResourceId: "r1",
+				Resource: &conformancev1.Resource{
+					Title:    "title",
+					Revision: 123,
+				},
+				ResponseDefinition: &conformancev1.UnaryResponseDefinition{
+					Response: &conformancev1.UnaryResponseDefinition_ResponseData{
+						ResponseData: []byte{0xff, 0xfe},
+					},
+				},
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v1/shelves/s1/resources",
+			expectedQuery: url.Values{
+				"resourceId":                      {"r1"},
+				"responseDefinition.responseData": {"__4="},
+			},
+			expectBody: true,
+		},
+		{
+			name:   "unset body field",
+			method: "CreateResource",
+			msg: &conformancev1.CreateResourceRequest{
+				Parent: "shelves/s1",
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v1/shelves/s1/resources",
+			expectedQuery:  url.Values{},
+		},
+		{
+			name:   "wildcard body",
+			method: "UpdateResource",
+			msg: &conformancev1.UpdateResourceRequest{
+				Resource: &conformancev1.Resource{
+					Name:   "shelves/s1/resources/r1",
+					Labels: []string{"a", "b"},
+				},
+				AllowMissing: true,
+			},
+			expectedMethod: http.MethodPatch,
+			expectedPath:   "/v1/shelves/s1/resources/r1",
+			expectedQuery:  url.Values{},
+			expectBody:     true,
+		},
+		{
+			name:   "double wildcard",
+			method: "DeleteResource",
+			msg: &conformancev1.DeleteResourceRequest{
+				Name: "shelves/s1/resources/a/b",
+				Etag: "xyz",
+			},
+			expectedMethod: http.MethodDelete,
+			expectedPath:   "/v1/shelves/s1/resources/a/b",
+			expectedQuery:  url.Values{"etag": {"xyz"}},
+		},
+		{
+			name:   "verb",
+			method: "SearchResources",
+			msg: &conformancev1.SearchResourcesRequest{
+				Parent:   "shelves/s1",
+				Query:    "foo",
+				PageSize: 10,
+			},
+			expectedMethod: http.MethodPost,
+			expectedPath:   "/v1/shelves/s1/resources:search",
+			expectedQuery:  url.Values{},
+			expectBody:     true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			rule, err := RuleForProcedure("connectrpc.conformance.v1.TranscodingService", testCase.method)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedMethod, rule.Method)
+			path, query, body, err := rule.EncodeRequest(testCase.msg)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPath, path)
+			assert.Equal(t, testCase.expectedQuery, query)
+			assert.Equal(t, testCase.expectBody, body != nil)
+
+			pathVars, ok := rule.Match(rule.Method, path)
+			require.True(t, ok)
+			decoded := testCase.msg.ProtoReflect().New().Interface()
+			require.NoError(t, rule.DecodeRequest(pathVars, query, body, decoded))
+			assert.Empty(t, cmp.Diff(testCase.msg, decoded, protocmp.Transform()))
+		})
+	}
+}
+
+func TestRule_DecodeRequestErrors(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		method      string
+		path        string
+		query       url.Values
+		body        string
+		expectedErr string
+	}{
+		{
+			name:        "body not allowed",
+			method:      "GetResource",
+			path:        "/v1/shelves/s1/resources/r1",
+			body:        "{}",
+			expectedErr: "request body is not allowed",
+		},
+		{
+			name:        "query not allowed",
+			method:      "SearchResources",
+			path:        "/v1/shelves/s1/resources:search",
+			query:       url.Values{"query": {"foo"}},
+			expectedErr: "query parameters are not allowed",
+		},
+		{
+			name:        "query param bound from path",
+			method:      "GetResource",
+			path:        "/v1/shelves/s1/resources/r1",
+			query:       url.Values{"name": {"foo"}},
+			expectedErr: "is bound from the path",
+		},
+		{
+			name:        "query param bound from body",
+			method:      "CreateResource",
+			path:        "/v1/shelves/s1/resources",
+			query:       url.Values{"resource.title": {"foo"}},
+			expectedErr: "is bound from the request body",
+		},
+		{
+			name:        "unknown query param",
+			method:      "GetResource",
+			path:        "/v1/shelves/s1/resources/r1",
+			query:       url.Values{"foo": {"bar"}},
+			expectedErr: "field foo does not exist",
+		},
+		{
+			name:        "invalid query param value",
+			method:      "GetResource",
+			path:        "/v1/shelves/s1/resources/r1",
+			query:       url.Values{"include_deleted": {"maybe"}},
+			expectedErr: "query parameter \"include_deleted\"",
+		},
+		{
+			name:        "invalid body",
+			method:      "UpdateResource",
+			path:        "/v1/shelves/s1/resources/r1",
+			body:        "{",
+			expectedErr: "could not parse request body",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			rule, err := RuleForProcedure("connectrpc.conformance.v1.TranscodingService", testCase.method)
+			require.NoError(t, err)
+			pathVars, ok := rule.Match(rule.Method, testCase.path)
+			require.True(t, ok)
+			decoded := dynamicpb.NewMessage(rule.MethodDescriptor().Input())
+			err = rule.DecodeRequest(pathVars, testCase.query, []byte(testCase.body), decoded)
+			require.ErrorContains(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestRuleForProcedure_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := RuleForProcedure("connectrpc.conformance.v1.TranscodingService", "Foo")
+	require.ErrorContains(t, err, "does not exist")
+	// Methods without google.api.http annotations have no rule.
+	_, err = RuleForProcedure("connectrpc.conformance.v1.ConformanceService", "Unary")
+	require.Error(t, err)
+}
+
+func TestErrors_RoundTrip(t *testing.T) {
+	t.Parallel()
+	for code := connect.CodeCanceled; code <= connect.CodeUnauthenticated; code++ {
+		connectErr := connect.NewError(code, errors.New("oops"))
+		detail, err := connect.NewErrorDetail(&conformancev1.Header{Name: "foo", Value: []string{"bar"}})
+		require.NoError(t, err)
+		connectErr.AddDetail(detail)
+		data, err := MarshalError(connectErr)
+		require.NoError(t, err)
+		httpStatus := HTTPStatusFromCode(code)
+		decoded := UnmarshalError(httpStatus, data)
+		assert.Equal(t, code, decoded.Code())
+		assert.Equal(t, "oops", decoded.Message())
+		require.Len(t, decoded.Details(), 1)
+		value, err := decoded.Details()[0].Value()
+		require.NoError(t, err)
+		assert.Empty(t, cmp.Diff(&conformancev1.Header{Name: "foo", Value: []string{"bar"}}, value, protocmp.Transform()))
+	}
+}
+
+func TestUnmarshalError_NotStatus(t *testing.T) {
+	t.Parallel()
+	connectErr := UnmarshalError(http.StatusNotFound, []byte("page not found\n"))
+	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
+	assert.Equal(t, "page not found", connectErr.Message())
+	connectErr = UnmarshalError(http.StatusBadGateway, nil)
+	assert.Equal(t, connect.CodeUnknown, connectErr.Code())
+	assert.Equal(t, "Bad Gateway", connectErr.Message())
+}
@@ -0,0 +1,352 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transcoding
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	segmentLiteral = segmentKind(iota)
+	segmentWildcard
+	segmentMultiWildcard
+)
+
+type segment struct {
+	kind    segmentKind
+	literal string
+}
+
+type variable struct {
+	// The path to the field, as a sequence of field names.
+	fieldPath []string
+	// The range of segments, [start, end), that this variable captures.
+	start, end int
+}
+
+func (v *variable) isMultiSegment(segments []segment) bool {
+	return v.end-v.start > 1 || segments[v.start].kind == segmentMultiWildcard
+}
+
+// Template is a parsed path template, from a google.api.http rule. The
+// syntax of path templates is described in google/api/http.proto.
+type Template struct {
+	segments  []segment
+	variables []variable
+	verb      string
+}
+
+// ParseTemplate parses the given path template.
+func ParseTemplate(template string) (*Template, error) {
+	parser := &templateParser{str: template}
+	result, err := parser.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid path template %q: %w", template, err)
+	}
+	return result, nil
+}
+
+// Match matches the given URL path against the template. If the path matches,
+// the values of all variables are returned, keyed by the dotted field path.
+// The given path should be escaped (i.e. the value of url.URL.EscapedPath).
+func (t *Template) Match(path string) (map[string]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	path = path[1:]
+	if t.verb != "" {
+		var ok bool
+		path, ok = strings.CutSuffix(path, ":"+t.verb)
+		if !ok {
+			return nil, false
+		}
+	}
+	parts := strings.Split(path, "/")
+	// The index into parts that corresponds to each segment, so we
+	// can compute the value of variables. There is an extra element
+	// at the end to simplify computing the end of the last segment.
+	positions := make([]int, len(t.segments)+1)
+	pos := 0
+	for i, seg := range t.segments {
+		positions[i] = pos
+		switch seg.kind {
+		case segmentLiteral:
+			if pos >= len(parts) || parts[pos] != seg.literal {
+				return nil, false
+			}
+			pos++
+		case segmentWildcard:
+			if pos >= len(parts) || parts[pos] == "" {
+				return nil, false
+			}
+			pos++
+		case segmentMultiWildcard:
+			// This must be the last segment, so it consumes the rest.
+			pos = len(parts)
+		}
+	}
+	positions[len(t.segments)] = pos
+	if pos != len(parts) {
+		return nil, false
+	}
+	values := make(map[string]string, len(t.variables))
+	for _, v := range t.variables {
+		value := strings.Join(parts[positions[v.start]:positions[v.end]], "/")
+		var err error
+		if v.isMultiSegment(t.segments) {
+			value, err = unescapeMultiSegment(value)
+		} else {
+			value, err = url.PathUnescape(value)
+		}
+		if err != nil {
+			return nil, false
+		}
+		values[strings.Join(v.fieldPath, ".")] = value
+	}
+	return values, true
+}
+
+// Expand computes a URL path from the template, using the given function to
+// compute the value of each variable. The returned path is escaped. An error
+// is returned if a value does not match the corresponding variable's pattern.
+func (t *Template) Expand(valueOf func(fieldPath []string) (string, error)) (string, error) {
+	var buf strings.Builder
+	nextVar := 0
+	for i := 0; i < len(t.segments); i++ {
+		buf.WriteByte('/')
+		if nextVar < len(t.variables) && t.variables[nextVar].start == i {
+			v := &t.variables[nextVar]
+			nextVar++
+			value, err := valueOf(v.fieldPath)
+			if err != nil {
+				return "", err
+			}
+			if !v.matches(value, t.segments) {
+				return "", fmt.Errorf("value %q for field %s does not match path template", value, strings.Join(v.fieldPath, "."))
+			}
+			if v.isMultiSegment(t.segments) {
+				parts := strings.Split(value, "/")
+				for j, part := range parts {
+					parts[j] = url.PathEscape(part)
+				}
+				buf.WriteString(strings.Join(parts, "/"))
+			} else {
+				buf.WriteString(url.PathEscape(value))
+			}
+			i = v.end - 1
+			continue
+		}
+		buf.WriteString(t.segments[i].literal)
+	}
+	if t.verb != "" {
+		buf.WriteByte(':')
+		buf.WriteString(t.verb)
+	}
+	return buf.String(), nil
+}
+
+// FieldPaths returns the paths of all fields bound by variables in the template.
+func (t *Template) FieldPaths() [][]string {
+	paths := make([][]string, len(t.variables))
+	for i, v := range t.variables {
+		paths[i] = v.fieldPath
+	}
+	return paths
+}
+
+// matches returns true if the given unescaped value matches the variable's
+// pattern. The value of a single-segment variable may contain any characters,
+// including slashes, since they are all escaped.
+func (v *variable) matches(value string, segments []segment) bool {
+	if !v.isMultiSegment(segments) {
+		if segments[v.start].kind == segmentLiteral {
+			return value == segments[v.start].literal
+		}
+		return value != ""
+	}
+	return matchesSegments(value, segments[v.start:v.end])
+}
+
+// matchesSegments returns true if the given unescaped variable value matches
+// the given segments of a variable's pattern.
+func matchesSegments(value string, segments []segment) bool {
+	parts := strings.Split(value, "/")
+	for i, seg := range segments {
+		switch seg.kind {
+		case segmentLiteral:
+			if i >= len(parts) || parts[i] != seg.literal {
+				return false
+			}
+		case segmentWildcard:
+			if i >= len(parts) || parts[i] == "" {
+				return false
+			}
+		case segmentMultiWildcard:
+			return true
+		}
+	}
+	return len(parts) == len(segments)
+}
+
+// unescapeMultiSegment unescapes the value of a variable that spans multiple
+// path segments. Per the rules for such variables, escaped slashes are left
+// escaped, so they can be distinguished from the path separators.
+func unescapeMultiSegment(value string) (string, error) {
+	parts := strings.Split(value, "%2F")
+	for i, part := range parts {
+		lowerParts := strings.Split(part, "%2f")
+		for j, lowerPart := range lowerParts {
+			unescaped, err := url.PathUnescape(lowerPart)
+			if err != nil {
+				return "", err
+			}
+			lowerParts[j] = unescaped
+		}
+		parts[i] = strings.Join(lowerParts, "%2f")
+	}
+	return strings.Join(parts, "%2F"), nil
+}
+
+type templateParser struct {
+	str string
+	pos int
+
+	result Template
+}
+
+func (p *templateParser) parse() (*Template, error) {
+	if !p.consume('/') {
+		return nil, errors.New("must start with '/'")
+	}
+	if err := p.parseSegments(false); err != nil {
+		return nil, err
+	}
+	if p.consume(':') {
+		p.result.verb = p.parseLiteral()
+		if p.result.verb == "" {
+			return nil, errors.New("verb must not be empty")
+		}
+	}
+	if p.pos < len(p.str) {
+		return nil, fmt.Errorf("unexpected character %q at offset %d", p.str[p.pos], p.pos)
+	}
+	for i, seg := range p.result.segments {
+		if seg.kind == segmentMultiWildcard && i != len(p.result.segments)-1 {
+			return nil, errors.New("'**' may only be used as the last segment")
+		}
+	}
+	return &p.result, nil
+}
+
+func (p *templateParser) parseSegments(inVariable bool) error {
+	for {
+		if err := p.parseSegment(inVariable); err != nil {
+			return err
+		}
+		if !p.consume('/') {
+			return nil
+		}
+	}
+}
+
+func (p *templateParser) parseSegment(inVariable bool) error {
+	switch {
+	case strings.HasPrefix(p.str[p.pos:], "**"):
+		p.pos += 2
+		p.result.segments = append(p.result.segments, segment{kind: segmentMultiWildcard})
+	case p.consume('*'):
+		p.result.segments = append(p.result.segments, segment{kind: segmentWildcard})
+	case p.consume('{'):
+		if inVariable {
+			return errors.New("variables may not be nested")
+		}
+		return p.parseVariable()
+	default:
+		literal := p.parseLiteral()
+		if literal == "" {
+			return fmt.Errorf("expecting a segment at offset %d", p.pos)
+		}
+		p.result.segments = append(p.result.segments, segment{kind: segmentLiteral, literal: literal})
+	}
+	return nil
+}
+
+func (p *templateParser) parseVariable() error {
+	var fieldPath []string
+	for {
+		name := p.parseIdent()
+		if name == "" {
+			return fmt.Errorf("expecting a field name at offset %d", p.pos)
+		}
+		fieldPath = append(fieldPath, name)
+		if !p.consume('.') {
+			break
+		}
+	}
+	start := len(p.result.segments)
+	if p.consume('=') {
+		if err := p.parseSegments(true); err != nil {
+			return err
+		}
+	} else {
+		p.result.segments = append(p.result.segments, segment{kind: segmentWildcard})
+	}
+	if !p.consume('}') {
+		return fmt.Errorf("expecting '}' at offset %d", p.pos)
+	}
+	p.result.variables = append(p.result.variables, variable{
+		fieldPath: fieldPath,
+		start:     start,
+		end:       len(p.result.segments),
+	})
+	return nil
+}
+
+func (p *templateParser) parseLiteral() string {
+	start := p.pos
+	for p.pos < len(p.str) {
+		switch p.str[p.pos] {
+		case '/', ':', '{', '}', '*', '=':
+			return p.str[start:p.pos]
+		}
+		p.pos++
+	}
+	return p.str[start:p.pos]
+}
+
+func (p *templateParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.str) {
+		ch := p.str[p.pos]
+		if ch != '_' && (ch < 'a' || ch > 'z') && (ch < 'A' || ch > 'Z') && (p.pos == start || ch < '0' || ch > '9') {
+			break
+		}
+		p.pos++
+	}
+	return p.str[start:p.pos]
+}
+
+func (p *templateParser) consume(ch byte) bool {
+	if p.pos < len(p.str) && p.str[p.pos] == ch {
+		p.pos++
+		return true
+	}
+	return false
+}
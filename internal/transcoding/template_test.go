@@ -0,0 +1,209 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package transcoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		template    string
+		expectedErr string
+	}{
+		{template: "/v1/foo"},
+		{template: "/v1/{name}"},
+		{template: "/v1/{name=shelves/*/resources/*}:verb"},
+		{template: "/v1/{resource.name=shelves/*}/resources"},
+		{template: "/v1/{name=shelves/**}"},
+		{template: "v1/foo", expectedErr: "must start with '/'"},
+		{template: "/v1/**/foo", expectedErr: "'**' may only be used as the last segment"},
+		{template: "/v1/{name={id}}", expectedErr: "variables may not be nested"},
+		{template: "/v1/{name", expectedErr: "expecting '}'"},
+		{template: "/v1/{}", expectedErr: "expecting a field name"},
+		{template: "/v1//foo", expectedErr: "expecting a segment"},
+		{template: "/v1/foo:", expectedErr: "verb must not be empty"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.template, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseTemplate(testCase.template)
+			if testCase.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func TestTemplate_Match(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		template string
+		path     string
+		expected map[string]string
+	}{
+		{
+			name:     "literal",
+			template: "/v1/foo",
+			path:     "/v1/foo",
+			expected: map[string]string{},
+		},
+		{
+			name:     "literal mismatch",
+			template: "/v1/foo",
+			path:     "/v1/bar",
+		},
+		{
+			name:     "single segment variable",
+			template: "/v1/{name}",
+			path:     "/v1/abc%20def",
+			expected: map[string]string{"name": "abc def"},
+		},
+		{
+			name:     "single segment variable with escaped slash",
+			template: "/v1/{name}",
+			path:     "/v1/abc%2Fdef",
+			expected: map[string]string{"name": "abc/def"},
+		},
+		{
+			name:     "empty segment",
+			template: "/v1/{name}",
+			path:     "/v1/",
+		},
+		{
+			name:     "too many segments",
+			template: "/v1/{name}",
+			path:     "/v1/abc/def",
+		},
+		{
+			name:     "multi-segment variable",
+			template: "/v1/{name=shelves/*/resources/*}",
+			path:     "/v1/shelves/s1/resources/r1",
+			expected: map[string]string{"name": "shelves/s1/resources/r1"},
+		},
+		{
+			name:     "multi-segment variable keeps escaped slash",
+			template: "/v1/{name=shelves/*/resources/*}",
+			path:     "/v1/shelves/s%2F1/resources/r%201",
+			expected: map[string]string{"name": "shelves/s%2F1/resources/r 1"},
+		},
+		{
+			name:     "nested field",
+			template: "/v1/{resource.name=shelves/*}/resources",
+			path:     "/v1/shelves/s1/resources",
+			expected: map[string]string{"resource.name": "shelves/s1"},
+		},
+		{
+			name:     "double wildcard",
+			template: "/v1/{name=shelves/**}",
+			path:     "/v1/shelves/a/b/c",
+			expected: map[string]string{"name": "shelves/a/b/c"},
+		},
+		{
+			name:     "verb",
+			template: "/v1/{parent=shelves/*}/resources:search",
+			path:     "/v1/shelves/s1/resources:search",
+			expected: map[string]string{"parent": "shelves/s1"},
+		},
+		{
+			name:     "missing verb",
+			template: "/v1/{parent=shelves/*}/resources:search",
+			path:     "/v1/shelves/s1/resources",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			template, err := ParseTemplate(testCase.template)
+			require.NoError(t, err)
+			values, ok := template.Match(testCase.path)
+			if testCase.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, testCase.expected, values)
+		})
+	}
+}
+
+func TestTemplate_Expand(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		template    string
+		values      map[string]string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "single segment variable",
+			template: "/v1/{name}:get",
+			values:   map[string]string{"name": "a b/c"},
+			expected: "/v1/a%20b%2Fc:get",
+		},
+		{
+			name:     "multi-segment variable",
+			template: "/v1/{resource.name=shelves/*/resources/*}",
+			values:   map[string]string{"resource.name": "shelves/s 1/resources/r1"},
+			expected: "/v1/shelves/s%201/resources/r1",
+		},
+		{
+			name:     "double wildcard",
+			template: "/v1/{name=shelves/**}",
+			values:   map[string]string{"name": "shelves/a/b"},
+			expected: "/v1/shelves/a/b",
+		},
+		{
+			name:        "value does not match",
+			template:    "/v1/{name=shelves/*/resources/*}",
+			values:      map[string]string{"name": "shelves/s1"},
+			expectedErr: "does not match path template",
+		},
+		{
+			name:        "empty value",
+			template:    "/v1/{name}",
+			values:      map[string]string{"name": ""},
+			expectedErr: "does not match path template",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			template, err := ParseTemplate(testCase.template)
+			require.NoError(t, err)
+			path, err := template.Expand(func(fieldPath []string) (string, error) {
+				return testCase.values[strings.Join(fieldPath, ".")], nil
+			})
+			if testCase.expectedErr != "" {
+				require.ErrorContains(t, err, testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, path)
+			// The expanded path should match the template and produce the same values.
+			values, ok := template.Match(path)
+			require.True(t, ok)
+			assert.Equal(t, testCase.values, values)
+		})
+	}
+}
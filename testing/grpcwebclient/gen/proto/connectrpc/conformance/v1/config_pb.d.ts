@@ -273,6 +273,8 @@ export enum Protocol {
   PROTOCOL_CONNECT = 1,
   PROTOCOL_GRPC = 2,
   PROTOCOL_GRPC_WEB = 3,
+  PROTOCOL_GRPC_WEB_TEXT = 4,
+  PROTOCOL_REST_TRANSCODING = 5,
 }
 export enum Codec { 
   CODEC_UNSPECIFIED = 0,
@@ -288,6 +290,7 @@ export enum Compression {
   COMPRESSION_ZSTD = 4,
   COMPRESSION_DEFLATE = 5,
   COMPRESSION_SNAPPY = 6,
+  COMPRESSION_LZ4 = 7,
 }
 export enum StreamType { 
   STREAM_TYPE_UNSPECIFIED = 0,
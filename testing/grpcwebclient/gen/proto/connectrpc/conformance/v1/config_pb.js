@@ -1710,7 +1710,9 @@ proto.connectrpc.conformance.v1.Protocol = {
   PROTOCOL_UNSPECIFIED: 0,
   PROTOCOL_CONNECT: 1,
   PROTOCOL_GRPC: 2,
-  PROTOCOL_GRPC_WEB: 3
+  PROTOCOL_GRPC_WEB: 3,
+  PROTOCOL_GRPC_WEB_TEXT: 4,
+  PROTOCOL_REST_TRANSCODING: 5
 };
 
 /**
@@ -1733,7 +1735,8 @@ proto.connectrpc.conformance.v1.Compression = {
   COMPRESSION_BR: 3,
   COMPRESSION_ZSTD: 4,
   COMPRESSION_DEFLATE: 5,
-  COMPRESSION_SNAPPY: 6
+  COMPRESSION_SNAPPY: 6,
+  COMPRESSION_LZ4: 7
 };
 
 /**